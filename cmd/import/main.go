@@ -0,0 +1,90 @@
+package main
+
+import (
+    "encoding/json"
+    "flag"
+    "log"
+    "os"
+    "path/filepath"
+    "strings"
+
+    "github.com/torvictorvic/seek-v2/internal/config"
+    "github.com/torvictorvic/seek-v2/internal/importer"
+    "github.com/torvictorvic/seek-v2/internal/repository"
+    "github.com/torvictorvic/seek-v2/internal/service"
+)
+
+// Importa candidatos desde un CSV o XLSX:
+//
+//	go run ./cmd/import -file candidatos.xlsx -map "name=Nombre,email=Correo" -dry-run
+func main() {
+    file := flag.String("file", "", "CSV or XLSX file to import")
+    format := flag.String("format", "", "csv or xlsx (defaults to the file extension)")
+    sheet := flag.String("sheet", "", "XLSX sheet (defaults to the first one)")
+    mapping := flag.String("map", "", "Column mapping: field=Column,field=Column")
+    dryRun := flag.Bool("dry-run", false, "Validate only and print the report")
+    upsert := flag.Bool("upsert", false, "Update the candidate with the same email")
+    flag.Parse()
+
+    if *file == "" {
+        flag.Usage()
+        os.Exit(2)
+    }
+
+    opts := importer.Options{DryRun: *dryRun, Upsert: *upsert, Mapping: importer.DefaultMapping()}
+    if *mapping != "" {
+        m, err := importer.ParseMapping(*mapping)
+        if err != nil {
+            log.Fatal(err)
+        }
+        opts.Mapping = m
+    }
+
+    f, err := os.Open(*file)
+    if err != nil {
+        log.Fatalf("Error opening file: %v\n", err)
+    }
+    defer f.Close()
+
+    if *format == "" {
+        *format = strings.TrimPrefix(strings.ToLower(filepath.Ext(*file)), ".")
+    }
+
+    var reader importer.RowReader
+    switch *format {
+    case importer.FormatCSV:
+        reader = importer.NewCSVReader(f, 0)
+    case importer.FormatXLSX:
+        info, err := f.Stat()
+        if err != nil {
+            log.Fatal(err)
+        }
+        reader, err = importer.NewXLSXReader(f, info.Size(), *sheet)
+        if err != nil {
+            log.Fatal(err)
+        }
+    default:
+        log.Fatalf("Unsupported format '%s'\n", *format)
+    }
+
+    dbCfg := config.LoadDatabaseConfig()
+    dialect, err := repository.DialectFor(dbCfg.Driver)
+    if err != nil {
+        log.Fatal(err)
+    }
+    db := config.ConnectDB(dbCfg)
+    defer db.Close()
+
+    candidateService := service.NewCandidateService(repository.NewCandidateRepositoryFor(db, dialect))
+    report, err := importer.NewImporter(candidateService).Import(reader, opts)
+    if err != nil {
+        log.Fatal(err)
+    }
+
+    enc := json.NewEncoder(os.Stdout)
+    enc.SetIndent("", "  ")
+    enc.Encode(report)
+    if report.Failed > 0 {
+        os.Exit(1)
+    }
+}
@@ -1,16 +1,32 @@
 package main
 
 import (
+    "context"
+    "database/sql"
+    "flag"
+    "fmt"
     "log"
+    "net"
+    "net/http"
     "os"
+    "time"
 
     "github.com/gin-gonic/gin"
 
+    "github.com/torvictorvic/seek-v2/internal/cache"
     "github.com/torvictorvic/seek-v2/internal/config"
+    "github.com/torvictorvic/seek-v2/internal/domain"
+    "github.com/torvictorvic/seek-v2/internal/events"
+    "github.com/torvictorvic/seek-v2/internal/graphqlapi"
+    "github.com/torvictorvic/seek-v2/internal/grpcapi"
     "github.com/torvictorvic/seek-v2/internal/handler"
+    "github.com/torvictorvic/seek-v2/internal/outbox"
     "github.com/torvictorvic/seek-v2/internal/repository"
+    "github.com/torvictorvic/seek-v2/internal/search"
     "github.com/torvictorvic/seek-v2/internal/security"
     "github.com/torvictorvic/seek-v2/internal/service"
+    "github.com/torvictorvic/seek-v2/internal/storage"
+    "github.com/torvictorvic/seek-v2/migrations"
 
     "github.com/swaggo/files"
     "github.com/swaggo/gin-swagger"
@@ -36,37 +52,494 @@ func main() {
     // Load environment variables
     _ = os.Setenv("JWT_SECRET", "L0ng1sl4nD") // Ejemplo, en prod usar .env
 
-    db := config.ConnectDB()
+    storageMode := flag.String("storage", "sql", "Where the candidates are kept: sql, or memory to serve the candidate API without a database")
+    flag.Parse()
+    switch *storageMode {
+    case "memory":
+        serveInMemory()
+        return
+    case "sql":
+    default:
+        log.Fatalf("Invalid storage '%s'\n", *storageMode)
+    }
+
+    dbCfg := config.LoadDatabaseConfig()
+    dialect, err := repository.DialectFor(dbCfg.Driver)
+    if err != nil {
+        log.Fatalf("Invalid database: %v\n", err)
+    }
+    db := config.ConnectDB(dbCfg)
     defer db.Close()
+    if dbCfg.Migrate {
+        if err := repository.Migrate(db, dialect, migrations.FS); err != nil {
+            log.Fatalf("Error migrating database: %v\n", err)
+        }
+    }
+
+    replicas := config.ConnectReplicas(dbCfg)
+    for _, replica := range replicas {
+        defer replica.Close()
+    }
+    replicaRouter := repository.NewReplicaRouter(db, replicas,
+        repository.WithReadYourWritesWindow(time.Duration(dbCfg.ReadYourWritesMs)*time.Millisecond))
+    if len(replicas) > 0 {
+        replicaRouter.CheckReplicas(context.Background())
+        go replicaRouter.Run(context.Background(), time.Duration(dbCfg.ReplicaCheckInterval)*time.Second)
+    }
 
     // Start repository and service
-    candidateRepo := repository.NewCandidateRepository(db)
-    candidateService := service.NewCandidateService(candidateRepo)
-    candidateHandler := handler.NewCandidateHandler(candidateService)
+    candidateRepo := repository.NewCandidateRepositoryFor(db, dialect, repository.WithReplicas(replicaRouter))
+    // The lookups of candidates by ID and email read through the cache selected by CACHE_BACKEND
+    cacheCfg := config.LoadCacheConfig()
+    cacheStore, err := newCacheStore(cacheCfg)
+    if err != nil {
+        log.Fatalf("Invalid cache: %v\n", err)
+    }
+    var cachedCandidates *repository.CachedCandidateRepository
+    if cacheStore != nil {
+        cachedCandidates = repository.NewCachedCandidateRepository(candidateRepo, cacheStore,
+            repository.WithCacheTTL(repository.CacheGetByID, time.Duration(cacheCfg.TTLGetByID)*time.Second),
+            repository.WithCacheTTL(repository.CacheGetByEmail, time.Duration(cacheCfg.TTLGetByEmail)*time.Second),
+            repository.WithCacheTTL(repository.CacheGetByIDs, time.Duration(cacheCfg.TTLGetByIDs)*time.Second),
+        )
+        candidateRepo = cachedCandidates
+    }
+    customFieldRepo := repository.NewCustomFieldRepositoryFor(db, dialect)
+    attributeRepo := repository.NewCandidateAttributeRepositoryFor(db, dialect)
+    serviceCfg := config.LoadServiceConfig()
+    unitOfWork := repository.NewUnitOfWork(db,
+        repository.WithTxRetries(dbCfg.TxMaxRetries, time.Duration(dbCfg.TxRetryBackoffMs)*time.Millisecond))
+    candidateOpts := []service.Option{
+        service.WithBatchMaxItems(serviceCfg.BatchMaxItems),
+        service.WithUnitOfWork(unitOfWork),
+        service.WithCustomFields(customFieldRepo, attributeRepo),
+        service.WithContactDetails(repository.NewCandidateContactRepositoryFor(db, dialect)),
+    }
+    // The full-text search needs the MySQL indexes, the other databases use the in-memory index
+    documentRepo := repository.NewDocumentRepositoryFor(db, dialect)
+    var idx *search.MemoryIndex
+    if dialect == repository.MySQL {
+        candidateOpts = append(candidateOpts, service.WithSearcher(repository.NewCandidateSearcher(db, repository.WithReplicas(replicaRouter))))
+    } else {
+        idx = newMemoryIndex(candidateRepo, documentRepo)
+        if searchCfg := config.LoadSearchConfig(); searchCfg.ReindexInterval > 0 {
+            go reindexCandidates(idx, candidateRepo, documentRepo, time.Duration(searchCfg.ReindexInterval)*time.Second)
+        }
+        candidateOpts = append(candidateOpts, service.WithSearcher(idx))
+    }
+    candidateService := service.NewCandidateService(candidateRepo, candidateOpts...)
+    customFieldHandler := handler.NewCustomFieldHandler(service.NewCustomFieldService(customFieldRepo, attributeRepo))
+    duplicateHandler := handler.NewDuplicateHandler(service.NewDuplicateService(candidateRepo))
+    historyHandler := handler.NewHistoryHandler(service.NewHistoryService(repository.NewCandidateHistoryRepositoryFor(db, dialect)))
+    jobRepo := repository.NewJobRepositoryFor(db, dialect)
+    jobService := service.NewJobService(jobRepo)
+    jobHandler := handler.NewJobHandler(jobService)
+    pipeline, err := domain.ParsePipeline(serviceCfg.HiringPipeline)
+    if err != nil {
+        log.Fatalf("Invalid hiring pipeline: %v\n", err)
+    }
+    applicationRepo := repository.NewApplicationRepositoryFor(db, dialect)
+    applicationService := service.NewApplicationService(
+        applicationRepo,
+        repository.NewRejectionReasonRepositoryFor(db, dialect),
+        candidateRepo,
+        jobRepo,
+        pipeline,
+    )
+    applicationHandler := handler.NewApplicationHandler(applicationService)
+    interviewRepo := repository.NewInterviewRepositoryFor(db, dialect)
+    interviewHandler := handler.NewInterviewHandler(service.NewInterviewService(
+        interviewRepo,
+        repository.NewCalendarFeedRepositoryFor(db, dialect),
+        candidateRepo,
+        applicationRepo,
+    ))
+    feedbackService := service.NewFeedbackService(
+        repository.NewFeedbackRepositoryFor(db, dialect),
+        repository.NewScorecardRepositoryFor(db, dialect),
+        candidateRepo,
+        interviewRepo,
+    )
+    feedbackHandler := handler.NewFeedbackHandler(feedbackService)
+    candidateHandler := handler.NewCandidateHandler(candidateService).WithFeedback(feedbackService)
+    noteService := service.NewNoteService(repository.NewNoteRepositoryFor(db, dialect), candidateRepo)
+    noteHandler := handler.NewNoteHandler(noteService)
+    notificationHandler := handler.NewNotificationHandler(service.NewNotificationService(repository.NewNotificationRepositoryFor(db, dialect)))
 
-    r := gin.Default()
+    storageCfg := config.LoadStorageConfig()
+    store, err := newStorage(storageCfg)
+    if err != nil {
+        log.Fatalf("Invalid storage: %v\n", err)
+    }
+    documentOpts := []service.DocumentOption{service.WithKnownTags(attributeRepo)}
+    if idx != nil {
+        // The in-memory index does not read the resumes from the database, the uploads update it
+        documentOpts = append(documentOpts, service.WithResumeIndex(idx))
+    }
+    documentService := service.NewDocumentService(documentRepo, candidateRepo, store, service.DocumentSettings{
+        MaxBytes:  int64(storageCfg.DocumentMaxBytes),
+        URLTTL:    time.Duration(storageCfg.DocumentURLTTL) * time.Second,
+        URLSecret: []byte(storageCfg.DocumentURLSecret),
+    }, documentOpts...)
+    documentHandler := handler.NewDocumentHandler(documentService, int64(storageCfg.DocumentMaxBytes))
+    // The files of the deleted documents and candidates are removed in the background
+    if storageCfg.PurgeInterval > 0 {
+        go purgeDocuments(documentService, time.Duration(storageCfg.PurgeInterval)*time.Second)
+    }
 
-    // Endpoint to generate token
-    r.POST("/login", handler.GenerateToken)
+    // The candidate events are written to the outbox with each change and published by the
+    // relay to the configured sink. Every instance tails the outbox for the clients of its
+    // event stream, whichever instance runs the relay.
+    outboxCfg := config.LoadOutboxConfig()
+    sink, err := newOutboxSink(outboxCfg)
+    if err != nil {
+        log.Fatalf("Invalid outbox sink: %v\n", err)
+    }
+    outboxRepo := repository.NewOutboxRepositoryFor(db, dialect)
+    relay := outbox.NewRelay(outboxRepo, sink,
+        outbox.WithBatchSize(outboxCfg.BatchSize),
+        outbox.WithRetention(time.Duration(outboxCfg.Retention)*time.Second),
+    )
+    if sink != nil && outboxCfg.RelayEnabled {
+        go relay.Run(context.Background(), time.Duration(outboxCfg.Interval)*time.Second)
+    }
+    eventsCfg := config.LoadEventsConfig()
+    hub := events.NewHub(eventsCfg.ReplaySize, eventsCfg.SubscriberBuffer)
+    go outbox.NewTail(outboxRepo, hub, outboxCfg.BatchSize).Run(context.Background(), time.Duration(outboxCfg.Interval)*time.Second)
+    outboxHandler := handler.NewOutboxHandler(relay)
+    candidateEventsHandler := handler.NewCandidateEventsHandler(hub, time.Duration(eventsCfg.Heartbeat)*time.Second)
+
+    graphqlCfg := config.LoadGraphQLConfig()
+    graphqlServer, err := graphqlapi.NewServer(graphqlapi.Services{
+        Candidates:   candidateService,
+        Applications: applicationService,
+        Jobs:         jobService,
+        Notes:        noteService,
+    }, graphqlapi.Limits{MaxDepth: graphqlCfg.MaxDepth, MaxComplexity: graphqlCfg.MaxComplexity})
+    if err != nil {
+        log.Fatalf("Invalid GraphQL schema: %v\n", err)
+    }
+    graphqlHandler := handler.NewGraphQLHandler(graphqlServer)
+
+    // Responses of the POST requests sent with an Idempotency-Key
+    idempotencyCfg := config.LoadIdempotencyConfig()
+    idempotencyStore, err := newIdempotencyStore(idempotencyCfg, db, dialect)
+    if err != nil {
+        log.Fatalf("Invalid idempotency store: %v\n", err)
+    }
+    go purgeIdempotencyKeys(idempotencyStore, time.Hour)
+    idempotency := security.IdempotencyMiddleware(idempotencyStore, idempotencyCfg)
+
+    httpCfg := config.LoadHTTPConfig()
+    r := newRouter(httpCfg)
 
     // JWT protected routes
-    auth := r.Group("/api", security.AuthMiddleware())
+    auth := r.Group("/api", security.AuthMiddleware(), idempotency)
 
     auth.POST("/candidates", candidateHandler.CreateCandidate)
+    auth.POST("/candidates/import", candidateHandler.ImportCandidates)
     auth.GET("/candidates/:id", candidateHandler.GetCandidateByID)
     auth.GET("/candidates", candidateHandler.GetAllCandidates)
+    auth.GET("/candidates/export", candidateHandler.ExportCandidates)
+    auth.GET("/candidates/search", candidateHandler.SearchCandidates)
+    auth.GET("/candidates/events", candidateEventsHandler.StreamEvents)
     auth.PUT("/candidates/:id", candidateHandler.UpdateCandidate)
     auth.DELETE("/candidates/:id", candidateHandler.DeleteCandidate)
+    auth.GET("/candidates/:id/duplicates", duplicateHandler.FindDuplicates)
+    auth.POST("/candidates/:id/merge", candidateHandler.MergeCandidates)
+    auth.GET("/candidates/:id/history", historyHandler.GetCandidateHistory)
+    auth.GET("/candidates/:id/applications", applicationHandler.ListByCandidate)
+    auth.GET("/candidates/:id/interviews", interviewHandler.ListByCandidate)
+    auth.POST("/candidates/:id/feedback", feedbackHandler.SubmitFeedback)
+    auth.GET("/candidates/:id/feedback", feedbackHandler.ListFeedback)
+    auth.POST("/candidates/:id/notes", noteHandler.AddNote)
+    auth.GET("/candidates/:id/notes", noteHandler.ListNotes)
+    auth.GET("/candidates/:id/notes/:noteId", noteHandler.GetNote)
+    auth.PUT("/candidates/:id/notes/:noteId", noteHandler.EditNote)
+    auth.DELETE("/candidates/:id/notes/:noteId", noteHandler.DeleteNote)
+    auth.POST("/candidates/:id/notes/:noteId/pin", noteHandler.PinNote)
+    auth.DELETE("/candidates/:id/notes/:noteId/pin", noteHandler.UnpinNote)
+    auth.GET("/candidates/:id/notes/:noteId/revisions", noteHandler.ListRevisions)
+    auth.POST("/candidates/:id/documents", documentHandler.UploadDocument)
+    auth.GET("/candidates/:id/documents", documentHandler.ListDocuments)
+    auth.GET("/candidates/:id/documents/:docId", documentHandler.GetDocument)
+    auth.GET("/candidates/:id/documents/:docId/content", documentHandler.DownloadDocument)
+    auth.POST("/candidates/:id/documents/:docId/url", documentHandler.SignDocumentURL)
+    auth.DELETE("/candidates/:id/documents/:docId", documentHandler.DeleteDocument)
+    auth.GET("/candidates/:id/documents/:docId/parse", documentHandler.ParseDocument)
+    // Custom methods: POST /candidates:batch, /candidates:batchUpdate and /candidates:batchDelete
+    handler.CustomMethods(auth, "/candidates", map[string]gin.HandlerFunc{
+        "batch":       candidateHandler.CreateCandidates,
+        "batchUpdate": candidateHandler.UpdateCandidates,
+        "batchDelete": candidateHandler.DeleteCandidates,
+    })
+
+    auth.POST("/custom-fields", customFieldHandler.CreateField)
+    auth.GET("/custom-fields", customFieldHandler.ListFields)
+    auth.GET("/custom-fields/schema", customFieldHandler.Schema)
+    auth.GET("/custom-fields/:key", customFieldHandler.GetField)
+    auth.PUT("/custom-fields/:key", customFieldHandler.UpdateField)
+    auth.DELETE("/custom-fields/:key", customFieldHandler.DeleteField)
+    auth.GET("/tags", customFieldHandler.ListTags)
+
+    auth.POST("/resumes/parse", documentHandler.ParseResume)
+
+    auth.POST("/jobs", jobHandler.CreateJob)
+    auth.GET("/jobs/:id", jobHandler.GetJobByID)
+    auth.GET("/jobs", jobHandler.GetAllJobs)
+    auth.PUT("/jobs/:id", jobHandler.UpdateJob)
+    auth.DELETE("/jobs/:id", jobHandler.DeleteJob)
+    auth.GET("/jobs/:id/applications", applicationHandler.ListByJob)
+
+    auth.POST("/applications", applicationHandler.Apply)
+    auth.GET("/applications/:id", applicationHandler.GetApplication)
+    auth.POST("/applications/:id/stage", applicationHandler.MoveStage)
+    auth.GET("/rejection-reasons", applicationHandler.ListRejectionReasons)
+    auth.POST("/rejection-reasons", applicationHandler.SaveRejectionReason)
+    auth.DELETE("/rejection-reasons/:code", applicationHandler.DeactivateRejectionReason)
+
+    auth.POST("/interviews", interviewHandler.ScheduleInterview)
+    auth.GET("/interviews/:id", interviewHandler.GetInterview)
+    auth.PUT("/interviews/:id", interviewHandler.RescheduleInterview)
+    auth.DELETE("/interviews/:id", interviewHandler.CancelInterview)
+    auth.GET("/interviews/:id/invite.ics", interviewHandler.DownloadInvite)
+    auth.POST("/calendar/feed", interviewHandler.IssueFeed)
+    auth.DELETE("/calendar/feed", interviewHandler.RevokeFeed)
+
+    auth.POST("/scorecards", feedbackHandler.CreateTemplate)
+    auth.GET("/scorecards", feedbackHandler.ListTemplates)
+    auth.GET("/scorecards/:id", feedbackHandler.GetTemplate)
+
+    auth.GET("/notifications", notificationHandler.ListNotifications)
+    auth.POST("/notifications/:id/read", notificationHandler.MarkRead)
+
+    auth.GET("/outbox/stats", outboxHandler.GetStats)
+    if cachedCandidates != nil {
+        auth.GET("/cache/stats", handler.NewCacheHandler(cachedCandidates).GetStats)
+    }
+
+    // Personal iCal feed, authenticated by the token in the URL
+    r.GET("/calendar/:token/interviews.ics", interviewHandler.Feed)
+    // Document download through a signed, expiring link
+    r.GET("/documents/:docId/download", documentHandler.DownloadSigned)
+
+    // GraphQL endpoint, with the same JWT as the API
+    r.POST("/graphql", security.AuthMiddleware(), idempotency, graphqlHandler.Serve)
+    r.GET("/graphql", security.AuthMiddleware(), graphqlHandler.Serve)
 
     // Routes Swagger UI
     r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
-    log.Println("Server run http://localhost:8080")
-    
+    // gRPC API on its own port, with the same candidate service
+    grpcCfg := config.LoadGRPCConfig()
+    if grpcCfg.Enabled {
+        lis, err := net.Listen("tcp", ":"+grpcCfg.Port)
+        if err != nil {
+            log.Fatalf("Error listening on the gRPC port: %v\n", err)
+        }
+        grpcServer := grpcapi.NewServer(candidateService, hub, grpcCfg.Reflection)
+        go func() {
+            if err := grpcServer.Serve(lis); err != nil {
+                log.Fatalf("Error serving gRPC: %v\n", err)
+            }
+        }()
+        log.Println("gRPC server run localhost:" + grpcCfg.Port)
+    }
+
+    log.Println("Server run http://localhost:" + httpCfg.Port)
+
+    r.Run(":" + httpCfg.Port)
+}
+
+// newRouter returns the router with the security middlewares and the login endpoint
+func newRouter(httpCfg config.HTTPConfig) *gin.Engine {
+    r := gin.Default()
+
+    // Only the configured proxies are trusted to set the client IP
+    if err := r.SetTrustedProxies(httpCfg.TrustedProxies); err != nil {
+        log.Fatalf("Invalid trusted proxies: %v\n", err)
+    }
+    r.Use(security.SecurityHeadersMiddleware(httpCfg.Headers, httpCfg.TrustedProxies))
+    r.Use(security.CORSMiddleware(httpCfg.CORS))
+
+    // Endpoint to generate token
+    r.POST("/login", handler.NewAuthHandler(config.LoadAuthConfig().Users).GenerateToken)
+    return r
+}
+
+// serveInMemory runs the candidate API with the candidates kept in memory, with no
+// database, for demos and local runs. The data is lost on exit, and the features kept
+// in other tables (jobs, interviews, notes, documents, events...) are not served.
+func serveInMemory() {
+    candidateRepo := repository.NewMemoryCandidateRepository()
+    serviceCfg := config.LoadServiceConfig()
+    candidateService := service.NewCandidateService(candidateRepo,
+        service.WithBatchMaxItems(serviceCfg.BatchMaxItems),
+        service.WithSearcher(search.NewMemoryIndex()),
+    )
+    candidateHandler := handler.NewCandidateHandler(candidateService)
+    duplicateHandler := handler.NewDuplicateHandler(service.NewDuplicateService(candidateRepo))
+
+    idempotencyCfg := config.LoadIdempotencyConfig()
+    idempotencyStore := repository.NewMemoryIdempotencyRepository()
+    go purgeIdempotencyKeys(idempotencyStore, time.Hour)
+
+    httpCfg := config.LoadHTTPConfig()
+    r := newRouter(httpCfg)
+
+    auth := r.Group("/api", security.AuthMiddleware(), security.IdempotencyMiddleware(idempotencyStore, idempotencyCfg))
+    auth.POST("/candidates", candidateHandler.CreateCandidate)
+    auth.POST("/candidates/import", candidateHandler.ImportCandidates)
+    auth.GET("/candidates/:id", candidateHandler.GetCandidateByID)
+    auth.GET("/candidates", candidateHandler.GetAllCandidates)
+    auth.GET("/candidates/export", candidateHandler.ExportCandidates)
+    auth.GET("/candidates/search", candidateHandler.SearchCandidates)
+    auth.PUT("/candidates/:id", candidateHandler.UpdateCandidate)
+    auth.DELETE("/candidates/:id", candidateHandler.DeleteCandidate)
+    auth.GET("/candidates/:id/duplicates", duplicateHandler.FindDuplicates)
+    auth.POST("/candidates/:id/merge", candidateHandler.MergeCandidates)
+    handler.CustomMethods(auth, "/candidates", map[string]gin.HandlerFunc{
+        "batch":       candidateHandler.CreateCandidates,
+        "batchUpdate": candidateHandler.UpdateCandidates,
+        "batchDelete": candidateHandler.DeleteCandidates,
+    })
+
+    r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+
+    log.Println("Server run http://localhost:" + httpCfg.Port + " (in-memory storage)")
+
+    r.Run(":" + httpCfg.Port)
+}
+
+// newMemoryIndex indexes the stored candidates and the text of their resumes for the
+// databases without full-text search. The index only sees the writes of this process, see
+// reindexCandidates.
+func newMemoryIndex(candidates repository.CandidateRepository, documents repository.DocumentRepository) *search.MemoryIndex {
+    idx := search.NewMemoryIndex()
+    if err := rebuildIndex(idx, candidates, documents); err != nil {
+        log.Fatalf("Error indexing candidates: %v\n", err)
+    }
+    return idx
+}
+
+// reindexCandidates periodically rebuilds the in-memory index from the database, to pick
+// up the writes of the other instances and of the import command
+func reindexCandidates(idx *search.MemoryIndex, candidates repository.CandidateRepository, documents repository.DocumentRepository, interval time.Duration) {
+    for range time.Tick(interval) {
+        if err := rebuildIndex(idx, candidates, documents); err != nil {
+            log.Printf("Error reindexing candidates: %v\n", err)
+        }
+    }
+}
+
+// rebuildIndex replaces the indexed candidates with the stored ones and the text extracted
+// from their documents, read in chunks of IDs
+func rebuildIndex(idx *search.MemoryIndex, candidates repository.CandidateRepository, documents repository.DocumentRepository) error {
+    all, err := candidates.GetAll(domain.CandidateFilter{})
+    if err != nil {
+        return err
+    }
+    const chunk = 500
+    for start := 0; start < len(all); start += chunk {
+        end := min(start+chunk, len(all))
+        ids := make([]int, 0, end-start)
+        for _, c := range all[start:end] {
+            ids = append(ids, c.ID)
+        }
+        texts, err := documents.ResumeTexts(ids)
+        if err != nil {
+            return err
+        }
+        for i := start; i < end; i++ {
+            all[i].ResumeText = texts[all[i].ID]
+        }
+    }
+    idx.Rebuild(all)
+    return nil
+}
+
+// newStorage builds the storage of the documents selected by STORAGE_DRIVER
+func newStorage(cfg config.StorageConfig) (storage.Storage, error) {
+    switch cfg.Driver {
+    case "s3":
+        return storage.NewS3(storage.S3Config{
+            Endpoint:  cfg.S3.Endpoint,
+            Bucket:    cfg.S3.Bucket,
+            Region:    cfg.S3.Region,
+            AccessKey: cfg.S3.AccessKey,
+            SecretKey: cfg.S3.SecretKey,
+            PathStyle: cfg.S3.PathStyle,
+        }, nil)
+    case "local", "":
+        return storage.NewLocal(cfg.LocalDir)
+    }
+    return nil, fmt.Errorf("unknown storage driver '%s'", cfg.Driver)
+}
+
+// newOutboxSink builds the sink of the outbox relay selected by OUTBOX_SINK, nil for none
+func newOutboxSink(cfg config.OutboxConfig) (outbox.Sink, error) {
+    switch cfg.Sink {
+    case "log", "":
+        return outbox.NewLogSink(nil), nil
+    case "http":
+        if cfg.HTTPURL == "" {
+            return nil, fmt.Errorf("OUTBOX_HTTP_URL is required by the http sink")
+        }
+        return outbox.NewHTTPSink(cfg.HTTPURL, &http.Client{Timeout: 10 * time.Second}), nil
+    case "none":
+        return nil, nil
+    }
+    return nil, fmt.Errorf("unknown outbox sink '%s'", cfg.Sink)
+}
+
+// newCacheStore builds the store of the candidate cache selected by CACHE_BACKEND, nil for none
+func newCacheStore(cfg config.CacheConfig) (cache.Store, error) {
+    switch cfg.Backend {
+    case "none", "":
+        return nil, nil
+    case "memory":
+        return cache.NewLRU(cfg.Capacity), nil
+    case "redis":
+        return cache.NewRedis(cache.RedisConfig{
+            Addr:     cfg.RedisAddr,
+            Password: cfg.RedisPassword,
+            DB:       cfg.RedisDB,
+            Prefix:   cfg.RedisPrefix,
+            Timeout:  time.Duration(cfg.RedisTimeout) * time.Millisecond,
+        })
+    }
+    return nil, fmt.Errorf("unknown cache backend '%s'", cfg.Backend)
+}
+
+// newIdempotencyStore builds the store of the idempotent responses selected by IDEMPOTENCY_STORE
+func newIdempotencyStore(cfg config.IdempotencyConfig, db *sql.DB, dialect repository.Dialect) (repository.IdempotencyRepository, error) {
+    switch cfg.Store {
+    case "sql", "":
+        return repository.NewIdempotencyRepositoryFor(db, dialect), nil
+    case "memory":
+        return repository.NewMemoryIdempotencyRepository(), nil
+    }
+    return nil, fmt.Errorf("unknown idempotency store '%s'", cfg.Store)
+}
+
+// purgeIdempotencyKeys periodically removes the expired idempotent responses
+func purgeIdempotencyKeys(store repository.IdempotencyRepository, interval time.Duration) {
+    for range time.Tick(interval) {
+        if _, err := store.PurgeExpired(time.Now()); err != nil {
+            log.Printf("Error purging idempotency keys: %v\n", err)
+        }
+    }
+}
 
-    port := os.Getenv("PORT")
-    if port == "" {
-        port = "8080" // solo para local, en Heroku vendrá un número aleatorio
+// purgeDocuments periodically removes the content of the deleted documents
+func purgeDocuments(documents service.DocumentService, interval time.Duration) {
+    for range time.Tick(interval) {
+        n, err := documents.PurgeDeleted(context.Background())
+        if err != nil {
+            log.Printf("Error purging documents: %v\n", err)
+        }
+        if n > 0 {
+            log.Printf("Purged %d deleted documents\n", n)
+        }
     }
-    r.Run(":" + port)
 }
@@ -24,14 +24,14 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/candidates": {
-            "get": {
+        "/applications": {
+            "post": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Retorna la lista de todos los candidatos",
+                "description": "Crea la postulación en la primera etapa del proceso. La vacante debe estar abierta.",
                 "consumes": [
                     "application/json"
                 ],
@@ -39,17 +39,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Candidates"
+                    "Applications"
+                ],
+                "summary": "Postular un candidato a una vacante",
+                "parameters": [
+                    {
+                        "description": "Candidato y vacante",
+                        "name": "application",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_torvictorvic_seek-v2_internal_domain.ApplyRequest"
+                        }
+                    }
                 ],
-                "summary": "Listar todos los candidatos",
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/github_com_torvictorvic_seek_internal_domain.Candidate"
-                            }
+                            "$ref": "#/definitions/github_com_torvictorvic_seek-v2_internal_domain.Application"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     },
                     "401": {
@@ -58,16 +73,32 @@ const docTemplate = `{
                             "type": "object",
                             "additionalProperties": true
                         }
+                    },
+                    "404": {
+                        "description": "Candidato o vacante no encontrados",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Postulación existente o vacante cerrada",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/applications/{id}": {
+            "get": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Crea un candidato con los datos enviados en el body",
+                "description": "Retorna la postulación con el historial de cambios de etapa",
                 "consumes": [
                     "application/json"
                 ],
@@ -75,26 +106,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Candidates"
+                    "Applications"
                 ],
-                "summary": "Crear un nuevo candidato",
+                "summary": "Obtener postulación por ID",
                 "parameters": [
                     {
-                        "description": "Datos del candidato",
-                        "name": "candidate",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/github_com_torvictorvic_seek_internal_domain.Candidate"
-                        }
+                        "type": "integer",
+                        "description": "ID de la Postulación",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "ok",
+                        "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/github_com_torvictorvic_seek-v2_internal_domain.Application"
                         }
                     },
                     "400": {
@@ -111,8 +139,8 @@ const docTemplate = `{
                             "additionalProperties": true
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "404": {
+                        "description": "Postulación no encontrada",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -121,14 +149,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/candidates/{id}": {
-            "get": {
+        "/applications/{id}/stage": {
+            "post": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Retorna el candidato cuyo ID se pasa como parámetro",
+                "description": "Mueve la postulación a otra etapa si el proceso lo permite. Para rechazar se requiere un motivo de la lista administrada.",
                 "consumes": [
                     "application/json"
                 ],
@@ -136,27 +164,36 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Candidates"
+                    "Applications"
                 ],
-                "summary": "Obtener candidato por ID",
+                "summary": "Cambiar la etapa de una postulación",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID del Candidato",
+                        "description": "ID de la Postulación",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Nueva etapa y motivo",
+                        "name": "stage",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_torvictorvic_seek-v2_internal_domain.StageChangeRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_torvictorvic_seek_internal_domain.Candidate"
+                            "$ref": "#/definitions/github_com_torvictorvic_seek-v2_internal_domain.Application"
                         }
                     },
                     "400": {
-                        "description": "Bad Request",
+                        "description": "Etapa o motivo de rechazo no válidos",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -170,21 +207,30 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "Candidato no encontrado",
+                        "description": "Postulación no encontrada",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Transición no permitida",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/cache/stats": {
+            "get": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Actualiza un candidato con los datos enviados en el body",
+                "description": "Retorna por operación (get_by_id, get_by_email, get_by_ids) el TTL, los aciertos, los fallos, las lecturas compartidas con otra en curso y los errores de la caché, y las invalidaciones hechas por las escrituras",
                 "consumes": [
                     "application/json"
                 ],
@@ -192,28 +238,81 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Candidates"
+                    "Cache"
                 ],
-                "summary": "Actualiza un candidato",
-                "parameters": [
+                "summary": "Métricas de la caché de candidatos",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_torvictorvic_seek-v2_internal_domain.CacheStats"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/calendar/feed": {
+            "post": {
+                "security": [
                     {
-                        "type": "integer",
-                        "description": "ID del Candidato",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "Bearer": []
                     }
                 ],
+                "description": "Genera una URL iCal con las entrevistas del usuario, protegida por un token. La URL anterior deja de funcionar.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Interviews"
+                ],
+                "summary": "Generar la URL del calendario personal",
                 "responses": {
                     "200": {
-                        "description": "ok",
+                        "description": "url",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "La URL iCal del usuario deja de funcionar",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Interviews"
+                ],
+                "summary": "Revocar la URL del calendario personal",
+                "responses": {
+                    "200": {
+                        "description": "ok",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -225,9 +324,101 @@ const docTemplate = `{
                             "type": "object",
                             "additionalProperties": true
                         }
+                    }
+                }
+            }
+        },
+        "/candidates": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Retorna la lista de todos los candidatos, opcionalmente filtrada",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Candidates"
+                ],
+                "summary": "Listar todos los candidatos",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Nombre (coincidencia parcial)",
+                        "name": "name",
+                        "in": "query"
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    {
+                        "type": "string",
+                        "description": "Email (coincidencia parcial)",
+                        "name": "email",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Género",
+                        "name": "gender",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Salario esperado mínimo",
+                        "name": "salary_min",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Salario esperado máximo",
+                        "name": "salary_max",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Etiquetas, el candidato debe tener todas",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "País de contacto (ISO 3166-1 alpha-2)",
+                        "name": "country",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Campo personalizado: cf[stack]=go, cf[years_experience]=3..10, cf[available_from]=..2026-12-31",
+                        "name": "cf[key]",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_torvictorvic_seek-v2_internal_domain.Candidate"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -235,13 +426,13 @@ const docTemplate = `{
                     }
                 }
             },
-            "delete": {
+            "post": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "Borra un candidato cuyo ID se pasa como parámetro",
+                "description": "Crea un candidato con los datos enviados en el body. 'tags' son etiquetas libres y 'custom_fields' los valores de los campos definidos en /custom-fields (ver /custom-fields/schema). 'contact' lleva teléfonos (se normalizan a E.164 con el país), dirección, zona horaria, enlaces y canal preferido.",
                 "consumes": [
                     "application/json"
                 ],
@@ -251,21 +442,30 @@ const docTemplate = `{
                 "tags": [
                     "Candidates"
                 ],
-                "summary": "Borra un candidato por ID",
+                "summary": "Crear un nuevo candidato",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "ID del Candidato",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Datos del candidato",
+                        "name": "candidate",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_torvictorvic_seek-v2_internal_domain.Candidate"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Clave para reintentar sin duplicar: se devuelve la primera respuesta",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "ok",
                         "schema": {
-                            "$ref": "#/definitions/github_com_torvictorvic_seek_internal_domain.Candidate"
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     },
                     "400": {
@@ -282,8 +482,22 @@ const docTemplate = `{
                             "additionalProperties": true
                         }
                     },
-                    "404": {
-                        "description": "Candidato no encontrado",
+                    "409": {
+                        "description": "La petición con la misma clave sigue en curso",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "422": {
+                        "description": "La clave ya se usó con otro body",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -291,32 +505,4877 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "github_com_torvictorvic_seek_internal_domain.Candidate": {
-            "type": "object",
-            "properties": {
-                "created_at": {
-                    "type": "string"
-                },
-                "email": {
-                    "type": "string"
-                },
-                "gender": {
-                    "type": "string"
-                },
+        },
+        "/candidates/events": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Stream Server-Sent Events con los cambios de candidatos. Cada evento lleva como id el del outbox, como event el tipo y como data el candidato (solo el id si fue borrado). Al reconectar con la cabecera Last-Event-ID se reenvían los eventos perdidos; si ya no se conservan se envía un evento 'reset' y el cliente debe recargar los datos. Cada cierto tiempo se envía un comentario ': heartbeat'.",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "Candidates"
+                ],
+                "summary": "Cambios de candidatos en tiempo real",
+                "parameters": [
+                    {
+                        "type": "array",
+                        "items": {
+                            "enum": [
+                                "candidate.created",
+                                "candidate.updated",
+                                "candidate.deleted",
+                                "candidate.merged"
+                            ],
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Tipos de evento a recibir, todos si se omite",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID del último evento recibido",
+                        "name": "Last-Event-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "text/event-stream",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/candidates/export": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Exporta los candidatos con los mismos filtros del listado. El formato se elige con el parámetro 'format' o con la cabecera Accept (text/csv, application/vnd.openxmlformats-officedocument.spreadsheetml.sheet, application/x-ndjson, application/pdf).\nLas filas se envían a medida que se leen de la base de datos.",
+                "produces": [
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+                    "application/x-ndjson",
+                    "application/pdf"
+                ],
+                "tags": [
+                    "Candidates"
+                ],
+                "summary": "Exportar candidatos",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "csv, xlsx, ndjson o pdf",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Columnas separadas por coma: id,name,email,gender,salary_expected,tags,created_at,updated_at y cf.\u003cclave\u003e para los campos personalizados",
+                        "name": "columns",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Idioma de las cabeceras: es o en (por defecto según Accept-Language)",
+                        "name": "lang",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Nombre (coincidencia parcial)",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Email (coincidencia parcial)",
+                        "name": "email",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Género",
+                        "name": "gender",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Salario esperado mínimo",
+                        "name": "salary_min",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Salario esperado máximo",
+                        "name": "salary_max",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Etiquetas, el candidato debe tener todas",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "País de contacto (ISO 3166-1 alpha-2)",
+                        "name": "country",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Campo personalizado: cf[stack]=go, cf[years_experience]=3..10, cf[available_from]=..2026-12-31",
+                        "name": "cf[key]",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "406": {
+                        "description": "Formato no soportado",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/candidates/import": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Importa candidatos desde un archivo CSV o XLSX (multipart, campo 'file'). El archivo se procesa fila a fila con las mismas validaciones que la creación.\nEl mapeo de columnas se indica con mapping[campo]=Columna, por ejemplo mapping[name]=Nombre.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Candidates"
+                ],
+                "summary": "Importar candidatos desde CSV o XLSX",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "Archivo CSV o XLSX",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "csv o xlsx (por defecto según la extensión)",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Hoja del XLSX (por defecto la primera)",
+                        "name": "sheet",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Solo valida y devuelve el reporte, no escribe",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Actualiza el candidato con el mismo email",
+                        "name": "upsert",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Columna del nombre",
+                        "name": "mapping[name]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Columna del email",
+                        "name": "mapping[email]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Columna del género",
+                        "name": "mapping[gender]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Columna del salario esperado",
+                        "name": "mapping[salary_expected]",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_torvictorvic_seek-v2_internal_domain.ImportReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/candidates/search": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Búsqueda de texto libre sobre nombre y email, con coincidencia por prefijo, tolerancia a errores de escritura y ordenada por relevancia.\nSe combina con los filtros del listado. Las coincidencias se marcan con \u003cmark\u003e en 'highlights'.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Candidates"
+                ],
+                "summary": "Buscar candidatos",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Texto a buscar, por ejemplo 'anna walkr'",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Número máximo de resultados (20 por defecto)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Nombre (coincidencia parcial)",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Email (coincidencia parcial)",
+                        "name": "email",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Género",
+                        "name": "gender",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Salario esperado mínimo",
+                        "name": "salary_min",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Salario esperado máximo",
+                        "name": "salary_max",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Etiquetas, el candidato debe tener todas",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "País de contacto (ISO 3166-1 alpha-2)",
+                        "name": "country",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Campo personalizado: cf[stack]=go, cf[years_experience]=3..10, cf[available_from]=..2026-12-31",
+                        "name": "cf[key]",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_torvictorvic_seek-v2_internal_domain.CandidateSearchHit"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/candidates/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Retorna el candidato cuyo ID se pasa como parámetro, con el resumen de sus evaluaciones si el usuario puede verlas",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Candidates"
+                ],
+                "summary": "Obtener candidato por ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID del Candidato",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_torvictorvic_seek-v2_internal_domain.CandidateDetail"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Candidato no encontrado",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Actualiza un candidato con los datos enviados en el body; 'name' y 'email' son obligatorios, como en el alta. Si se omiten 'tags' o 'custom_fields' se conservan los actuales.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Candidates"
+                ],
+                "summary": "Actualiza un candidato",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID del Candidato",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Datos del candidato",
+                        "name": "candidate",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_torvictorvic_seek-v2_internal_domain.Candidate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Candidato no encontrado",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Borra un candidato cuyo ID se pasa como parámetro",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Candidates"
+                ],
+                "summary": "Borra un candidato por ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID del Candidato",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_torvictorvic_seek-v2_internal_domain.Candidate"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Candidato no encontrado",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/candidates/{id}/applications": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Retorna las postulaciones del candidato, cada una con su etapa actual",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Applications"
+                ],
+                "summary": "Postulaciones de un candidato",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID del Candidato",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_torvictorvic_seek-v2_internal_domain.Application"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/candidates/{id}/documents": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Retorna los documentos del candidato, primero los más recientes",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Documents"
+                ],
+                "summary": "Documentos de un candidato",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID del Candidato",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_torvictorvic_seek-v2_internal_domain.Document"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Sube un archivo en el campo \"file\" (multipart/form-data). El tipo se detecta por el contenido: solo se aceptan PDF, DOCX e imágenes (PNG, JPEG, GIF, WebP).",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Documents"
+                ],
+                "summary": "Adjuntar un documento a un candidato",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID del Candidato",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Documento",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_torvictorvic_seek-v2_internal_domain.Document"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Candidato no encontrado",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "413": {
+                        "description": "Archivo demasiado grande",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "415": {
+                        "description": "Tipo de archivo no permitido",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/candidates/{id}/documents/{docId}": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Documents"
+                ],
+                "summary": "Metadatos de un documento",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID del Candidato",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID del Documento",
+                        "name": "docId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_torvictorvic_seek-v2_internal_domain.Document"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Documento no encontrado",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Elimina el documento y su contenido del almacenamiento",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Documents"
+                ],
+                "summary": "Eliminar un documento",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID del Candidato",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID del Documento",
+                        "name": "docId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Documento no encontrado",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/candidates/{id}/documents/{docId}/content": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Retorna el contenido del documento. Soporta peticiones parciales con la cabecera Range.",
+                "produces": [
+                    "application/octet-stream"
+                ],
+                "tags": [
+                    "Documents"
+                ],
+                "summary": "Descargar un documento",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID del Candidato",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID del Documento",
+                        "name": "docId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Rango de bytes, ej. bytes=0-1023",
+                        "name": "Range",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "206": {
+                        "description": "Partial Content",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Documento no encontrado",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "416": {
+                        "description": "Rango no válido",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/candidates/{id}/documents/{docId}/parse": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Analiza el texto de un documento PDF o DOCX del candidato y sugiere nombre, email, teléfonos, enlaces y etiquetas de habilidades. No guarda nada: los datos se revisan antes de actualizar el candidato.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Documents"
+                ],
+                "summary": "Extraer los datos de un CV adjunto",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID del Candidato",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID del Documento",
+                        "name": "docId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_torvictorvic_seek-v2_internal_domain.ResumeSuggestion"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Documento no encontrado",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "422": {
+                        "description": "El documento no tiene texto",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/candidates/{id}/documents/{docId}/url": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Retorna un enlace que permite descargar el documento sin token hasta que expire",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Documents"
+                ],
+                "summary": "Generar un enlace de descarga firmado",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID del Candidato",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID del Documento",
+                        "name": "docId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_torvictorvic_seek-v2_internal_domain.DocumentURL"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Documento no encontrado",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/candidates/{id}/duplicates": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Compara el candidato con el resto por nombre normalizado y parte local del email, y retorna los que superan el umbral ordenados por puntuación",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Candidates"
+                ],
+                "summary": "Posibles duplicados de un candidato",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID del Candidato",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "number",
+                        "description": "Puntuación mínima entre 0 y 1 (0.7 por defecto)",
+                        "name": "threshold",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Número máximo de resultados",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_torvictorvic_seek-v2_internal_domain.DuplicateCandidate"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Candidato no encontrado",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/candidates/{id}/feedback": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Retorna las evaluaciones del candidato. Un entrevistador del candidato no ve las de los demás hasta enviar la suya.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Feedback"
+                ],
+                "summary": "Evaluaciones de un candidato",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID del Candidato",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_torvictorvic_seek-v2_internal_domain.FeedbackList"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Registra la evaluación del usuario del token. Se deben calificar todas las competencias de la plantilla y no se puede modificar después.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Feedback"
+                ],
+                "summary": "Enviar la evaluación de un candidato",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID del Candidato",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Evaluación",
+                        "name": "feedback",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_torvictorvic_seek-v2_internal_domain.Feedback"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_torvictorvic_seek-v2_internal_domain.Feedback"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "No es entrevistador de la entrevista",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Candidato o plantilla no encontrados",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Evaluación ya enviada",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/candidates/{id}/history": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Retorna los cambios registrados de un candidato, del más antiguo al más reciente",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Candidates"
+                ],
+                "summary": "Historial de un candidato",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID del Candidato",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_torvictorvic_seek-v2_internal_domain.CandidateHistoryEntry"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/candidates/{id}/interviews": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Retorna las entrevistas del candidato ordenadas por fecha",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Interviews"
+                ],
+                "summary": "Entrevistas de un candidato",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID del Candidato",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_torvictorvic_seek-v2_internal_domain.Interview"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/candidates/{id}/merge": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Fusiona el candidato 'source_id' en el candidato de la ruta. Para cada atributo (name, email, gender, salary_expected) se indica qué lado conserva su valor ('target' por defecto).\nEl candidato origen se elimina, sus datos relacionados pasan al destino y la fusión queda en el historial.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Candidates"
+                ],
+                "summary": "Fusionar dos candidatos",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID del Candidato destino",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Candidato origen y atributos a conservar",
+                        "name": "merge",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_torvictorvic_seek-v2_internal_domain.MergeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_torvictorvic_seek-v2_internal_domain.Candidate"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Candidato no encontrado",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/candidates/{id}/notes": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Retorna las notas de equipo y las privadas del usuario, primero las fijadas",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notes"
+                ],
+                "summary": "Notas de un candidato",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID del Candidato",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_torvictorvic_seek-v2_internal_domain.Note"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Crea una nota en markdown. Las menciones @usuario de las notas de equipo generan notificaciones.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notes"
+                ],
+                "summary": "Agregar una nota a un candidato",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID del Candidato",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Contenido y visibilidad (private o team)",
+                        "name": "note",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_torvictorvic_seek-v2_internal_domain.NoteRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_torvictorvic_seek-v2_internal_domain.Note"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Candidato no encontrado",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/candidates/{id}/notes/{noteId}": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Retorna la nota si pertenece al candidato y el usuario puede verla",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notes"
+                ],
+                "summary": "Obtener una nota",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID del Candidato",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID de la Nota",
+                        "name": "noteId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_torvictorvic_seek-v2_internal_domain.Note"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Nota no encontrada",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Solo el autor puede editar la nota. La versión anterior queda en el historial de ediciones.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notes"
+                ],
+                "summary": "Editar una nota",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID del Candidato",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID de la Nota",
+                        "name": "noteId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Contenido y visibilidad (private o team)",
+                        "name": "note",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_torvictorvic_seek-v2_internal_domain.NoteRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_torvictorvic_seek-v2_internal_domain.Note"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "No es el autor",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Nota no encontrada",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Solo el autor puede borrar la nota. Se conserva marcada como borrada.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notes"
+                ],
+                "summary": "Borrar una nota",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID del Candidato",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID de la Nota",
+                        "name": "noteId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "No es el autor",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Nota no encontrada",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/candidates/{id}/notes/{noteId}/pin": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Fija la nota al inicio de la lista del candidato",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notes"
+                ],
+                "summary": "Fijar una nota",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID del Candidato",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID de la Nota",
+                        "name": "noteId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_torvictorvic_seek-v2_internal_domain.Note"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Nota no encontrada",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "La nota vuelve a su lugar por fecha",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notes"
+                ],
+                "summary": "Desfijar una nota",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID del Candidato",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID de la Nota",
+                        "name": "noteId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_torvictorvic_seek-v2_internal_domain.Note"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Nota no encontrada",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/candidates/{id}/notes/{noteId}/revisions": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Retorna las versiones anteriores de la nota, de la más antigua a la más reciente",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notes"
+                ],
+                "summary": "Historial de ediciones de una nota",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID del Candidato",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID de la Nota",
+                        "name": "noteId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_torvictorvic_seek-v2_internal_domain.NoteRevision"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Nota no encontrada",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/candidates:batch": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Crea varios candidatos en una transacción. En modo 'all_or_nothing' no se escribe nada si algún elemento falla, en modo 'partial' se escriben los válidos.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Candidates"
+                ],
+                "summary": "Crear candidatos en lote",
+                "parameters": [
+                    {
+                        "description": "Candidatos y modo del lote",
+                        "name": "batch",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_torvictorvic_seek-v2_internal_domain.CandidateBatchRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_torvictorvic_seek-v2_internal_domain.BatchResult"
+                        }
+                    },
+                    "207": {
+                        "description": "Algunos elementos fallaron (modo partial)",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_torvictorvic_seek-v2_internal_domain.BatchResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "422": {
+                        "description": "Algún elemento falló (modo all_or_nothing)",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_torvictorvic_seek-v2_internal_domain.BatchResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/candidates:batchDelete": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Borra varios candidatos por ID con una sola sentencia",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Candidates"
+                ],
+                "summary": "Borrar candidatos en lote",
+                "parameters": [
+                    {
+                        "description": "IDs y modo del lote",
+                        "name": "batch",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_torvictorvic_seek-v2_internal_domain.CandidateBatchDeleteRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_torvictorvic_seek-v2_internal_domain.BatchResult"
+                        }
+                    },
+                    "207": {
+                        "description": "Algunos elementos fallaron (modo partial)",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_torvictorvic_seek-v2_internal_domain.BatchResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "422": {
+                        "description": "Algún elemento falló (modo all_or_nothing)",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_torvictorvic_seek-v2_internal_domain.BatchResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/candidates:batchUpdate": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Actualiza varios candidatos en una transacción. Cada elemento debe incluir su 'id'.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Candidates"
+                ],
+                "summary": "Actualizar candidatos en lote",
+                "parameters": [
+                    {
+                        "description": "Candidatos y modo del lote",
+                        "name": "batch",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_torvictorvic_seek-v2_internal_domain.CandidateBatchRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_torvictorvic_seek-v2_internal_domain.BatchResult"
+                        }
+                    },
+                    "207": {
+                        "description": "Algunos elementos fallaron (modo partial)",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_torvictorvic_seek-v2_internal_domain.BatchResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "422": {
+                        "description": "Algún elemento falló (modo all_or_nothing)",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_torvictorvic_seek-v2_internal_domain.BatchResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/custom-fields": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Retorna las definiciones de los campos personalizados de los candidatos",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Custom Fields"
+                ],
+                "summary": "Listar los campos personalizados",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_torvictorvic_seek-v2_internal_domain.CustomField"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Define un campo de los candidatos. Tipos: text, number, date (YYYY-MM-DD), enum y multi_select (estos dos con 'options').\nLa clave no se puede cambiar y se usa en los filtros (cf[clave]) y en las exportaciones (cf.clave).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Custom Fields"
+                ],
+                "summary": "Crear un campo personalizado",
+                "parameters": [
+                    {
+                        "description": "Definición del campo",
+                        "name": "field",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_torvictorvic_seek-v2_internal_domain.CustomField"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_torvictorvic_seek-v2_internal_domain.CustomField"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "La clave ya existe",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/custom-fields/schema": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Retorna el JSON Schema del objeto 'custom_fields' de los candidatos según los campos definidos",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Custom Fields"
+                ],
+                "summary": "Esquema de los campos personalizados",
+                "responses": {
+                    "200": {
+                        "description": "JSON Schema",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/custom-fields/{key}": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Retorna la definición del campo con la clave indicada",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Custom Fields"
+                ],
+                "summary": "Obtener un campo personalizado",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Clave del campo",
+                        "name": "key",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_torvictorvic_seek-v2_internal_domain.CustomField"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Campo no encontrado",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Cambia la etiqueta, las opciones y si es obligatorio. La clave y el tipo no se pueden cambiar.\nLos valores ya guardados de opciones eliminadas se conservan.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Custom Fields"
+                ],
+                "summary": "Actualizar un campo personalizado",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Clave del campo",
+                        "name": "key",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Definición del campo",
+                        "name": "field",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_torvictorvic_seek-v2_internal_domain.CustomField"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_torvictorvic_seek-v2_internal_domain.CustomField"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Campo no encontrado",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Borra el campo y sus valores en todos los candidatos",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Custom Fields"
+                ],
+                "summary": "Borrar un campo personalizado",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Clave del campo",
+                        "name": "key",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Campo no encontrado",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/interviews": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Agenda una entrevista con un candidato (y opcionalmente una de sus postulaciones). Se rechaza si se superpone con otra entrevista del candidato o de alguno de los entrevistadores.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Interviews"
+                ],
+                "summary": "Agendar una entrevista",
+                "parameters": [
+                    {
+                        "description": "Datos de la entrevista",
+                        "name": "interview",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_torvictorvic_seek-v2_internal_domain.Interview"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_torvictorvic_seek-v2_internal_domain.Interview"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Candidato no encontrado",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflicto de horario",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/interviews/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Retorna la entrevista cuyo ID se pasa como parámetro",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Interviews"
+                ],
+                "summary": "Obtener entrevista por ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID de la Entrevista",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_torvictorvic_seek-v2_internal_domain.Interview"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Entrevista no encontrada",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Cambia horario, lugar o entrevistadores. El candidato no cambia. La secuencia de la invitación se incrementa.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Interviews"
+                ],
+                "summary": "Reprogramar una entrevista",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID de la Entrevista",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Datos de la entrevista",
+                        "name": "interview",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_torvictorvic_seek-v2_internal_domain.Interview"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_torvictorvic_seek-v2_internal_domain.Interview"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Entrevista no encontrada",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflicto de horario",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Borra la entrevista cuyo ID se pasa como parámetro",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Interviews"
+                ],
+                "summary": "Cancelar una entrevista",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID de la Entrevista",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Entrevista no encontrada",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/interviews/{id}/invite.ics": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Retorna la invitación en formato iCalendar (RFC 5545)",
+                "produces": [
+                    "text/calendar"
+                ],
+                "tags": [
+                    "Interviews"
+                ],
+                "summary": "Descargar la invitación de una entrevista",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID de la Entrevista",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Invitación .ics",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Entrevista no encontrada",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/jobs": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Retorna la lista de vacantes, opcionalmente filtrada",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Jobs"
+                ],
+                "summary": "Listar vacantes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Título (coincidencia parcial)",
+                        "name": "title",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Departamento",
+                        "name": "department",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Ubicación",
+                        "name": "location",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Estado: open, on_hold o closed",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Responsable de la contratación",
+                        "name": "hiring_manager",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_torvictorvic_seek-v2_internal_domain.Job"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Crea una vacante con los datos enviados en el body. El estado por defecto es 'open'.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Jobs"
+                ],
+                "summary": "Crear una vacante",
+                "parameters": [
+                    {
+                        "description": "Datos de la vacante",
+                        "name": "job",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_torvictorvic_seek-v2_internal_domain.Job"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/jobs/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Retorna la vacante cuyo ID se pasa como parámetro",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Jobs"
+                ],
+                "summary": "Obtener vacante por ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID de la Vacante",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_torvictorvic_seek-v2_internal_domain.Job"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Vacante no encontrada",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Actualiza una vacante con los datos enviados en el body",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Jobs"
+                ],
+                "summary": "Actualiza una vacante",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID de la Vacante",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Datos de la vacante",
+                        "name": "job",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_torvictorvic_seek-v2_internal_domain.Job"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Borra una vacante cuyo ID se pasa como parámetro",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Jobs"
+                ],
+                "summary": "Borra una vacante por ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID de la Vacante",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/jobs/{id}/applications": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Retorna las postulaciones recibidas por la vacante, cada una con su etapa actual",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Applications"
+                ],
+                "summary": "Postulaciones a una vacante",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID de la Vacante",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_torvictorvic_seek-v2_internal_domain.Application"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/notifications": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Retorna las notificaciones del usuario del token, de la más reciente a la más antigua",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notifications"
+                ],
+                "summary": "Notificaciones del usuario",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Solo las no leídas",
+                        "name": "unread",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_torvictorvic_seek-v2_internal_domain.Notification"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/notifications/{id}/read": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Marca como leída una notificación del usuario del token",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notifications"
+                ],
+                "summary": "Marcar una notificación como leída",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID de la Notificación",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Notificación no encontrada",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/outbox/stats": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Retorna los eventos pendientes de publicar, el retraso del más antiguo y los contadores del relay",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Outbox"
+                ],
+                "summary": "Estado del outbox de eventos",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_torvictorvic_seek-v2_internal_domain.OutboxStats"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/rejection-reasons": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Retorna la lista administrada de motivos de rechazo, activos e inactivos",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Applications"
+                ],
+                "summary": "Listar motivos de rechazo",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_torvictorvic_seek-v2_internal_domain.RejectionReason"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Crea el motivo o actualiza la etiqueta de uno existente, reactivándolo",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Applications"
+                ],
+                "summary": "Crear o actualizar un motivo de rechazo",
+                "parameters": [
+                    {
+                        "description": "Código y etiqueta del motivo",
+                        "name": "reason",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_torvictorvic_seek-v2_internal_domain.RejectionReason"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/rejection-reasons/{code}": {
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "El motivo deja de estar disponible para nuevos rechazos pero se conserva en las postulaciones existentes",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Applications"
+                ],
+                "summary": "Desactivar un motivo de rechazo",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Código del motivo",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Motivo no encontrado",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/resumes/parse": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Analiza un CV en PDF o DOCX (campo \"file\") sin guardarlo, para completar el formulario de un candidato nuevo. El análisis se hace localmente, sin servicios externos.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Documents"
+                ],
+                "summary": "Extraer los datos de un CV",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "CV en PDF o DOCX",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_torvictorvic_seek-v2_internal_domain.ResumeSuggestion"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "413": {
+                        "description": "Archivo demasiado grande",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "415": {
+                        "description": "Tipo de archivo no permitido",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "422": {
+                        "description": "El documento no tiene texto",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/scorecards": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Retorna todas las plantillas con sus competencias",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Feedback"
+                ],
+                "summary": "Listar plantillas de evaluación",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_torvictorvic_seek-v2_internal_domain.ScorecardTemplate"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Crea una plantilla con competencias ponderadas y una escala de calificación (por defecto 1 a 5)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Feedback"
+                ],
+                "summary": "Crear una plantilla de evaluación",
+                "parameters": [
+                    {
+                        "description": "Plantilla",
+                        "name": "template",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_torvictorvic_seek-v2_internal_domain.ScorecardTemplate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_torvictorvic_seek-v2_internal_domain.ScorecardTemplate"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/scorecards/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Retorna la plantilla con sus competencias",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Feedback"
+                ],
+                "summary": "Obtener plantilla de evaluación por ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID de la Plantilla",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_torvictorvic_seek-v2_internal_domain.ScorecardTemplate"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Plantilla no encontrada",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/tags": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Retorna las etiquetas en uso con el número de candidatos que las tienen",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Custom Fields"
+                ],
+                "summary": "Listar las etiquetas",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_torvictorvic_seek-v2_internal_domain.TagCount"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "github_com_torvictorvic_seek-v2_internal_domain.Address": {
+            "type": "object",
+            "properties": {
+                "city": {
+                    "type": "string",
+                    "example": "Lima"
+                },
+                "line1": {
+                    "type": "string",
+                    "example": "Av. Larco 1150"
+                },
+                "line2": {
+                    "type": "string"
+                },
+                "postal_code": {
+                    "type": "string",
+                    "example": "15074"
+                },
+                "region": {
+                    "type": "string",
+                    "example": "Lima"
+                }
+            }
+        },
+        "github_com_torvictorvic_seek-v2_internal_domain.Application": {
+            "type": "object",
+            "properties": {
+                "candidate_id": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "job_id": {
+                    "type": "integer"
+                },
+                "rejection_reason": {
+                    "type": "string"
+                },
+                "stage": {
+                    "type": "string",
+                    "enum": [
+                        "applied",
+                        "screening",
+                        "interview",
+                        "offer",
+                        "hired",
+                        "rejected"
+                    ]
+                },
+                "transitions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_torvictorvic_seek-v2_internal_domain.StageTransition"
+                    }
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_torvictorvic_seek-v2_internal_domain.ApplyRequest": {
+            "type": "object",
+            "required": [
+                "candidate_id",
+                "job_id"
+            ],
+            "properties": {
+                "candidate_id": {
+                    "type": "integer"
+                },
+                "job_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_torvictorvic_seek-v2_internal_domain.BatchItemResult": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "index": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_torvictorvic_seek-v2_internal_domain.BatchMode": {
+            "type": "string",
+            "enum": [
+                "all_or_nothing",
+                "partial"
+            ],
+            "x-enum-varnames": [
+                "BatchAllOrNothing",
+                "BatchPartial"
+            ]
+        },
+        "github_com_torvictorvic_seek-v2_internal_domain.BatchResult": {
+            "type": "object",
+            "properties": {
+                "failed": {
+                    "type": "integer"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_torvictorvic_seek-v2_internal_domain.BatchItemResult"
+                    }
+                },
+                "mode": {
+                    "$ref": "#/definitions/github_com_torvictorvic_seek-v2_internal_domain.BatchMode"
+                },
+                "succeeded": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_torvictorvic_seek-v2_internal_domain.CacheOperationStats": {
+            "type": "object",
+            "properties": {
+                "errors": {
+                    "description": "failed calls to the cache, answered from the database",
+                    "type": "integer"
+                },
+                "hit_ratio": {
+                    "description": "hits / (hits + misses)",
+                    "type": "number"
+                },
+                "hits": {
+                    "type": "integer"
+                },
+                "misses": {
+                    "type": "integer"
+                },
+                "shared": {
+                    "description": "misses answered by a database read already in flight",
+                    "type": "integer"
+                },
+                "ttl_seconds": {
+                    "description": "0 when the operation is not cached",
+                    "type": "number"
+                }
+            }
+        },
+        "github_com_torvictorvic_seek-v2_internal_domain.CacheStats": {
+            "type": "object",
+            "properties": {
+                "invalidation_errors": {
+                    "description": "failed removals, those candidates may be stale until their TTL",
+                    "type": "integer"
+                },
+                "invalidations": {
+                    "description": "candidates removed from the cache by a write",
+                    "type": "integer"
+                },
+                "operations": {
+                    "description": "by operation: get_by_id, get_by_email, get_by_ids",
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/github_com_torvictorvic_seek-v2_internal_domain.CacheOperationStats"
+                    }
+                }
+            }
+        },
+        "github_com_torvictorvic_seek-v2_internal_domain.Candidate": {
+            "type": "object",
+            "properties": {
+                "contact": {
+                    "description": "unchanged on update when omitted",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_torvictorvic_seek-v2_internal_domain.ContactDetails"
+                        }
+                    ]
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "custom_fields": {
+                    "description": "by field key, see /custom-fields/schema. Unchanged on update when omitted",
+                    "type": "object"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "gender": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "salary_expected": {
+                    "type": "number"
+                },
+                "tags": {
+                    "description": "unchanged on update when omitted",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "backend",
+                        "referido"
+                    ]
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_torvictorvic_seek-v2_internal_domain.CandidateBatchDeleteRequest": {
+            "type": "object",
+            "properties": {
+                "ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "mode": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_torvictorvic_seek-v2_internal_domain.BatchMode"
+                        }
+                    ],
+                    "example": "all_or_nothing"
+                }
+            }
+        },
+        "github_com_torvictorvic_seek-v2_internal_domain.CandidateBatchRequest": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_torvictorvic_seek-v2_internal_domain.Candidate"
+                    }
+                },
+                "mode": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_torvictorvic_seek-v2_internal_domain.BatchMode"
+                        }
+                    ],
+                    "example": "partial"
+                }
+            }
+        },
+        "github_com_torvictorvic_seek-v2_internal_domain.CandidateDetail": {
+            "type": "object",
+            "properties": {
+                "contact": {
+                    "description": "unchanged on update when omitted",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_torvictorvic_seek-v2_internal_domain.ContactDetails"
+                        }
+                    ]
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "custom_fields": {
+                    "description": "by field key, see /custom-fields/schema. Unchanged on update when omitted",
+                    "type": "object"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "gender": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "salary_expected": {
+                    "type": "number"
+                },
+                "scorecard": {
+                    "$ref": "#/definitions/github_com_torvictorvic_seek-v2_internal_domain.ScorecardSummary"
+                },
+                "tags": {
+                    "description": "unchanged on update when omitted",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "backend",
+                        "referido"
+                    ]
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_torvictorvic_seek-v2_internal_domain.CandidateHistoryEntry": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "actor": {
+                    "type": "string"
+                },
+                "candidate_id": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "details": {
+                    "type": "object"
+                },
+                "id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_torvictorvic_seek-v2_internal_domain.CandidateSearchHit": {
+            "type": "object",
+            "properties": {
+                "candidate": {
+                    "$ref": "#/definitions/github_com_torvictorvic_seek-v2_internal_domain.Candidate"
+                },
+                "highlights": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "score": {
+                    "type": "number"
+                }
+            }
+        },
+        "github_com_torvictorvic_seek-v2_internal_domain.Competency": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "weight": {
+                    "type": "number",
+                    "example": 1
+                }
+            }
+        },
+        "github_com_torvictorvic_seek-v2_internal_domain.CompetencyScore": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "score": {
+                    "type": "number"
+                }
+            }
+        },
+        "github_com_torvictorvic_seek-v2_internal_domain.ContactDetails": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "$ref": "#/definitions/github_com_torvictorvic_seek-v2_internal_domain.Address"
+                },
+                "country": {
+                    "description": "ISO 3166-1 alpha-2",
+                    "type": "string",
+                    "example": "PE"
+                },
+                "github_url": {
+                    "type": "string",
+                    "example": "https://github.com/janedoe"
+                },
+                "linkedin_url": {
+                    "type": "string",
+                    "example": "https://www.linkedin.com/in/janedoe"
+                },
+                "phones": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_torvictorvic_seek-v2_internal_domain.Phone"
+                    }
+                },
+                "portfolio_url": {
+                    "type": "string",
+                    "example": "https://janedoe.dev"
+                },
+                "preferred_channel": {
+                    "type": "string",
+                    "enum": [
+                        "email",
+                        "phone",
+                        "whatsapp",
+                        "linkedin"
+                    ]
+                },
+                "time_zone": {
+                    "description": "IANA",
+                    "type": "string",
+                    "example": "America/Lima"
+                }
+            }
+        },
+        "github_com_torvictorvic_seek-v2_internal_domain.CustomField": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "key": {
+                    "description": "immutable, used in the filters and exports",
+                    "type": "string",
+                    "example": "years_experience"
+                },
+                "label": {
+                    "type": "string",
+                    "example": "Años de experiencia"
+                },
+                "options": {
+                    "description": "allowed values of enum and multi_select",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "required": {
+                    "type": "boolean"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "text",
+                        "number",
+                        "date",
+                        "enum",
+                        "multi_select"
+                    ],
+                    "example": "number"
+                }
+            }
+        },
+        "github_com_torvictorvic_seek-v2_internal_domain.Document": {
+            "type": "object",
+            "properties": {
+                "candidate_id": {
+                    "type": "integer"
+                },
+                "content_type": {
+                    "description": "sniffed from the content, not the one sent by the client",
+                    "type": "string",
+                    "example": "application/pdf"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "file_name": {
+                    "type": "string",
+                    "example": "cv.pdf"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "kind": {
+                    "type": "string",
+                    "enum": [
+                        "pdf",
+                        "docx",
+                        "image"
+                    ]
+                },
+                "sha256": {
+                    "type": "string"
+                },
+                "size": {
+                    "type": "integer"
+                },
+                "uploaded_by": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_torvictorvic_seek-v2_internal_domain.DocumentURL": {
+            "type": "object",
+            "properties": {
+                "expires_at": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_torvictorvic_seek-v2_internal_domain.DuplicateCandidate": {
+            "type": "object",
+            "properties": {
+                "candidate": {
+                    "$ref": "#/definitions/github_com_torvictorvic_seek-v2_internal_domain.Candidate"
+                },
+                "reasons": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "score": {
+                    "type": "number"
+                }
+            }
+        },
+        "github_com_torvictorvic_seek-v2_internal_domain.Feedback": {
+            "type": "object",
+            "required": [
+                "ratings",
+                "template_id"
+            ],
+            "properties": {
+                "candidate_id": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "interview_id": {
+                    "type": "integer"
+                },
+                "interviewer": {
+                    "type": "string"
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "ratings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_torvictorvic_seek-v2_internal_domain.Rating"
+                    }
+                },
+                "recommendation": {
+                    "type": "string",
+                    "enum": [
+                        "strong_no",
+                        "no",
+                        "yes",
+                        "strong_yes"
+                    ]
+                },
+                "score": {
+                    "type": "number"
+                },
+                "submitted_at": {
+                    "type": "string"
+                },
+                "template_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_torvictorvic_seek-v2_internal_domain.FeedbackList": {
+            "type": "object",
+            "properties": {
+                "feedback": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_torvictorvic_seek-v2_internal_domain.Feedback"
+                    }
+                },
+                "hidden": {
+                    "type": "integer"
+                },
+                "submitted": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "github_com_torvictorvic_seek-v2_internal_domain.ImportReport": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "type": "integer"
+                },
+                "dry_run": {
+                    "type": "boolean"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_torvictorvic_seek-v2_internal_domain.ImportRowError"
+                    }
+                },
+                "failed": {
+                    "type": "integer"
+                },
+                "rows": {
+                    "type": "integer"
+                },
+                "updated": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_torvictorvic_seek-v2_internal_domain.ImportRowError": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "row": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_torvictorvic_seek-v2_internal_domain.Interview": {
+            "type": "object",
+            "required": [
+                "ends_at",
+                "interviewers",
+                "starts_at"
+            ],
+            "properties": {
+                "application_id": {
+                    "type": "integer"
+                },
+                "candidate_id": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "ends_at": {
+                    "type": "string"
+                },
                 "id": {
                     "type": "integer"
                 },
-                "name": {
+                "interviewers": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "location": {
                     "type": "string"
                 },
-                "salary_expected": {
+                "sequence": {
+                    "description": "revision of the invite, increases on every change",
+                    "type": "integer"
+                },
+                "starts_at": {
+                    "type": "string"
+                },
+                "time_zone": {
+                    "type": "string",
+                    "example": "America/Lima"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "phone",
+                        "video",
+                        "onsite",
+                        "technical"
+                    ]
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "video_link": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_torvictorvic_seek-v2_internal_domain.Job": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "department": {
+                    "type": "string"
+                },
+                "hiring_manager": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "location": {
+                    "type": "string"
+                },
+                "salary_max": {
                     "type": "number"
                 },
+                "salary_min": {
+                    "type": "number"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "open",
+                        "on_hold",
+                        "closed"
+                    ]
+                },
+                "title": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_torvictorvic_seek-v2_internal_domain.MergeRequest": {
+            "type": "object",
+            "required": [
+                "source_id"
+            ],
+            "properties": {
+                "fields": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    },
+                    "example": {
+                        "email": "source"
+                    }
+                },
+                "source_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_torvictorvic_seek-v2_internal_domain.Note": {
+            "type": "object",
+            "properties": {
+                "author": {
+                    "type": "string"
+                },
+                "body": {
+                    "description": "markdown",
+                    "type": "string"
+                },
+                "candidate_id": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "edited": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "mentions": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "pinned": {
+                    "type": "boolean"
+                },
                 "updated_at": {
                     "type": "string"
+                },
+                "visibility": {
+                    "type": "string",
+                    "enum": [
+                        "private",
+                        "team"
+                    ]
+                }
+            }
+        },
+        "github_com_torvictorvic_seek-v2_internal_domain.NoteRequest": {
+            "type": "object",
+            "required": [
+                "body"
+            ],
+            "properties": {
+                "body": {
+                    "type": "string"
+                },
+                "visibility": {
+                    "type": "string",
+                    "enum": [
+                        "private",
+                        "team"
+                    ]
+                }
+            }
+        },
+        "github_com_torvictorvic_seek-v2_internal_domain.NoteRevision": {
+            "type": "object",
+            "properties": {
+                "body": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "editor": {
+                    "description": "who replaced this version",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "note_id": {
+                    "type": "integer"
+                },
+                "visibility": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_torvictorvic_seek-v2_internal_domain.Notification": {
+            "type": "object",
+            "properties": {
+                "actor": {
+                    "type": "string"
+                },
+                "candidate_id": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "note_id": {
+                    "type": "integer"
+                },
+                "read_at": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "user": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_torvictorvic_seek-v2_internal_domain.OutboxStats": {
+            "type": "object",
+            "properties": {
+                "failures": {
+                    "description": "failed publish attempts since the start",
+                    "type": "integer"
+                },
+                "lag_seconds": {
+                    "description": "age of the oldest pending event",
+                    "type": "number"
+                },
+                "last_error": {
+                    "type": "string"
+                },
+                "last_published_at": {
+                    "type": "string"
+                },
+                "pending": {
+                    "description": "events not published yet",
+                    "type": "integer"
+                },
+                "published": {
+                    "description": "events published since the start",
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_torvictorvic_seek-v2_internal_domain.Phone": {
+            "type": "object",
+            "properties": {
+                "number": {
+                    "description": "national numbers are completed with the calling code of the country",
+                    "type": "string",
+                    "example": "+51987654321"
+                },
+                "primary": {
+                    "type": "boolean"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "mobile",
+                        "home",
+                        "work"
+                    ]
+                }
+            }
+        },
+        "github_com_torvictorvic_seek-v2_internal_domain.Rating": {
+            "type": "object",
+            "properties": {
+                "comment": {
+                    "type": "string"
+                },
+                "competency_id": {
+                    "type": "integer"
+                },
+                "score": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_torvictorvic_seek-v2_internal_domain.RejectionReason": {
+            "type": "object",
+            "required": [
+                "code",
+                "label"
+            ],
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "code": {
+                    "type": "string"
+                },
+                "label": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_torvictorvic_seek-v2_internal_domain.ResumeLink": {
+            "type": "object",
+            "properties": {
+                "kind": {
+                    "type": "string",
+                    "enum": [
+                        "linkedin",
+                        "github",
+                        "portfolio"
+                    ]
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://github.com/janedoe"
+                }
+            }
+        },
+        "github_com_torvictorvic_seek-v2_internal_domain.ResumeSuggestion": {
+            "type": "object",
+            "properties": {
+                "candidate": {
+                    "description": "only name and email are suggested",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_torvictorvic_seek-v2_internal_domain.Candidate"
+                        }
+                    ]
+                },
+                "document_id": {
+                    "type": "integer"
+                },
+                "links": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_torvictorvic_seek-v2_internal_domain.ResumeLink"
+                    }
+                },
+                "phones": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "tags": {
+                    "description": "skills found, as tags",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "github_com_torvictorvic_seek-v2_internal_domain.ScorecardSummary": {
+            "type": "object",
+            "properties": {
+                "competencies": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_torvictorvic_seek-v2_internal_domain.CompetencyScore"
+                    }
+                },
+                "count": {
+                    "type": "integer"
+                },
+                "recommendations": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "score": {
+                    "description": "average of the feedback scores, 0-100",
+                    "type": "number"
+                }
+            }
+        },
+        "github_com_torvictorvic_seek-v2_internal_domain.ScorecardTemplate": {
+            "type": "object",
+            "required": [
+                "competencies",
+                "name"
+            ],
+            "properties": {
+                "competencies": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_torvictorvic_seek-v2_internal_domain.Competency"
+                    }
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "scale_max": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "scale_min": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "github_com_torvictorvic_seek-v2_internal_domain.StageChangeRequest": {
+            "type": "object",
+            "required": [
+                "stage"
+            ],
+            "properties": {
+                "reason": {
+                    "type": "string"
+                },
+                "rejection_reason": {
+                    "type": "string"
+                },
+                "stage": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_torvictorvic_seek-v2_internal_domain.StageTransition": {
+            "type": "object",
+            "properties": {
+                "actor": {
+                    "type": "string"
+                },
+                "application_id": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "from_stage": {
+                    "description": "empty for the initial stage",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "to_stage": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_torvictorvic_seek-v2_internal_domain.TagCount": {
+            "type": "object",
+            "properties": {
+                "candidates": {
+                    "type": "integer"
+                },
+                "tag": {
+                    "type": "string"
                 }
             }
         }
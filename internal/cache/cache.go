@@ -0,0 +1,19 @@
+// Package cache keeps copies of hot reads. The callers only depend on the Store
+// interface, so values can live in the process (LRU with TTL) or in a Redis server
+// shared by all the instances.
+package cache
+
+import (
+    "context"
+    "time"
+)
+
+// Store saves values by key for a time
+type Store interface {
+    // Get returns the value of the key, ok is false when it is missing or expired
+    Get(ctx context.Context, key string) (value []byte, ok bool, err error)
+    // Set saves the value under the key for ttl, replacing any previous one
+    Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+    // Delete removes the keys. Deleting a missing key is not an error.
+    Delete(ctx context.Context, keys ...string) error
+}
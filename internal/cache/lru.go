@@ -0,0 +1,105 @@
+package cache
+
+import (
+    "container/list"
+    "context"
+    "sync"
+    "time"
+)
+
+// DefaultLRUCapacity is used when no capacity is configured
+const DefaultLRUCapacity = 10000
+
+// LRU keeps up to a number of values in the process. The least recently used value is
+// evicted to make room, and the expired ones are dropped when they are read.
+type LRU struct {
+    mu       sync.Mutex
+    capacity int
+    items    map[string]*list.Element
+    order    *list.List // front is the most recently used
+    now      func() time.Time
+}
+
+type lruItem struct {
+    key       string
+    value     []byte
+    expiresAt time.Time
+}
+
+// LRUOption customizes the in-process store
+type LRUOption func(*LRU)
+
+// WithLRUClock replaces the clock of the expirations, for the tests
+func WithLRUClock(now func() time.Time) LRUOption {
+    return func(c *LRU) {
+        c.now = now
+    }
+}
+
+func NewLRU(capacity int, opts ...LRUOption) *LRU {
+    if capacity <= 0 {
+        capacity = DefaultLRUCapacity
+    }
+    c := &LRU{capacity: capacity, items: map[string]*list.Element{}, order: list.New(), now: time.Now}
+    for _, opt := range opts {
+        opt(c)
+    }
+    return c
+}
+
+// Get returns a copy of the value, so the callers cannot change the cached one
+func (c *LRU) Get(_ context.Context, key string) ([]byte, bool, error) {
+    c.mu.Lock()
+    defer c.mu.Unlock()
+    el, ok := c.items[key]
+    if !ok {
+        return nil, false, nil
+    }
+    item := el.Value.(*lruItem)
+    if !c.now().Before(item.expiresAt) {
+        c.remove(el)
+        return nil, false, nil
+    }
+    c.order.MoveToFront(el)
+    return append([]byte(nil), item.value...), true, nil
+}
+
+func (c *LRU) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+    c.mu.Lock()
+    defer c.mu.Unlock()
+    item := &lruItem{key: key, value: append([]byte(nil), value...), expiresAt: c.now().Add(ttl)}
+    if el, ok := c.items[key]; ok {
+        el.Value = item
+        c.order.MoveToFront(el)
+        return nil
+    }
+    c.items[key] = c.order.PushFront(item)
+    for c.order.Len() > c.capacity {
+        c.remove(c.order.Back())
+    }
+    return nil
+}
+
+func (c *LRU) Delete(_ context.Context, keys ...string) error {
+    c.mu.Lock()
+    defer c.mu.Unlock()
+    for _, key := range keys {
+        if el, ok := c.items[key]; ok {
+            c.remove(el)
+        }
+    }
+    return nil
+}
+
+// Len returns the number of values kept, expired or not
+func (c *LRU) Len() int {
+    c.mu.Lock()
+    defer c.mu.Unlock()
+    return c.order.Len()
+}
+
+// remove drops the element. Must hold the lock.
+func (c *LRU) remove(el *list.Element) {
+    c.order.Remove(el)
+    delete(c.items, el.Value.(*lruItem).key)
+}
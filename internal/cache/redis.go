@@ -0,0 +1,212 @@
+package cache
+
+import (
+    "bufio"
+    "context"
+    "errors"
+    "fmt"
+    "io"
+    "net"
+    "strconv"
+    "time"
+)
+
+// RedisConfig locates a Redis-compatible server (Redis, Valkey, KeyDB, Dragonfly...)
+type RedisConfig struct {
+    Addr     string // host:port
+    Password string
+    DB       int
+    Prefix   string // prepended to every key, so several apps can share the server
+    Timeout  time.Duration
+    MaxIdle  int // idle connections kept for reuse
+}
+
+// Redis keeps the values in a Redis server, talking RESP over TCP with GET, SET PX and DEL
+type Redis struct {
+    cfg  RedisConfig
+    idle chan *redisConn
+}
+
+type redisConn struct {
+    conn net.Conn
+    r    *bufio.Reader
+}
+
+// redisError is an error reply of the server
+type redisError string
+
+func (e redisError) Error() string {
+    return "redis: " + string(e)
+}
+
+func NewRedis(cfg RedisConfig) (*Redis, error) {
+    if cfg.Addr == "" {
+        return nil, fmt.Errorf("The Redis address is required")
+    }
+    if cfg.Timeout <= 0 {
+        cfg.Timeout = time.Second
+    }
+    if cfg.MaxIdle <= 0 {
+        cfg.MaxIdle = 8
+    }
+    return &Redis{cfg: cfg, idle: make(chan *redisConn, cfg.MaxIdle)}, nil
+}
+
+func (s *Redis) Get(ctx context.Context, key string) ([]byte, bool, error) {
+    reply, err := s.do(ctx, "GET", s.cfg.Prefix+key)
+    if err != nil {
+        return nil, false, err
+    }
+    if reply == nil {
+        return nil, false, nil
+    }
+    value, ok := reply.([]byte)
+    if !ok {
+        return nil, false, fmt.Errorf("redis: unexpected GET reply %v", reply)
+    }
+    return value, true, nil
+}
+
+func (s *Redis) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+    ms := ttl.Milliseconds()
+    if ms <= 0 {
+        return s.Delete(ctx, key)
+    }
+    _, err := s.do(ctx, "SET", s.cfg.Prefix+key, string(value), "PX", strconv.FormatInt(ms, 10))
+    return err
+}
+
+func (s *Redis) Delete(ctx context.Context, keys ...string) error {
+    if len(keys) == 0 {
+        return nil
+    }
+    args := []string{"DEL"}
+    for _, key := range keys {
+        args = append(args, s.cfg.Prefix+key)
+    }
+    _, err := s.do(ctx, args...)
+    return err
+}
+
+// Ping checks that the server answers
+func (s *Redis) Ping(ctx context.Context) error {
+    _, err := s.do(ctx, "PING")
+    return err
+}
+
+// Close closes the idle connections
+func (s *Redis) Close() error {
+    for {
+        select {
+        case c := <-s.idle:
+            c.conn.Close()
+        default:
+            return nil
+        }
+    }
+}
+
+// do runs a command on an idle connection, or a new one, and returns its reply: nil,
+// []byte for bulk strings, string for status replies or int64
+func (s *Redis) do(ctx context.Context, args ...string) (interface{}, error) {
+    c, err := s.conn(ctx)
+    if err != nil {
+        return nil, err
+    }
+    reply, err := c.command(ctx, s.cfg.Timeout, args...)
+    var replyErr redisError
+    if err != nil && !errors.As(err, &replyErr) {
+        // The connection may be left in the middle of a reply
+        c.conn.Close()
+        return nil, err
+    }
+    select {
+    case s.idle <- c:
+    default:
+        c.conn.Close()
+    }
+    return reply, err
+}
+
+// conn returns an idle connection or dials a new one, authenticated and on the configured DB
+func (s *Redis) conn(ctx context.Context) (*redisConn, error) {
+    select {
+    case c := <-s.idle:
+        return c, nil
+    default:
+    }
+    dialer := net.Dialer{Timeout: s.cfg.Timeout}
+    conn, err := dialer.DialContext(ctx, "tcp", s.cfg.Addr)
+    if err != nil {
+        return nil, fmt.Errorf("Error connecting to Redis: %w", err)
+    }
+    c := &redisConn{conn: conn, r: bufio.NewReader(conn)}
+    if s.cfg.Password != "" {
+        if _, err := c.command(ctx, s.cfg.Timeout, "AUTH", s.cfg.Password); err != nil {
+            conn.Close()
+            return nil, err
+        }
+    }
+    if s.cfg.DB != 0 {
+        if _, err := c.command(ctx, s.cfg.Timeout, "SELECT", strconv.Itoa(s.cfg.DB)); err != nil {
+            conn.Close()
+            return nil, err
+        }
+    }
+    return c, nil
+}
+
+// command writes the command as an array of bulk strings and reads its reply
+func (c *redisConn) command(ctx context.Context, timeout time.Duration, args ...string) (interface{}, error) {
+    deadline := time.Now().Add(timeout)
+    if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+        deadline = d
+    }
+    c.conn.SetDeadline(deadline)
+
+    buf := []byte("*" + strconv.Itoa(len(args)) + "\r\n")
+    for _, arg := range args {
+        buf = append(buf, "$"+strconv.Itoa(len(arg))+"\r\n"...)
+        buf = append(buf, arg...)
+        buf = append(buf, "\r\n"...)
+    }
+    if _, err := c.conn.Write(buf); err != nil {
+        return nil, err
+    }
+    return readReply(c.r)
+}
+
+// readReply reads a RESP2 reply. Arrays are not needed by the commands used.
+func readReply(r *bufio.Reader) (interface{}, error) {
+    line, err := r.ReadString('\n')
+    if err != nil {
+        return nil, err
+    }
+    if len(line) < 3 || line[len(line)-2] != '\r' {
+        return nil, fmt.Errorf("redis: invalid reply %q", line)
+    }
+    kind, body := line[0], line[1:len(line)-2]
+    switch kind {
+    case '+':
+        return body, nil
+    case '-':
+        return nil, redisError(body)
+    case ':':
+        return strconv.ParseInt(body, 10, 64)
+    case '$':
+        n, err := strconv.Atoi(body)
+        if err != nil {
+            return nil, fmt.Errorf("redis: invalid bulk length %q", body)
+        }
+        if n < 0 {
+            return nil, nil
+        }
+        value := make([]byte, n+2)
+        if _, err := io.ReadFull(r, value); err != nil {
+            return nil, err
+        }
+        return value[:n], nil
+    default:
+        return nil, fmt.Errorf("redis: unsupported reply %q", line)
+    }
+}
@@ -0,0 +1,26 @@
+package config
+
+import (
+    "log"
+    "strings"
+)
+
+// AuthConfig holds the users allowed to log in
+type AuthConfig struct {
+    Users map[string]string // password of each user
+}
+
+// LoadAuthConfig reads the users from AUTH_USERS, a list of user:password pairs. With no
+// users nobody can log in.
+func LoadAuthConfig() AuthConfig {
+    users := map[string]string{}
+    for _, pair := range getEnvList("AUTH_USERS", nil) {
+        user, password, ok := strings.Cut(pair, ":")
+        if !ok || user == "" || password == "" {
+            log.Printf("Invalid AUTH_USERS entry for %q, it needs user:password\n", user)
+            continue
+        }
+        users[user] = password
+    }
+    return AuthConfig{Users: users}
+}
@@ -0,0 +1,32 @@
+package config
+
+// CacheConfig selects where the candidate lookups are cached and for how long
+type CacheConfig struct {
+    Backend       string // none, memory or redis; memory is per instance, redis is shared
+    Capacity      int    // candidates kept by the memory backend
+    RedisAddr     string
+    RedisPassword string
+    RedisDB       int
+    RedisPrefix   string
+    RedisTimeout  int // milliseconds
+    // Seconds the results of each operation are kept, 0 disables its cache
+    TTLGetByID    int
+    TTLGetByEmail int
+    TTLGetByIDs   int
+}
+
+// LoadCacheConfig reads the cache configuration from environment variables
+func LoadCacheConfig() CacheConfig {
+    return CacheConfig{
+        Backend:       getEnv("CACHE_BACKEND", "none"),
+        Capacity:      getEnvPositiveInt("CACHE_CAPACITY", 10000),
+        RedisAddr:     getEnv("CACHE_REDIS_ADDR", "localhost:6379"),
+        RedisPassword: getEnv("CACHE_REDIS_PASSWORD", ""),
+        RedisDB:       getEnvInt("CACHE_REDIS_DB", 0),
+        RedisPrefix:   getEnv("CACHE_REDIS_PREFIX", "seek:"),
+        RedisTimeout:  getEnvPositiveInt("CACHE_REDIS_TIMEOUT_MS", 500),
+        TTLGetByID:    getEnvNonNegativeInt("CACHE_TTL_GET_BY_ID", 60),
+        TTLGetByEmail: getEnvNonNegativeInt("CACHE_TTL_GET_BY_EMAIL", 60),
+        TTLGetByIDs:   getEnvNonNegativeInt("CACHE_TTL_GET_BY_IDS", 60),
+    }
+}
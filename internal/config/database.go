@@ -4,19 +4,43 @@ import (
     "database/sql"
     "fmt"
     "log"
-    "os"
 
     _ "github.com/go-sql-driver/mysql"
+    _ "github.com/lib/pq"
+    _ "modernc.org/sqlite"
 )
 
-func ConnectDB() *sql.DB {
-    // Read environment variable
-    dbURL := os.Getenv("DB_URL")
-    if dbURL == "" {
-        dbURL = ""
+// DatabaseConfig selects the database of the candidates
+type DatabaseConfig struct {
+    Driver  string // mysql, postgres or sqlite
+    URL     string // DSN of the driver; for sqlite a file, e.g. file:seek.db?_pragma=busy_timeout(5000)
+    Migrate bool   // apply the embedded migrations of the driver on start
+    // Transactions aborted by a deadlock or a serialization failure are retried
+    TxMaxRetries     int
+    TxRetryBackoffMs int // milliseconds, multiplied by the attempt number
+    // The candidate lists and searches of the API read from the replicas, if any
+    ReplicaURLs          []string
+    ReadYourWritesMs     int // milliseconds a user reads from the primary after a write
+    ReplicaCheckInterval int // seconds between the health checks of the replicas
+}
+
+// LoadDatabaseConfig reads the database configuration from environment variables
+func LoadDatabaseConfig() DatabaseConfig {
+    return DatabaseConfig{
+        Driver:           getEnv("DB_DRIVER", "mysql"),
+        URL:              getEnv("DB_URL", ""),
+        Migrate:          getEnvBool("DB_MIGRATE", false),
+        TxMaxRetries:     getEnvInt("DB_TX_MAX_RETRIES", 3),
+        TxRetryBackoffMs: getEnvInt("DB_TX_RETRY_BACKOFF_MS", 50),
+
+        ReplicaURLs:          getEnvList("DB_REPLICA_URLS", nil),
+        ReadYourWritesMs:     getEnvInt("DB_READ_YOUR_WRITES_MS", 5000),
+        ReplicaCheckInterval: getEnvPositiveInt("DB_REPLICA_CHECK_INTERVAL", 10),
     }
+}
 
-    db, err := sql.Open("mysql", dbURL)
+func ConnectDB(cfg DatabaseConfig) *sql.DB {
+    db, err := sql.Open(cfg.Driver, cfg.URL)
     if err != nil {
         log.Fatalf("Error to open conexion: %v\n", err)
     }
@@ -29,3 +53,20 @@ func ConnectDB() *sql.DB {
     fmt.Println("Connection successful")
     return db
 }
+
+// ConnectReplicas opens the read replicas. A replica that does not answer is kept, the
+// router sends no reads to it until a health check succeeds.
+func ConnectReplicas(cfg DatabaseConfig) []*sql.DB {
+    var replicas []*sql.DB
+    for i, url := range cfg.ReplicaURLs {
+        db, err := sql.Open(cfg.Driver, url)
+        if err != nil {
+            log.Fatalf("Error to open replica %d: %v\n", i+1, err)
+        }
+        if err := db.Ping(); err != nil {
+            log.Printf("Replica %d is not available: %v\n", i+1, err)
+        }
+        replicas = append(replicas, db)
+    }
+    return replicas
+}
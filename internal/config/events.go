@@ -0,0 +1,17 @@
+package config
+
+// EventsConfig holds the settings of the candidate event stream
+type EventsConfig struct {
+    ReplaySize       int // events kept to resume the streams
+    SubscriberBuffer int // events queued per client before it is disconnected
+    Heartbeat        int // seconds between heartbeats
+}
+
+// LoadEventsConfig reads the event stream configuration from environment variables
+func LoadEventsConfig() EventsConfig {
+    return EventsConfig{
+        ReplaySize:       getEnvInt("SSE_REPLAY_SIZE", 1000),
+        SubscriberBuffer: getEnvInt("SSE_SUBSCRIBER_BUFFER", 64),
+        Heartbeat:        getEnvPositiveInt("SSE_HEARTBEAT", 15),
+    }
+}
@@ -0,0 +1,15 @@
+package config
+
+// GraphQLConfig holds the limits of the GraphQL endpoint
+type GraphQLConfig struct {
+    MaxDepth      int // levels of nested fields
+    MaxComplexity int // fields to resolve, counting each item of the lists
+}
+
+// LoadGraphQLConfig reads the GraphQL configuration from environment variables
+func LoadGraphQLConfig() GraphQLConfig {
+    return GraphQLConfig{
+        MaxDepth:      getEnvInt("GRAPHQL_MAX_DEPTH", 8),
+        MaxComplexity: getEnvInt("GRAPHQL_MAX_COMPLEXITY", 5000),
+    }
+}
@@ -0,0 +1,17 @@
+package config
+
+// GRPCConfig holds the settings of the gRPC server
+type GRPCConfig struct {
+    Enabled    bool
+    Port       string
+    Reflection bool
+}
+
+// LoadGRPCConfig reads the gRPC configuration from environment variables
+func LoadGRPCConfig() GRPCConfig {
+    return GRPCConfig{
+        Enabled:    getEnvBool("GRPC_ENABLED", true),
+        Port:       getEnv("GRPC_PORT", "9090"),
+        Reflection: getEnvBool("GRPC_REFLECTION", true),
+    }
+}
@@ -0,0 +1,122 @@
+package config
+
+import (
+    "log"
+    "os"
+    "strconv"
+    "strings"
+)
+
+// CORSConfig holds the cross-origin settings for the API
+type CORSConfig struct {
+    AllowedOrigins   []string
+    AllowedMethods   []string
+    AllowedHeaders   []string
+    ExposedHeaders   []string
+    AllowCredentials bool
+    MaxAge           int // seconds
+}
+
+// SecurityHeadersConfig holds the values of the standard security headers
+type SecurityHeadersConfig struct {
+    HSTSMaxAge     int // seconds, 0 disables the header
+    FrameOptions   string
+    CSP            string
+    SwaggerCSP     string
+    ReferrerPolicy string
+}
+
+// HTTPConfig groups the settings of the HTTP server
+type HTTPConfig struct {
+    Port           string
+    CORS           CORSConfig
+    Headers        SecurityHeadersConfig
+    TrustedProxies []string
+}
+
+// LoadHTTPConfig reads the HTTP configuration from environment variables
+func LoadHTTPConfig() HTTPConfig {
+    port := os.Getenv("PORT")
+    if port == "" {
+        port = "8080" // solo para local, en Heroku vendrá un número aleatorio
+    }
+
+    return HTTPConfig{
+        Port: port,
+        CORS: CORSConfig{
+            AllowedOrigins:   getEnvList("CORS_ALLOWED_ORIGINS", nil),
+            AllowedMethods:   getEnvList("CORS_ALLOWED_METHODS", []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"}),
+            AllowedHeaders:   getEnvList("CORS_ALLOWED_HEADERS", []string{"Authorization", "Content-Type", "Last-Event-ID", "Idempotency-Key"}),
+            ExposedHeaders:   getEnvList("CORS_EXPOSED_HEADERS", []string{"Idempotent-Replayed"}),
+            AllowCredentials: getEnvBool("CORS_ALLOW_CREDENTIALS", false),
+            MaxAge:           getEnvInt("CORS_MAX_AGE", 600),
+        },
+        Headers: SecurityHeadersConfig{
+            HSTSMaxAge:     getEnvInt("HSTS_MAX_AGE", 31536000),
+            FrameOptions:   getEnv("FRAME_OPTIONS", "DENY"),
+            CSP:            getEnv("CONTENT_SECURITY_POLICY", "default-src 'none'; frame-ancestors 'none'"),
+            SwaggerCSP:     getEnv("SWAGGER_CONTENT_SECURITY_POLICY", "default-src 'self'; script-src 'self' 'unsafe-inline'; style-src 'self' 'unsafe-inline'; img-src 'self' data:; frame-ancestors 'none'"),
+            ReferrerPolicy: getEnv("REFERRER_POLICY", "no-referrer"),
+        },
+        TrustedProxies: getEnvList("TRUSTED_PROXIES", nil),
+    }
+}
+
+func getEnv(key, def string) string {
+    if v, ok := os.LookupEnv(key); ok {
+        return v
+    }
+    return def
+}
+
+func getEnvList(key string, def []string) []string {
+    v, ok := os.LookupEnv(key)
+    if !ok {
+        return def
+    }
+    var list []string
+    for _, item := range strings.Split(v, ",") {
+        if item = strings.TrimSpace(item); item != "" {
+            list = append(list, item)
+        }
+    }
+    return list
+}
+
+func getEnvBool(key string, def bool) bool {
+    v, err := strconv.ParseBool(os.Getenv(key))
+    if err != nil {
+        return def
+    }
+    return v
+}
+
+func getEnvInt(key string, def int) int {
+    v, err := strconv.Atoi(os.Getenv(key))
+    if err != nil {
+        return def
+    }
+    return v
+}
+
+// getEnvPositiveInt is getEnvInt for the values that must be greater than zero, such as
+// the periods of the tickers, falling back to the default otherwise
+func getEnvPositiveInt(key string, def int) int {
+    v := getEnvInt(key, def)
+    if v <= 0 {
+        log.Printf("%s must be greater than 0, using %d\n", key, def)
+        return def
+    }
+    return v
+}
+
+// getEnvNonNegativeInt is getEnvInt for the periods where 0 disables the task, falling
+// back to the default for negative values
+func getEnvNonNegativeInt(key string, def int) int {
+    v := getEnvInt(key, def)
+    if v < 0 {
+        log.Printf("%s must not be negative, using %d\n", key, def)
+        return def
+    }
+    return v
+}
@@ -0,0 +1,19 @@
+package config
+
+// IdempotencyConfig holds the settings of the Idempotency-Key support of the POST endpoints
+type IdempotencyConfig struct {
+    Store        string // sql or memory
+    TTL          int    // seconds a response is replayed
+    LockTimeout  int    // seconds a request in flight holds its key
+    MaxBodyBytes int    // largest request body accepted with a key, above DOCUMENT_MAX_BYTES for the uploads
+}
+
+// LoadIdempotencyConfig reads the idempotency configuration from environment variables
+func LoadIdempotencyConfig() IdempotencyConfig {
+    return IdempotencyConfig{
+        Store:        getEnv("IDEMPOTENCY_STORE", "sql"),
+        TTL:          getEnvInt("IDEMPOTENCY_TTL", 86400),
+        LockTimeout:  getEnvInt("IDEMPOTENCY_LOCK_TIMEOUT", 60),
+        MaxBodyBytes: getEnvInt("IDEMPOTENCY_MAX_BODY_BYTES", 12<<20),
+    }
+}
@@ -0,0 +1,25 @@
+package config
+
+// OutboxConfig holds where the relay publishes the candidate events and how often
+type OutboxConfig struct {
+    Sink      string // log, http or none; none leaves the events to a relay in another process
+    HTTPURL   string
+    Interval  int // seconds between polls
+    BatchSize int
+    Retention int // seconds the published events are kept, 0 keeps them
+    // RelayEnabled runs the relay in this instance. With several instances it is enabled
+    // in only one of them, otherwise each one publishes the same events.
+    RelayEnabled bool
+}
+
+// LoadOutboxConfig reads the outbox configuration from environment variables
+func LoadOutboxConfig() OutboxConfig {
+    return OutboxConfig{
+        Sink:         getEnv("OUTBOX_SINK", "log"),
+        HTTPURL:      getEnv("OUTBOX_HTTP_URL", ""),
+        Interval:     getEnvPositiveInt("OUTBOX_INTERVAL", 1),
+        BatchSize:    getEnvPositiveInt("OUTBOX_BATCH_SIZE", 100),
+        Retention:    getEnvNonNegativeInt("OUTBOX_RETENTION", 7*24*3600),
+        RelayEnabled: getEnvBool("OUTBOX_RELAY_ENABLED", true),
+    }
+}
@@ -0,0 +1,15 @@
+package config
+
+// SearchConfig holds the settings of the in-memory search index
+type SearchConfig struct {
+    // ReindexInterval is the seconds between rebuilds of the in-memory index from the
+    // database, 0 disables them
+    ReindexInterval int
+}
+
+// LoadSearchConfig reads the search configuration from environment variables
+func LoadSearchConfig() SearchConfig {
+    return SearchConfig{
+        ReindexInterval: getEnvNonNegativeInt("SEARCH_REINDEX_INTERVAL", 300),
+    }
+}
@@ -0,0 +1,16 @@
+package config
+
+// ServiceConfig holds the business rules that can be tuned per environment
+type ServiceConfig struct {
+    BatchMaxItems int
+    // HiringPipeline is parsed by domain.ParsePipeline, empty means the default pipeline
+    HiringPipeline string
+}
+
+// LoadServiceConfig reads the service configuration from environment variables
+func LoadServiceConfig() ServiceConfig {
+    return ServiceConfig{
+        BatchMaxItems:  getEnvInt("BATCH_MAX_ITEMS", 100),
+        HiringPipeline: getEnv("HIRING_PIPELINE", ""),
+    }
+}
@@ -0,0 +1,45 @@
+package config
+
+import "os"
+
+// S3Config locates the S3-compatible bucket of the documents
+type S3Config struct {
+    Endpoint  string
+    Bucket    string
+    Region    string
+    AccessKey string
+    SecretKey string
+    PathStyle bool
+}
+
+// StorageConfig holds where the documents are kept and the limits of the uploads
+type StorageConfig struct {
+    Driver            string // local or s3
+    LocalDir          string
+    S3                S3Config
+    DocumentMaxBytes  int
+    DocumentURLTTL    int // seconds
+    DocumentURLSecret string
+    PurgeInterval     int // seconds, 0 disables the periodic purge
+}
+
+// LoadStorageConfig reads the storage configuration from environment variables
+func LoadStorageConfig() StorageConfig {
+    return StorageConfig{
+        Driver:   getEnv("STORAGE_DRIVER", "local"),
+        LocalDir: getEnv("STORAGE_LOCAL_DIR", "./data/documents"),
+        S3: S3Config{
+            Endpoint:  getEnv("S3_ENDPOINT", "https://s3.amazonaws.com"),
+            Bucket:    getEnv("S3_BUCKET", ""),
+            Region:    getEnv("S3_REGION", "us-east-1"),
+            AccessKey: getEnv("S3_ACCESS_KEY", ""),
+            SecretKey: getEnv("S3_SECRET_KEY", ""),
+            PathStyle: getEnvBool("S3_PATH_STYLE", false),
+        },
+        DocumentMaxBytes: getEnvInt("DOCUMENT_MAX_BYTES", 10<<20),
+        DocumentURLTTL:   getEnvInt("DOCUMENT_URL_TTL", 900),
+        // The download links are signed with the JWT secret unless a specific one is given
+        DocumentURLSecret: getEnv("DOCUMENT_URL_SECRET", os.Getenv("JWT_SECRET")),
+        PurgeInterval:     getEnvNonNegativeInt("DOCUMENT_PURGE_INTERVAL", 3600),
+    }
+}
@@ -0,0 +1,147 @@
+package dedupe
+
+import (
+    "math"
+    "sort"
+    "strings"
+    "unicode"
+    "unicode/utf8"
+
+    "github.com/torvictorvic/seek-v2/internal/domain"
+    "github.com/torvictorvic/seek-v2/internal/search"
+)
+
+// DefaultThreshold is the minimum score reported as a duplicate
+const DefaultThreshold = 0.7
+
+// Signal compares one aspect of two candidates. It returns a similarity between 0 and 1
+// and the reason shown to the recruiter when it is relevant.
+type Signal struct {
+    Weight  float64
+    Compare func(a, b domain.Candidate) (float64, string)
+}
+
+// Signals are the comparisons combined in the score
+var Signals = []Signal{
+    {Weight: 0.55, Compare: compareNames},
+    {Weight: 0.45, Compare: compareEmails},
+}
+
+// Score combines the signals into a weighted similarity between 0 and 1
+func Score(a, b domain.Candidate) (float64, []string) {
+    var score, total float64
+    reasons := []string{}
+    for _, s := range Signals {
+        sim, reason := s.Compare(a, b)
+        if sim < 0 {
+            // The signal does not apply (missing data on either side)
+            continue
+        }
+        score += s.Weight * sim
+        total += s.Weight
+        if reason != "" {
+            reasons = append(reasons, reason)
+        }
+    }
+    if total == 0 {
+        return 0, reasons
+    }
+    return math.Round(score/total*1000) / 1000, reasons
+}
+
+// NormalizeName removes accents, punctuation and the order of the words, so
+// "Walker, Anna" and "anna walker" are equal
+func NormalizeName(name string) string {
+    words := strings.FieldsFunc(search.Normalize(name), func(r rune) bool {
+        return !unicode.IsLetter(r)
+    })
+    sort.Strings(words)
+    return strings.Join(words, " ")
+}
+
+// NormalizeEmailLocal keeps the local part of the email without "+tags", dots and other separators
+func NormalizeEmailLocal(email string) string {
+    local, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(email)), "@")
+    local, _, _ = strings.Cut(local, "+")
+    return strings.Map(func(r rune) rune {
+        if unicode.IsLetter(r) || unicode.IsDigit(r) {
+            return r
+        }
+        return -1
+    }, search.Normalize(local))
+}
+
+// Similarity is 1 minus the edit distance relative to the longest string
+func Similarity(a, b string) float64 {
+    if a == "" && b == "" {
+        return 1
+    }
+    longest := max(utf8.RuneCountInString(a), utf8.RuneCountInString(b))
+    return 1 - float64(search.Levenshtein(a, b))/float64(longest)
+}
+
+func compareNames(a, b domain.Candidate) (float64, string) {
+    na, nb := NormalizeName(a.Name), NormalizeName(b.Name)
+    if na == "" || nb == "" {
+        return -1, ""
+    }
+    sim := Similarity(na, nb)
+    switch {
+    case sim == 1:
+        return 1, "same name"
+    case sim >= 0.8:
+        return sim, "similar name"
+    }
+    return sim, ""
+}
+
+func compareEmails(a, b domain.Candidate) (float64, string) {
+    la, lb := NormalizeEmailLocal(a.Email), NormalizeEmailLocal(b.Email)
+    if la == "" || lb == "" {
+        return -1, ""
+    }
+    if la == lb {
+        return 1, "same email local part"
+    }
+
+    sim := Similarity(la, lb)
+    // A work address such as "awalker" is usually built from the name of the person
+    if nameSim := max(emailMatchesName(la, b.Name), emailMatchesName(lb, a.Name)); nameSim > sim {
+        sim = nameSim
+        if sim >= 0.9 {
+            return sim, "email matches name"
+        }
+    }
+    if sim >= 0.8 {
+        return sim, "similar email local part"
+    }
+    return sim, ""
+}
+
+// emailMatchesName compares the local part with the usual ways of building it from a name:
+// "annawalker", "walkeranna", "awalker"
+func emailMatchesName(local, name string) float64 {
+    words := strings.Fields(search.Normalize(name))
+    if len(words) < 2 {
+        return 0
+    }
+    first, last := onlyLetters(words[0]), onlyLetters(words[len(words)-1])
+    if first == "" || last == "" {
+        return 0
+    }
+    best := 0.0
+    initial := func(s string) string { return string([]rune(s)[:1]) }
+    for _, candidate := range []string{first + last, last + first, initial(first) + last, first + initial(last)} {
+        best = max(best, Similarity(onlyLetters(local), candidate))
+    }
+    return best
+}
+
+func onlyLetters(s string) string {
+    return strings.Map(func(r rune) rune {
+        if unicode.IsLetter(r) {
+            return r
+        }
+        return -1
+    }, s)
+}
@@ -0,0 +1,141 @@
+package domain
+
+import (
+    "fmt"
+    "strings"
+    "time"
+)
+
+// Pipeline stages
+const (
+    StageApplied   = "applied"
+    StageScreening = "screening"
+    StageInterview = "interview"
+    StageOffer     = "offer"
+    StageHired     = "hired"
+    StageRejected  = "rejected"
+)
+
+// Application is the candidacy of a candidate to a job. It moves through the pipeline
+// stages independently of the other applications of the same candidate.
+type Application struct {
+    ID              int               `json:"id"`
+    CandidateID     int               `json:"candidate_id"`
+    JobID           int               `json:"job_id"`
+    Stage           string            `json:"stage" enums:"applied,screening,interview,offer,hired,rejected"`
+    RejectionReason string            `json:"rejection_reason,omitempty"`
+    CreatedAt       time.Time         `json:"created_at"`
+    UpdatedAt       time.Time         `json:"updated_at"`
+    Transitions     []StageTransition `json:"transitions,omitempty"`
+}
+
+// StageTransition records a move of an application between two stages
+type StageTransition struct {
+    ID            int       `json:"id"`
+    ApplicationID int       `json:"application_id"`
+    FromStage     string    `json:"from_stage"` // empty for the initial stage
+    ToStage       string    `json:"to_stage"`
+    Reason        string    `json:"reason,omitempty"`
+    Actor         string    `json:"actor,omitempty"`
+    CreatedAt     time.Time `json:"created_at"`
+}
+
+// ApplyRequest is the body of the application creation
+type ApplyRequest struct {
+    CandidateID int `json:"candidate_id" binding:"required"`
+    JobID       int `json:"job_id" binding:"required"`
+}
+
+// StageChangeRequest is the body of a stage move. RejectionReason is the code of a
+// managed rejection reason and is required when moving to rejected.
+type StageChangeRequest struct {
+    Stage           string `json:"stage" binding:"required"`
+    Reason          string `json:"reason"`
+    RejectionReason string `json:"rejection_reason"`
+}
+
+// RejectionReason is an entry of the managed list of reasons to reject an application
+type RejectionReason struct {
+    Code   string `json:"code" binding:"required"`
+    Label  string `json:"label" binding:"required"`
+    Active bool   `json:"active"`
+}
+
+// Pipeline lists the stages of the hiring process and the moves allowed from each one.
+// The first stage is the one new applications start in.
+type Pipeline struct {
+    Stages      []string
+    Transitions map[string][]string
+}
+
+// DefaultPipeline is the standard hiring process. Any open stage can be rejected.
+func DefaultPipeline() Pipeline {
+    return Pipeline{
+        Stages: []string{StageApplied, StageScreening, StageInterview, StageOffer, StageHired, StageRejected},
+        Transitions: map[string][]string{
+            StageApplied:   {StageScreening, StageRejected},
+            StageScreening: {StageInterview, StageRejected},
+            StageInterview: {StageOffer, StageRejected},
+            StageOffer:     {StageHired, StageRejected},
+        },
+    }
+}
+
+// ParsePipeline reads a pipeline from "stage:next|next,stage:next" pairs, in stage
+// order. An empty spec gives the default pipeline.
+func ParsePipeline(spec string) (Pipeline, error) {
+    if strings.TrimSpace(spec) == "" {
+        return DefaultPipeline(), nil
+    }
+    p := Pipeline{Transitions: map[string][]string{}}
+    seen := map[string]bool{}
+    addStage := func(s string) {
+        if !seen[s] {
+            seen[s] = true
+            p.Stages = append(p.Stages, s)
+        }
+    }
+    for _, pair := range strings.Split(spec, ",") {
+        from, next, _ := strings.Cut(strings.TrimSpace(pair), ":")
+        from = strings.TrimSpace(from)
+        if from == "" {
+            return Pipeline{}, fmt.Errorf("invalid pipeline entry '%s'", pair)
+        }
+        addStage(from)
+        for _, to := range strings.Split(next, "|") {
+            if to = strings.TrimSpace(to); to != "" {
+                addStage(to)
+                p.Transitions[from] = append(p.Transitions[from], to)
+            }
+        }
+    }
+    if !seen[StageRejected] {
+        return Pipeline{}, fmt.Errorf("the pipeline must include the '%s' stage", StageRejected)
+    }
+    return p, nil
+}
+
+// Initial returns the stage of a new application
+func (p Pipeline) Initial() string {
+    return p.Stages[0]
+}
+
+// HasStage reports whether the stage belongs to the pipeline
+func (p Pipeline) HasStage(stage string) bool {
+    for _, s := range p.Stages {
+        if s == stage {
+            return true
+        }
+    }
+    return false
+}
+
+// CanMove reports whether an application can go from one stage to the other
+func (p Pipeline) CanMove(from, to string) bool {
+    for _, s := range p.Transitions[from] {
+        if s == to {
+            return true
+        }
+    }
+    return false
+}
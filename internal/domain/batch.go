@@ -0,0 +1,48 @@
+package domain
+
+// BatchMode defines how a batch reacts when one of its items fails
+type BatchMode string
+
+const (
+    // BatchAllOrNothing writes every item in a single transaction or none at all
+    BatchAllOrNothing BatchMode = "all_or_nothing"
+    // BatchPartial writes the valid items and reports the failed ones
+    BatchPartial BatchMode = "partial"
+)
+
+// Batch item statuses
+const (
+    BatchStatusCreated = "created"
+    BatchStatusUpdated = "updated"
+    BatchStatusDeleted = "deleted"
+    BatchStatusFailed  = "failed"
+    BatchStatusSkipped = "skipped" // not written because another item failed in all_or_nothing mode
+)
+
+// BatchItemResult is the outcome of a single item of a batch
+type BatchItemResult struct {
+    Index  int    `json:"index"`
+    ID     int    `json:"id,omitempty"`
+    Status string `json:"status"`
+    Error  string `json:"error,omitempty"`
+}
+
+// BatchResult is the outcome of a whole batch
+type BatchResult struct {
+    Mode      BatchMode         `json:"mode"`
+    Succeeded int               `json:"succeeded"`
+    Failed    int               `json:"failed"`
+    Items     []BatchItemResult `json:"items"`
+}
+
+// CandidateBatchRequest is the body of the batch create and update endpoints
+type CandidateBatchRequest struct {
+    Mode  BatchMode   `json:"mode" example:"partial"`
+    Items []Candidate `json:"items"`
+}
+
+// CandidateBatchDeleteRequest is the body of the batch delete endpoint
+type CandidateBatchDeleteRequest struct {
+    Mode BatchMode `json:"mode" example:"all_or_nothing"`
+    IDs  []int     `json:"ids"`
+}
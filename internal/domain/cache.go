@@ -0,0 +1,18 @@
+package domain
+
+// CacheStats describes the use of the candidate cache since the start
+type CacheStats struct {
+    Operations         map[string]CacheOperationStats `json:"operations"`          // by operation: get_by_id, get_by_email, get_by_ids
+    Invalidations      int64                          `json:"invalidations"`       // candidates removed from the cache by a write
+    InvalidationErrors int64                          `json:"invalidation_errors"` // failed removals, those candidates may be stale until their TTL
+}
+
+// CacheOperationStats counts the lookups of a cached operation
+type CacheOperationStats struct {
+    TTLSeconds float64 `json:"ttl_seconds"` // 0 when the operation is not cached
+    Hits       int64   `json:"hits"`
+    Misses     int64   `json:"misses"`
+    Shared     int64   `json:"shared"`    // misses answered by a database read already in flight
+    Errors     int64   `json:"errors"`    // failed calls to the cache, answered from the database
+    HitRatio   float64 `json:"hit_ratio"` // hits / (hits + misses)
+}
@@ -3,11 +3,15 @@ package domain
 import "time"
 
 type Candidate struct {
-    ID             int       `json:"id"`
-    Name           string    `json:"name"`
-    Email          string    `json:"email"`
-    Gender         string    `json:"gender"`
-    SalaryExpected float64   `json:"salary_expected"`
-    CreatedAt      time.Time `json:"created_at"`
-    UpdatedAt      time.Time `json:"updated_at"`
+    ID             int                    `json:"id"`
+    Name           string                 `json:"name"`
+    Email          string                 `json:"email"`
+    Gender         string                 `json:"gender"`
+    SalaryExpected float64                `json:"salary_expected"`
+    Tags           []string               `json:"tags,omitempty" example:"backend,referido"`    // unchanged on update when omitted
+    CustomFields   map[string]interface{} `json:"custom_fields,omitempty" swaggertype:"object"` // by field key, see /custom-fields/schema. Unchanged on update when omitted
+    Contact        *ContactDetails        `json:"contact,omitempty"`                            // unchanged on update when omitted
+    CreatedAt      time.Time              `json:"created_at"`
+    UpdatedAt      time.Time              `json:"updated_at"`
+    ResumeText     string                 `json:"-"` // text of the resumes, only loaded by the search
 }
@@ -0,0 +1,49 @@
+package domain
+
+import "strings"
+
+// CandidateFilter holds the optional filters of the candidate list. Empty fields are ignored.
+type CandidateFilter struct {
+    Name      string   `form:"name"`   // partial match
+    Email     string   `form:"email"`  // partial match
+    Gender    string   `form:"gender"` // exact match
+    SalaryMin *float64 `form:"salary_min"`
+    SalaryMax *float64 `form:"salary_max"`
+    Tags      []string `form:"tag"`     // candidates with all the tags
+    Country   string   `form:"country"` // ISO 3166-1 alpha-2 country of the contact details
+    // Fields are built by the service from the cf[key] query parameters
+    Fields []FieldCondition `form:"-"`
+}
+
+// Matches tells whether the candidate satisfies the filter, with the same semantics as the SQL query
+func (f CandidateFilter) Matches(c Candidate) bool {
+    if f.Name != "" && !strings.Contains(strings.ToLower(c.Name), strings.ToLower(f.Name)) {
+        return false
+    }
+    if f.Email != "" && !strings.Contains(strings.ToLower(c.Email), strings.ToLower(f.Email)) {
+        return false
+    }
+    if f.Gender != "" && !strings.EqualFold(c.Gender, f.Gender) {
+        return false
+    }
+    if f.SalaryMin != nil && c.SalaryExpected < *f.SalaryMin {
+        return false
+    }
+    if f.SalaryMax != nil && c.SalaryExpected > *f.SalaryMax {
+        return false
+    }
+    if f.Country != "" && (c.Contact == nil || !strings.EqualFold(c.Contact.Country, f.Country)) {
+        return false
+    }
+    for _, tag := range f.Tags {
+        if !containsValue(c.Tags, strings.ToLower(tag)) {
+            return false
+        }
+    }
+    for _, cond := range f.Fields {
+        if !cond.Matches(c.CustomFields[cond.Key]) {
+            return false
+        }
+    }
+    return true
+}
@@ -0,0 +1,252 @@
+package domain
+
+import (
+    "fmt"
+    "net/url"
+    "strings"
+    "time"
+    _ "time/tzdata" // the time zones are validated without depending on the host
+)
+
+// Phone types
+const (
+    PhoneMobile = "mobile"
+    PhoneHome   = "home"
+    PhoneWork   = "work"
+)
+
+// Contact channels
+const (
+    ChannelEmail    = "email"
+    ChannelPhone    = "phone"
+    ChannelWhatsApp = "whatsapp"
+    ChannelLinkedIn = "linkedin"
+)
+
+// MaxPhones is the maximum number of phone numbers of a candidate
+const MaxPhones = 5
+
+// Phone is a phone number in E.164 format
+type Phone struct {
+    Number  string `json:"number" example:"+51987654321"` // national numbers are completed with the calling code of the country
+    Type    string `json:"type,omitempty" enums:"mobile,home,work"`
+    Primary bool   `json:"primary,omitempty"`
+}
+
+// Address is a postal address, the country is in the contact details
+type Address struct {
+    Line1      string `json:"line1,omitempty" example:"Av. Larco 1150"`
+    Line2      string `json:"line2,omitempty"`
+    City       string `json:"city,omitempty" example:"Lima"`
+    Region     string `json:"region,omitempty" example:"Lima"`
+    PostalCode string `json:"postal_code,omitempty" example:"15074"`
+}
+
+// ContactDetails are the ways to reach a candidate. Omitted on a candidate update, the
+// current ones are kept; sent, they replace the current ones.
+type ContactDetails struct {
+    Phones           []Phone  `json:"phones,omitempty"`
+    Address          *Address `json:"address,omitempty"`
+    Country          string   `json:"country,omitempty" example:"PE"`             // ISO 3166-1 alpha-2
+    TimeZone         string   `json:"time_zone,omitempty" example:"America/Lima"` // IANA
+    LinkedInURL      string   `json:"linkedin_url,omitempty" example:"https://www.linkedin.com/in/janedoe"`
+    GitHubURL        string   `json:"github_url,omitempty" example:"https://github.com/janedoe"`
+    PortfolioURL     string   `json:"portfolio_url,omitempty" example:"https://janedoe.dev"`
+    PreferredChannel string   `json:"preferred_channel,omitempty" enums:"email,phone,whatsapp,linkedin"`
+}
+
+// Normalize validates the contact details and leaves them in their canonical form: phones
+// in E.164, the country in uppercase and the URLs with their scheme
+func (c *ContactDetails) Normalize() error {
+    c.Country = strings.ToUpper(strings.TrimSpace(c.Country))
+    if c.Country != "" && !ValidCountry(c.Country) {
+        return fmt.Errorf("the country must be an ISO 3166-1 alpha-2 code")
+    }
+    if c.TimeZone != "" {
+        if _, err := time.LoadLocation(c.TimeZone); err != nil {
+            return fmt.Errorf("unknown time zone '%s'", c.TimeZone)
+        }
+    }
+
+    if len(c.Phones) > MaxPhones {
+        return fmt.Errorf("at most %d phones are allowed", MaxPhones)
+    }
+    primaries := 0
+    for i := range c.Phones {
+        p := &c.Phones[i]
+        number, err := NormalizePhone(p.Number, c.Country)
+        if err != nil {
+            return err
+        }
+        p.Number = number
+        switch p.Type {
+        case "", PhoneMobile, PhoneHome, PhoneWork:
+        default:
+            return fmt.Errorf("the phone type must be mobile, home or work")
+        }
+        for _, other := range c.Phones[:i] {
+            if other.Number == p.Number {
+                return fmt.Errorf("the phone %s is repeated", p.Number)
+            }
+        }
+        if p.Primary {
+            primaries++
+        }
+    }
+    if primaries > 1 {
+        return fmt.Errorf("only one phone can be primary")
+    }
+    if primaries == 0 && len(c.Phones) > 0 {
+        c.Phones[0].Primary = true
+    }
+
+    if c.Address != nil {
+        a := c.Address
+        for _, field := range []*string{&a.Line1, &a.Line2, &a.City, &a.Region, &a.PostalCode} {
+            *field = strings.TrimSpace(*field)
+            if len(*field) > 255 {
+                return fmt.Errorf("the address fields cannot exceed 255 characters")
+            }
+        }
+        if *a == (Address{}) {
+            c.Address = nil
+        }
+    }
+
+    var err error
+    if c.LinkedInURL, err = normalizeURL("linkedin_url", c.LinkedInURL, "linkedin.com"); err != nil {
+        return err
+    }
+    if c.GitHubURL, err = normalizeURL("github_url", c.GitHubURL, "github.com"); err != nil {
+        return err
+    }
+    if c.PortfolioURL, err = normalizeURL("portfolio_url", c.PortfolioURL, ""); err != nil {
+        return err
+    }
+
+    switch c.PreferredChannel {
+    case "", ChannelEmail:
+    case ChannelPhone, ChannelWhatsApp:
+        if len(c.Phones) == 0 {
+            return fmt.Errorf("the preferred channel %s needs a phone", c.PreferredChannel)
+        }
+    case ChannelLinkedIn:
+        if c.LinkedInURL == "" {
+            return fmt.Errorf("the preferred channel linkedin needs the linkedin_url")
+        }
+    default:
+        return fmt.Errorf("the preferred channel must be email, phone, whatsapp or linkedin")
+    }
+    return nil
+}
+
+// PrimaryPhone returns the primary phone number, empty when there is none
+func (c ContactDetails) PrimaryPhone() string {
+    for _, p := range c.Phones {
+        if p.Primary {
+            return p.Number
+        }
+    }
+    return ""
+}
+
+// normalizeURL adds the https scheme when missing and checks the host of the profile sites
+func normalizeURL(field, raw, site string) (string, error) {
+    raw = strings.TrimSpace(raw)
+    if raw == "" {
+        return "", nil
+    }
+    if !strings.Contains(raw, "://") {
+        raw = "https://" + raw
+    }
+    u, err := url.Parse(raw)
+    if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" || len(raw) > 255 {
+        return "", fmt.Errorf("'%s' must be an http or https URL", field)
+    }
+    host := strings.ToLower(u.Hostname())
+    if site != "" && host != site && !strings.HasSuffix(host, "."+site) {
+        return "", fmt.Errorf("'%s' must be a %s URL", field, site)
+    }
+    return raw, nil
+}
+
+// NormalizePhone returns the number in E.164 (+ and up to 15 digits). Numbers without
+// the international prefix take the calling code of the country, dropping the trunk 0.
+func NormalizePhone(raw, country string) (string, error) {
+    raw = strings.TrimSpace(raw)
+    var digits strings.Builder
+    for i, r := range raw {
+        switch {
+        case r >= '0' && r <= '9':
+            digits.WriteRune(r)
+        case r == '+' && i == 0:
+        case r == ' ' || r == '-' || r == '.' || r == '(' || r == ')':
+        default:
+            return "", fmt.Errorf("the phone '%s' has invalid characters", raw)
+        }
+    }
+    number := digits.String()
+    switch {
+    case strings.HasPrefix(raw, "+"):
+    case strings.HasPrefix(number, "00"):
+        number = number[2:]
+    default:
+        code, ok := callingCodes[country]
+        if !ok {
+            return "", fmt.Errorf("the phone '%s' needs the international prefix or the country of the candidate", raw)
+        }
+        // Italy keeps the leading 0 of its numbers
+        if strings.HasPrefix(number, "0") && code != "39" {
+            number = number[1:]
+        }
+        if code == "1" && len(number) == 11 && strings.HasPrefix(number, "1") {
+            number = number[1:]
+        }
+        number = code + number
+    }
+    if len(number) < 8 || len(number) > 15 || number[0] == '0' || !knownCallingCode(number) {
+        return "", fmt.Errorf("the phone '%s' is not a valid international number", raw)
+    }
+    return "+" + number, nil
+}
+
+func knownCallingCode(number string) bool {
+    for n := 1; n <= 3 && n < len(number); n++ {
+        if knownCodes[number[:n]] {
+            return true
+        }
+    }
+    return false
+}
+
+// ValidCountry tells whether the code is an ISO 3166-1 alpha-2 country with a calling code
+func ValidCountry(code string) bool {
+    _, ok := callingCodes[code]
+    return ok
+}
+
+// countryCodes lists the ISO 3166-1 alpha-2 countries with their calling code
+const countryCodes = "AD376 AE971 AF93 AG1 AI1 AL355 AM374 AO244 AR54 AS1 AT43 AU61 AW297 AX358 AZ994 " +
+    "BA387 BB1 BD880 BE32 BF226 BG359 BH973 BI257 BJ229 BL590 BM1 BN673 BO591 BQ599 BR55 BS1 BT975 BW267 BY375 BZ501 " +
+    "CA1 CC61 CD243 CF236 CG242 CH41 CI225 CK682 CL56 CM237 CN86 CO57 CR506 CU53 CV238 CW599 CX61 CY357 CZ420 " +
+    "DE49 DJ253 DK45 DM1 DO1 DZ213 EC593 EE372 EG20 EH212 ER291 ES34 ET251 FI358 FJ679 FK500 FM691 FO298 FR33 " +
+    "GA241 GB44 GD1 GE995 GF594 GG44 GH233 GI350 GL299 GM220 GN224 GP590 GQ240 GR30 GT502 GU1 GW245 GY592 " +
+    "HK852 HN504 HR385 HT509 HU36 ID62 IE353 IL972 IM44 IN91 IO246 IQ964 IR98 IS354 IT39 JE44 JM1 JO962 JP81 " +
+    "KE254 KG996 KH855 KI686 KM269 KN1 KP850 KR82 KW965 KY1 KZ7 LA856 LB961 LC1 LI423 LK94 LR231 LS266 LT370 LU352 LV371 LY218 " +
+    "MA212 MC377 MD373 ME382 MF590 MG261 MH692 MK389 ML223 MM95 MN976 MO853 MP1 MQ596 MR222 MS1 MT356 MU230 MV960 MW265 MX52 MY60 MZ258 " +
+    "NA264 NC687 NE227 NF672 NG234 NI505 NL31 NO47 NP977 NR674 NU683 NZ64 OM968 " +
+    "PA507 PE51 PF689 PG675 PH63 PK92 PL48 PM508 PR1 PS970 PT351 PW680 PY595 QA974 RE262 RO40 RS381 RU7 RW250 " +
+    "SA966 SB677 SC248 SD249 SE46 SG65 SH290 SI386 SJ47 SK421 SL232 SM378 SN221 SO252 SR597 SS211 ST239 SV503 SX1 SY963 SZ268 " +
+    "TC1 TD235 TG228 TH66 TJ992 TK690 TL670 TM993 TN216 TO676 TR90 TT1 TV688 TW886 TZ255 " +
+    "UA380 UG256 US1 UY598 UZ998 VA39 VC1 VE58 VG1 VI1 VN84 VU678 WF681 WS685 YE967 YT262 ZA27 ZM260 ZW263"
+
+var callingCodes, knownCodes = parseCountryCodes(countryCodes)
+
+func parseCountryCodes(s string) (map[string]string, map[string]bool) {
+    countries, codes := map[string]string{}, map[string]bool{}
+    for _, entry := range strings.Fields(s) {
+        countries[entry[:2]] = entry[2:]
+        codes[entry[2:]] = true
+    }
+    return countries, codes
+}
@@ -0,0 +1,336 @@
+package domain
+
+import (
+    "encoding/json"
+    "fmt"
+    "regexp"
+    "sort"
+    "strconv"
+    "strings"
+    "time"
+)
+
+// Custom field types
+const (
+    FieldText        = "text"
+    FieldNumber      = "number"
+    FieldDate        = "date"
+    FieldEnum        = "enum"
+    FieldMultiSelect = "multi_select"
+)
+
+// DateLayout is the format of the date custom fields
+const DateLayout = "2006-01-02"
+
+// MaxTagLength is the maximum length of a tag
+const MaxTagLength = 64
+
+// MaxTextFieldLength is the maximum length of a text custom field value
+const MaxTextFieldLength = 255
+
+var fieldKeyPattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,63}$`)
+
+// CustomField is an admin-defined attribute of the candidates
+type CustomField struct {
+    ID        int       `json:"id"`
+    Key       string    `json:"key" example:"years_experience"` // immutable, used in the filters and exports
+    Label     string    `json:"label" example:"Años de experiencia"`
+    Type      string    `json:"type" example:"number" enums:"text,number,date,enum,multi_select"`
+    Options   []string  `json:"options,omitempty"` // allowed values of enum and multi_select
+    Required  bool      `json:"required"`
+    CreatedAt time.Time `json:"created_at"`
+}
+
+// CandidateAttributes are the tags and custom field values of a candidate
+type CandidateAttributes struct {
+    Tags         []string
+    CustomFields map[string]interface{}
+}
+
+// TagCount is a tag in use and the number of candidates that have it
+type TagCount struct {
+    Tag        string `json:"tag"`
+    Candidates int    `json:"candidates"`
+}
+
+// FieldValue is a stored value of a custom field. Multi-select fields have one per option.
+type FieldValue struct {
+    Key    string
+    Text   string
+    Number *float64
+    Date   *time.Time
+}
+
+// ValidKey tells whether the key can be used for a custom field
+func ValidKey(key string) bool {
+    return fieldKeyPattern.MatchString(key)
+}
+
+// Validate checks the definition of the field
+func (f CustomField) Validate() error {
+    if !ValidKey(f.Key) {
+        return fmt.Errorf("the key must be lowercase letters, digits and '_', starting with a letter")
+    }
+    if strings.TrimSpace(f.Label) == "" {
+        return fmt.Errorf("the field 'label' is required")
+    }
+    switch f.Type {
+    case FieldText, FieldNumber, FieldDate:
+        if len(f.Options) > 0 {
+            return fmt.Errorf("only enum and multi_select fields have options")
+        }
+    case FieldEnum, FieldMultiSelect:
+        if len(f.Options) == 0 {
+            return fmt.Errorf("the field 'options' is required for %s fields", f.Type)
+        }
+        seen := map[string]bool{}
+        for _, o := range f.Options {
+            if strings.TrimSpace(o) == "" || len(o) > MaxTextFieldLength || seen[o] {
+                return fmt.Errorf("the options must be unique and not empty")
+            }
+            seen[o] = true
+        }
+    default:
+        return fmt.Errorf("the type must be text, number, date, enum or multi_select")
+    }
+    return nil
+}
+
+// Normalize checks a value against the field and returns it in its canonical form:
+// string for text, enum and date (YYYY-MM-DD), float64 for number and []string for multi_select
+func (f CustomField) Normalize(value interface{}) (interface{}, error) {
+    switch f.Type {
+    case FieldText:
+        s, ok := value.(string)
+        if !ok {
+            return nil, fmt.Errorf("'%s' must be a string", f.Key)
+        }
+        if len(s) > MaxTextFieldLength {
+            return nil, fmt.Errorf("'%s' exceeds %d characters", f.Key, MaxTextFieldLength)
+        }
+        return s, nil
+    case FieldNumber:
+        switch v := value.(type) {
+        case float64:
+            return v, nil
+        case int:
+            return float64(v), nil
+        case json.Number:
+            return v.Float64()
+        case string:
+            n, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+            if err != nil {
+                return nil, fmt.Errorf("'%s' must be a number", f.Key)
+            }
+            return n, nil
+        }
+        return nil, fmt.Errorf("'%s' must be a number", f.Key)
+    case FieldDate:
+        s, ok := value.(string)
+        if !ok {
+            return nil, fmt.Errorf("'%s' must be a date YYYY-MM-DD", f.Key)
+        }
+        if _, err := time.Parse(DateLayout, s); err != nil {
+            return nil, fmt.Errorf("'%s' must be a date YYYY-MM-DD", f.Key)
+        }
+        return s, nil
+    case FieldEnum:
+        s, ok := value.(string)
+        if !ok || !f.hasOption(s) {
+            return nil, fmt.Errorf("'%s' must be one of: %s", f.Key, strings.Join(f.Options, ", "))
+        }
+        return s, nil
+    case FieldMultiSelect:
+        var items []string
+        switch v := value.(type) {
+        case []string:
+            items = v
+        case []interface{}:
+            for _, item := range v {
+                s, ok := item.(string)
+                if !ok {
+                    return nil, fmt.Errorf("'%s' must be a list of strings", f.Key)
+                }
+                items = append(items, s)
+            }
+        default:
+            return nil, fmt.Errorf("'%s' must be a list of strings", f.Key)
+        }
+        selected := []string{}
+        for _, s := range items {
+            if !f.hasOption(s) {
+                return nil, fmt.Errorf("'%s' must only contain: %s", f.Key, strings.Join(f.Options, ", "))
+            }
+            if !containsValue(selected, s) {
+                selected = append(selected, s)
+            }
+        }
+        return selected, nil
+    }
+    return nil, fmt.Errorf("'%s' has an unknown type", f.Key)
+}
+
+// Values converts a normalized value to its stored form
+func (f CustomField) Values(value interface{}) []FieldValue {
+    switch v := value.(type) {
+    case float64:
+        return []FieldValue{{Key: f.Key, Text: strconv.FormatFloat(v, 'f', -1, 64), Number: &v}}
+    case []string:
+        values := make([]FieldValue, len(v))
+        for i, s := range v {
+            values[i] = FieldValue{Key: f.Key, Text: s}
+        }
+        return values
+    case string:
+        if f.Type == FieldDate {
+            d, _ := time.Parse(DateLayout, v)
+            return []FieldValue{{Key: f.Key, Text: v, Date: &d}}
+        }
+        return []FieldValue{{Key: f.Key, Text: v}}
+    }
+    return nil
+}
+
+func (f CustomField) hasOption(s string) bool {
+    return containsValue(f.Options, s)
+}
+
+func containsValue(list []string, s string) bool {
+    for _, item := range list {
+        if item == s {
+            return true
+        }
+    }
+    return false
+}
+
+// NormalizeTags trims and lowercases the tags, drops the empty and repeated ones and sorts them
+func NormalizeTags(tags []string) ([]string, error) {
+    normalized := []string{}
+    for _, t := range tags {
+        t = strings.ToLower(strings.TrimSpace(t))
+        if t == "" || containsValue(normalized, t) {
+            continue
+        }
+        if len(t) > MaxTagLength {
+            return nil, fmt.Errorf("the tag '%s' exceeds %d characters", t, MaxTagLength)
+        }
+        normalized = append(normalized, t)
+    }
+    sort.Strings(normalized)
+    return normalized, nil
+}
+
+// FieldCondition filters the candidates by a custom field. Text fields match partially,
+// enum and multi_select exactly (multi_select when any selected option matches) and
+// number and date fields by range, with inclusive and optional bounds.
+type FieldCondition struct {
+    Key   string
+    Type  string
+    Value string
+    Min   *float64
+    Max   *float64
+    From  string // YYYY-MM-DD
+    To    string // YYYY-MM-DD
+}
+
+// ParseFieldCondition builds the condition of the field from a query value. Number and date
+// fields take a range "min..max" where either side may be empty, or a single value.
+func ParseFieldCondition(f CustomField, raw string) (FieldCondition, error) {
+    cond := FieldCondition{Key: f.Key, Type: f.Type}
+    switch f.Type {
+    case FieldNumber:
+        lo, hi := splitRange(raw)
+        for _, bound := range []struct {
+            text string
+            dest **float64
+        }{{lo, &cond.Min}, {hi, &cond.Max}} {
+            if bound.text == "" {
+                continue
+            }
+            n, err := strconv.ParseFloat(bound.text, 64)
+            if err != nil {
+                return cond, fmt.Errorf("'%s' must be a number or a range min..max", f.Key)
+            }
+            *bound.dest = &n
+        }
+    case FieldDate:
+        lo, hi := splitRange(raw)
+        for _, d := range []string{lo, hi} {
+            if _, err := time.Parse(DateLayout, d); d != "" && err != nil {
+                return cond, fmt.Errorf("'%s' must be a date or a range YYYY-MM-DD..YYYY-MM-DD", f.Key)
+            }
+        }
+        cond.From, cond.To = lo, hi
+    default:
+        cond.Value = raw
+    }
+    return cond, nil
+}
+
+func splitRange(raw string) (string, string) {
+    raw = strings.TrimSpace(raw)
+    if lo, hi, ok := strings.Cut(raw, ".."); ok {
+        return strings.TrimSpace(lo), strings.TrimSpace(hi)
+    }
+    return raw, raw
+}
+
+// Matches tells whether the value of the candidate satisfies the condition
+func (c FieldCondition) Matches(value interface{}) bool {
+    switch c.Type {
+    case FieldNumber:
+        n, ok := value.(float64)
+        return ok && (c.Min == nil || n >= *c.Min) && (c.Max == nil || n <= *c.Max)
+    case FieldDate:
+        d, ok := value.(string)
+        // YYYY-MM-DD dates sort as strings
+        return ok && (c.From == "" || d >= c.From) && (c.To == "" || d <= c.To)
+    case FieldText:
+        s, ok := value.(string)
+        return ok && strings.Contains(strings.ToLower(s), strings.ToLower(c.Value))
+    case FieldMultiSelect:
+        items, ok := value.([]string)
+        return ok && containsValue(items, c.Value)
+    default:
+        s, ok := value.(string)
+        return ok && s == c.Value
+    }
+}
+
+// CustomFieldsSchema describes the custom_fields object of the candidates as a JSON Schema
+func CustomFieldsSchema(fields []CustomField) map[string]interface{} {
+    properties := map[string]interface{}{}
+    required := []string{}
+    for _, f := range fields {
+        prop := map[string]interface{}{"title": f.Label}
+        switch f.Type {
+        case FieldNumber:
+            prop["type"] = "number"
+        case FieldDate:
+            prop["type"] = "string"
+            prop["format"] = "date"
+        case FieldEnum:
+            prop["type"] = "string"
+            prop["enum"] = f.Options
+        case FieldMultiSelect:
+            prop["type"] = "array"
+            prop["items"] = map[string]interface{}{"type": "string", "enum": f.Options}
+            prop["uniqueItems"] = true
+        default:
+            prop["type"] = "string"
+            prop["maxLength"] = MaxTextFieldLength
+        }
+        properties[f.Key] = prop
+        if f.Required {
+            required = append(required, f.Key)
+        }
+    }
+    return map[string]interface{}{
+        "$schema":              "https://json-schema.org/draft/2020-12/schema",
+        "type":                 "object",
+        "properties":           properties,
+        "required":             required,
+        "additionalProperties": false,
+    }
+}
@@ -0,0 +1,31 @@
+package domain
+
+import "time"
+
+// Document kinds, derived from the sniffed content type
+const (
+    DocumentPDF   = "pdf"
+    DocumentDOCX  = "docx"
+    DocumentImage = "image"
+)
+
+// Document is a file attached to a candidate, like a resume or a certificate
+type Document struct {
+    ID          int       `json:"id"`
+    CandidateID int       `json:"candidate_id"`
+    FileName    string    `json:"file_name" example:"cv.pdf"`
+    ContentType string    `json:"content_type" example:"application/pdf"` // sniffed from the content, not the one sent by the client
+    Kind        string    `json:"kind" enums:"pdf,docx,image"`
+    Size        int64     `json:"size"`
+    SHA256      string    `json:"sha256"`
+    StorageKey  string    `json:"-"`
+    Text        string    `json:"-"` // extracted from PDF and DOCX documents
+    UploadedBy  string    `json:"uploaded_by"`
+    CreatedAt   time.Time `json:"created_at"`
+}
+
+// DocumentURL is a signed link to download a document without a token
+type DocumentURL struct {
+    URL       string    `json:"url"`
+    ExpiresAt time.Time `json:"expires_at"`
+}
@@ -0,0 +1,21 @@
+package domain
+
+// DuplicateCandidate is a candidate that is probably the same person as another one
+type DuplicateCandidate struct {
+    Candidate Candidate `json:"candidate"`
+    Score     float64   `json:"score"`
+    Reasons   []string  `json:"reasons"`
+}
+
+// Sides of a merge
+const (
+    MergeKeepTarget = "target"
+    MergeKeepSource = "source"
+)
+
+// MergeRequest merges the source candidate into the target one. Fields tells, per attribute
+// (name, email, gender, salary_expected), which side keeps its value; the target by default.
+type MergeRequest struct {
+    SourceID int               `json:"source_id" binding:"required"`
+    Fields   map[string]string `json:"fields" example:"email:source"`
+}
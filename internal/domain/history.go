@@ -0,0 +1,28 @@
+package domain
+
+import (
+    "encoding/json"
+    "time"
+)
+
+// History actions
+const (
+    HistoryMerged          = "merged" // the candidate absorbed another one
+    HistoryNoteAdded       = "note_added"
+    HistoryNoteEdited      = "note_edited"
+    HistoryNotePinned      = "note_pinned"
+    HistoryNoteUnpinned    = "note_unpinned"
+    HistoryNoteDeleted     = "note_deleted"
+    HistoryDocumentAdded   = "document_added"
+    HistoryDocumentDeleted = "document_deleted"
+)
+
+// CandidateHistoryEntry records a change made to a candidate
+type CandidateHistoryEntry struct {
+    ID          int             `json:"id"`
+    CandidateID int             `json:"candidate_id"`
+    Action      string          `json:"action"`
+    Details     json.RawMessage `json:"details,omitempty" swaggertype:"object"`
+    Actor       string          `json:"actor"`
+    CreatedAt   time.Time       `json:"created_at"`
+}
@@ -0,0 +1,19 @@
+package domain
+
+import "time"
+
+// IdempotencyRecord is the response stored for an Idempotency-Key, with the
+// fingerprint of the request that produced it
+type IdempotencyRecord struct {
+    Key         string
+    Fingerprint string // SHA-256 of the method, path and body
+    StatusCode  int    // 0 while the first request is in flight
+    ContentType string
+    Body        []byte
+    ExpiresAt   time.Time // lock timeout while in flight, then the end of the TTL
+}
+
+// InFlight tells whether the first request with the key has not finished yet
+func (r IdempotencyRecord) InFlight() bool {
+    return r.StatusCode == 0
+}
@@ -0,0 +1,18 @@
+package domain
+
+// ImportRowError describes why a row of an imported file was rejected
+type ImportRowError struct {
+    Row   int    `json:"row"`
+    Error string `json:"error"`
+}
+
+// ImportReport summarizes the import of a file. In dry-run mode the counters
+// report what would have been written.
+type ImportReport struct {
+    DryRun  bool             `json:"dry_run"`
+    Rows    int              `json:"rows"`
+    Created int              `json:"created"`
+    Updated int              `json:"updated"`
+    Failed  int              `json:"failed"`
+    Errors  []ImportRowError `json:"errors"`
+}
@@ -0,0 +1,43 @@
+package domain
+
+import "time"
+
+// Interview types
+const (
+    InterviewPhone     = "phone"
+    InterviewVideo     = "video"
+    InterviewOnsite    = "onsite"
+    InterviewTechnical = "technical"
+)
+
+// Interview is a meeting of one or more interviewers with a candidate, optionally
+// within one of the candidate's applications. Start and end are stored in UTC,
+// TimeZone is the zone the interview was scheduled in.
+type Interview struct {
+    ID            int       `json:"id"`
+    CandidateID   int       `json:"candidate_id"`
+    ApplicationID *int      `json:"application_id,omitempty"`
+    Interviewers  []string  `json:"interviewers" binding:"required"`
+    StartsAt      time.Time `json:"starts_at" binding:"required"`
+    EndsAt        time.Time `json:"ends_at" binding:"required"`
+    TimeZone      string    `json:"time_zone" example:"America/Lima"`
+    Location      string    `json:"location,omitempty"`
+    VideoLink     string    `json:"video_link,omitempty"`
+    Type          string    `json:"type" enums:"phone,video,onsite,technical"`
+    Sequence      int       `json:"sequence"` // revision of the invite, increases on every change
+    CreatedBy     string    `json:"created_by"`
+    CreatedAt     time.Time `json:"created_at"`
+    UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// Overlaps reports whether both interviews share part of their time range
+func (iv Interview) Overlaps(other Interview) bool {
+    return iv.StartsAt.Before(other.EndsAt) && other.StartsAt.Before(iv.EndsAt)
+}
+
+// InterviewConflict describes an existing interview that overlaps a new one
+type InterviewConflict struct {
+    InterviewID int    `json:"interview_id"`
+    Reason      string `json:"reason"` // "candidate" or "interviewer"
+    Interviewer string `json:"interviewer,omitempty"`
+}
@@ -0,0 +1,32 @@
+package domain
+
+import "time"
+
+// Job statuses
+const (
+    JobOpen   = "open"
+    JobOnHold = "on_hold"
+    JobClosed = "closed"
+)
+
+type Job struct {
+    ID            int       `json:"id"`
+    Title         string    `json:"title"`
+    Department    string    `json:"department"`
+    Location      string    `json:"location"`
+    SalaryMin     float64   `json:"salary_min"`
+    SalaryMax     float64   `json:"salary_max"`
+    Status        string    `json:"status" enums:"open,on_hold,closed"`
+    HiringManager string    `json:"hiring_manager"`
+    CreatedAt     time.Time `json:"created_at"`
+    UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// JobFilter holds the optional filters of the job list. Empty fields are ignored.
+type JobFilter struct {
+    Title         string `form:"title"` // partial match
+    Department    string `form:"department"`
+    Location      string `form:"location"`
+    Status        string `form:"status"`
+    HiringManager string `form:"hiring_manager"`
+}
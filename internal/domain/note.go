@@ -0,0 +1,65 @@
+package domain
+
+import (
+    "regexp"
+    "strings"
+    "time"
+)
+
+// Note visibilities
+const (
+    NotePrivate = "private" // only the author
+    NoteTeam    = "team"    // every user
+)
+
+// Note is a markdown comment on a candidate profile
+type Note struct {
+    ID          int       `json:"id"`
+    CandidateID int       `json:"candidate_id"`
+    Author      string    `json:"author"`
+    Body        string    `json:"body"` // markdown
+    Visibility  string    `json:"visibility" enums:"private,team"`
+    Pinned      bool      `json:"pinned"`
+    Mentions    []string  `json:"mentions"`
+    Edited      bool      `json:"edited"`
+    CreatedAt   time.Time `json:"created_at"`
+    UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// NoteRequest is the body to create or edit a note. The visibility defaults to team.
+type NoteRequest struct {
+    Body       string `json:"body" binding:"required"`
+    Visibility string `json:"visibility" enums:"private,team"`
+}
+
+// NoteRevision is a previous version of an edited note
+type NoteRevision struct {
+    ID         int       `json:"id"`
+    NoteID     int       `json:"note_id"`
+    Body       string    `json:"body"`
+    Visibility string    `json:"visibility"`
+    Editor     string    `json:"editor"` // who replaced this version
+    CreatedAt  time.Time `json:"created_at"`
+}
+
+var (
+    codeBlockPattern = regexp.MustCompile("(?s)```.*?```|`[^`\n]*`")
+    mentionPattern   = regexp.MustCompile(`(^|[^\w@.])@([A-Za-z0-9_][A-Za-z0-9_.-]*)`)
+)
+
+// ParseMentions returns the users mentioned with @user in a markdown body, in order and
+// without repetitions. Mentions inside code and email addresses are ignored.
+func ParseMentions(body string) []string {
+    body = codeBlockPattern.ReplaceAllString(body, " ")
+    seen := map[string]bool{}
+    mentions := []string{}
+    for _, m := range mentionPattern.FindAllStringSubmatch(body, -1) {
+        // A trailing dot or dash is punctuation, not part of the user
+        user := strings.TrimRight(m[2], ".-")
+        if user != "" && !seen[user] {
+            seen[user] = true
+            mentions = append(mentions, user)
+        }
+    }
+    return mentions
+}
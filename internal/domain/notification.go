@@ -0,0 +1,21 @@
+package domain
+
+import "time"
+
+// Notification types
+const (
+    NotificationMention = "mention"
+)
+
+// Notification tells a user about something that concerns them, like a mention in a note
+type Notification struct {
+    ID          int        `json:"id"`
+    User        string     `json:"user"`
+    Type        string     `json:"type"`
+    CandidateID int        `json:"candidate_id"`
+    NoteID      int        `json:"note_id,omitempty"`
+    Actor       string     `json:"actor"`
+    Message     string     `json:"message"`
+    ReadAt      *time.Time `json:"read_at,omitempty"`
+    CreatedAt   time.Time  `json:"created_at"`
+}
@@ -0,0 +1,50 @@
+package domain
+
+import (
+    "encoding/json"
+    "time"
+)
+
+// Candidate event types
+const (
+    EventCandidateCreated = "candidate.created"
+    EventCandidateUpdated = "candidate.updated"
+    EventCandidateDeleted = "candidate.deleted"
+    EventCandidateMerged  = "candidate.merged"
+)
+
+// CandidateEventTypes lists the candidate event types
+var CandidateEventTypes = []string{EventCandidateCreated, EventCandidateUpdated, EventCandidateDeleted, EventCandidateMerged}
+
+// OutboxEvent is a domain event written in the same transaction as the change it describes
+// and published afterwards by the relay. Consumers may receive an event more than once and
+// must use the idempotency key to discard the repeated ones.
+type OutboxEvent struct {
+    ID             int64           `json:"id"` // ascending in the order of the changes
+    Type           string          `json:"type" example:"candidate.created"`
+    AggregateID    int             `json:"aggregate_id"` // ID of the candidate
+    IdempotencyKey string          `json:"idempotency_key"`
+    Payload        json.RawMessage `json:"payload" swaggertype:"object"`
+    CreatedAt      time.Time       `json:"created_at"`
+    Attempts       int             `json:"-"`
+}
+
+// CandidateEventPayload is the payload of the candidate events. Deleted candidates only carry the ID.
+type CandidateEventPayload struct {
+    ID             int     `json:"id"`
+    Name           string  `json:"name,omitempty"`
+    Email          string  `json:"email,omitempty"`
+    Gender         string  `json:"gender,omitempty"`
+    SalaryExpected float64 `json:"salary_expected,omitempty"`
+    SourceID       int     `json:"source_id,omitempty"` // candidate absorbed by a merge
+}
+
+// OutboxStats describes the progress of the relay
+type OutboxStats struct {
+    Pending         int        `json:"pending"`     // events not published yet
+    LagSeconds      float64    `json:"lag_seconds"` // age of the oldest pending event
+    Published       int64      `json:"published"`   // events published since the start
+    Failures        int64      `json:"failures"`    // failed publish attempts since the start
+    LastError       string     `json:"last_error,omitempty"`
+    LastPublishedAt *time.Time `json:"last_published_at,omitempty"`
+}
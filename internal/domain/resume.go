@@ -0,0 +1,24 @@
+package domain
+
+// Resume link kinds
+const (
+    LinkLinkedIn  = "linkedin"
+    LinkGitHub    = "github"
+    LinkPortfolio = "portfolio"
+)
+
+// ResumeLink is a profile or site found in a resume
+type ResumeLink struct {
+    Kind string `json:"kind" enums:"linkedin,github,portfolio"`
+    URL  string `json:"url" example:"https://github.com/janedoe"`
+}
+
+// ResumeSuggestion is the data found in a resume. It is not saved, the recruiter reviews
+// it before creating or updating the candidate.
+type ResumeSuggestion struct {
+    DocumentID int          `json:"document_id,omitempty"`
+    Candidate  Candidate    `json:"candidate"` // only name and email are suggested
+    Tags       []string     `json:"tags"`      // skills found, as tags
+    Phones     []string     `json:"phones"`
+    Links      []ResumeLink `json:"links"`
+}
@@ -0,0 +1,81 @@
+package domain
+
+import "time"
+
+// Feedback recommendations
+const (
+    RecommendStrongNo  = "strong_no"
+    RecommendNo        = "no"
+    RecommendYes       = "yes"
+    RecommendStrongYes = "strong_yes"
+)
+
+// ScorecardTemplate defines the competencies evaluated after an interview and the
+// rating scale used for all of them
+type ScorecardTemplate struct {
+    ID           int          `json:"id"`
+    Name         string       `json:"name" binding:"required"`
+    ScaleMin     int          `json:"scale_min" example:"1"`
+    ScaleMax     int          `json:"scale_max" example:"5"`
+    Competencies []Competency `json:"competencies" binding:"required"`
+    CreatedAt    time.Time    `json:"created_at"`
+}
+
+// Competency is a weighted item of a scorecard template
+type Competency struct {
+    ID          int     `json:"id"`
+    Name        string  `json:"name"`
+    Description string  `json:"description,omitempty"`
+    Weight      float64 `json:"weight" example:"1"`
+}
+
+// Feedback is the evaluation of a candidate submitted by one interviewer. Score is the
+// weighted average of the ratings as a percentage of the template scale.
+type Feedback struct {
+    ID             int       `json:"id"`
+    CandidateID    int       `json:"candidate_id"`
+    InterviewID    *int      `json:"interview_id,omitempty"`
+    TemplateID     int       `json:"template_id" binding:"required"`
+    Interviewer    string    `json:"interviewer"`
+    Ratings        []Rating  `json:"ratings" binding:"required"`
+    Recommendation string    `json:"recommendation" enums:"strong_no,no,yes,strong_yes"`
+    Notes          string    `json:"notes,omitempty"`
+    Score          float64   `json:"score"`
+    SubmittedAt    time.Time `json:"submitted_at"`
+}
+
+// Rating is the score given to one competency
+type Rating struct {
+    CompetencyID int    `json:"competency_id"`
+    Score        int    `json:"score"`
+    Comment      string `json:"comment,omitempty"`
+}
+
+// FeedbackList is the feedback of a candidate as seen by one user. Until the user
+// submits their own feedback the others are hidden and only counted.
+type FeedbackList struct {
+    Submitted bool       `json:"submitted"`
+    Hidden    int        `json:"hidden"`
+    Feedback  []Feedback `json:"feedback"`
+}
+
+// ScorecardSummary aggregates the submitted feedback of a candidate
+type ScorecardSummary struct {
+    Count           int               `json:"count"`
+    Score           float64           `json:"score"` // average of the feedback scores, 0-100
+    Competencies    []CompetencyScore `json:"competencies"`
+    Recommendations map[string]int    `json:"recommendations"`
+}
+
+// CompetencyScore is the average rating of a competency across the feedback, 0-100
+type CompetencyScore struct {
+    Name  string  `json:"name"`
+    Score float64 `json:"score"`
+    Count int     `json:"count"`
+}
+
+// CandidateDetail is a candidate with the aggregated scorecard, when visible to the user
+type CandidateDetail struct {
+    Candidate
+    Scorecard *ScorecardSummary `json:"scorecard,omitempty"`
+}
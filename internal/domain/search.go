@@ -0,0 +1,9 @@
+package domain
+
+// CandidateSearchHit is a candidate found by the search with its relevance.
+// Highlights hold the matched fields with the matches wrapped in <mark> tags (HTML escaped).
+type CandidateSearchHit struct {
+    Candidate  Candidate         `json:"candidate"`
+    Score      float64           `json:"score"`
+    Highlights map[string]string `json:"highlights"`
+}
@@ -0,0 +1,139 @@
+package events
+
+import (
+    "context"
+    "sync"
+
+    "github.com/torvictorvic/seek-v2/internal/domain"
+)
+
+// Defaults of the hub
+const (
+    DefaultReplaySize       = 1000
+    DefaultSubscriberBuffer = 64
+)
+
+// Hub fans the candidate events out to the subscribers of this process. It keeps the
+// last events so a client that reconnects can resume from the last one it received.
+// It is an outbox.Sink, the events come from the outbox tail with their outbox ID.
+type Hub struct {
+    mu          sync.Mutex
+    replay      []domain.OutboxEvent // ring of the last events, oldest at start
+    start       int
+    replaySize  int
+    subBuffer   int
+    lastID      int64
+    subscribers map[*Subscription]struct{}
+}
+
+// Subscription receives the events of the types it asked for on C. C is closed when the
+// subscription is closed or falls too far behind; the client then resumes from its last event.
+type Subscription struct {
+    C     <-chan domain.OutboxEvent
+    ch    chan domain.OutboxEvent
+    types map[string]bool
+    hub   *Hub
+}
+
+func NewHub(replaySize, subscriberBuffer int) *Hub {
+    if replaySize <= 0 {
+        replaySize = DefaultReplaySize
+    }
+    if subscriberBuffer <= 0 {
+        subscriberBuffer = DefaultSubscriberBuffer
+    }
+    return &Hub{replaySize: replaySize, subBuffer: subscriberBuffer, subscribers: map[*Subscription]struct{}{}}
+}
+
+// Publish sends the event to the subscribers. The tail may repeat events, the ones not
+// newer than the last published are ignored.
+func (h *Hub) Publish(ctx context.Context, event domain.OutboxEvent) error {
+    h.mu.Lock()
+    defer h.mu.Unlock()
+    if event.ID <= h.lastID {
+        return nil
+    }
+    h.lastID = event.ID
+
+    if len(h.replay) < h.replaySize {
+        h.replay = append(h.replay, event)
+    } else {
+        h.replay[h.start] = event
+        h.start = (h.start + 1) % h.replaySize
+    }
+
+    for s := range h.subscribers {
+        if !s.wants(event.Type) {
+            continue
+        }
+        select {
+        case s.ch <- event:
+        default:
+            // A slow client must not hold the others back
+            h.remove(s)
+        }
+    }
+    return nil
+}
+
+// Subscribe registers a subscriber to the given event types, all when empty. With the ID
+// of the last event the client received, it returns the newer events to send first; complete
+// is false when some of them are no longer kept and the client must reload its data. A new
+// client, with lastID 0, only receives the events published from now on.
+func (h *Hub) Subscribe(lastID int64, types []string) (sub *Subscription, replay []domain.OutboxEvent, complete bool) {
+    ch := make(chan domain.OutboxEvent, h.subBuffer)
+    sub = &Subscription{C: ch, ch: ch, hub: h}
+    if len(types) > 0 {
+        sub.types = map[string]bool{}
+        for _, t := range types {
+            sub.types[t] = true
+        }
+    }
+
+    h.mu.Lock()
+    defer h.mu.Unlock()
+    h.subscribers[sub] = struct{}{}
+    if lastID == 0 {
+        return sub, nil, true
+    }
+    for i := range h.replay {
+        e := h.replay[(h.start+i)%len(h.replay)]
+        if e.ID == lastID {
+            complete = true
+        }
+        if e.ID > lastID && sub.wants(e.Type) {
+            replay = append(replay, e)
+        }
+    }
+    // An up to date client has nothing to replay, unless the hub lost its events on a restart
+    if !complete && h.lastID > 0 && lastID >= h.lastID {
+        complete = true
+    }
+    return sub, replay, complete
+}
+
+// Subscribers returns the number of active subscriptions
+func (h *Hub) Subscribers() int {
+    h.mu.Lock()
+    defer h.mu.Unlock()
+    return len(h.subscribers)
+}
+
+// Close ends the subscription, it can be called more than once
+func (s *Subscription) Close() {
+    s.hub.mu.Lock()
+    defer s.hub.mu.Unlock()
+    s.hub.remove(s)
+}
+
+// remove is called with the lock held
+func (h *Hub) remove(s *Subscription) {
+    if _, ok := h.subscribers[s]; ok {
+        delete(h.subscribers, s)
+        close(s.ch)
+    }
+}
+
+func (s *Subscription) wants(eventType string) bool {
+    return s.types == nil || s.types[eventType]
+}
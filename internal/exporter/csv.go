@@ -0,0 +1,49 @@
+package exporter
+
+import (
+    "encoding/csv"
+    "io"
+
+    "github.com/torvictorvic/seek-v2/internal/domain"
+)
+
+// flushEvery is the number of rows buffered before being sent to the client
+const flushEvery = 100
+
+type csvWriter struct {
+    w       *csv.Writer
+    columns []Column
+    record  []string
+    pending int
+}
+
+func newCSVWriter(w io.Writer, columns []Column) (*csvWriter, error) {
+    cw := &csvWriter{w: csv.NewWriter(w), columns: columns, record: make([]string, len(columns))}
+    for i, col := range columns {
+        cw.record[i] = col.Label
+    }
+    if err := cw.w.Write(cw.record); err != nil {
+        return nil, err
+    }
+    return cw, nil
+}
+
+func (cw *csvWriter) WriteRow(c domain.Candidate) error {
+    for i, col := range cw.columns {
+        cw.record[i] = col.Text(c)
+    }
+    if err := cw.w.Write(cw.record); err != nil {
+        return err
+    }
+    if cw.pending++; cw.pending == flushEvery {
+        cw.pending = 0
+        cw.w.Flush()
+        return cw.w.Error()
+    }
+    return nil
+}
+
+func (cw *csvWriter) Close() error {
+    cw.w.Flush()
+    return cw.w.Error()
+}
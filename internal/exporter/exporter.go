@@ -0,0 +1,240 @@
+package exporter
+
+import (
+    "encoding/json"
+    "fmt"
+    "io"
+    "mime"
+    "strconv"
+    "strings"
+    "time"
+
+    "github.com/torvictorvic/seek-v2/internal/domain"
+)
+
+// Supported formats
+const (
+    FormatCSV    = "csv"
+    FormatXLSX   = "xlsx"
+    FormatNDJSON = "ndjson"
+    FormatPDF    = "pdf"
+)
+
+// ContentTypes maps every format to its media type
+var ContentTypes = map[string]string{
+    FormatCSV:    "text/csv; charset=utf-8",
+    FormatXLSX:   "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+    FormatNDJSON: "application/x-ndjson",
+    FormatPDF:    "application/pdf",
+}
+
+// Writer writes candidates one at a time to the output
+type Writer interface {
+    WriteRow(c domain.Candidate) error
+    Close() error
+}
+
+// Column is an exportable field of the candidate
+type Column struct {
+    Key     string
+    Label   string
+    Numeric bool
+    value   func(c domain.Candidate) interface{}
+}
+
+// Value returns the typed value of the column
+func (col Column) Value(c domain.Candidate) interface{} {
+    return col.value(c)
+}
+
+// Text returns the value of the column formatted as text
+func (col Column) Text(c domain.Candidate) string {
+    switch v := col.value(c).(type) {
+    case nil:
+        return ""
+    case string:
+        return v
+    case []string:
+        return strings.Join(v, ", ")
+    case json.Number:
+        return string(v)
+    case int:
+        return strconv.Itoa(v)
+    case float64:
+        return strconv.FormatFloat(v, 'f', 2, 64)
+    case time.Time:
+        if v.IsZero() {
+            return ""
+        }
+        return v.Format(time.RFC3339)
+    default:
+        return fmt.Sprint(v)
+    }
+}
+
+type columnDef struct {
+    labels  map[string]string // by language
+    numeric bool
+    value   func(c domain.Candidate) interface{}
+}
+
+var columnOrder = []string{"id", "name", "email", "gender", "salary_expected", "tags", "created_at", "updated_at"}
+
+// CustomPrefix starts the key of the custom field columns, as in "cf.years_experience"
+const CustomPrefix = "cf."
+
+var columnDefs = map[string]columnDef{
+    "id": {
+        labels:  map[string]string{"es": "ID", "en": "ID"},
+        numeric: true,
+        value:   func(c domain.Candidate) interface{} { return c.ID },
+    },
+    "name": {
+        labels: map[string]string{"es": "Nombre", "en": "Name"},
+        value:  func(c domain.Candidate) interface{} { return c.Name },
+    },
+    "email": {
+        labels: map[string]string{"es": "Correo", "en": "Email"},
+        value:  func(c domain.Candidate) interface{} { return c.Email },
+    },
+    "gender": {
+        labels: map[string]string{"es": "Género", "en": "Gender"},
+        value:  func(c domain.Candidate) interface{} { return c.Gender },
+    },
+    "salary_expected": {
+        labels:  map[string]string{"es": "Salario esperado", "en": "Expected salary"},
+        numeric: true,
+        value:   func(c domain.Candidate) interface{} { return c.SalaryExpected },
+    },
+    "tags": {
+        labels: map[string]string{"es": "Etiquetas", "en": "Tags"},
+        value: func(c domain.Candidate) interface{} {
+            if c.Tags == nil {
+                return []string{}
+            }
+            return c.Tags
+        },
+    },
+    "created_at": {
+        labels: map[string]string{"es": "Creado", "en": "Created at"},
+        value:  func(c domain.Candidate) interface{} { return c.CreatedAt },
+    },
+    "updated_at": {
+        labels: map[string]string{"es": "Actualizado", "en": "Updated at"},
+        value:  func(c domain.Candidate) interface{} { return c.UpdatedAt },
+    },
+}
+
+// Columns resolves the requested column keys (all of them when empty) with the labels
+// in the given language ("es" or "en", Spanish by default). The custom fields are
+// available as "cf.<key>" columns, labeled with their own label.
+func Columns(keys []string, lang string, custom ...domain.CustomField) ([]Column, error) {
+    if len(keys) == 0 {
+        keys = append([]string{}, columnOrder...)
+        for _, f := range custom {
+            keys = append(keys, CustomPrefix+f.Key)
+        }
+    }
+    if lang != "en" {
+        lang = "es"
+    }
+
+    columns := make([]Column, 0, len(keys))
+    for _, key := range keys {
+        key = strings.TrimSpace(key)
+        if col, ok := customColumn(key, custom); ok {
+            columns = append(columns, col)
+            continue
+        }
+        def, ok := columnDefs[key]
+        if !ok {
+            return nil, fmt.Errorf("Unknown column '%s'", key)
+        }
+        columns = append(columns, Column{Key: key, Label: def.labels[lang], Numeric: def.numeric, value: def.value})
+    }
+    return columns, nil
+}
+
+func customColumn(key string, custom []domain.CustomField) (Column, bool) {
+    if !strings.HasPrefix(key, CustomPrefix) {
+        return Column{}, false
+    }
+    for _, f := range custom {
+        if CustomPrefix+f.Key != key {
+            continue
+        }
+        fieldKey, fieldType := f.Key, f.Type
+        return Column{
+            Key:     key,
+            Label:   f.Label,
+            Numeric: fieldType == domain.FieldNumber,
+            value: func(c domain.Candidate) interface{} {
+                v, ok := c.CustomFields[fieldKey]
+                if !ok {
+                    return nil
+                }
+                // Numbers are written as entered, without the two decimals of the salary
+                if n, isNumber := v.(float64); isNumber {
+                    return json.Number(strconv.FormatFloat(n, 'f', -1, 64))
+                }
+                return v
+            },
+        }, true
+    }
+    return Column{}, false
+}
+
+// Title returns the title of the report in the given language
+func Title(lang string) string {
+    if lang == "en" {
+        return "Candidates"
+    }
+    return "Candidatos"
+}
+
+// NewWriter creates the writer of the given format. The header is written right away,
+// the title is only used by the formats that have one (PDF).
+func NewWriter(format string, w io.Writer, columns []Column, title string) (Writer, error) {
+    switch format {
+    case FormatCSV:
+        return newCSVWriter(w, columns)
+    case FormatNDJSON:
+        return newNDJSONWriter(w, columns), nil
+    case FormatXLSX:
+        return newXLSXWriter(w, columns)
+    case FormatPDF:
+        return newPDFWriter(w, columns, title)
+    default:
+        return nil, fmt.Errorf("Unsupported format '%s'", format)
+    }
+}
+
+// Negotiate picks the format from the explicit parameter or from the Accept header.
+// It returns an empty string when nothing acceptable is found.
+func Negotiate(format, accept string) string {
+    if format != "" {
+        format = strings.ToLower(format)
+        if _, ok := ContentTypes[format]; ok {
+            return format
+        }
+        return ""
+    }
+    if accept == "" {
+        return FormatCSV
+    }
+    for _, part := range strings.Split(accept, ",") {
+        mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(part))
+        if err != nil {
+            continue
+        }
+        if mediaType == "*/*" || mediaType == "text/*" {
+            return FormatCSV
+        }
+        for f, ct := range ContentTypes {
+            if ct, _, _ := mime.ParseMediaType(ct); ct == mediaType {
+                return f
+            }
+        }
+    }
+    return ""
+}
@@ -0,0 +1,48 @@
+package exporter
+
+import (
+    "bufio"
+    "encoding/json"
+    "io"
+
+    "github.com/torvictorvic/seek-v2/internal/domain"
+)
+
+type ndjsonWriter struct {
+    w       *bufio.Writer
+    columns []Column
+    pending int
+}
+
+func newNDJSONWriter(w io.Writer, columns []Column) *ndjsonWriter {
+    return &ndjsonWriter{w: bufio.NewWriter(w), columns: columns}
+}
+
+// WriteRow writes one JSON object per line, keeping the order of the columns
+func (nw *ndjsonWriter) WriteRow(c domain.Candidate) error {
+    nw.w.WriteByte('{')
+    for i, col := range nw.columns {
+        if i > 0 {
+            nw.w.WriteByte(',')
+        }
+        key, _ := json.Marshal(col.Key)
+        value, err := json.Marshal(col.Value(c))
+        if err != nil {
+            return err
+        }
+        nw.w.Write(key)
+        nw.w.WriteByte(':')
+        nw.w.Write(value)
+    }
+    nw.w.WriteString("}\n")
+
+    if nw.pending++; nw.pending == flushEvery {
+        nw.pending = 0
+        return nw.w.Flush()
+    }
+    return nil
+}
+
+func (nw *ndjsonWriter) Close() error {
+    return nw.w.Flush()
+}
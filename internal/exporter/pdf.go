@@ -0,0 +1,180 @@
+package exporter
+
+import (
+    "bytes"
+    "compress/zlib"
+    "fmt"
+    "io"
+    "strings"
+
+    "github.com/torvictorvic/seek-v2/internal/domain"
+)
+
+// Landscape A4 in points
+const (
+    pdfPageWidth  = 842.0
+    pdfPageHeight = 595.0
+    pdfMargin     = 36.0
+    pdfFontSize   = 8.0
+    pdfRowHeight  = 12.0
+)
+
+// Fixed object numbers, pages are numbered after them
+const (
+    pdfCatalogObj = 1
+    pdfPagesObj   = 2
+    pdfFontObj    = 3
+    pdfBoldObj    = 4
+)
+
+// pdfWriter writes a simple table report. Each page is written as soon as it is full,
+// only the object offsets are kept until the end to build the cross-reference table.
+type pdfWriter struct {
+    w       *countingWriter
+    columns []Column
+    title   string
+    offsets map[int]int64
+    nextObj int
+    pages   []int
+    content bytes.Buffer
+    y       float64
+    colW    float64
+}
+
+type countingWriter struct {
+    w io.Writer
+    n int64
+}
+
+func (cw *countingWriter) Write(p []byte) (int, error) {
+    n, err := cw.w.Write(p)
+    cw.n += int64(n)
+    return n, err
+}
+
+func newPDFWriter(w io.Writer, columns []Column, title string) (*pdfWriter, error) {
+    pw := &pdfWriter{
+        w:       &countingWriter{w: w},
+        columns: columns,
+        title:   title,
+        offsets: map[int]int64{},
+        nextObj: pdfBoldObj + 1,
+        colW:    (pdfPageWidth - 2*pdfMargin) / float64(len(columns)),
+    }
+    if _, err := io.WriteString(pw.w, "%PDF-1.4\n%\xe2\xe3\xcf\xd3\n"); err != nil {
+        return nil, err
+    }
+    pw.writeObject(pdfCatalogObj, fmt.Sprintf("<< /Type /Catalog /Pages %d 0 R >>", pdfPagesObj))
+    pw.writeObject(pdfFontObj, "<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+    pw.writeObject(pdfBoldObj, "<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+    pw.startPage()
+    return pw, nil
+}
+
+func (pw *pdfWriter) WriteRow(c domain.Candidate) error {
+    if pw.y < pdfMargin+pdfRowHeight {
+        if err := pw.endPage(); err != nil {
+            return err
+        }
+        pw.startPage()
+    }
+    for i, col := range pw.columns {
+        pw.text("F1", pdfMargin+float64(i)*pw.colW, pw.y, col.Text(c))
+    }
+    pw.y -= pdfRowHeight
+    return nil
+}
+
+func (pw *pdfWriter) Close() error {
+    if err := pw.endPage(); err != nil {
+        return err
+    }
+
+    kids := make([]string, len(pw.pages))
+    for i, p := range pw.pages {
+        kids[i] = fmt.Sprintf("%d 0 R", p)
+    }
+    pw.writeObject(pdfPagesObj, fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pw.pages)))
+
+    xref := pw.w.n
+    fmt.Fprintf(pw.w, "xref\n0 %d\n0000000000 65535 f \n", pw.nextObj)
+    for obj := 1; obj < pw.nextObj; obj++ {
+        fmt.Fprintf(pw.w, "%010d 00000 n \n", pw.offsets[obj])
+    }
+    _, err := fmt.Fprintf(pw.w, "trailer\n<< /Size %d /Root %d 0 R >>\nstartxref\n%d\n%%%%EOF\n", pw.nextObj, pdfCatalogObj, xref)
+    return err
+}
+
+// startPage writes the title (first page only) and the header of the table
+func (pw *pdfWriter) startPage() {
+    pw.content.Reset()
+    pw.y = pdfPageHeight - pdfMargin
+    if len(pw.pages) == 0 && pw.title != "" {
+        fmt.Fprintf(&pw.content, "BT /F2 14 Tf %.2f %.2f Td (%s) Tj ET\n", pdfMargin, pw.y-14, pdfEscape(pw.title))
+        pw.y -= 30
+    }
+    for i, col := range pw.columns {
+        pw.text("F2", pdfMargin+float64(i)*pw.colW, pw.y, col.Label)
+    }
+    line := pw.y - 3
+    fmt.Fprintf(&pw.content, "0.5 w %.2f %.2f m %.2f %.2f l S\n", pdfMargin, line, pdfPageWidth-pdfMargin, line)
+    pw.y -= pdfRowHeight + 2
+}
+
+func (pw *pdfWriter) endPage() error {
+    var compressed bytes.Buffer
+    zw := zlib.NewWriter(&compressed)
+    zw.Write(pw.content.Bytes())
+    zw.Close()
+
+    contentObj := pw.nextObj
+    pageObj := pw.nextObj + 1
+    pw.nextObj += 2
+
+    pw.offsets[contentObj] = pw.w.n
+    fmt.Fprintf(pw.w, "%d 0 obj\n<< /Length %d /Filter /FlateDecode >>\nstream\n", contentObj, compressed.Len())
+    pw.w.Write(compressed.Bytes())
+    io.WriteString(pw.w, "\nendstream\nendobj\n")
+
+    pw.pages = append(pw.pages, pageObj)
+    return pw.writeObject(pageObj, fmt.Sprintf(
+        "<< /Type /Page /Parent %d 0 R /MediaBox [0 0 %.0f %.0f] /Resources << /Font << /F1 %d 0 R /F2 %d 0 R >> >> /Contents %d 0 R >>",
+        pdfPagesObj, pdfPageWidth, pdfPageHeight, pdfFontObj, pdfBoldObj, contentObj))
+}
+
+func (pw *pdfWriter) writeObject(obj int, body string) error {
+    pw.offsets[obj] = pw.w.n
+    _, err := fmt.Fprintf(pw.w, "%d 0 obj\n%s\nendobj\n", obj, body)
+    return err
+}
+
+// text writes s truncated to the width of a column
+func (pw *pdfWriter) text(font string, x, y float64, s string) {
+    // Helvetica averages about half the font size per character
+    maxChars := int((pw.colW - 4) / (pdfFontSize * 0.5))
+    if r := []rune(s); len(r) > maxChars && maxChars > 1 {
+        s = string(r[:maxChars-1]) + "…"
+    }
+    fmt.Fprintf(&pw.content, "BT /%s %.0f Tf %.2f %.2f Td (%s) Tj ET\n", font, pdfFontSize, x, y, pdfEscape(s))
+}
+
+// pdfEscape encodes the text in WinAnsi (Latin-1 plus a few symbols) and escapes the delimiters
+func pdfEscape(s string) string {
+    var sb strings.Builder
+    for _, r := range s {
+        switch {
+        case r == '(' || r == ')' || r == '\\':
+            sb.WriteByte('\\')
+            sb.WriteByte(byte(r))
+        case r == '…':
+            sb.WriteByte(0x85)
+        case r == '€':
+            sb.WriteByte(0x80)
+        case r >= 0x20 && r < 0x7f, r >= 0xa0 && r <= 0xff:
+            sb.WriteByte(byte(r))
+        default:
+            sb.WriteByte('?')
+        }
+    }
+    return sb.String()
+}
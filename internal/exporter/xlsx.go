@@ -0,0 +1,101 @@
+package exporter
+
+import (
+    "archive/zip"
+    "bufio"
+    "encoding/xml"
+    "io"
+    "strconv"
+
+    "github.com/torvictorvic/seek-v2/internal/domain"
+)
+
+// Static parts of a minimal workbook with a single sheet
+var xlsxParts = []struct{ name, content string }{
+    {"[Content_Types].xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types"><Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/><Default Extension="xml" ContentType="application/xml"/><Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/><Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/></Types>`},
+    {"_rels/.rels", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/></Relationships>`},
+    {"xl/workbook.xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets><sheet name="Candidates" sheetId="1" r:id="rId1"/></sheets></workbook>`},
+    {"xl/_rels/workbook.xml.rels", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/></Relationships>`},
+}
+
+// xlsxWriter writes the sheet as the rows arrive. Strings are written inline,
+// so there is no shared strings table to keep in memory.
+type xlsxWriter struct {
+    zw      *zip.Writer
+    w       *bufio.Writer
+    columns []Column
+    row     int
+}
+
+func newXLSXWriter(w io.Writer, columns []Column) (*xlsxWriter, error) {
+    zw := zip.NewWriter(w)
+    for _, part := range xlsxParts {
+        f, err := zw.Create(part.name)
+        if err != nil {
+            return nil, err
+        }
+        if _, err := io.WriteString(f, part.content); err != nil {
+            return nil, err
+        }
+    }
+
+    sheet, err := zw.Create("xl/worksheets/sheet1.xml")
+    if err != nil {
+        return nil, err
+    }
+    xw := &xlsxWriter{zw: zw, w: bufio.NewWriter(sheet), columns: columns}
+    xw.w.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`)
+
+    xw.startRow()
+    for i, col := range columns {
+        xw.writeString(i, col.Label)
+    }
+    xw.w.WriteString("</row>")
+    return xw, nil
+}
+
+func (xw *xlsxWriter) WriteRow(c domain.Candidate) error {
+    xw.startRow()
+    for i, col := range xw.columns {
+        if col.Numeric && col.Value(c) != nil {
+            xw.w.WriteString(`<c r="` + cellRef(i, xw.row) + `"><v>` + col.Text(c) + `</v></c>`)
+            continue
+        }
+        xw.writeString(i, col.Text(c))
+    }
+    _, err := xw.w.WriteString("</row>")
+    return err
+}
+
+func (xw *xlsxWriter) Close() error {
+    xw.w.WriteString("</sheetData></worksheet>")
+    if err := xw.w.Flush(); err != nil {
+        return err
+    }
+    return xw.zw.Close()
+}
+
+func (xw *xlsxWriter) startRow() {
+    xw.row++
+    xw.w.WriteString(`<row r="` + strconv.Itoa(xw.row) + `">`)
+}
+
+func (xw *xlsxWriter) writeString(col int, s string) {
+    xw.w.WriteString(`<c r="` + cellRef(col, xw.row) + `" t="inlineStr"><is><t xml:space="preserve">`)
+    xml.EscapeText(xw.w, []byte(s))
+    xw.w.WriteString(`</t></is></c>`)
+}
+
+// cellRef converts a zero-based column and a row number to a reference such as "AB12"
+func cellRef(col, row int) string {
+    name := ""
+    for col++; col > 0; col = (col - 1) / 26 {
+        name = string(rune('A'+(col-1)%26)) + name
+    }
+    return name + strconv.Itoa(row)
+}
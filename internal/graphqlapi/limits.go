@@ -0,0 +1,130 @@
+package graphqlapi
+
+import (
+    "errors"
+    "fmt"
+    "strconv"
+    "strings"
+
+    "github.com/graphql-go/graphql/language/ast"
+)
+
+var (
+    ErrQueryTooDeep    = errors.New("The query exceeds the maximum depth")
+    ErrQueryTooComplex = errors.New("The query exceeds the maximum complexity")
+)
+
+// Limits bound the cost of a query, checked before it runs. Zero disables a limit.
+type Limits struct {
+    MaxDepth      int
+    MaxComplexity int
+}
+
+// listSizes estimate how many items the lists without a 'first' argument return, so
+// the fields selected under them count once per item
+var listSizes = map[string]int{
+    "candidates":   DefaultPageSize,
+    "applications": 10,
+    "notes":        10,
+}
+
+// checkLimits measures the operation to run. Every field costs 1, and the selection
+// of a list costs once per item; the introspection fields are free.
+func checkLimits(doc *ast.Document, operationName string, variables map[string]interface{}, limits Limits) error {
+    c := costCounter{fragments: map[string]*ast.FragmentDefinition{}, variables: variables}
+    var ops []*ast.OperationDefinition
+    for _, def := range doc.Definitions {
+        switch d := def.(type) {
+        case *ast.FragmentDefinition:
+            c.fragments[d.Name.Value] = d
+        case *ast.OperationDefinition:
+            if operationName == "" || (d.Name != nil && d.Name.Value == operationName) {
+                ops = append(ops, d)
+            }
+        }
+    }
+
+    for _, op := range ops {
+        depth, complexity := c.selectionSet(op.SelectionSet, map[string]bool{})
+        if limits.MaxDepth > 0 && depth > limits.MaxDepth {
+            return fmt.Errorf("%w: %d, the limit is %d", ErrQueryTooDeep, depth, limits.MaxDepth)
+        }
+        if limits.MaxComplexity > 0 && complexity > limits.MaxComplexity {
+            return fmt.Errorf("%w: %d, the limit is %d", ErrQueryTooComplex, complexity, limits.MaxComplexity)
+        }
+    }
+    return nil
+}
+
+type costCounter struct {
+    fragments map[string]*ast.FragmentDefinition
+    variables map[string]interface{}
+}
+
+// selectionSet returns the depth and complexity of a selection, following the fragments
+func (c costCounter) selectionSet(set *ast.SelectionSet, visiting map[string]bool) (int, int) {
+    if set == nil {
+        return 0, 0
+    }
+    depth, complexity := 0, 0
+    for _, selection := range set.Selections {
+        var d, n int
+        switch s := selection.(type) {
+        case *ast.Field:
+            if strings.HasPrefix(s.Name.Value, "__") {
+                continue
+            }
+            childDepth, childComplexity := c.selectionSet(s.SelectionSet, visiting)
+            d = childDepth + 1
+            n = 1 + childComplexity*c.items(s)
+        case *ast.InlineFragment:
+            d, n = c.selectionSet(s.SelectionSet, visiting)
+        case *ast.FragmentSpread:
+            // The cycles are rejected by the validation, the guard only avoids looping
+            name := s.Name.Value
+            if frag, ok := c.fragments[name]; ok && !visiting[name] {
+                visiting[name] = true
+                d, n = c.selectionSet(frag.SelectionSet, visiting)
+                delete(visiting, name)
+            }
+        }
+        depth = max(depth, d)
+        complexity += n
+    }
+    return depth, complexity
+}
+
+// items is the number of items the field returns: its 'first' argument, the estimate
+// of the list or 1
+func (c costCounter) items(field *ast.Field) int {
+    for _, arg := range field.Arguments {
+        if arg.Name.Value != "first" {
+            continue
+        }
+        switch v := arg.Value.(type) {
+        case *ast.IntValue:
+            if n, err := strconv.Atoi(v.Value); err == nil && n > 0 {
+                return min(n, MaxPageSize)
+            }
+        case *ast.Variable:
+            if n, ok := toInt(c.variables[v.Name.Value]); ok && n > 0 {
+                return min(n, MaxPageSize)
+            }
+        }
+    }
+    if n, ok := listSizes[field.Name.Value]; ok {
+        return n
+    }
+    return 1
+}
+
+// toInt reads a number of the variables, decoded from JSON as float64
+func toInt(v interface{}) (int, bool) {
+    switch n := v.(type) {
+    case int:
+        return n, true
+    case float64:
+        return int(n), true
+    }
+    return 0, false
+}
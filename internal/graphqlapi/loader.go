@@ -0,0 +1,103 @@
+package graphqlapi
+
+import (
+    "context"
+    "sync"
+
+    "github.com/torvictorvic/seek-v2/internal/domain"
+)
+
+// Loader batches the keys requested while a level of the query is resolved: the
+// resolvers register their key and return a thunk, and the first thunk called loads
+// every pending key in one call. The results are cached for the rest of the request.
+type Loader[K comparable, V any] struct {
+    mu      sync.Mutex
+    batch   func(keys []K) (map[K]V, error)
+    pending []K
+    queued  map[K]bool
+    results map[K]V
+    errs    map[K]error
+}
+
+// NewLoader returns a loader that reads the keys with batch. Keys missing from its
+// result resolve to the zero value.
+func NewLoader[K comparable, V any](batch func(keys []K) (map[K]V, error)) *Loader[K, V] {
+    return &Loader[K, V]{batch: batch, queued: map[K]bool{}, results: map[K]V{}, errs: map[K]error{}}
+}
+
+// Load registers the key and returns the thunk that resolves it
+func (l *Loader[K, V]) Load(key K) func() (V, error) {
+    l.mu.Lock()
+    _, done := l.results[key]
+    if !done && l.errs[key] == nil && !l.queued[key] {
+        l.pending = append(l.pending, key)
+        l.queued[key] = true
+    }
+    l.mu.Unlock()
+
+    return func() (V, error) {
+        l.mu.Lock()
+        defer l.mu.Unlock()
+        if l.queued[key] {
+            l.flush()
+        }
+        return l.results[key], l.errs[key]
+    }
+}
+
+// flush loads the pending keys, with the lock held
+func (l *Loader[K, V]) flush() {
+    keys := l.pending
+    l.pending = nil
+    values, err := l.batch(keys)
+    for _, key := range keys {
+        delete(l.queued, key)
+        if err != nil {
+            l.errs[key] = err
+            continue
+        }
+        l.results[key] = values[key]
+    }
+}
+
+// loaders are the loaders of one request
+type loaders struct {
+    candidates *Loader[int, *domain.Candidate]
+    jobs       *Loader[int, *domain.Job]
+}
+
+type loadersKey struct{}
+
+func withLoaders(ctx context.Context, svc Services) context.Context {
+    l := &loaders{
+        candidates: NewLoader(func(ids []int) (map[int]*domain.Candidate, error) {
+            candidates, err := svc.Candidates.GetCandidatesByIDs(ids)
+            if err != nil {
+                return nil, err
+            }
+            byID := make(map[int]*domain.Candidate, len(candidates))
+            for i := range candidates {
+                byID[candidates[i].ID] = &candidates[i]
+            }
+            return byID, nil
+        }),
+        // The job service has no batch read, the loader still reads each job once per request
+        jobs: NewLoader(func(ids []int) (map[int]*domain.Job, error) {
+            byID := make(map[int]*domain.Job, len(ids))
+            for _, id := range ids {
+                job, err := svc.Jobs.GetJobByID(id)
+                if err != nil {
+                    return nil, err
+                }
+                byID[id] = job
+            }
+            return byID, nil
+        }),
+    }
+    return context.WithValue(ctx, loadersKey{}, l)
+}
+
+func loadersFrom(ctx context.Context) *loaders {
+    l, _ := ctx.Value(loadersKey{}).(*loaders)
+    return l
+}
@@ -0,0 +1,625 @@
+package graphqlapi
+
+import (
+    "context"
+    "encoding/base64"
+    "encoding/json"
+    "errors"
+    "fmt"
+    "strconv"
+
+    "github.com/graphql-go/graphql"
+    "github.com/graphql-go/graphql/language/ast"
+
+    "github.com/torvictorvic/seek-v2/internal/domain"
+    "github.com/torvictorvic/seek-v2/internal/service"
+)
+
+const (
+    DefaultPageSize = 20
+    MaxPageSize     = 100
+)
+
+var (
+    ErrInvalidCursor   = errors.New("Invalid cursor")
+    ErrInvalidPageSize = errors.New("The argument 'first' must be between 1 and 100")
+)
+
+// jsonScalar carries free-form values, like the custom fields, as plain JSON
+var jsonScalar = graphql.NewScalar(graphql.ScalarConfig{
+    Name:         "JSON",
+    Description:  "Any JSON value",
+    Serialize:    func(value interface{}) interface{} { return value },
+    ParseValue:   func(value interface{}) interface{} { return value },
+    ParseLiteral: parseJSONLiteral,
+})
+
+func parseJSONLiteral(value ast.Value) interface{} {
+    switch v := value.(type) {
+    case *ast.StringValue:
+        return v.Value
+    case *ast.BooleanValue:
+        return v.Value
+    case *ast.IntValue:
+        n, _ := strconv.ParseFloat(v.Value, 64)
+        return n
+    case *ast.FloatValue:
+        n, _ := strconv.ParseFloat(v.Value, 64)
+        return n
+    case *ast.EnumValue:
+        return v.Value
+    case *ast.ListValue:
+        list := make([]interface{}, len(v.Values))
+        for i, item := range v.Values {
+            list[i] = parseJSONLiteral(item)
+        }
+        return list
+    case *ast.ObjectValue:
+        obj := make(map[string]interface{}, len(v.Fields))
+        for _, f := range v.Fields {
+            obj[f.Name.Value] = parseJSONLiteral(f.Value)
+        }
+        return obj
+    }
+    return nil
+}
+
+// schemaBuilder builds the types of the schema around the services
+type schemaBuilder struct {
+    svc Services
+
+    candidate   *graphql.Object
+    application *graphql.Object
+    job         *graphql.Object
+    note        *graphql.Object
+}
+
+func newSchema(svc Services) (graphql.Schema, error) {
+    b := &schemaBuilder{svc: svc}
+    b.job = b.jobType()
+    b.candidate = b.candidateType()
+    b.application = b.applicationType()
+
+    return graphql.NewSchema(graphql.SchemaConfig{
+        Query:    b.queryType(),
+        Mutation: b.mutationType(),
+    })
+}
+
+// candidateOf returns the candidate a field is resolved on
+func candidateOf(p graphql.ResolveParams) *domain.Candidate {
+    c, _ := p.Source.(*domain.Candidate)
+    if c == nil {
+        return &domain.Candidate{}
+    }
+    return c
+}
+
+func (b *schemaBuilder) candidateType() *graphql.Object {
+    phone := graphql.NewObject(graphql.ObjectConfig{
+        Name: "Phone",
+        Fields: graphql.Fields{
+            "number":  &graphql.Field{Type: graphql.String, Resolve: func(p graphql.ResolveParams) (interface{}, error) { return p.Source.(domain.Phone).Number, nil }},
+            "type":    &graphql.Field{Type: graphql.String, Resolve: func(p graphql.ResolveParams) (interface{}, error) { return p.Source.(domain.Phone).Type, nil }},
+            "primary": &graphql.Field{Type: graphql.Boolean, Resolve: func(p graphql.ResolveParams) (interface{}, error) { return p.Source.(domain.Phone).Primary, nil }},
+        },
+    })
+    address := graphql.NewObject(graphql.ObjectConfig{
+        Name: "Address",
+        Fields: graphql.Fields{
+            "line1":      &graphql.Field{Type: graphql.String, Resolve: addressField(func(a *domain.Address) string { return a.Line1 })},
+            "line2":      &graphql.Field{Type: graphql.String, Resolve: addressField(func(a *domain.Address) string { return a.Line2 })},
+            "city":       &graphql.Field{Type: graphql.String, Resolve: addressField(func(a *domain.Address) string { return a.City })},
+            "region":     &graphql.Field{Type: graphql.String, Resolve: addressField(func(a *domain.Address) string { return a.Region })},
+            "postalCode": &graphql.Field{Type: graphql.String, Resolve: addressField(func(a *domain.Address) string { return a.PostalCode })},
+        },
+    })
+    contact := graphql.NewObject(graphql.ObjectConfig{
+        Name: "ContactDetails",
+        Fields: graphql.Fields{
+            "phones": &graphql.Field{Type: graphql.NewList(graphql.NewNonNull(phone)), Resolve: contactField(func(c *domain.ContactDetails) interface{} { return c.Phones })},
+            "address": &graphql.Field{Type: address, Resolve: contactField(func(c *domain.ContactDetails) interface{} {
+                if c.Address == nil {
+                    return nil
+                }
+                return c.Address
+            })},
+            "country":          &graphql.Field{Type: graphql.String, Resolve: contactField(func(c *domain.ContactDetails) interface{} { return c.Country })},
+            "timeZone":         &graphql.Field{Type: graphql.String, Resolve: contactField(func(c *domain.ContactDetails) interface{} { return c.TimeZone })},
+            "linkedinUrl":      &graphql.Field{Type: graphql.String, Resolve: contactField(func(c *domain.ContactDetails) interface{} { return c.LinkedInURL })},
+            "githubUrl":        &graphql.Field{Type: graphql.String, Resolve: contactField(func(c *domain.ContactDetails) interface{} { return c.GitHubURL })},
+            "portfolioUrl":     &graphql.Field{Type: graphql.String, Resolve: contactField(func(c *domain.ContactDetails) interface{} { return c.PortfolioURL })},
+            "preferredChannel": &graphql.Field{Type: graphql.String, Resolve: contactField(func(c *domain.ContactDetails) interface{} { return c.PreferredChannel })},
+        },
+    })
+
+    return graphql.NewObject(graphql.ObjectConfig{
+        Name: "Candidate",
+        Fields: graphql.FieldsThunk(func() graphql.Fields {
+            return graphql.Fields{
+                "id":             &graphql.Field{Type: graphql.NewNonNull(graphql.Int), Resolve: candidateField(func(c *domain.Candidate) interface{} { return c.ID })},
+                "name":           &graphql.Field{Type: graphql.String, Resolve: candidateField(func(c *domain.Candidate) interface{} { return c.Name })},
+                "email":          &graphql.Field{Type: graphql.String, Resolve: candidateField(func(c *domain.Candidate) interface{} { return c.Email })},
+                "gender":         &graphql.Field{Type: graphql.String, Resolve: candidateField(func(c *domain.Candidate) interface{} { return c.Gender })},
+                "salaryExpected": &graphql.Field{Type: graphql.Float, Resolve: candidateField(func(c *domain.Candidate) interface{} { return c.SalaryExpected })},
+                "tags": &graphql.Field{Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(graphql.String))), Resolve: candidateField(func(c *domain.Candidate) interface{} {
+                    if c.Tags == nil {
+                        return []string{}
+                    }
+                    return c.Tags
+                })},
+                "customFields": &graphql.Field{Type: jsonScalar, Description: "Values by field key, see /api/custom-fields/schema", Resolve: candidateField(func(c *domain.Candidate) interface{} {
+                    if c.CustomFields == nil {
+                        return map[string]interface{}{}
+                    }
+                    return c.CustomFields
+                })},
+                "contact": &graphql.Field{Type: contact, Resolve: candidateField(func(c *domain.Candidate) interface{} {
+                    if c.Contact == nil {
+                        return nil
+                    }
+                    return c.Contact
+                })},
+                "createdAt": &graphql.Field{Type: graphql.DateTime, Resolve: candidateField(func(c *domain.Candidate) interface{} { return c.CreatedAt })},
+                "updatedAt": &graphql.Field{Type: graphql.DateTime, Resolve: candidateField(func(c *domain.Candidate) interface{} { return c.UpdatedAt })},
+                "applications": &graphql.Field{
+                    Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(b.application))),
+                    Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+                        return b.svc.Applications.ListByCandidate(candidateOf(p).ID)
+                    },
+                },
+                "notes": &graphql.Field{
+                    Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(b.noteType()))),
+                    Description: "The team notes and the private notes of the current user",
+                    Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+                        return b.svc.Notes.ListNotes(candidateOf(p).ID, currentUser(p.Context))
+                    },
+                },
+            }
+        }),
+    })
+}
+
+func candidateField(fn func(c *domain.Candidate) interface{}) graphql.FieldResolveFn {
+    return func(p graphql.ResolveParams) (interface{}, error) {
+        return fn(candidateOf(p)), nil
+    }
+}
+
+func contactField(fn func(c *domain.ContactDetails) interface{}) graphql.FieldResolveFn {
+    return func(p graphql.ResolveParams) (interface{}, error) {
+        return fn(p.Source.(*domain.ContactDetails)), nil
+    }
+}
+
+func addressField(fn func(a *domain.Address) string) graphql.FieldResolveFn {
+    return func(p graphql.ResolveParams) (interface{}, error) {
+        return fn(p.Source.(*domain.Address)), nil
+    }
+}
+
+func (b *schemaBuilder) noteType() *graphql.Object {
+    if b.note != nil {
+        return b.note
+    }
+    field := func(fn func(n domain.Note) interface{}) graphql.FieldResolveFn {
+        return func(p graphql.ResolveParams) (interface{}, error) {
+            return fn(p.Source.(domain.Note)), nil
+        }
+    }
+    b.note = graphql.NewObject(graphql.ObjectConfig{
+        Name: "Note",
+        Fields: graphql.Fields{
+            "id":         &graphql.Field{Type: graphql.NewNonNull(graphql.Int), Resolve: field(func(n domain.Note) interface{} { return n.ID })},
+            "author":     &graphql.Field{Type: graphql.String, Resolve: field(func(n domain.Note) interface{} { return n.Author })},
+            "body":       &graphql.Field{Type: graphql.String, Description: "Markdown", Resolve: field(func(n domain.Note) interface{} { return n.Body })},
+            "visibility": &graphql.Field{Type: graphql.String, Resolve: field(func(n domain.Note) interface{} { return n.Visibility })},
+            "pinned":     &graphql.Field{Type: graphql.Boolean, Resolve: field(func(n domain.Note) interface{} { return n.Pinned })},
+            "mentions":   &graphql.Field{Type: graphql.NewList(graphql.NewNonNull(graphql.String)), Resolve: field(func(n domain.Note) interface{} { return n.Mentions })},
+            "edited":     &graphql.Field{Type: graphql.Boolean, Resolve: field(func(n domain.Note) interface{} { return n.Edited })},
+            "createdAt":  &graphql.Field{Type: graphql.DateTime, Resolve: field(func(n domain.Note) interface{} { return n.CreatedAt })},
+            "updatedAt":  &graphql.Field{Type: graphql.DateTime, Resolve: field(func(n domain.Note) interface{} { return n.UpdatedAt })},
+        },
+    })
+    return b.note
+}
+
+func (b *schemaBuilder) jobType() *graphql.Object {
+    field := func(fn func(j *domain.Job) interface{}) graphql.FieldResolveFn {
+        return func(p graphql.ResolveParams) (interface{}, error) {
+            return fn(p.Source.(*domain.Job)), nil
+        }
+    }
+    return graphql.NewObject(graphql.ObjectConfig{
+        Name: "Job",
+        Fields: graphql.Fields{
+            "id":            &graphql.Field{Type: graphql.NewNonNull(graphql.Int), Resolve: field(func(j *domain.Job) interface{} { return j.ID })},
+            "title":         &graphql.Field{Type: graphql.String, Resolve: field(func(j *domain.Job) interface{} { return j.Title })},
+            "department":    &graphql.Field{Type: graphql.String, Resolve: field(func(j *domain.Job) interface{} { return j.Department })},
+            "location":      &graphql.Field{Type: graphql.String, Resolve: field(func(j *domain.Job) interface{} { return j.Location })},
+            "salaryMin":     &graphql.Field{Type: graphql.Float, Resolve: field(func(j *domain.Job) interface{} { return j.SalaryMin })},
+            "salaryMax":     &graphql.Field{Type: graphql.Float, Resolve: field(func(j *domain.Job) interface{} { return j.SalaryMax })},
+            "status":        &graphql.Field{Type: graphql.String, Resolve: field(func(j *domain.Job) interface{} { return j.Status })},
+            "hiringManager": &graphql.Field{Type: graphql.String, Resolve: field(func(j *domain.Job) interface{} { return j.HiringManager })},
+            "createdAt":     &graphql.Field{Type: graphql.DateTime, Resolve: field(func(j *domain.Job) interface{} { return j.CreatedAt })},
+            "updatedAt":     &graphql.Field{Type: graphql.DateTime, Resolve: field(func(j *domain.Job) interface{} { return j.UpdatedAt })},
+        },
+    })
+}
+
+func (b *schemaBuilder) applicationType() *graphql.Object {
+    field := func(fn func(a domain.Application) interface{}) graphql.FieldResolveFn {
+        return func(p graphql.ResolveParams) (interface{}, error) {
+            return fn(p.Source.(domain.Application)), nil
+        }
+    }
+    return graphql.NewObject(graphql.ObjectConfig{
+        Name: "Application",
+        Fields: graphql.Fields{
+            "id":              &graphql.Field{Type: graphql.NewNonNull(graphql.Int), Resolve: field(func(a domain.Application) interface{} { return a.ID })},
+            "candidateId":     &graphql.Field{Type: graphql.NewNonNull(graphql.Int), Resolve: field(func(a domain.Application) interface{} { return a.CandidateID })},
+            "jobId":           &graphql.Field{Type: graphql.NewNonNull(graphql.Int), Resolve: field(func(a domain.Application) interface{} { return a.JobID })},
+            "stage":           &graphql.Field{Type: graphql.String, Resolve: field(func(a domain.Application) interface{} { return a.Stage })},
+            "rejectionReason": &graphql.Field{Type: graphql.String, Resolve: field(func(a domain.Application) interface{} { return a.RejectionReason })},
+            "createdAt":       &graphql.Field{Type: graphql.DateTime, Resolve: field(func(a domain.Application) interface{} { return a.CreatedAt })},
+            "updatedAt":       &graphql.Field{Type: graphql.DateTime, Resolve: field(func(a domain.Application) interface{} { return a.UpdatedAt })},
+            // The candidates and jobs of a list are read in one batch by the loaders
+            "candidate": &graphql.Field{
+                Type: b.candidate,
+                Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+                    load := loadersFrom(p.Context).candidates.Load(p.Source.(domain.Application).CandidateID)
+                    return func() (interface{}, error) {
+                        c, err := load()
+                        if err != nil || c == nil {
+                            return nil, err
+                        }
+                        return c, nil
+                    }, nil
+                },
+            },
+            "job": &graphql.Field{
+                Type: b.job,
+                Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+                    load := loadersFrom(p.Context).jobs.Load(p.Source.(domain.Application).JobID)
+                    return func() (interface{}, error) {
+                        j, err := load()
+                        if err != nil || j == nil {
+                            return nil, err
+                        }
+                        return j, nil
+                    }, nil
+                },
+            },
+        },
+    })
+}
+
+func (b *schemaBuilder) queryType() *graphql.Object {
+    pageInfo := graphql.NewObject(graphql.ObjectConfig{
+        Name: "PageInfo",
+        Fields: graphql.Fields{
+            "hasNextPage": &graphql.Field{Type: graphql.NewNonNull(graphql.Boolean)},
+            "endCursor":   &graphql.Field{Type: graphql.String},
+        },
+    })
+    edge := graphql.NewObject(graphql.ObjectConfig{
+        Name: "CandidateEdge",
+        Fields: graphql.Fields{
+            "cursor": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+            "node":   &graphql.Field{Type: graphql.NewNonNull(b.candidate)},
+        },
+    })
+    connection := graphql.NewObject(graphql.ObjectConfig{
+        Name: "CandidateConnection",
+        Fields: graphql.Fields{
+            "edges":    &graphql.Field{Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(edge)))},
+            "pageInfo": &graphql.Field{Type: graphql.NewNonNull(pageInfo)},
+            "totalCount": &graphql.Field{
+                Type: graphql.NewNonNull(graphql.Int),
+                Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+                    return p.Source.(map[string]interface{})["totalCount"].(func() (int, error))()
+                },
+            },
+        },
+    })
+    fieldFilter := graphql.NewInputObject(graphql.InputObjectConfig{
+        Name: "FieldFilter",
+        Fields: graphql.InputObjectConfigFieldMap{
+            "key":   &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+            "value": &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String), Description: "Same syntax as cf[key] in GET /api/candidates"},
+        },
+    })
+    filter := graphql.NewInputObject(graphql.InputObjectConfig{
+        Name: "CandidateFilter",
+        Fields: graphql.InputObjectConfigFieldMap{
+            "name":      &graphql.InputObjectFieldConfig{Type: graphql.String},
+            "email":     &graphql.InputObjectFieldConfig{Type: graphql.String},
+            "gender":    &graphql.InputObjectFieldConfig{Type: graphql.String},
+            "salaryMin": &graphql.InputObjectFieldConfig{Type: graphql.Float},
+            "salaryMax": &graphql.InputObjectFieldConfig{Type: graphql.Float},
+            "tags":      &graphql.InputObjectFieldConfig{Type: graphql.NewList(graphql.NewNonNull(graphql.String))},
+            "country":   &graphql.InputObjectFieldConfig{Type: graphql.String},
+            "fields":    &graphql.InputObjectFieldConfig{Type: graphql.NewList(graphql.NewNonNull(fieldFilter))},
+        },
+    })
+
+    return graphql.NewObject(graphql.ObjectConfig{
+        Name: "Query",
+        Fields: graphql.Fields{
+            "candidate": &graphql.Field{
+                Type: b.candidate,
+                Args: graphql.FieldConfigArgument{"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.Int)}},
+                Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+                    c, err := b.candidates(p.Context).GetCandidateByID(p.Args["id"].(int))
+                    if err != nil || c == nil {
+                        return nil, err
+                    }
+                    return c, nil
+                },
+            },
+            "candidates": &graphql.Field{
+                Type: graphql.NewNonNull(connection),
+                Args: graphql.FieldConfigArgument{
+                    "filter": &graphql.ArgumentConfig{Type: filter},
+                    "first":  &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: DefaultPageSize},
+                    "after":  &graphql.ArgumentConfig{Type: graphql.String},
+                },
+                Resolve: b.listCandidates,
+            },
+            "job": &graphql.Field{
+                Type: b.job,
+                Args: graphql.FieldConfigArgument{"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.Int)}},
+                Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+                    j, err := b.svc.Jobs.GetJobByID(p.Args["id"].(int))
+                    if err != nil || j == nil {
+                        return nil, err
+                    }
+                    return j, nil
+                },
+            },
+            "applications": &graphql.Field{
+                Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(b.application))),
+                Description: "The applications to a job",
+                Args:        graphql.FieldConfigArgument{"jobId": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.Int)}},
+                Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+                    return b.svc.Applications.ListByJob(p.Args["jobId"].(int))
+                },
+            },
+        },
+    })
+}
+
+// listCandidates returns a page of the candidates matching the filter, in ID order. The
+// cursor of an edge is the opaque ID of the candidate, so a page starts right after it
+// whatever was created or deleted since the previous one.
+func (b *schemaBuilder) listCandidates(p graphql.ResolveParams) (interface{}, error) {
+    first, _ := p.Args["first"].(int)
+    if first < 1 || first > MaxPageSize {
+        return nil, ErrInvalidPageSize
+    }
+    afterID := 0
+    if after, ok := p.Args["after"].(string); ok && after != "" {
+        id, err := decodeCursor(after)
+        if err != nil {
+            return nil, err
+        }
+        afterID = id
+    }
+    filter, err := b.candidateFilter(p.Context, p.Args["filter"])
+    if err != nil {
+        return nil, err
+    }
+
+    // One more than the page tells whether there is a next one
+    candidates, err := b.candidates(p.Context).ListCandidatesPage(filter, afterID, first+1)
+    if err != nil {
+        return nil, err
+    }
+    hasNext := len(candidates) > first
+    if hasNext {
+        candidates = candidates[:first]
+    }
+
+    edges := make([]map[string]interface{}, 0, len(candidates))
+    for i := range candidates {
+        edges = append(edges, map[string]interface{}{"cursor": encodeCursor(candidates[i].ID), "node": &candidates[i]})
+    }
+    pageInfo := map[string]interface{}{"hasNextPage": hasNext}
+    if len(candidates) > 0 {
+        pageInfo["endCursor"] = encodeCursor(candidates[len(candidates)-1].ID)
+    }
+    // The count is a query of its own, only run when the field is selected
+    totalCount := func() (int, error) {
+        return b.candidates(p.Context).CountCandidates(filter)
+    }
+    return map[string]interface{}{"edges": edges, "pageInfo": pageInfo, "totalCount": totalCount}, nil
+}
+
+func encodeCursor(id int) string {
+    return base64.RawURLEncoding.EncodeToString([]byte(strconv.Itoa(id)))
+}
+
+// decodeCursor returns the candidate ID of the cursor, which must be a valid ID
+func decodeCursor(cursor string) (int, error) {
+    raw, err := base64.RawURLEncoding.DecodeString(cursor)
+    if err != nil {
+        return 0, ErrInvalidCursor
+    }
+    id, err := strconv.ParseInt(string(raw), 10, 32)
+    if err != nil || id < 1 {
+        return 0, ErrInvalidCursor
+    }
+    return int(id), nil
+}
+
+// candidateFilter builds the list filter from the argument, with the same semantics
+// as the query parameters of GET /api/candidates
+func (b *schemaBuilder) candidateFilter(ctx context.Context, arg interface{}) (domain.CandidateFilter, error) {
+    var filter domain.CandidateFilter
+    in, ok := arg.(map[string]interface{})
+    if !ok {
+        return filter, nil
+    }
+    filter.Name, _ = in["name"].(string)
+    filter.Email, _ = in["email"].(string)
+    filter.Gender, _ = in["gender"].(string)
+    filter.Country, _ = in["country"].(string)
+    if v, ok := in["salaryMin"].(float64); ok {
+        filter.SalaryMin = &v
+    }
+    if v, ok := in["salaryMax"].(float64); ok {
+        filter.SalaryMax = &v
+    }
+    filter.Tags = toStrings(in["tags"])
+
+    if fields, ok := in["fields"].([]interface{}); ok && len(fields) > 0 {
+        raw := make(map[string]string, len(fields))
+        for _, f := range fields {
+            if kv, ok := f.(map[string]interface{}); ok {
+                key, _ := kv["key"].(string)
+                raw[key], _ = kv["value"].(string)
+            }
+        }
+        conds, err := b.candidates(ctx).ParseFieldFilters(raw)
+        if err != nil {
+            return filter, err
+        }
+        filter.Fields = conds
+    }
+    return filter, nil
+}
+
+func toStrings(v interface{}) []string {
+    list, ok := v.([]interface{})
+    if !ok {
+        return nil
+    }
+    out := make([]string, 0, len(list))
+    for _, item := range list {
+        if s, ok := item.(string); ok {
+            out = append(out, s)
+        }
+    }
+    return out
+}
+
+func (b *schemaBuilder) mutationType() *graphql.Object {
+    input := graphql.NewInputObject(graphql.InputObjectConfig{
+        Name:        "CandidateInput",
+        Description: "On update, the omitted fields are unchanged",
+        Fields: graphql.InputObjectConfigFieldMap{
+            "name":           &graphql.InputObjectFieldConfig{Type: graphql.String},
+            "email":          &graphql.InputObjectFieldConfig{Type: graphql.String},
+            "gender":         &graphql.InputObjectFieldConfig{Type: graphql.String},
+            "salaryExpected": &graphql.InputObjectFieldConfig{Type: graphql.Float},
+            "tags":           &graphql.InputObjectFieldConfig{Type: graphql.NewList(graphql.NewNonNull(graphql.String))},
+            "customFields":   &graphql.InputObjectFieldConfig{Type: jsonScalar, Description: "Values by field key"},
+            "contact":        &graphql.InputObjectFieldConfig{Type: jsonScalar, Description: "Contact details with the fields of the REST API"},
+        },
+    })
+
+    return graphql.NewObject(graphql.ObjectConfig{
+        Name: "Mutation",
+        Fields: graphql.Fields{
+            "createCandidate": &graphql.Field{
+                Type: graphql.NewNonNull(b.candidate),
+                Args: graphql.FieldConfigArgument{"input": &graphql.ArgumentConfig{Type: graphql.NewNonNull(input)}},
+                Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+                    var c domain.Candidate
+                    if err := applyCandidateInput(&c, p.Args["input"].(map[string]interface{})); err != nil {
+                        return nil, err
+                    }
+                    id, err := b.candidates(p.Context).CreateCandidate(c)
+                    if err != nil {
+                        return nil, err
+                    }
+                    return b.mustGetCandidate(p.Context, id)
+                },
+            },
+            "updateCandidate": &graphql.Field{
+                Type: graphql.NewNonNull(b.candidate),
+                Args: graphql.FieldConfigArgument{
+                    "id":    &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.Int)},
+                    "input": &graphql.ArgumentConfig{Type: graphql.NewNonNull(input)},
+                },
+                Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+                    id := p.Args["id"].(int)
+                    current, err := b.mustGetCandidate(p.Context, id)
+                    if err != nil {
+                        return nil, err
+                    }
+                    // The attributes are only saved when they are sent
+                    c := *current
+                    c.Tags, c.CustomFields, c.Contact = nil, nil, nil
+                    if err := applyCandidateInput(&c, p.Args["input"].(map[string]interface{})); err != nil {
+                        return nil, err
+                    }
+                    if err := b.candidates(p.Context).UpdateCandidate(c); err != nil {
+                        return nil, err
+                    }
+                    return b.mustGetCandidate(p.Context, id)
+                },
+            },
+            "deleteCandidate": &graphql.Field{
+                Type: graphql.NewNonNull(graphql.Boolean),
+                Args: graphql.FieldConfigArgument{"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.Int)}},
+                Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+                    id := p.Args["id"].(int)
+                    if _, err := b.mustGetCandidate(p.Context, id); err != nil {
+                        return nil, err
+                    }
+                    if err := b.candidates(p.Context).DeleteCandidate(id); err != nil {
+                        return nil, err
+                    }
+                    return true, nil
+                },
+            },
+        },
+    })
+}
+
+// mustGetCandidate reads a candidate, failing with service.ErrCandidateNotFound when missing
+func (b *schemaBuilder) mustGetCandidate(ctx context.Context, id int) (*domain.Candidate, error) {
+    c, err := b.candidates(ctx).GetCandidateByID(id)
+    if err != nil {
+        return nil, err
+    }
+    if c == nil {
+        return nil, service.ErrCandidateNotFound
+    }
+    return c, nil
+}
+
+// applyCandidateInput copies the fields sent in the input to the candidate
+func applyCandidateInput(c *domain.Candidate, in map[string]interface{}) error {
+    if v, ok := in["name"].(string); ok {
+        c.Name = v
+    }
+    if v, ok := in["email"].(string); ok {
+        c.Email = v
+    }
+    if v, ok := in["gender"].(string); ok {
+        c.Gender = v
+    }
+    if v, ok := in["salaryExpected"].(float64); ok {
+        c.SalaryExpected = v
+    }
+    if _, ok := in["tags"].([]interface{}); ok {
+        c.Tags = toStrings(in["tags"])
+    }
+    if v, ok := in["customFields"].(map[string]interface{}); ok {
+        c.CustomFields = v
+    }
+    if v, ok := in["contact"]; ok && v != nil {
+        raw, err := json.Marshal(v)
+        if err != nil {
+            return fmt.Errorf("%w: %v", service.ErrInvalidContact, err)
+        }
+        var contact domain.ContactDetails
+        if err := json.Unmarshal(raw, &contact); err != nil {
+            return fmt.Errorf("%w: %v", service.ErrInvalidContact, err)
+        }
+        c.Contact = &contact
+    }
+    return nil
+}
@@ -0,0 +1,110 @@
+package graphqlapi
+
+import (
+    "context"
+
+    "github.com/graphql-go/graphql"
+    "github.com/graphql-go/graphql/gqlerrors"
+    "github.com/graphql-go/graphql/language/ast"
+    "github.com/graphql-go/graphql/language/parser"
+    "github.com/graphql-go/graphql/language/source"
+
+    "github.com/torvictorvic/seek-v2/internal/service"
+)
+
+// Services are the services the resolvers read and write through
+type Services struct {
+    Candidates   service.CandidateService
+    Applications service.ApplicationService
+    Jobs         service.JobService
+    Notes        service.NoteService
+}
+
+// Request is a GraphQL call, in the JSON body of a POST or the query of a GET
+type Request struct {
+    Query         string                 `json:"query" form:"query"`
+    OperationName string                 `json:"operationName" form:"operationName"`
+    Variables     map[string]interface{} `json:"variables" form:"-"`
+}
+
+// Server runs the GraphQL queries on the candidates and their related data
+type Server struct {
+    schema graphql.Schema
+    svc    Services
+    limits Limits
+}
+
+func NewServer(svc Services, limits Limits) (*Server, error) {
+    schema, err := newSchema(svc)
+    if err != nil {
+        return nil, err
+    }
+    return &Server{schema: schema, svc: svc, limits: limits}, nil
+}
+
+type (
+    userKey       struct{}
+    candidatesKey struct{}
+)
+
+// Execute parses and validates the query and checks its limits before running it on
+// behalf of user. Each call gets its own loaders, so nothing is cached between requests.
+func (s *Server) Execute(ctx context.Context, user string, req Request) *graphql.Result {
+    src := source.NewSource(&source.Source{Body: []byte(req.Query), Name: "GraphQL request"})
+    doc, err := parser.Parse(parser.ParseParams{Source: src})
+    if err != nil {
+        return &graphql.Result{Errors: gqlerrors.FormatErrors(err)}
+    }
+    if validation := graphql.ValidateDocument(&s.schema, doc, nil); !validation.IsValid {
+        return &graphql.Result{Errors: validation.Errors}
+    }
+    if err := checkLimits(doc, req.OperationName, req.Variables, s.limits); err != nil {
+        return &graphql.Result{Errors: gqlerrors.FormatErrors(err)}
+    }
+
+    // The candidates are read through the service bound to the user, which reads from the replicas
+    svc := s.svc
+    svc.Candidates = service.ForUser(s.svc.Candidates, user)
+    ctx = context.WithValue(context.WithValue(ctx, userKey{}, user), candidatesKey{}, svc.Candidates)
+    ctx = withLoaders(ctx, svc)
+    return graphql.Execute(graphql.ExecuteParams{
+        Schema:        s.schema,
+        AST:           doc,
+        OperationName: req.OperationName,
+        Args:          req.Variables,
+        Context:       ctx,
+    })
+}
+
+// IsMutation reports whether the operation of the query that runs is a mutation, so GET
+// requests can refuse it. A query that does not parse is not one, Execute reports its errors.
+func IsMutation(query, operationName string) bool {
+    src := source.NewSource(&source.Source{Body: []byte(query), Name: "GraphQL request"})
+    doc, err := parser.Parse(parser.ParseParams{Source: src})
+    if err != nil {
+        return false
+    }
+    for _, def := range doc.Definitions {
+        op, ok := def.(*ast.OperationDefinition)
+        if !ok || (operationName != "" && (op.Name == nil || op.Name.Value != operationName)) {
+            continue
+        }
+        if op.Operation == ast.OperationTypeMutation {
+            return true
+        }
+    }
+    return false
+}
+
+func currentUser(ctx context.Context) string {
+    user, _ := ctx.Value(userKey{}).(string)
+    return user
+}
+
+// candidates returns the candidate service of the request, or the shared one outside Execute
+func (b *schemaBuilder) candidates(ctx context.Context) service.CandidateService {
+    if svc, ok := ctx.Value(candidatesKey{}).(service.CandidateService); ok {
+        return svc
+    }
+    return b.svc.Candidates
+}
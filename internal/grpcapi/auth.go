@@ -0,0 +1,74 @@
+package grpcapi
+
+import (
+    "context"
+    "strings"
+
+    "google.golang.org/grpc"
+    "google.golang.org/grpc/codes"
+    "google.golang.org/grpc/metadata"
+    "google.golang.org/grpc/status"
+
+    "github.com/torvictorvic/seek-v2/internal/security"
+)
+
+type userKey struct{}
+
+// publicServices can be called without a token, so probes and tools work as usual
+var publicServices = []string{"/grpc.health.v1.Health/", "/grpc.reflection."}
+
+// UnaryAuthInterceptor validates the JWT of the "authorization" metadata like security.AuthMiddleware
+func UnaryAuthInterceptor() grpc.UnaryServerInterceptor {
+    return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+        ctx, err := authenticate(ctx, info.FullMethod)
+        if err != nil {
+            return nil, err
+        }
+        return handler(ctx, req)
+    }
+}
+
+// StreamAuthInterceptor validates the JWT of the streaming calls
+func StreamAuthInterceptor() grpc.StreamServerInterceptor {
+    return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+        ctx, err := authenticate(ss.Context(), info.FullMethod)
+        if err != nil {
+            return err
+        }
+        return handler(srv, &authenticatedStream{ServerStream: ss, ctx: ctx})
+    }
+}
+
+// CurrentUser returns the user of the token validated by the interceptors
+func CurrentUser(ctx context.Context) string {
+    user, _ := ctx.Value(userKey{}).(string)
+    return user
+}
+
+func authenticate(ctx context.Context, method string) (context.Context, error) {
+    for _, prefix := range publicServices {
+        if strings.HasPrefix(method, prefix) {
+            return ctx, nil
+        }
+    }
+    md, _ := metadata.FromIncomingContext(ctx)
+    values := md.Get("authorization")
+    if len(values) == 0 || values[0] == "" {
+        return nil, status.Error(codes.Unauthenticated, "Missing token in metadata 'authorization'")
+    }
+    user, err := security.ValidateToken(strings.Replace(values[0], "Bearer ", "", 1))
+    if err != nil {
+        return nil, status.Error(codes.Unauthenticated, err.Error())
+    }
+    return context.WithValue(ctx, userKey{}, user), nil
+}
+
+// authenticatedStream carries the context with the user to the stream handler
+type authenticatedStream struct {
+    grpc.ServerStream
+    ctx context.Context
+}
+
+func (s *authenticatedStream) Context() context.Context {
+    return s.ctx
+}
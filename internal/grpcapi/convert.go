@@ -0,0 +1,108 @@
+package grpcapi
+
+import (
+    "google.golang.org/protobuf/types/known/structpb"
+    "google.golang.org/protobuf/types/known/timestamppb"
+
+    "github.com/torvictorvic/seek-v2/internal/domain"
+    "github.com/torvictorvic/seek-v2/internal/grpcapi/seekpb"
+)
+
+func toPB(c domain.Candidate) *seekpb.Candidate {
+    pb := &seekpb.Candidate{
+        Id:             int64(c.ID),
+        Name:           c.Name,
+        Email:          c.Email,
+        Gender:         c.Gender,
+        SalaryExpected: c.SalaryExpected,
+        Tags:           c.Tags,
+        Contact:        contactToPB(c.Contact),
+    }
+    if len(c.CustomFields) > 0 {
+        fields := map[string]interface{}{}
+        for key, value := range c.CustomFields {
+            // structpb only knows the types of encoding/json
+            if values, ok := value.([]string); ok {
+                list := make([]interface{}, len(values))
+                for i, v := range values {
+                    list[i] = v
+                }
+                value = list
+            }
+            fields[key] = value
+        }
+        pb.CustomFields, _ = structpb.NewStruct(fields)
+    }
+    if !c.CreatedAt.IsZero() {
+        pb.CreatedAt = timestamppb.New(c.CreatedAt)
+    }
+    if !c.UpdatedAt.IsZero() {
+        pb.UpdatedAt = timestamppb.New(c.UpdatedAt)
+    }
+    return pb
+}
+
+// fromPB returns the candidate of the request. Tags, custom fields and contact details
+// that are not set stay nil, which the service reads as unchanged.
+func fromPB(pb *seekpb.Candidate) domain.Candidate {
+    if pb == nil {
+        return domain.Candidate{}
+    }
+    c := domain.Candidate{
+        ID:             int(pb.Id),
+        Name:           pb.Name,
+        Email:          pb.Email,
+        Gender:         pb.Gender,
+        SalaryExpected: pb.SalaryExpected,
+        Contact:        contactFromPB(pb.Contact),
+    }
+    if len(pb.Tags) > 0 {
+        c.Tags = pb.Tags
+    }
+    if pb.CustomFields != nil {
+        c.CustomFields = pb.CustomFields.AsMap()
+    }
+    return c
+}
+
+func contactToPB(c *domain.ContactDetails) *seekpb.ContactDetails {
+    if c == nil {
+        return nil
+    }
+    pb := &seekpb.ContactDetails{
+        Country:          c.Country,
+        TimeZone:         c.TimeZone,
+        LinkedinUrl:      c.LinkedInURL,
+        GithubUrl:        c.GitHubURL,
+        PortfolioUrl:     c.PortfolioURL,
+        PreferredChannel: c.PreferredChannel,
+    }
+    for _, p := range c.Phones {
+        pb.Phones = append(pb.Phones, &seekpb.Phone{Number: p.Number, Type: p.Type, Primary: p.Primary})
+    }
+    if a := c.Address; a != nil {
+        pb.Address = &seekpb.Address{Line1: a.Line1, Line2: a.Line2, City: a.City, Region: a.Region, PostalCode: a.PostalCode}
+    }
+    return pb
+}
+
+func contactFromPB(pb *seekpb.ContactDetails) *domain.ContactDetails {
+    if pb == nil {
+        return nil
+    }
+    c := &domain.ContactDetails{
+        Country:          pb.Country,
+        TimeZone:         pb.TimeZone,
+        LinkedInURL:      pb.LinkedinUrl,
+        GitHubURL:        pb.GithubUrl,
+        PortfolioURL:     pb.PortfolioUrl,
+        PreferredChannel: pb.PreferredChannel,
+    }
+    for _, p := range pb.Phones {
+        c.Phones = append(c.Phones, domain.Phone{Number: p.Number, Type: p.Type, Primary: p.Primary})
+    }
+    if a := pb.Address; a != nil {
+        c.Address = &domain.Address{Line1: a.Line1, Line2: a.Line2, City: a.City, Region: a.Region, PostalCode: a.PostalCode}
+    }
+    return c
+}
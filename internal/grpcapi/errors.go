@@ -0,0 +1,29 @@
+package grpcapi
+
+import (
+    "errors"
+
+    "google.golang.org/grpc/codes"
+    "google.golang.org/grpc/status"
+
+    "github.com/torvictorvic/seek-v2/internal/service"
+)
+
+// toStatus maps the errors of the service to the gRPC code matching the HTTP status of
+// the REST API: 400 is InvalidArgument, 404 NotFound and 500 Internal
+func toStatus(err error) error {
+    switch {
+    case errors.Is(err, service.ErrInvalidCandidate),
+        errors.Is(err, service.ErrInvalidAttributes),
+        errors.Is(err, service.ErrUnknownCustomField),
+        errors.Is(err, service.ErrInvalidContact):
+        return status.Error(codes.InvalidArgument, err.Error())
+    case errors.Is(err, service.ErrCandidateNotFound):
+        return status.Error(codes.NotFound, err.Error())
+    }
+    return status.Error(codes.Internal, err.Error())
+}
+
+func notFound() error {
+    return status.Error(codes.NotFound, service.ErrCandidateNotFound.Error())
+}
@@ -0,0 +1,324 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: seek/v1/candidate.proto
+
+package seekpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	CandidateService_CreateCandidate_FullMethodName = "/seek.v1.CandidateService/CreateCandidate"
+	CandidateService_GetCandidate_FullMethodName    = "/seek.v1.CandidateService/GetCandidate"
+	CandidateService_ListCandidates_FullMethodName  = "/seek.v1.CandidateService/ListCandidates"
+	CandidateService_UpdateCandidate_FullMethodName = "/seek.v1.CandidateService/UpdateCandidate"
+	CandidateService_DeleteCandidate_FullMethodName = "/seek.v1.CandidateService/DeleteCandidate"
+	CandidateService_WatchCandidates_FullMethodName = "/seek.v1.CandidateService/WatchCandidates"
+)
+
+// CandidateServiceClient is the client API for CandidateService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// CandidateService exposes the candidates over gRPC with the same rules as the REST API.
+// Every call needs the JWT of /login in the "authorization: Bearer <token>" metadata.
+type CandidateServiceClient interface {
+	CreateCandidate(ctx context.Context, in *CreateCandidateRequest, opts ...grpc.CallOption) (*Candidate, error)
+	GetCandidate(ctx context.Context, in *GetCandidateRequest, opts ...grpc.CallOption) (*Candidate, error)
+	ListCandidates(ctx context.Context, in *ListCandidatesRequest, opts ...grpc.CallOption) (*ListCandidatesResponse, error)
+	UpdateCandidate(ctx context.Context, in *UpdateCandidateRequest, opts ...grpc.CallOption) (*Candidate, error)
+	DeleteCandidate(ctx context.Context, in *DeleteCandidateRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// WatchCandidates streams the changes to the candidates as they happen
+	WatchCandidates(ctx context.Context, in *WatchCandidatesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[CandidateEvent], error)
+}
+
+type candidateServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewCandidateServiceClient(cc grpc.ClientConnInterface) CandidateServiceClient {
+	return &candidateServiceClient{cc}
+}
+
+func (c *candidateServiceClient) CreateCandidate(ctx context.Context, in *CreateCandidateRequest, opts ...grpc.CallOption) (*Candidate, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Candidate)
+	err := c.cc.Invoke(ctx, CandidateService_CreateCandidate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *candidateServiceClient) GetCandidate(ctx context.Context, in *GetCandidateRequest, opts ...grpc.CallOption) (*Candidate, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Candidate)
+	err := c.cc.Invoke(ctx, CandidateService_GetCandidate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *candidateServiceClient) ListCandidates(ctx context.Context, in *ListCandidatesRequest, opts ...grpc.CallOption) (*ListCandidatesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListCandidatesResponse)
+	err := c.cc.Invoke(ctx, CandidateService_ListCandidates_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *candidateServiceClient) UpdateCandidate(ctx context.Context, in *UpdateCandidateRequest, opts ...grpc.CallOption) (*Candidate, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Candidate)
+	err := c.cc.Invoke(ctx, CandidateService_UpdateCandidate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *candidateServiceClient) DeleteCandidate(ctx context.Context, in *DeleteCandidateRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, CandidateService_DeleteCandidate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *candidateServiceClient) WatchCandidates(ctx context.Context, in *WatchCandidatesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[CandidateEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &CandidateService_ServiceDesc.Streams[0], CandidateService_WatchCandidates_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchCandidatesRequest, CandidateEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CandidateService_WatchCandidatesClient = grpc.ServerStreamingClient[CandidateEvent]
+
+// CandidateServiceServer is the server API for CandidateService service.
+// All implementations must embed UnimplementedCandidateServiceServer
+// for forward compatibility.
+//
+// CandidateService exposes the candidates over gRPC with the same rules as the REST API.
+// Every call needs the JWT of /login in the "authorization: Bearer <token>" metadata.
+type CandidateServiceServer interface {
+	CreateCandidate(context.Context, *CreateCandidateRequest) (*Candidate, error)
+	GetCandidate(context.Context, *GetCandidateRequest) (*Candidate, error)
+	ListCandidates(context.Context, *ListCandidatesRequest) (*ListCandidatesResponse, error)
+	UpdateCandidate(context.Context, *UpdateCandidateRequest) (*Candidate, error)
+	DeleteCandidate(context.Context, *DeleteCandidateRequest) (*emptypb.Empty, error)
+	// WatchCandidates streams the changes to the candidates as they happen
+	WatchCandidates(*WatchCandidatesRequest, grpc.ServerStreamingServer[CandidateEvent]) error
+	mustEmbedUnimplementedCandidateServiceServer()
+}
+
+// UnimplementedCandidateServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedCandidateServiceServer struct{}
+
+func (UnimplementedCandidateServiceServer) CreateCandidate(context.Context, *CreateCandidateRequest) (*Candidate, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateCandidate not implemented")
+}
+func (UnimplementedCandidateServiceServer) GetCandidate(context.Context, *GetCandidateRequest) (*Candidate, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCandidate not implemented")
+}
+func (UnimplementedCandidateServiceServer) ListCandidates(context.Context, *ListCandidatesRequest) (*ListCandidatesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCandidates not implemented")
+}
+func (UnimplementedCandidateServiceServer) UpdateCandidate(context.Context, *UpdateCandidateRequest) (*Candidate, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateCandidate not implemented")
+}
+func (UnimplementedCandidateServiceServer) DeleteCandidate(context.Context, *DeleteCandidateRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteCandidate not implemented")
+}
+func (UnimplementedCandidateServiceServer) WatchCandidates(*WatchCandidatesRequest, grpc.ServerStreamingServer[CandidateEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchCandidates not implemented")
+}
+func (UnimplementedCandidateServiceServer) mustEmbedUnimplementedCandidateServiceServer() {}
+func (UnimplementedCandidateServiceServer) testEmbeddedByValue()                          {}
+
+// UnsafeCandidateServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to CandidateServiceServer will
+// result in compilation errors.
+type UnsafeCandidateServiceServer interface {
+	mustEmbedUnimplementedCandidateServiceServer()
+}
+
+func RegisterCandidateServiceServer(s grpc.ServiceRegistrar, srv CandidateServiceServer) {
+	// If the following call pancis, it indicates UnimplementedCandidateServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&CandidateService_ServiceDesc, srv)
+}
+
+func _CandidateService_CreateCandidate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateCandidateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CandidateServiceServer).CreateCandidate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CandidateService_CreateCandidate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CandidateServiceServer).CreateCandidate(ctx, req.(*CreateCandidateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CandidateService_GetCandidate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCandidateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CandidateServiceServer).GetCandidate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CandidateService_GetCandidate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CandidateServiceServer).GetCandidate(ctx, req.(*GetCandidateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CandidateService_ListCandidates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCandidatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CandidateServiceServer).ListCandidates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CandidateService_ListCandidates_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CandidateServiceServer).ListCandidates(ctx, req.(*ListCandidatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CandidateService_UpdateCandidate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateCandidateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CandidateServiceServer).UpdateCandidate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CandidateService_UpdateCandidate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CandidateServiceServer).UpdateCandidate(ctx, req.(*UpdateCandidateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CandidateService_DeleteCandidate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteCandidateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CandidateServiceServer).DeleteCandidate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CandidateService_DeleteCandidate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CandidateServiceServer).DeleteCandidate(ctx, req.(*DeleteCandidateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CandidateService_WatchCandidates_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchCandidatesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CandidateServiceServer).WatchCandidates(m, &grpc.GenericServerStream[WatchCandidatesRequest, CandidateEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CandidateService_WatchCandidatesServer = grpc.ServerStreamingServer[CandidateEvent]
+
+// CandidateService_ServiceDesc is the grpc.ServiceDesc for CandidateService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var CandidateService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "seek.v1.CandidateService",
+	HandlerType: (*CandidateServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateCandidate",
+			Handler:    _CandidateService_CreateCandidate_Handler,
+		},
+		{
+			MethodName: "GetCandidate",
+			Handler:    _CandidateService_GetCandidate_Handler,
+		},
+		{
+			MethodName: "ListCandidates",
+			Handler:    _CandidateService_ListCandidates_Handler,
+		},
+		{
+			MethodName: "UpdateCandidate",
+			Handler:    _CandidateService_UpdateCandidate_Handler,
+		},
+		{
+			MethodName: "DeleteCandidate",
+			Handler:    _CandidateService_DeleteCandidate_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchCandidates",
+			Handler:       _CandidateService_WatchCandidates_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "seek/v1/candidate.proto",
+}
@@ -0,0 +1,280 @@
+// Package grpcapi serves the candidates over gRPC, on top of the same service as the REST API
+package grpcapi
+
+//go:generate protoc -I ../../proto --go_out=../.. --go_opt=module=github.com/torvictorvic/seek-v2 --go-grpc_out=../.. --go-grpc_opt=module=github.com/torvictorvic/seek-v2 seek/v1/candidate.proto
+
+import (
+    "context"
+    "encoding/base64"
+    "encoding/json"
+    "strconv"
+
+    "google.golang.org/grpc"
+    "google.golang.org/grpc/codes"
+    "google.golang.org/grpc/health"
+    healthpb "google.golang.org/grpc/health/grpc_health_v1"
+    "google.golang.org/grpc/metadata"
+    "google.golang.org/grpc/reflection"
+    "google.golang.org/grpc/status"
+    "google.golang.org/protobuf/types/known/emptypb"
+
+    "github.com/torvictorvic/seek-v2/internal/domain"
+    "github.com/torvictorvic/seek-v2/internal/events"
+    "github.com/torvictorvic/seek-v2/internal/grpcapi/seekpb"
+    "github.com/torvictorvic/seek-v2/internal/service"
+)
+
+// Page sizes of ListCandidates
+const (
+    DefaultPageSize = 50
+    MaxPageSize     = 500
+)
+
+// EventReset is the type of the event sent when the changes since after_id are no longer kept
+const EventReset = "reset"
+
+type candidateServer struct {
+    seekpb.UnimplementedCandidateServiceServer
+    service service.CandidateService
+    hub     *events.Hub
+}
+
+// NewCandidateServer implements the gRPC CandidateService with the candidate service.
+// The changes watched come from the hub.
+func NewCandidateServer(s service.CandidateService, hub *events.Hub) seekpb.CandidateServiceServer {
+    return &candidateServer{service: s, hub: hub}
+}
+
+// NewServer returns a gRPC server with the candidate service, the authentication
+// interceptors, the health service and, if asked, reflection
+func NewServer(s service.CandidateService, hub *events.Hub, withReflection bool) *grpc.Server {
+    server := grpc.NewServer(
+        grpc.ChainUnaryInterceptor(UnaryAuthInterceptor()),
+        grpc.ChainStreamInterceptor(StreamAuthInterceptor()),
+    )
+    seekpb.RegisterCandidateServiceServer(server, NewCandidateServer(s, hub))
+
+    healthServer := health.NewServer()
+    healthServer.SetServingStatus(seekpb.CandidateService_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_SERVING)
+    healthpb.RegisterHealthServer(server, healthServer)
+    if withReflection {
+        reflection.Register(server)
+    }
+    return server
+}
+
+// svc returns the service for the user of the call, so their reads are routed to the
+// replicas unless they just wrote
+func (s *candidateServer) svc(ctx context.Context) service.CandidateService {
+    return service.ForUser(s.service, CurrentUser(ctx))
+}
+
+func (s *candidateServer) CreateCandidate(ctx context.Context, req *seekpb.CreateCandidateRequest) (*seekpb.Candidate, error) {
+    svc := s.svc(ctx)
+    id, err := svc.CreateCandidate(fromPB(req.Candidate))
+    if err != nil {
+        return nil, toStatus(err)
+    }
+    return get(svc, id)
+}
+
+func (s *candidateServer) GetCandidate(ctx context.Context, req *seekpb.GetCandidateRequest) (*seekpb.Candidate, error) {
+    return get(s.svc(ctx), int(req.Id))
+}
+
+func get(svc service.CandidateService, id int) (*seekpb.Candidate, error) {
+    candidate, err := svc.GetCandidateByID(id)
+    if err != nil {
+        return nil, toStatus(err)
+    }
+    if candidate == nil {
+        return nil, notFound()
+    }
+    return toPB(*candidate), nil
+}
+
+func (s *candidateServer) ListCandidates(ctx context.Context, req *seekpb.ListCandidatesRequest) (*seekpb.ListCandidatesResponse, error) {
+    size := int(req.PageSize)
+    switch {
+    case size <= 0:
+        size = DefaultPageSize
+    case size > MaxPageSize:
+        size = MaxPageSize
+    }
+    afterID, err := decodePageToken(req.PageToken)
+    if err != nil {
+        return nil, err
+    }
+
+    filter := domain.CandidateFilter{
+        Name:      req.Name,
+        Email:     req.Email,
+        Gender:    req.Gender,
+        SalaryMin: req.SalaryMin,
+        SalaryMax: req.SalaryMax,
+        Tags:      req.Tags,
+        Country:   req.Country,
+    }
+    svc := s.svc(ctx)
+    if filter.Fields, err = svc.ParseFieldFilters(req.CustomFields); err != nil {
+        return nil, toStatus(err)
+    }
+    // One more than the page tells whether there is a next one
+    candidates, err := svc.ListCandidatesPage(filter, afterID, size+1)
+    if err != nil {
+        return nil, toStatus(err)
+    }
+
+    resp := &seekpb.ListCandidatesResponse{Candidates: []*seekpb.Candidate{}}
+    if len(candidates) > size {
+        candidates = candidates[:size]
+        resp.NextPageToken = encodePageToken(candidates[size-1].ID)
+    }
+    for _, c := range candidates {
+        resp.Candidates = append(resp.Candidates, toPB(c))
+    }
+    return resp, nil
+}
+
+// The page token is the opaque ID of the last candidate of the previous page, the next
+// one starts right after it
+func encodePageToken(lastID int) string {
+    return base64.RawURLEncoding.EncodeToString([]byte(strconv.Itoa(lastID)))
+}
+
+func decodePageToken(token string) (int, error) {
+    if token == "" {
+        return 0, nil
+    }
+    raw, err := base64.RawURLEncoding.DecodeString(token)
+    lastID, convErr := strconv.ParseInt(string(raw), 10, 32)
+    if err != nil || convErr != nil || lastID < 1 {
+        return 0, status.Error(codes.InvalidArgument, "Invalid page_token")
+    }
+    return int(lastID), nil
+}
+
+func (s *candidateServer) UpdateCandidate(ctx context.Context, req *seekpb.UpdateCandidateRequest) (*seekpb.Candidate, error) {
+    if req.Candidate == nil {
+        return nil, status.Error(codes.InvalidArgument, "The candidate is required")
+    }
+    svc := s.svc(ctx)
+    current, err := svc.GetCandidateByID(int(req.Candidate.Id))
+    if err != nil {
+        return nil, toStatus(err)
+    }
+    if current == nil {
+        return nil, notFound()
+    }
+
+    candidate := fromPB(req.Candidate)
+    if paths := req.UpdateMask.GetPaths(); len(paths) > 0 {
+        // Only the fields of the mask change, the attributes outside it are left as they are
+        update := *current
+        update.Tags, update.CustomFields, update.Contact = nil, nil, nil
+        for _, path := range paths {
+            switch path {
+            case "name":
+                update.Name = candidate.Name
+            case "email":
+                update.Email = candidate.Email
+            case "gender":
+                update.Gender = candidate.Gender
+            case "salary_expected":
+                update.SalaryExpected = candidate.SalaryExpected
+            case "tags":
+                update.Tags = append([]string{}, req.Candidate.Tags...)
+            case "custom_fields":
+                update.CustomFields = candidate.CustomFields
+                if update.CustomFields == nil {
+                    update.CustomFields = map[string]interface{}{}
+                }
+            case "contact":
+                update.Contact = candidate.Contact
+                if update.Contact == nil {
+                    update.Contact = &domain.ContactDetails{}
+                }
+            default:
+                return nil, status.Errorf(codes.InvalidArgument, "Unknown field '%s' in update_mask", path)
+            }
+        }
+        candidate = update
+    }
+
+    if err := svc.UpdateCandidate(candidate); err != nil {
+        return nil, toStatus(err)
+    }
+    return get(svc, candidate.ID)
+}
+
+func (s *candidateServer) DeleteCandidate(ctx context.Context, req *seekpb.DeleteCandidateRequest) (*emptypb.Empty, error) {
+    if err := s.svc(ctx).DeleteCandidate(int(req.Id)); err != nil {
+        return nil, toStatus(err)
+    }
+    return &emptypb.Empty{}, nil
+}
+
+func (s *candidateServer) WatchCandidates(req *seekpb.WatchCandidatesRequest, stream seekpb.CandidateService_WatchCandidatesServer) error {
+    for _, t := range req.Types {
+        if !containsString(domain.CandidateEventTypes, t) {
+            return status.Errorf(codes.InvalidArgument, "Unknown event type '%s'", t)
+        }
+    }
+    sub, replay, complete := s.hub.Subscribe(req.AfterId, req.Types)
+    defer sub.Close()
+    // The headers tell the client the subscription is active, before any event
+    if err := stream.SendHeader(metadata.MD{}); err != nil {
+        return err
+    }
+
+    if !complete {
+        if err := stream.Send(&seekpb.CandidateEvent{Type: EventReset}); err != nil {
+            return err
+        }
+    }
+    for _, e := range replay {
+        if err := stream.Send(eventToPB(e)); err != nil {
+            return err
+        }
+    }
+    for {
+        select {
+        case <-stream.Context().Done():
+            return nil
+        case e, ok := <-sub.C:
+            if !ok {
+                return status.Error(codes.Unavailable, "The client fell behind, resume with after_id")
+            }
+            if err := stream.Send(eventToPB(e)); err != nil {
+                return err
+            }
+        }
+    }
+}
+
+func eventToPB(e domain.OutboxEvent) *seekpb.CandidateEvent {
+    var payload domain.CandidateEventPayload
+    json.Unmarshal(e.Payload, &payload)
+    return &seekpb.CandidateEvent{
+        Id:          e.ID,
+        Type:        e.Type,
+        CandidateId: int64(e.AggregateID),
+        Candidate: toPB(domain.Candidate{
+            ID:             payload.ID,
+            Name:           payload.Name,
+            Email:          payload.Email,
+            Gender:         payload.Gender,
+            SalaryExpected: payload.SalaryExpected,
+        }),
+        SourceId: int64(payload.SourceID),
+    }
+}
+
+func containsString(values []string, value string) bool {
+    for _, v := range values {
+        if v == value {
+            return true
+        }
+    }
+    return false
+}
@@ -0,0 +1,254 @@
+package handler
+
+import (
+    "errors"
+    "net/http"
+    "strconv"
+
+    "github.com/gin-gonic/gin"
+    "github.com/torvictorvic/seek-v2/internal/domain"
+    "github.com/torvictorvic/seek-v2/internal/repository"
+    "github.com/torvictorvic/seek-v2/internal/security"
+    "github.com/torvictorvic/seek-v2/internal/service"
+)
+
+type ApplicationHandler struct {
+    service service.ApplicationService
+}
+
+func NewApplicationHandler(s service.ApplicationService) *ApplicationHandler {
+    return &ApplicationHandler{service: s}
+}
+
+// Apply godoc
+// @Summary Postular un candidato a una vacante
+// @Description Crea la postulación en la primera etapa del proceso. La vacante debe estar abierta.
+// @Tags Applications
+// @Accept  json
+// @Produce  json
+// @Param application body domain.ApplyRequest true "Candidato y vacante"
+// @Success 201 {object} domain.Application
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "Candidato o vacante no encontrados"
+// @Failure 409 {object} map[string]interface{} "Postulación existente o vacante cerrada"
+// @Router /applications [post]
+// @Security Bearer
+func (h *ApplicationHandler) Apply(c *gin.Context) {
+    var req domain.ApplyRequest
+    if err := c.ShouldBindJSON(&req); err != nil {
+        c.JSON(http.StatusBadRequest, gin.H{"error": "JSON no valid"})
+        return
+    }
+
+    app, err := h.service.Apply(req, security.CurrentUser(c))
+    if err != nil {
+        respondApplicationError(c, err)
+        return
+    }
+    c.JSON(http.StatusCreated, app)
+}
+
+// GetApplication godoc
+// @Summary Obtener postulación por ID
+// @Description Retorna la postulación con el historial de cambios de etapa
+// @Tags Applications
+// @Accept  json
+// @Produce  json
+// @Param  id path int true "ID de la Postulación"
+// @Success 200 {object} domain.Application
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "Postulación no encontrada"
+// @Router /applications/{id} [get]
+// @Security Bearer
+func (h *ApplicationHandler) GetApplication(c *gin.Context) {
+    id, err := strconv.Atoi(c.Param("id"))
+    if err != nil {
+        c.JSON(http.StatusBadRequest, gin.H{"error": "The ID must be an integer"})
+        return
+    }
+
+    app, err := h.service.GetApplication(id)
+    if err != nil {
+        respondApplicationError(c, err)
+        return
+    }
+    c.JSON(http.StatusOK, app)
+}
+
+// MoveStage godoc
+// @Summary Cambiar la etapa de una postulación
+// @Description Mueve la postulación a otra etapa si el proceso lo permite. Para rechazar se requiere un motivo de la lista administrada.
+// @Tags Applications
+// @Accept  json
+// @Produce  json
+// @Param  id path int true "ID de la Postulación"
+// @Param stage body domain.StageChangeRequest true "Nueva etapa y motivo"
+// @Success 200 {object} domain.Application
+// @Failure 400 {object} map[string]interface{} "Etapa o motivo de rechazo no válidos"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "Postulación no encontrada"
+// @Failure 409 {object} map[string]interface{} "Transición no permitida"
+// @Router /applications/{id}/stage [post]
+// @Security Bearer
+func (h *ApplicationHandler) MoveStage(c *gin.Context) {
+    id, err := strconv.Atoi(c.Param("id"))
+    if err != nil {
+        c.JSON(http.StatusBadRequest, gin.H{"error": "The ID must be an integer"})
+        return
+    }
+
+    var req domain.StageChangeRequest
+    if err := c.ShouldBindJSON(&req); err != nil {
+        c.JSON(http.StatusBadRequest, gin.H{"error": "JSON no valid"})
+        return
+    }
+
+    app, err := h.service.MoveStage(id, req, security.CurrentUser(c))
+    if err != nil {
+        respondApplicationError(c, err)
+        return
+    }
+    c.JSON(http.StatusOK, app)
+}
+
+// ListByCandidate godoc
+// @Summary Postulaciones de un candidato
+// @Description Retorna las postulaciones del candidato, cada una con su etapa actual
+// @Tags Applications
+// @Accept  json
+// @Produce  json
+// @Param  id path int true "ID del Candidato"
+// @Success 200 {array} domain.Application
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /candidates/{id}/applications [get]
+// @Security Bearer
+func (h *ApplicationHandler) ListByCandidate(c *gin.Context) {
+    id, err := strconv.Atoi(c.Param("id"))
+    if err != nil {
+        c.JSON(http.StatusBadRequest, gin.H{"error": "The ID must be an integer"})
+        return
+    }
+
+    var apps []domain.Application
+    apps, err = h.service.ListByCandidate(id)
+    if err != nil {
+        c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+        return
+    }
+    c.JSON(http.StatusOK, apps)
+}
+
+// ListByJob godoc
+// @Summary Postulaciones a una vacante
+// @Description Retorna las postulaciones recibidas por la vacante, cada una con su etapa actual
+// @Tags Applications
+// @Accept  json
+// @Produce  json
+// @Param  id path int true "ID de la Vacante"
+// @Success 200 {array} domain.Application
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /jobs/{id}/applications [get]
+// @Security Bearer
+func (h *ApplicationHandler) ListByJob(c *gin.Context) {
+    id, err := strconv.Atoi(c.Param("id"))
+    if err != nil {
+        c.JSON(http.StatusBadRequest, gin.H{"error": "The ID must be an integer"})
+        return
+    }
+
+    apps, err := h.service.ListByJob(id)
+    if err != nil {
+        c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+        return
+    }
+    c.JSON(http.StatusOK, apps)
+}
+
+// ListRejectionReasons godoc
+// @Summary Listar motivos de rechazo
+// @Description Retorna la lista administrada de motivos de rechazo, activos e inactivos
+// @Tags Applications
+// @Accept  json
+// @Produce  json
+// @Success 200 {array} domain.RejectionReason
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /rejection-reasons [get]
+// @Security Bearer
+func (h *ApplicationHandler) ListRejectionReasons(c *gin.Context) {
+    reasons, err := h.service.ListRejectionReasons()
+    if err != nil {
+        c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+        return
+    }
+    c.JSON(http.StatusOK, reasons)
+}
+
+// SaveRejectionReason godoc
+// @Summary Crear o actualizar un motivo de rechazo
+// @Description Crea el motivo o actualiza la etiqueta de uno existente, reactivándolo
+// @Tags Applications
+// @Accept  json
+// @Produce  json
+// @Param reason body domain.RejectionReason true "Código y etiqueta del motivo"
+// @Success 200 {object} map[string]interface{} "ok"
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /rejection-reasons [post]
+// @Security Bearer
+func (h *ApplicationHandler) SaveRejectionReason(c *gin.Context) {
+    var reason domain.RejectionReason
+    if err := c.ShouldBindJSON(&reason); err != nil {
+        c.JSON(http.StatusBadRequest, gin.H{"error": "The fields 'code' and 'label' are required"})
+        return
+    }
+
+    if err := h.service.SaveRejectionReason(reason); err != nil {
+        c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+        return
+    }
+    c.JSON(http.StatusOK, gin.H{"message": "Rejection reason saved", "code": reason.Code})
+}
+
+// DeactivateRejectionReason godoc
+// @Summary Desactivar un motivo de rechazo
+// @Description El motivo deja de estar disponible para nuevos rechazos pero se conserva en las postulaciones existentes
+// @Tags Applications
+// @Accept  json
+// @Produce  json
+// @Param  code path string true "Código del motivo"
+// @Success 200 {object} map[string]interface{} "ok"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "Motivo no encontrado"
+// @Router /rejection-reasons/{code} [delete]
+// @Security Bearer
+func (h *ApplicationHandler) DeactivateRejectionReason(c *gin.Context) {
+    if err := h.service.DeactivateRejectionReason(c.Param("code")); err != nil {
+        if errors.Is(err, service.ErrUnknownRejectionReason) {
+            c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+            return
+        }
+        c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+        return
+    }
+    c.JSON(http.StatusOK, gin.H{"message": "Rejection reason deactivated"})
+}
+
+func respondApplicationError(c *gin.Context, err error) {
+    switch {
+    case errors.Is(err, service.ErrApplicationNotFound), errors.Is(err, service.ErrCandidateNotFound),
+        errors.Is(err, service.ErrJobNotFound):
+        c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+    case errors.Is(err, service.ErrInvalidStage), errors.Is(err, service.ErrRejectionReasonRequired),
+        errors.Is(err, service.ErrUnknownRejectionReason):
+        c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+    case errors.Is(err, service.ErrInvalidTransition), errors.Is(err, service.ErrApplicationExists),
+        errors.Is(err, service.ErrJobNotOpen), errors.Is(err, repository.ErrStageChanged):
+        c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+    default:
+        c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+    }
+}
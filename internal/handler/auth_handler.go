@@ -1,6 +1,7 @@
 package handler
 
 import (
+    "crypto/subtle"
     "net/http"
     "os"
     "time"
@@ -9,17 +10,40 @@ import (
     "github.com/golang-jwt/jwt/v4"
 )
 
-// GenerateToken is responsible for creating and returning the JWT token
-func GenerateToken(c *gin.Context) {
+// loginRequest holds the credentials, the user becomes the identity of the token
+type loginRequest struct {
+    User     string `json:"user" binding:"required"`
+    Password string `json:"password" binding:"required"`
+}
 
-    secret := os.Getenv("JWT_SECRET")
-    if secret == "" {
-        secret = ""
+type AuthHandler struct {
+    users map[string]string
+}
+
+// NewAuthHandler returns the handler that logs in the users with the given passwords
+func NewAuthHandler(users map[string]string) *AuthHandler {
+    return &AuthHandler{users: users}
+}
+
+// GenerateToken is responsible for checking the credentials and returning the JWT token
+func (h *AuthHandler) GenerateToken(c *gin.Context) {
+    var req loginRequest
+    if err := c.ShouldBindJSON(&req); err != nil {
+        c.JSON(http.StatusBadRequest, gin.H{"error": "user and password are required"})
+        return
+    }
+    // An unknown user is compared too, so the time does not tell which users exist
+    password, ok := h.users[req.User]
+    if subtle.ConstantTimeCompare([]byte(password), []byte(req.Password)) != 1 || !ok {
+        c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid credentials"})
+        return
     }
 
+    secret := os.Getenv("JWT_SECRET")
+
     token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
         "authorized": true,
-        "user":       "usuario_demo",
+        "user":       req.User,
         "exp":        time.Now().Add(time.Hour * 24).Unix(),
     })
 
@@ -0,0 +1,35 @@
+package handler
+
+import (
+    "net/http"
+
+    "github.com/gin-gonic/gin"
+    "github.com/torvictorvic/seek-v2/internal/domain"
+)
+
+// CacheStatsSource reports the use of the candidate cache
+type CacheStatsSource interface {
+    Stats() domain.CacheStats
+}
+
+type CacheHandler struct {
+    cache CacheStatsSource
+}
+
+func NewCacheHandler(cache CacheStatsSource) *CacheHandler {
+    return &CacheHandler{cache: cache}
+}
+
+// GetStats godoc
+// @Summary Métricas de la caché de candidatos
+// @Description Retorna por operación (get_by_id, get_by_email, get_by_ids) el TTL, los aciertos, los fallos, las lecturas compartidas con otra en curso y los errores de la caché, y las invalidaciones hechas por las escrituras
+// @Tags Cache
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} domain.CacheStats
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /cache/stats [get]
+// @Security Bearer
+func (h *CacheHandler) GetStats(c *gin.Context) {
+    c.JSON(http.StatusOK, h.cache.Stats())
+}
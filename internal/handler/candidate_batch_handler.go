@@ -0,0 +1,105 @@
+package handler
+
+import (
+    "errors"
+    "net/http"
+
+    "github.com/gin-gonic/gin"
+    "github.com/torvictorvic/seek-v2/internal/domain"
+    "github.com/torvictorvic/seek-v2/internal/service"
+)
+
+// CreateCandidates godoc
+// @Summary Crear candidatos en lote
+// @Description Crea varios candidatos en una transacción. En modo 'all_or_nothing' no se escribe nada si algún elemento falla, en modo 'partial' se escriben los válidos.
+// @Tags Candidates
+// @Accept  json
+// @Produce  json
+// @Param batch body domain.CandidateBatchRequest true "Candidatos y modo del lote"
+// @Success 200 {object} domain.BatchResult
+// @Success 207 {object} domain.BatchResult "Algunos elementos fallaron (modo partial)"
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 422 {object} domain.BatchResult "Algún elemento falló (modo all_or_nothing)"
+// @Router /candidates:batch [post]
+// @Security Bearer
+func (h *CandidateHandler) CreateCandidates(c *gin.Context) {
+    var req domain.CandidateBatchRequest
+    if err := c.ShouldBindJSON(&req); err != nil {
+        c.JSON(http.StatusBadRequest, gin.H{"error": "JSON no valid"})
+        return
+    }
+
+    result, err := h.svc(c).CreateCandidates(req.Items, req.Mode)
+    respondBatch(c, result, err)
+}
+
+// UpdateCandidates godoc
+// @Summary Actualizar candidatos en lote
+// @Description Actualiza varios candidatos en una transacción. Cada elemento debe incluir su 'id'.
+// @Tags Candidates
+// @Accept  json
+// @Produce  json
+// @Param batch body domain.CandidateBatchRequest true "Candidatos y modo del lote"
+// @Success 200 {object} domain.BatchResult
+// @Success 207 {object} domain.BatchResult "Algunos elementos fallaron (modo partial)"
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 422 {object} domain.BatchResult "Algún elemento falló (modo all_or_nothing)"
+// @Router /candidates:batchUpdate [post]
+// @Security Bearer
+func (h *CandidateHandler) UpdateCandidates(c *gin.Context) {
+    var req domain.CandidateBatchRequest
+    if err := c.ShouldBindJSON(&req); err != nil {
+        c.JSON(http.StatusBadRequest, gin.H{"error": "JSON no valid"})
+        return
+    }
+
+    result, err := h.svc(c).UpdateCandidates(req.Items, req.Mode)
+    respondBatch(c, result, err)
+}
+
+// DeleteCandidates godoc
+// @Summary Borrar candidatos en lote
+// @Description Borra varios candidatos por ID con una sola sentencia
+// @Tags Candidates
+// @Accept  json
+// @Produce  json
+// @Param batch body domain.CandidateBatchDeleteRequest true "IDs y modo del lote"
+// @Success 200 {object} domain.BatchResult
+// @Success 207 {object} domain.BatchResult "Algunos elementos fallaron (modo partial)"
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 422 {object} domain.BatchResult "Algún elemento falló (modo all_or_nothing)"
+// @Router /candidates:batchDelete [post]
+// @Security Bearer
+func (h *CandidateHandler) DeleteCandidates(c *gin.Context) {
+    var req domain.CandidateBatchDeleteRequest
+    if err := c.ShouldBindJSON(&req); err != nil {
+        c.JSON(http.StatusBadRequest, gin.H{"error": "JSON no valid"})
+        return
+    }
+
+    result, err := h.svc(c).DeleteCandidates(req.IDs, req.Mode)
+    respondBatch(c, result, err)
+}
+
+func respondBatch(c *gin.Context, result *domain.BatchResult, err error) {
+    if err != nil {
+        if errors.Is(err, service.ErrBatchEmpty) || errors.Is(err, service.ErrBatchTooLarge) || errors.Is(err, service.ErrInvalidBatchMode) {
+            c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+            return
+        }
+        c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+        return
+    }
+
+    switch {
+    case result.Failed == 0:
+        c.JSON(http.StatusOK, result)
+    case result.Mode == domain.BatchPartial:
+        c.JSON(http.StatusMultiStatus, result)
+    default:
+        c.JSON(http.StatusUnprocessableEntity, result)
+    }
+}
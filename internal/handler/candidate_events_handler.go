@@ -0,0 +1,110 @@
+package handler
+
+import (
+    "fmt"
+    "net/http"
+    "strconv"
+    "strings"
+    "time"
+
+    "github.com/gin-gonic/gin"
+    "github.com/torvictorvic/seek-v2/internal/domain"
+    "github.com/torvictorvic/seek-v2/internal/events"
+)
+
+type CandidateEventsHandler struct {
+    hub       *events.Hub
+    heartbeat time.Duration
+}
+
+func NewCandidateEventsHandler(hub *events.Hub, heartbeat time.Duration) *CandidateEventsHandler {
+    return &CandidateEventsHandler{hub: hub, heartbeat: heartbeat}
+}
+
+// StreamEvents godoc
+// @Summary Cambios de candidatos en tiempo real
+// @Description Stream Server-Sent Events con los cambios de candidatos. Cada evento lleva como id el del outbox, como event el tipo y como data el candidato (solo el id si fue borrado). Al reconectar con la cabecera Last-Event-ID se reenvían los eventos perdidos; si ya no se conservan se envía un evento 'reset' y el cliente debe recargar los datos. Cada cierto tiempo se envía un comentario ': heartbeat'.
+// @Tags Candidates
+// @Produce  text/event-stream
+// @Param type query []string false "Tipos de evento a recibir, todos si se omite" collectionFormat(multi) Enums(candidate.created, candidate.updated, candidate.deleted, candidate.merged)
+// @Param Last-Event-ID header int false "ID del último evento recibido"
+// @Success 200 {string} string "text/event-stream"
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /candidates/events [get]
+// @Security Bearer
+func (h *CandidateEventsHandler) StreamEvents(c *gin.Context) {
+    var types []string
+    for _, raw := range c.QueryArray("type") {
+        for _, t := range strings.Split(raw, ",") {
+            if t = strings.TrimSpace(t); t == "" {
+                continue
+            }
+            if !containsString(domain.CandidateEventTypes, t) {
+                c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Unknown event type '%s'", t)})
+                return
+            }
+            types = append(types, t)
+        }
+    }
+    var lastID int64
+    if raw := c.GetHeader("Last-Event-ID"); raw != "" {
+        id, err := strconv.ParseInt(raw, 10, 64)
+        if err != nil || id < 0 {
+            c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid Last-Event-ID"})
+            return
+        }
+        lastID = id
+    }
+
+    sub, replay, complete := h.hub.Subscribe(lastID, types)
+    defer sub.Close()
+
+    c.Header("Content-Type", "text/event-stream")
+    c.Header("Cache-Control", "no-cache")
+    c.Header("Connection", "keep-alive")
+    // Proxies such as nginx must not buffer the stream
+    c.Header("X-Accel-Buffering", "no")
+    c.Status(http.StatusOK)
+
+    w := c.Writer
+    fmt.Fprint(w, "retry: 3000\n\n")
+    if !complete {
+        fmt.Fprint(w, "event: reset\ndata: {}\n\n")
+    }
+    for _, e := range replay {
+        writeEvent(w, e)
+    }
+    w.Flush()
+
+    heartbeat := time.NewTicker(h.heartbeat)
+    defer heartbeat.Stop()
+    for {
+        select {
+        case <-c.Request.Context().Done():
+            return
+        case e, ok := <-sub.C:
+            if !ok {
+                // Too far behind, the client reconnects and resumes from its last event
+                return
+            }
+            writeEvent(w, e)
+        case <-heartbeat.C:
+            fmt.Fprint(w, ": heartbeat\n\n")
+        }
+        w.Flush()
+    }
+}
+
+func writeEvent(w gin.ResponseWriter, e domain.OutboxEvent) {
+    fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", e.ID, e.Type, e.Payload)
+}
+
+func containsString(values []string, value string) bool {
+    for _, v := range values {
+        if v == value {
+            return true
+        }
+    }
+    return false
+}
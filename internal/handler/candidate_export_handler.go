@@ -0,0 +1,82 @@
+package handler
+
+import (
+    "log"
+    "net/http"
+    "strings"
+
+    "github.com/gin-gonic/gin"
+    "github.com/torvictorvic/seek-v2/internal/exporter"
+)
+
+// ExportCandidates godoc
+// @Summary Exportar candidatos
+// @Description Exporta los candidatos con los mismos filtros del listado. El formato se elige con el parámetro 'format' o con la cabecera Accept (text/csv, application/vnd.openxmlformats-officedocument.spreadsheetml.sheet, application/x-ndjson, application/pdf).
+// @Description Las filas se envían a medida que se leen de la base de datos.
+// @Tags Candidates
+// @Produce  text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet,application/x-ndjson,application/pdf
+// @Param format query string false "csv, xlsx, ndjson o pdf"
+// @Param columns query string false "Columnas separadas por coma: id,name,email,gender,salary_expected,tags,created_at,updated_at y cf.<clave> para los campos personalizados"
+// @Param lang query string false "Idioma de las cabeceras: es o en (por defecto según Accept-Language)"
+// @Param name query string false "Nombre (coincidencia parcial)"
+// @Param email query string false "Email (coincidencia parcial)"
+// @Param gender query string false "Género"
+// @Param salary_min query number false "Salario esperado mínimo"
+// @Param salary_max query number false "Salario esperado máximo"
+// @Param tag query []string false "Etiquetas, el candidato debe tener todas" collectionFormat(multi)
+// @Param country query string false "País de contacto (ISO 3166-1 alpha-2)"
+// @Param cf[key] query string false "Campo personalizado: cf[stack]=go, cf[years_experience]=3..10, cf[available_from]=..2026-12-31"
+// @Success 200 {file} file
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 406 {object} map[string]interface{} "Formato no soportado"
+// @Router /candidates/export [get]
+// @Security Bearer
+func (h *CandidateHandler) ExportCandidates(c *gin.Context) {
+    filter, ok := h.bindCandidateFilter(c)
+    if !ok {
+        return
+    }
+
+    format := exporter.Negotiate(c.Query("format"), c.GetHeader("Accept"))
+    if format == "" {
+        c.JSON(http.StatusNotAcceptable, gin.H{"error": "The format must be csv, xlsx, ndjson or pdf"})
+        return
+    }
+
+    var keys []string
+    if cols := c.Query("columns"); cols != "" {
+        keys = strings.Split(cols, ",")
+    }
+    lang := c.Query("lang")
+    if lang == "" && strings.HasPrefix(strings.ToLower(c.GetHeader("Accept-Language")), "en") {
+        lang = "en"
+    }
+    custom, err := h.svc(c).CustomFields()
+    if err != nil {
+        c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+        return
+    }
+    columns, err := exporter.Columns(keys, lang, custom...)
+    if err != nil {
+        c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+        return
+    }
+
+    c.Header("Content-Type", exporter.ContentTypes[format])
+    c.Header("Content-Disposition", `attachment; filename="candidates.`+format+`"`)
+    c.Status(http.StatusOK)
+
+    w, err := exporter.NewWriter(format, c.Writer, columns, exporter.Title(lang))
+    if err == nil {
+        err = h.svc(c).StreamCandidates(filter, w.WriteRow)
+        if closeErr := w.Close(); err == nil {
+            err = closeErr
+        }
+    }
+    if err != nil {
+        // The status is already sent, the truncated body is the only signal left for the client
+        log.Printf("Error exporting candidates: %v\n", err)
+        c.Abort()
+    }
+}
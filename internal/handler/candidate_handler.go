@@ -1,32 +1,78 @@
 package handler
 
 import (
+    "errors"
     "net/http"
     "strconv"
 
     "github.com/gin-gonic/gin"
     "github.com/torvictorvic/seek-v2/internal/domain"
+    "github.com/torvictorvic/seek-v2/internal/security"
     "github.com/torvictorvic/seek-v2/internal/service"
 )
 
 type CandidateHandler struct {
-    service service.CandidateService
+    service  service.CandidateService
+    feedback service.FeedbackService
 }
 
 func NewCandidateHandler(s service.CandidateService) *CandidateHandler {
     return &CandidateHandler{service: s}
 }
 
+// WithFeedback adds the aggregated scorecard to the candidate detail
+func (h *CandidateHandler) WithFeedback(f service.FeedbackService) *CandidateHandler {
+    h.feedback = f
+    return h
+}
+
+// svc returns the service for the user of the request, so their reads are routed to the
+// replicas unless they just wrote
+func (h *CandidateHandler) svc(c *gin.Context) service.CandidateService {
+    return service.ForUser(h.service, security.CurrentUser(c))
+}
+
+// bindCandidateFilter reads the list filters from the query, with the custom fields
+// given as cf[key]=value. It answers 400 and returns false when they are not valid.
+func (h *CandidateHandler) bindCandidateFilter(c *gin.Context) (domain.CandidateFilter, bool) {
+    var filter domain.CandidateFilter
+    if err := c.ShouldBindQuery(&filter); err != nil {
+        c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid filters"})
+        return filter, false
+    }
+    fields, err := h.svc(c).ParseFieldFilters(c.QueryMap("cf"))
+    if err != nil {
+        respondAttributesError(c, err)
+        return filter, false
+    }
+    filter.Fields = fields
+    return filter, true
+}
+
+// respondAttributesError answers the validation errors of the candidate, its tags, custom
+// fields and contact details
+func respondAttributesError(c *gin.Context, err error) {
+    if errors.Is(err, service.ErrInvalidCandidate) || errors.Is(err, service.ErrInvalidAttributes) ||
+        errors.Is(err, service.ErrUnknownCustomField) || errors.Is(err, service.ErrInvalidContact) {
+        c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+        return
+    }
+    c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+}
+
 // CreateCandidate godoc
 // @Summary Crear un nuevo candidato
-// @Description Crea un candidato con los datos enviados en el body
+// @Description Crea un candidato con los datos enviados en el body. 'tags' son etiquetas libres y 'custom_fields' los valores de los campos definidos en /custom-fields (ver /custom-fields/schema). 'contact' lleva teléfonos (se normalizan a E.164 con el país), dirección, zona horaria, enlaces y canal preferido.
 // @Tags Candidates
 // @Accept  json
 // @Produce  json
 // @Param candidate body domain.Candidate true "Datos del candidato"
+// @Param Idempotency-Key header string false "Clave para reintentar sin duplicar: se devuelve la primera respuesta"
 // @Success 200 {object} map[string]interface{} "ok"
 // @Failure 400 {object} map[string]interface{} "Bad Request"
 // @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 409 {object} map[string]interface{} "La petición con la misma clave sigue en curso"
+// @Failure 422 {object} map[string]interface{} "La clave ya se usó con otro body"
 // @Failure 500 {object} map[string]interface{} "Internal Server Error"
 // @Router /candidates [post]
 // @Security Bearer
@@ -37,9 +83,9 @@ func (h *CandidateHandler) CreateCandidate(c *gin.Context) {
         return
     }
 
-    id, err := h.service.CreateCandidate(candidate)
+    id, err := h.svc(c).CreateCandidate(candidate)
     if err != nil {
-        c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+        respondAttributesError(c, err)
         return
     }
 
@@ -48,12 +94,12 @@ func (h *CandidateHandler) CreateCandidate(c *gin.Context) {
 
 // GetCandidateByID godoc
 // @Summary Obtener candidato por ID
-// @Description Retorna el candidato cuyo ID se pasa como parámetro
+// @Description Retorna el candidato cuyo ID se pasa como parámetro, con el resumen de sus evaluaciones si el usuario puede verlas
 // @Tags Candidates
 // @Accept  json
 // @Produce  json
 // @Param  id path int true "ID del Candidato"
-// @Success 200 {object} domain.Candidate
+// @Success 200 {object} domain.CandidateDetail
 // @Failure 400 {object} map[string]interface{} "Bad Request"
 // @Failure 404 {object} map[string]interface{} "Candidato no encontrado"
 // @Failure 401 {object} map[string]interface{} "Unauthorized"
@@ -67,7 +113,7 @@ func (h *CandidateHandler) GetCandidateByID(c *gin.Context) {
         return
     }
 
-    candidate, err := h.service.GetCandidateByID(id)
+    candidate, err := h.svc(c).GetCandidateByID(id)
     if err != nil {
         c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
         return
@@ -77,21 +123,43 @@ func (h *CandidateHandler) GetCandidateByID(c *gin.Context) {
         return
     }
 
-    c.JSON(http.StatusOK, candidate)
+    detail := domain.CandidateDetail{Candidate: *candidate}
+    if h.feedback != nil {
+        detail.Scorecard, err = h.feedback.Summary(id, security.CurrentUser(c))
+        if err != nil {
+            c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+            return
+        }
+    }
+    c.JSON(http.StatusOK, detail)
 }
 
 // GetAllCandidates godoc
 // @Summary Listar todos los candidatos
-// @Description Retorna la lista de todos los candidatos
+// @Description Retorna la lista de todos los candidatos, opcionalmente filtrada
 // @Tags Candidates
 // @Accept  json
 // @Produce  json
+// @Param name query string false "Nombre (coincidencia parcial)"
+// @Param email query string false "Email (coincidencia parcial)"
+// @Param gender query string false "Género"
+// @Param salary_min query number false "Salario esperado mínimo"
+// @Param salary_max query number false "Salario esperado máximo"
+// @Param tag query []string false "Etiquetas, el candidato debe tener todas" collectionFormat(multi)
+// @Param country query string false "País de contacto (ISO 3166-1 alpha-2)"
+// @Param cf[key] query string false "Campo personalizado: cf[stack]=go, cf[years_experience]=3..10, cf[available_from]=..2026-12-31"
 // @Success 200 {array} domain.Candidate
+// @Failure 400 {object} map[string]interface{} "Bad Request"
 // @Failure 401 {object} map[string]interface{} "Unauthorized"
 // @Router /candidates [get]
 // @Security Bearer
 func (h *CandidateHandler) GetAllCandidates(c *gin.Context) {
-    candidates, err := h.service.GetAllCandidates()
+    filter, ok := h.bindCandidateFilter(c)
+    if !ok {
+        return
+    }
+
+    candidates, err := h.svc(c).GetAllCandidates(filter)
     if err != nil {
         c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
         return
@@ -101,14 +169,16 @@ func (h *CandidateHandler) GetAllCandidates(c *gin.Context) {
 
 // UpdateCandidate godoc
 // @Summary Actualiza un candidato
-// @Description Actualiza un candidato con los datos enviados en el body
+// @Description Actualiza un candidato con los datos enviados en el body; 'name' y 'email' son obligatorios, como en el alta. Si se omiten 'tags' o 'custom_fields' se conservan los actuales.
 // @Tags Candidates
 // @Accept  json
 // @Produce  json
 // @Param  id path int true "ID del Candidato"
+// @Param candidate body domain.Candidate true "Datos del candidato"
 // @Success 200 {object} map[string]interface{} "ok"
 // @Failure 400 {object} map[string]interface{} "Bad Request"
 // @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "Candidato no encontrado"
 // @Failure 500 {object} map[string]interface{} "Internal Server Error"
 // @Router /candidates/{id} [put]
 // @Security Bearer
@@ -127,9 +197,13 @@ func (h *CandidateHandler) UpdateCandidate(c *gin.Context) {
     }
     candidate.ID = id
 
-    err = h.service.UpdateCandidate(candidate)
+    err = h.svc(c).UpdateCandidate(candidate)
+    if errors.Is(err, service.ErrCandidateNotFound) {
+        c.JSON(http.StatusNotFound, gin.H{"error": "Candidate not found"})
+        return
+    }
     if err != nil {
-        c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+        respondAttributesError(c, err)
         return
     }
 
@@ -157,7 +231,11 @@ func (h *CandidateHandler) DeleteCandidate(c *gin.Context) {
         return
     }
 
-    err = h.service.DeleteCandidate(id)
+    err = h.svc(c).DeleteCandidate(id)
+    if errors.Is(err, service.ErrCandidateNotFound) {
+        c.JSON(http.StatusNotFound, gin.H{"error": "Candidate not found"})
+        return
+    }
     if err != nil {
         c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
         return
@@ -0,0 +1,120 @@
+package handler
+
+import (
+    "errors"
+    "io"
+    "net/http"
+    "os"
+    "path/filepath"
+    "strings"
+
+    "github.com/gin-gonic/gin"
+    "github.com/torvictorvic/seek-v2/internal/domain"
+    "github.com/torvictorvic/seek-v2/internal/importer"
+)
+
+// maxImportSize limits the size of the uploaded spreadsheets
+const maxImportSize = 100 << 20
+
+// ImportCandidates godoc
+// @Summary Importar candidatos desde CSV o XLSX
+// @Description Importa candidatos desde un archivo CSV o XLSX (multipart, campo 'file'). El archivo se procesa fila a fila con las mismas validaciones que la creación.
+// @Description El mapeo de columnas se indica con mapping[campo]=Columna, por ejemplo mapping[name]=Nombre.
+// @Tags Candidates
+// @Accept  multipart/form-data
+// @Produce  json
+// @Param file formData file true "Archivo CSV o XLSX"
+// @Param format query string false "csv o xlsx (por defecto según la extensión)"
+// @Param sheet query string false "Hoja del XLSX (por defecto la primera)"
+// @Param dry_run query bool false "Solo valida y devuelve el reporte, no escribe"
+// @Param upsert query bool false "Actualiza el candidato con el mismo email"
+// @Param mapping[name] query string false "Columna del nombre"
+// @Param mapping[email] query string false "Columna del email"
+// @Param mapping[gender] query string false "Columna del género"
+// @Param mapping[salary_expected] query string false "Columna del salario esperado"
+// @Success 200 {object} domain.ImportReport
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /candidates/import [post]
+// @Security Bearer
+func (h *CandidateHandler) ImportCandidates(c *gin.Context) {
+    c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxImportSize)
+
+    // The multipart body is read as a stream instead of being parsed into memory
+    mr, err := c.Request.MultipartReader()
+    if err != nil {
+        c.JSON(http.StatusBadRequest, gin.H{"error": "A multipart body with a 'file' field is required"})
+        return
+    }
+    var part io.ReadCloser
+    var filename string
+    for {
+        p, err := mr.NextPart()
+        if err != nil {
+            c.JSON(http.StatusBadRequest, gin.H{"error": "A multipart body with a 'file' field is required"})
+            return
+        }
+        if p.FormName() == "file" {
+            part, filename = p, p.FileName()
+            break
+        }
+        p.Close()
+    }
+    defer part.Close()
+
+    format := strings.ToLower(c.Query("format"))
+    if format == "" {
+        format = strings.TrimPrefix(strings.ToLower(filepath.Ext(filename)), ".")
+    }
+
+    var reader importer.RowReader
+    switch format {
+    case importer.FormatCSV:
+        reader = importer.NewCSVReader(part, 0)
+    case importer.FormatXLSX:
+        // Zip archives need random access, so the upload is spooled to disk
+        tmp, err := os.CreateTemp("", "import-*.xlsx")
+        if err != nil {
+            c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+            return
+        }
+        defer os.Remove(tmp.Name())
+        defer tmp.Close()
+
+        size, err := io.Copy(tmp, part)
+        if err != nil {
+            c.JSON(http.StatusBadRequest, gin.H{"error": "Error reading uploaded file"})
+            return
+        }
+        reader, err = importer.NewXLSXReader(tmp, size, c.Query("sheet"))
+        if err != nil {
+            c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+            return
+        }
+    default:
+        c.JSON(http.StatusBadRequest, gin.H{"error": "The format must be 'csv' or 'xlsx'"})
+        return
+    }
+
+    opts := importer.Options{
+        Mapping: importer.Mapping(c.QueryMap("mapping")),
+        DryRun:  c.Query("dry_run") == "true",
+        Upsert:  c.Query("upsert") == "true",
+    }
+    if len(opts.Mapping) == 0 {
+        opts.Mapping = importer.DefaultMapping()
+    }
+
+    var report *domain.ImportReport
+    report, err = importer.NewImporter(h.svc(c)).Import(reader, opts)
+    if err != nil {
+        if errors.Is(err, importer.ErrUnknownColumn) {
+            c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+            return
+        }
+        c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+        return
+    }
+    c.JSON(http.StatusOK, report)
+}
@@ -0,0 +1,56 @@
+package handler
+
+import (
+    "errors"
+    "net/http"
+    "strconv"
+
+    "github.com/gin-gonic/gin"
+    "github.com/torvictorvic/seek-v2/internal/domain"
+    "github.com/torvictorvic/seek-v2/internal/security"
+    "github.com/torvictorvic/seek-v2/internal/service"
+)
+
+// MergeCandidates godoc
+// @Summary Fusionar dos candidatos
+// @Description Fusiona el candidato 'source_id' en el candidato de la ruta. Para cada atributo (name, email, gender, salary_expected) se indica qué lado conserva su valor ('target' por defecto).
+// @Description El candidato origen se elimina, sus datos relacionados pasan al destino y la fusión queda en el historial.
+// @Tags Candidates
+// @Accept  json
+// @Produce  json
+// @Param  id path int true "ID del Candidato destino"
+// @Param merge body domain.MergeRequest true "Candidato origen y atributos a conservar"
+// @Success 200 {object} domain.Candidate
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "Candidato no encontrado"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /candidates/{id}/merge [post]
+// @Security Bearer
+func (h *CandidateHandler) MergeCandidates(c *gin.Context) {
+    id, err := strconv.Atoi(c.Param("id"))
+    if err != nil {
+        c.JSON(http.StatusBadRequest, gin.H{"error": "The ID must be an integer"})
+        return
+    }
+
+    var req domain.MergeRequest
+    if err := c.ShouldBindJSON(&req); err != nil {
+        c.JSON(http.StatusBadRequest, gin.H{"error": "JSON no valid"})
+        return
+    }
+
+    merged, err := h.svc(c).MergeCandidates(id, req, security.CurrentUser(c))
+    if err != nil {
+        switch {
+        case errors.Is(err, service.ErrCandidateNotFound):
+            c.JSON(http.StatusNotFound, gin.H{"error": "Candidate not found"})
+        case errors.Is(err, service.ErrSameCandidate), errors.Is(err, service.ErrInvalidMergeField):
+            c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+        default:
+            c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+        }
+        return
+    }
+    c.JSON(http.StatusOK, merged)
+}
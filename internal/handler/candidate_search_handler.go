@@ -0,0 +1,65 @@
+package handler
+
+import (
+    "errors"
+    "net/http"
+    "strconv"
+
+    "github.com/gin-gonic/gin"
+    "github.com/torvictorvic/seek-v2/internal/domain"
+    "github.com/torvictorvic/seek-v2/internal/search"
+    "github.com/torvictorvic/seek-v2/internal/service"
+)
+
+// SearchCandidates godoc
+// @Summary Buscar candidatos
+// @Description Búsqueda de texto libre sobre nombre y email, con coincidencia por prefijo, tolerancia a errores de escritura y ordenada por relevancia.
+// @Description Se combina con los filtros del listado. Las coincidencias se marcan con <mark> en 'highlights'.
+// @Tags Candidates
+// @Accept  json
+// @Produce  json
+// @Param q query string true "Texto a buscar, por ejemplo 'anna walkr'"
+// @Param limit query int false "Número máximo de resultados (20 por defecto)"
+// @Param name query string false "Nombre (coincidencia parcial)"
+// @Param email query string false "Email (coincidencia parcial)"
+// @Param gender query string false "Género"
+// @Param salary_min query number false "Salario esperado mínimo"
+// @Param salary_max query number false "Salario esperado máximo"
+// @Param tag query []string false "Etiquetas, el candidato debe tener todas" collectionFormat(multi)
+// @Param country query string false "País de contacto (ISO 3166-1 alpha-2)"
+// @Param cf[key] query string false "Campo personalizado: cf[stack]=go, cf[years_experience]=3..10, cf[available_from]=..2026-12-31"
+// @Success 200 {array} domain.CandidateSearchHit
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /candidates/search [get]
+// @Security Bearer
+func (h *CandidateHandler) SearchCandidates(c *gin.Context) {
+    filter, ok := h.bindCandidateFilter(c)
+    if !ok {
+        return
+    }
+
+    limit := search.DefaultLimit
+    if l := c.Query("limit"); l != "" {
+        var err error
+        if limit, err = strconv.Atoi(l); err != nil || limit <= 0 {
+            c.JSON(http.StatusBadRequest, gin.H{"error": "The limit must be a positive integer"})
+            return
+        }
+    }
+
+    hits, err := h.svc(c).SearchCandidates(c.Query("q"), filter, limit)
+    if err != nil {
+        if errors.Is(err, service.ErrEmptyQuery) {
+            c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+            return
+        }
+        c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+        return
+    }
+    if hits == nil {
+        hits = []domain.CandidateSearchHit{}
+    }
+    c.JSON(http.StatusOK, hits)
+}
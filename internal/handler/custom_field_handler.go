@@ -0,0 +1,189 @@
+package handler
+
+import (
+    "errors"
+    "net/http"
+
+    "github.com/gin-gonic/gin"
+    "github.com/torvictorvic/seek-v2/internal/domain"
+    "github.com/torvictorvic/seek-v2/internal/service"
+)
+
+type CustomFieldHandler struct {
+    service service.CustomFieldService
+}
+
+func NewCustomFieldHandler(s service.CustomFieldService) *CustomFieldHandler {
+    return &CustomFieldHandler{service: s}
+}
+
+// CreateField godoc
+// @Summary Crear un campo personalizado
+// @Description Define un campo de los candidatos. Tipos: text, number, date (YYYY-MM-DD), enum y multi_select (estos dos con 'options').
+// @Description La clave no se puede cambiar y se usa en los filtros (cf[clave]) y en las exportaciones (cf.clave).
+// @Tags Custom Fields
+// @Accept  json
+// @Produce  json
+// @Param field body domain.CustomField true "Definición del campo"
+// @Success 201 {object} domain.CustomField
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 409 {object} map[string]interface{} "La clave ya existe"
+// @Router /custom-fields [post]
+// @Security Bearer
+func (h *CustomFieldHandler) CreateField(c *gin.Context) {
+    var field domain.CustomField
+    if err := c.ShouldBindJSON(&field); err != nil {
+        c.JSON(http.StatusBadRequest, gin.H{"error": "JSON no valid"})
+        return
+    }
+
+    created, err := h.service.CreateField(field)
+    if err != nil {
+        respondCustomFieldError(c, err)
+        return
+    }
+    c.JSON(http.StatusCreated, created)
+}
+
+// ListFields godoc
+// @Summary Listar los campos personalizados
+// @Description Retorna las definiciones de los campos personalizados de los candidatos
+// @Tags Custom Fields
+// @Accept  json
+// @Produce  json
+// @Success 200 {array} domain.CustomField
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /custom-fields [get]
+// @Security Bearer
+func (h *CustomFieldHandler) ListFields(c *gin.Context) {
+    fields, err := h.service.ListFields()
+    if err != nil {
+        c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+        return
+    }
+    c.JSON(http.StatusOK, fields)
+}
+
+// Schema godoc
+// @Summary Esquema de los campos personalizados
+// @Description Retorna el JSON Schema del objeto 'custom_fields' de los candidatos según los campos definidos
+// @Tags Custom Fields
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} map[string]interface{} "JSON Schema"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /custom-fields/schema [get]
+// @Security Bearer
+func (h *CustomFieldHandler) Schema(c *gin.Context) {
+    fields, err := h.service.ListFields()
+    if err != nil {
+        c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+        return
+    }
+    c.JSON(http.StatusOK, domain.CustomFieldsSchema(fields))
+}
+
+// GetField godoc
+// @Summary Obtener un campo personalizado
+// @Description Retorna la definición del campo con la clave indicada
+// @Tags Custom Fields
+// @Accept  json
+// @Produce  json
+// @Param  key path string true "Clave del campo"
+// @Success 200 {object} domain.CustomField
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "Campo no encontrado"
+// @Router /custom-fields/{key} [get]
+// @Security Bearer
+func (h *CustomFieldHandler) GetField(c *gin.Context) {
+    field, err := h.service.GetField(c.Param("key"))
+    if err != nil {
+        respondCustomFieldError(c, err)
+        return
+    }
+    c.JSON(http.StatusOK, field)
+}
+
+// UpdateField godoc
+// @Summary Actualizar un campo personalizado
+// @Description Cambia la etiqueta, las opciones y si es obligatorio. La clave y el tipo no se pueden cambiar.
+// @Description Los valores ya guardados de opciones eliminadas se conservan.
+// @Tags Custom Fields
+// @Accept  json
+// @Produce  json
+// @Param  key path string true "Clave del campo"
+// @Param field body domain.CustomField true "Definición del campo"
+// @Success 200 {object} domain.CustomField
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "Campo no encontrado"
+// @Router /custom-fields/{key} [put]
+// @Security Bearer
+func (h *CustomFieldHandler) UpdateField(c *gin.Context) {
+    var field domain.CustomField
+    if err := c.ShouldBindJSON(&field); err != nil {
+        c.JSON(http.StatusBadRequest, gin.H{"error": "JSON no valid"})
+        return
+    }
+    field.Key = c.Param("key")
+
+    updated, err := h.service.UpdateField(field)
+    if err != nil {
+        respondCustomFieldError(c, err)
+        return
+    }
+    c.JSON(http.StatusOK, updated)
+}
+
+// DeleteField godoc
+// @Summary Borrar un campo personalizado
+// @Description Borra el campo y sus valores en todos los candidatos
+// @Tags Custom Fields
+// @Accept  json
+// @Produce  json
+// @Param  key path string true "Clave del campo"
+// @Success 200 {object} map[string]interface{} "ok"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "Campo no encontrado"
+// @Router /custom-fields/{key} [delete]
+// @Security Bearer
+func (h *CustomFieldHandler) DeleteField(c *gin.Context) {
+    if err := h.service.DeleteField(c.Param("key")); err != nil {
+        respondCustomFieldError(c, err)
+        return
+    }
+    c.JSON(http.StatusOK, gin.H{"message": "Custom field deleted"})
+}
+
+// ListTags godoc
+// @Summary Listar las etiquetas
+// @Description Retorna las etiquetas en uso con el número de candidatos que las tienen
+// @Tags Custom Fields
+// @Accept  json
+// @Produce  json
+// @Success 200 {array} domain.TagCount
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /tags [get]
+// @Security Bearer
+func (h *CustomFieldHandler) ListTags(c *gin.Context) {
+    tags, err := h.service.ListTags()
+    if err != nil {
+        c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+        return
+    }
+    c.JSON(http.StatusOK, tags)
+}
+
+func respondCustomFieldError(c *gin.Context, err error) {
+    switch {
+    case errors.Is(err, service.ErrInvalidCustomField):
+        c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+    case errors.Is(err, service.ErrCustomFieldNotFound):
+        c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+    case errors.Is(err, service.ErrCustomFieldExists):
+        c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+    default:
+        c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+    }
+}
@@ -0,0 +1,23 @@
+package handler
+
+import (
+    "net/http"
+    "strings"
+
+    "github.com/gin-gonic/gin"
+)
+
+// CustomMethods registers the custom methods of a collection, POST <collection>:<verb> for
+// each verb of methods. gin cannot register a literal colon after a segment, so the verbs share
+// one wildcard route; any other verb answers 404 like an unknown route.
+func CustomMethods(routes gin.IRoutes, collection string, methods map[string]gin.HandlerFunc) {
+    routes.POST(collection+":verb", func(c *gin.Context) {
+        verb, ok := strings.CutPrefix(c.Param("verb"), ":")
+        method, found := methods[verb]
+        if !ok || !found {
+            c.JSON(http.StatusNotFound, gin.H{"error": "Action not found"})
+            return
+        }
+        method(c)
+    })
+}
@@ -0,0 +1,350 @@
+package handler
+
+import (
+    "errors"
+    "mime"
+    "mime/multipart"
+    "net/http"
+    "strconv"
+
+    "github.com/gin-gonic/gin"
+    "github.com/torvictorvic/seek-v2/internal/domain"
+    "github.com/torvictorvic/seek-v2/internal/security"
+    "github.com/torvictorvic/seek-v2/internal/service"
+)
+
+// multipartOverhead is the room left in the request body for the multipart headers
+const multipartOverhead = 64 << 10
+
+type DocumentHandler struct {
+    service  service.DocumentService
+    maxBytes int64
+}
+
+func NewDocumentHandler(s service.DocumentService, maxBytes int64) *DocumentHandler {
+    return &DocumentHandler{service: s, maxBytes: maxBytes}
+}
+
+// documentIDs reads the candidate and document IDs of the path
+func documentIDs(c *gin.Context) (int, int, bool) {
+    candidateID, err := strconv.Atoi(c.Param("id"))
+    if err != nil {
+        c.JSON(http.StatusBadRequest, gin.H{"error": "The ID must be an integer"})
+        return 0, 0, false
+    }
+    docID, err := strconv.Atoi(c.Param("docId"))
+    if err != nil {
+        c.JSON(http.StatusBadRequest, gin.H{"error": "The document ID must be an integer"})
+        return 0, 0, false
+    }
+    return candidateID, docID, true
+}
+
+// UploadDocument godoc
+// @Summary Adjuntar un documento a un candidato
+// @Description Sube un archivo en el campo "file" (multipart/form-data). El tipo se detecta por el contenido: solo se aceptan PDF, DOCX e imágenes (PNG, JPEG, GIF, WebP).
+// @Tags Documents
+// @Accept  multipart/form-data
+// @Produce  json
+// @Param  id path int true "ID del Candidato"
+// @Param  file formData file true "Documento"
+// @Success 201 {object} domain.Document
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "Candidato no encontrado"
+// @Failure 413 {object} map[string]interface{} "Archivo demasiado grande"
+// @Failure 415 {object} map[string]interface{} "Tipo de archivo no permitido"
+// @Router /candidates/{id}/documents [post]
+// @Security Bearer
+func (h *DocumentHandler) UploadDocument(c *gin.Context) {
+    id, err := strconv.Atoi(c.Param("id"))
+    if err != nil {
+        c.JSON(http.StatusBadRequest, gin.H{"error": "The ID must be an integer"})
+        return
+    }
+
+    file, header, ok := h.formFile(c)
+    if !ok {
+        return
+    }
+    defer file.Close()
+
+    doc, err := h.service.Upload(c.Request.Context(), id, header.Filename, file, header.Size, security.CurrentUser(c))
+    if err != nil {
+        respondDocumentError(c, err)
+        return
+    }
+    c.JSON(http.StatusCreated, doc)
+}
+
+// formFile opens the uploaded "file" field, with the body limited to the maximum size
+func (h *DocumentHandler) formFile(c *gin.Context) (multipart.File, *multipart.FileHeader, bool) {
+    if h.maxBytes > 0 {
+        c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, h.maxBytes+multipartOverhead)
+    }
+    header, err := c.FormFile("file")
+    if err != nil {
+        var tooLarge *http.MaxBytesError
+        if errors.As(err, &tooLarge) {
+            c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": service.ErrDocumentTooLarge.Error()})
+            return nil, nil, false
+        }
+        c.JSON(http.StatusBadRequest, gin.H{"error": "The multipart field 'file' is required"})
+        return nil, nil, false
+    }
+    file, err := header.Open()
+    if err != nil {
+        c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+        return nil, nil, false
+    }
+    return file, header, true
+}
+
+// ListDocuments godoc
+// @Summary Documentos de un candidato
+// @Description Retorna los documentos del candidato, primero los más recientes
+// @Tags Documents
+// @Accept  json
+// @Produce  json
+// @Param  id path int true "ID del Candidato"
+// @Success 200 {array} domain.Document
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /candidates/{id}/documents [get]
+// @Security Bearer
+func (h *DocumentHandler) ListDocuments(c *gin.Context) {
+    id, err := strconv.Atoi(c.Param("id"))
+    if err != nil {
+        c.JSON(http.StatusBadRequest, gin.H{"error": "The ID must be an integer"})
+        return
+    }
+
+    docs, err := h.service.ListDocuments(id)
+    if err != nil {
+        c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+        return
+    }
+    c.JSON(http.StatusOK, docs)
+}
+
+// GetDocument godoc
+// @Summary Metadatos de un documento
+// @Tags Documents
+// @Accept  json
+// @Produce  json
+// @Param  id path int true "ID del Candidato"
+// @Param  docId path int true "ID del Documento"
+// @Success 200 {object} domain.Document
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "Documento no encontrado"
+// @Router /candidates/{id}/documents/{docId} [get]
+// @Security Bearer
+func (h *DocumentHandler) GetDocument(c *gin.Context) {
+    candidateID, docID, ok := documentIDs(c)
+    if !ok {
+        return
+    }
+
+    doc, err := h.service.GetDocument(candidateID, docID)
+    if err != nil {
+        respondDocumentError(c, err)
+        return
+    }
+    c.JSON(http.StatusOK, doc)
+}
+
+// DownloadDocument godoc
+// @Summary Descargar un documento
+// @Description Retorna el contenido del documento. Soporta peticiones parciales con la cabecera Range.
+// @Tags Documents
+// @Produce  octet-stream
+// @Param  id path int true "ID del Candidato"
+// @Param  docId path int true "ID del Documento"
+// @Param  Range header string false "Rango de bytes, ej. bytes=0-1023"
+// @Success 200 {file} file
+// @Success 206 {file} file
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "Documento no encontrado"
+// @Failure 416 {object} map[string]interface{} "Rango no válido"
+// @Router /candidates/{id}/documents/{docId}/content [get]
+// @Security Bearer
+func (h *DocumentHandler) DownloadDocument(c *gin.Context) {
+    candidateID, docID, ok := documentIDs(c)
+    if !ok {
+        return
+    }
+
+    doc, err := h.service.GetDocument(candidateID, docID)
+    if err != nil {
+        respondDocumentError(c, err)
+        return
+    }
+    h.serve(c, *doc)
+}
+
+// serve writes the content of the document with http.ServeContent, which answers the
+// range and conditional requests
+func (h *DocumentHandler) serve(c *gin.Context, doc domain.Document) {
+    content := h.service.Open(c.Request.Context(), doc)
+    defer content.Close()
+
+    c.Header("Content-Type", doc.ContentType)
+    c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": doc.FileName}))
+    c.Header("ETag", `"`+doc.SHA256+`"`)
+    c.Header("Cache-Control", "private, no-cache")
+    http.ServeContent(c.Writer, c.Request, doc.FileName, doc.CreatedAt, content)
+}
+
+// SignDocumentURL godoc
+// @Summary Generar un enlace de descarga firmado
+// @Description Retorna un enlace que permite descargar el documento sin token hasta que expire
+// @Tags Documents
+// @Accept  json
+// @Produce  json
+// @Param  id path int true "ID del Candidato"
+// @Param  docId path int true "ID del Documento"
+// @Success 200 {object} domain.DocumentURL
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "Documento no encontrado"
+// @Router /candidates/{id}/documents/{docId}/url [post]
+// @Security Bearer
+func (h *DocumentHandler) SignDocumentURL(c *gin.Context) {
+    candidateID, docID, ok := documentIDs(c)
+    if !ok {
+        return
+    }
+
+    doc, err := h.service.GetDocument(candidateID, docID)
+    if err != nil {
+        respondDocumentError(c, err)
+        return
+    }
+    c.JSON(http.StatusOK, h.service.SignURL(*doc))
+}
+
+// DownloadSigned serves a document through a signed link, without a token. It is
+// outside the API group, so it is not part of the Swagger docs.
+func (h *DocumentHandler) DownloadSigned(c *gin.Context) {
+    docID, err := strconv.Atoi(c.Param("docId"))
+    if err != nil {
+        c.JSON(http.StatusBadRequest, gin.H{"error": "The document ID must be an integer"})
+        return
+    }
+
+    doc, err := h.service.VerifyURL(docID, c.Query("expires"), c.Query("signature"))
+    if err != nil {
+        respondDocumentError(c, err)
+        return
+    }
+    h.serve(c, *doc)
+}
+
+// DeleteDocument godoc
+// @Summary Eliminar un documento
+// @Description Elimina el documento y su contenido del almacenamiento
+// @Tags Documents
+// @Accept  json
+// @Produce  json
+// @Param  id path int true "ID del Candidato"
+// @Param  docId path int true "ID del Documento"
+// @Success 200 {object} map[string]interface{} "ok"
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "Documento no encontrado"
+// @Router /candidates/{id}/documents/{docId} [delete]
+// @Security Bearer
+func (h *DocumentHandler) DeleteDocument(c *gin.Context) {
+    candidateID, docID, ok := documentIDs(c)
+    if !ok {
+        return
+    }
+
+    if err := h.service.DeleteDocument(c.Request.Context(), candidateID, docID, security.CurrentUser(c)); err != nil {
+        respondDocumentError(c, err)
+        return
+    }
+    c.JSON(http.StatusOK, gin.H{"message": "Document deleted"})
+}
+
+// ParseDocument godoc
+// @Summary Extraer los datos de un CV adjunto
+// @Description Analiza el texto de un documento PDF o DOCX del candidato y sugiere nombre, email, teléfonos, enlaces y etiquetas de habilidades. No guarda nada: los datos se revisan antes de actualizar el candidato.
+// @Tags Documents
+// @Accept  json
+// @Produce  json
+// @Param  id path int true "ID del Candidato"
+// @Param  docId path int true "ID del Documento"
+// @Success 200 {object} domain.ResumeSuggestion
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "Documento no encontrado"
+// @Failure 422 {object} map[string]interface{} "El documento no tiene texto"
+// @Router /candidates/{id}/documents/{docId}/parse [get]
+// @Security Bearer
+func (h *DocumentHandler) ParseDocument(c *gin.Context) {
+    candidateID, docID, ok := documentIDs(c)
+    if !ok {
+        return
+    }
+
+    suggestion, err := h.service.ParseDocument(candidateID, docID)
+    if err != nil {
+        respondDocumentError(c, err)
+        return
+    }
+    c.JSON(http.StatusOK, suggestion)
+}
+
+// ParseResume godoc
+// @Summary Extraer los datos de un CV
+// @Description Analiza un CV en PDF o DOCX (campo "file") sin guardarlo, para completar el formulario de un candidato nuevo. El análisis se hace localmente, sin servicios externos.
+// @Tags Documents
+// @Accept  multipart/form-data
+// @Produce  json
+// @Param  file formData file true "CV en PDF o DOCX"
+// @Success 200 {object} domain.ResumeSuggestion
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 413 {object} map[string]interface{} "Archivo demasiado grande"
+// @Failure 415 {object} map[string]interface{} "Tipo de archivo no permitido"
+// @Failure 422 {object} map[string]interface{} "El documento no tiene texto"
+// @Router /resumes/parse [post]
+// @Security Bearer
+func (h *DocumentHandler) ParseResume(c *gin.Context) {
+    file, header, ok := h.formFile(c)
+    if !ok {
+        return
+    }
+    defer file.Close()
+
+    suggestion, err := h.service.ParseResume(file, header.Size)
+    if err != nil {
+        respondDocumentError(c, err)
+        return
+    }
+    c.JSON(http.StatusOK, suggestion)
+}
+
+func respondDocumentError(c *gin.Context, err error) {
+    switch {
+    case errors.Is(err, service.ErrInvalidDocument):
+        c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+    case errors.Is(err, service.ErrIdentityRequired):
+        c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+    case errors.Is(err, service.ErrInvalidDocumentURL):
+        c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+    case errors.Is(err, service.ErrDocumentNotFound), errors.Is(err, service.ErrCandidateNotFound):
+        c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+    case errors.Is(err, service.ErrDocumentTooLarge):
+        c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": err.Error()})
+    case errors.Is(err, service.ErrUnsupportedDocument):
+        c.JSON(http.StatusUnsupportedMediaType, gin.H{"error": err.Error()})
+    case errors.Is(err, service.ErrNoResumeText):
+        c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+    default:
+        c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+    }
+}
@@ -0,0 +1,66 @@
+package handler
+
+import (
+    "errors"
+    "net/http"
+    "strconv"
+
+    "github.com/gin-gonic/gin"
+    "github.com/torvictorvic/seek-v2/internal/domain"
+    "github.com/torvictorvic/seek-v2/internal/security"
+    "github.com/torvictorvic/seek-v2/internal/service"
+)
+
+type DuplicateHandler struct {
+    service service.DuplicateService
+}
+
+func NewDuplicateHandler(s service.DuplicateService) *DuplicateHandler {
+    return &DuplicateHandler{service: s}
+}
+
+// FindDuplicates godoc
+// @Summary Posibles duplicados de un candidato
+// @Description Compara el candidato con el resto por nombre normalizado y parte local del email, y retorna los que superan el umbral ordenados por puntuación
+// @Tags Candidates
+// @Accept  json
+// @Produce  json
+// @Param  id path int true "ID del Candidato"
+// @Param threshold query number false "Puntuación mínima entre 0 y 1 (0.7 por defecto)"
+// @Param limit query int false "Número máximo de resultados"
+// @Success 200 {array} domain.DuplicateCandidate
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "Candidato no encontrado"
+// @Router /candidates/{id}/duplicates [get]
+// @Security Bearer
+func (h *DuplicateHandler) FindDuplicates(c *gin.Context) {
+    id, err := strconv.Atoi(c.Param("id"))
+    if err != nil {
+        c.JSON(http.StatusBadRequest, gin.H{"error": "The ID must be an integer"})
+        return
+    }
+    threshold, err := strconv.ParseFloat(c.DefaultQuery("threshold", "0"), 64)
+    if err != nil || threshold < 0 || threshold > 1 {
+        c.JSON(http.StatusBadRequest, gin.H{"error": "The threshold must be a number between 0 and 1"})
+        return
+    }
+    limit, err := strconv.Atoi(c.DefaultQuery("limit", "0"))
+    if err != nil || limit < 0 {
+        c.JSON(http.StatusBadRequest, gin.H{"error": "The limit must be a positive integer"})
+        return
+    }
+
+    var duplicates []domain.DuplicateCandidate
+    // The candidates are read like the other reads of the user, from the replicas
+    duplicates, err = service.DuplicatesForUser(h.service, security.CurrentUser(c)).FindDuplicates(id, threshold, limit)
+    if err != nil {
+        if errors.Is(err, service.ErrCandidateNotFound) {
+            c.JSON(http.StatusNotFound, gin.H{"error": "Candidate not found"})
+            return
+        }
+        c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+        return
+    }
+    c.JSON(http.StatusOK, duplicates)
+}
@@ -0,0 +1,175 @@
+package handler
+
+import (
+    "errors"
+    "net/http"
+    "strconv"
+
+    "github.com/gin-gonic/gin"
+    "github.com/torvictorvic/seek-v2/internal/domain"
+    "github.com/torvictorvic/seek-v2/internal/security"
+    "github.com/torvictorvic/seek-v2/internal/service"
+)
+
+type FeedbackHandler struct {
+    service service.FeedbackService
+}
+
+func NewFeedbackHandler(s service.FeedbackService) *FeedbackHandler {
+    return &FeedbackHandler{service: s}
+}
+
+// CreateTemplate godoc
+// @Summary Crear una plantilla de evaluación
+// @Description Crea una plantilla con competencias ponderadas y una escala de calificación (por defecto 1 a 5)
+// @Tags Feedback
+// @Accept  json
+// @Produce  json
+// @Param template body domain.ScorecardTemplate true "Plantilla"
+// @Success 201 {object} domain.ScorecardTemplate
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /scorecards [post]
+// @Security Bearer
+func (h *FeedbackHandler) CreateTemplate(c *gin.Context) {
+    var t domain.ScorecardTemplate
+    if err := c.ShouldBindJSON(&t); err != nil {
+        c.JSON(http.StatusBadRequest, gin.H{"error": "JSON no valid"})
+        return
+    }
+
+    created, err := h.service.CreateTemplate(t)
+    if err != nil {
+        respondFeedbackError(c, err)
+        return
+    }
+    c.JSON(http.StatusCreated, created)
+}
+
+// GetTemplate godoc
+// @Summary Obtener plantilla de evaluación por ID
+// @Description Retorna la plantilla con sus competencias
+// @Tags Feedback
+// @Accept  json
+// @Produce  json
+// @Param  id path int true "ID de la Plantilla"
+// @Success 200 {object} domain.ScorecardTemplate
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "Plantilla no encontrada"
+// @Router /scorecards/{id} [get]
+// @Security Bearer
+func (h *FeedbackHandler) GetTemplate(c *gin.Context) {
+    id, err := strconv.Atoi(c.Param("id"))
+    if err != nil {
+        c.JSON(http.StatusBadRequest, gin.H{"error": "The ID must be an integer"})
+        return
+    }
+
+    t, err := h.service.GetTemplate(id)
+    if err != nil {
+        respondFeedbackError(c, err)
+        return
+    }
+    c.JSON(http.StatusOK, t)
+}
+
+// ListTemplates godoc
+// @Summary Listar plantillas de evaluación
+// @Description Retorna todas las plantillas con sus competencias
+// @Tags Feedback
+// @Accept  json
+// @Produce  json
+// @Success 200 {array} domain.ScorecardTemplate
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /scorecards [get]
+// @Security Bearer
+func (h *FeedbackHandler) ListTemplates(c *gin.Context) {
+    templates, err := h.service.ListTemplates()
+    if err != nil {
+        c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+        return
+    }
+    c.JSON(http.StatusOK, templates)
+}
+
+// SubmitFeedback godoc
+// @Summary Enviar la evaluación de un candidato
+// @Description Registra la evaluación del usuario del token. Se deben calificar todas las competencias de la plantilla y no se puede modificar después.
+// @Tags Feedback
+// @Accept  json
+// @Produce  json
+// @Param  id path int true "ID del Candidato"
+// @Param feedback body domain.Feedback true "Evaluación"
+// @Success 201 {object} domain.Feedback
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "No es entrevistador de la entrevista"
+// @Failure 404 {object} map[string]interface{} "Candidato o plantilla no encontrados"
+// @Failure 409 {object} map[string]interface{} "Evaluación ya enviada"
+// @Router /candidates/{id}/feedback [post]
+// @Security Bearer
+func (h *FeedbackHandler) SubmitFeedback(c *gin.Context) {
+    id, err := strconv.Atoi(c.Param("id"))
+    if err != nil {
+        c.JSON(http.StatusBadRequest, gin.H{"error": "The ID must be an integer"})
+        return
+    }
+
+    var fb domain.Feedback
+    if err := c.ShouldBindJSON(&fb); err != nil {
+        c.JSON(http.StatusBadRequest, gin.H{"error": "JSON no valid"})
+        return
+    }
+
+    created, err := h.service.SubmitFeedback(id, fb, security.CurrentUser(c))
+    if err != nil {
+        respondFeedbackError(c, err)
+        return
+    }
+    c.JSON(http.StatusCreated, created)
+}
+
+// ListFeedback godoc
+// @Summary Evaluaciones de un candidato
+// @Description Retorna las evaluaciones del candidato. Un entrevistador del candidato no ve las de los demás hasta enviar la suya.
+// @Tags Feedback
+// @Accept  json
+// @Produce  json
+// @Param  id path int true "ID del Candidato"
+// @Success 200 {object} domain.FeedbackList
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /candidates/{id}/feedback [get]
+// @Security Bearer
+func (h *FeedbackHandler) ListFeedback(c *gin.Context) {
+    id, err := strconv.Atoi(c.Param("id"))
+    if err != nil {
+        c.JSON(http.StatusBadRequest, gin.H{"error": "The ID must be an integer"})
+        return
+    }
+
+    list, err := h.service.ListFeedback(id, security.CurrentUser(c))
+    if err != nil {
+        c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+        return
+    }
+    c.JSON(http.StatusOK, list)
+}
+
+func respondFeedbackError(c *gin.Context, err error) {
+    switch {
+    case errors.Is(err, service.ErrInvalidTemplate), errors.Is(err, service.ErrInvalidFeedback):
+        c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+    case errors.Is(err, service.ErrIdentityRequired):
+        c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+    case errors.Is(err, service.ErrNotOnInterviewPanel):
+        c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+    case errors.Is(err, service.ErrTemplateNotFound), errors.Is(err, service.ErrCandidateNotFound):
+        c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+    case errors.Is(err, service.ErrFeedbackExists):
+        c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+    default:
+        c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+    }
+}
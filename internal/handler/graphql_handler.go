@@ -0,0 +1,59 @@
+package handler
+
+import (
+    "context"
+    "encoding/json"
+    "net/http"
+
+    "github.com/gin-gonic/gin"
+    "github.com/graphql-go/graphql"
+
+    "github.com/torvictorvic/seek-v2/internal/graphqlapi"
+    "github.com/torvictorvic/seek-v2/internal/security"
+)
+
+// GraphQLExecutor runs a GraphQL request on behalf of a user
+type GraphQLExecutor interface {
+    Execute(ctx context.Context, user string, req graphqlapi.Request) *graphql.Result
+}
+
+type GraphQLHandler struct {
+    executor GraphQLExecutor
+}
+
+func NewGraphQLHandler(executor GraphQLExecutor) *GraphQLHandler {
+    return &GraphQLHandler{executor: executor}
+}
+
+// Serve runs a query sent as JSON in a POST, or in the query, operationName and
+// variables parameters of a GET, which only runs queries. The errors of a valid request are returned in the
+// "errors" member of the result with status 200, as GraphQL clients expect. It is
+// outside the API group, so it is not part of the Swagger docs.
+func (h *GraphQLHandler) Serve(c *gin.Context) {
+    var req graphqlapi.Request
+    if c.Request.Method == http.MethodGet {
+        req.Query = c.Query("query")
+        req.OperationName = c.Query("operationName")
+        if raw := c.Query("variables"); raw != "" {
+            if err := json.Unmarshal([]byte(raw), &req.Variables); err != nil {
+                c.JSON(http.StatusBadRequest, gin.H{"error": "The parameter 'variables' must be a JSON object"})
+                return
+            }
+        }
+    } else if err := c.ShouldBindJSON(&req); err != nil {
+        c.JSON(http.StatusBadRequest, gin.H{"error": "JSON no valid"})
+        return
+    }
+    if req.Query == "" {
+        c.JSON(http.StatusBadRequest, gin.H{"error": "The field 'query' is required"})
+        return
+    }
+    // GET must be safe: a link or a prefetch must not change data
+    if c.Request.Method == http.MethodGet && graphqlapi.IsMutation(req.Query, req.OperationName) {
+        c.Header("Allow", http.MethodPost)
+        c.JSON(http.StatusMethodNotAllowed, gin.H{"error": "Mutations must be sent with POST"})
+        return
+    }
+
+    c.JSON(http.StatusOK, h.executor.Execute(c.Request.Context(), security.CurrentUser(c), req))
+}
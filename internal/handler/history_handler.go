@@ -0,0 +1,46 @@
+package handler
+
+import (
+    "net/http"
+    "strconv"
+
+    "github.com/gin-gonic/gin"
+    "github.com/torvictorvic/seek-v2/internal/domain"
+    "github.com/torvictorvic/seek-v2/internal/service"
+)
+
+type HistoryHandler struct {
+    service service.HistoryService
+}
+
+func NewHistoryHandler(s service.HistoryService) *HistoryHandler {
+    return &HistoryHandler{service: s}
+}
+
+// GetCandidateHistory godoc
+// @Summary Historial de un candidato
+// @Description Retorna los cambios registrados de un candidato, del más antiguo al más reciente
+// @Tags Candidates
+// @Accept  json
+// @Produce  json
+// @Param  id path int true "ID del Candidato"
+// @Success 200 {array} domain.CandidateHistoryEntry
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /candidates/{id}/history [get]
+// @Security Bearer
+func (h *HistoryHandler) GetCandidateHistory(c *gin.Context) {
+    id, err := strconv.Atoi(c.Param("id"))
+    if err != nil {
+        c.JSON(http.StatusBadRequest, gin.H{"error": "The ID must be an integer"})
+        return
+    }
+
+    var entries []domain.CandidateHistoryEntry
+    entries, err = h.service.GetCandidateHistory(id)
+    if err != nil {
+        c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+        return
+    }
+    c.JSON(http.StatusOK, entries)
+}
@@ -0,0 +1,275 @@
+package handler
+
+import (
+    "errors"
+    "fmt"
+    "net/http"
+    "strconv"
+
+    "github.com/gin-gonic/gin"
+    "github.com/torvictorvic/seek-v2/internal/domain"
+    "github.com/torvictorvic/seek-v2/internal/ical"
+    "github.com/torvictorvic/seek-v2/internal/security"
+    "github.com/torvictorvic/seek-v2/internal/service"
+)
+
+type InterviewHandler struct {
+    service service.InterviewService
+}
+
+func NewInterviewHandler(s service.InterviewService) *InterviewHandler {
+    return &InterviewHandler{service: s}
+}
+
+// ScheduleInterview godoc
+// @Summary Agendar una entrevista
+// @Description Agenda una entrevista con un candidato (y opcionalmente una de sus postulaciones). Se rechaza si se superpone con otra entrevista del candidato o de alguno de los entrevistadores.
+// @Tags Interviews
+// @Accept  json
+// @Produce  json
+// @Param interview body domain.Interview true "Datos de la entrevista"
+// @Success 201 {object} domain.Interview
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "Candidato no encontrado"
+// @Failure 409 {object} map[string]interface{} "Conflicto de horario"
+// @Router /interviews [post]
+// @Security Bearer
+func (h *InterviewHandler) ScheduleInterview(c *gin.Context) {
+    var iv domain.Interview
+    if err := c.ShouldBindJSON(&iv); err != nil {
+        c.JSON(http.StatusBadRequest, gin.H{"error": "JSON no valid"})
+        return
+    }
+
+    created, err := h.service.ScheduleInterview(iv, security.CurrentUser(c))
+    if err != nil {
+        respondInterviewError(c, err)
+        return
+    }
+    c.JSON(http.StatusCreated, created)
+}
+
+// GetInterview godoc
+// @Summary Obtener entrevista por ID
+// @Description Retorna la entrevista cuyo ID se pasa como parámetro
+// @Tags Interviews
+// @Accept  json
+// @Produce  json
+// @Param  id path int true "ID de la Entrevista"
+// @Success 200 {object} domain.Interview
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "Entrevista no encontrada"
+// @Router /interviews/{id} [get]
+// @Security Bearer
+func (h *InterviewHandler) GetInterview(c *gin.Context) {
+    id, err := strconv.Atoi(c.Param("id"))
+    if err != nil {
+        c.JSON(http.StatusBadRequest, gin.H{"error": "The ID must be an integer"})
+        return
+    }
+
+    iv, err := h.service.GetInterview(id)
+    if err != nil {
+        respondInterviewError(c, err)
+        return
+    }
+    c.JSON(http.StatusOK, iv)
+}
+
+// RescheduleInterview godoc
+// @Summary Reprogramar una entrevista
+// @Description Cambia horario, lugar o entrevistadores. El candidato no cambia. La secuencia de la invitación se incrementa.
+// @Tags Interviews
+// @Accept  json
+// @Produce  json
+// @Param  id path int true "ID de la Entrevista"
+// @Param interview body domain.Interview true "Datos de la entrevista"
+// @Success 200 {object} domain.Interview
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "Entrevista no encontrada"
+// @Failure 409 {object} map[string]interface{} "Conflicto de horario"
+// @Router /interviews/{id} [put]
+// @Security Bearer
+func (h *InterviewHandler) RescheduleInterview(c *gin.Context) {
+    id, err := strconv.Atoi(c.Param("id"))
+    if err != nil {
+        c.JSON(http.StatusBadRequest, gin.H{"error": "The ID must be an integer"})
+        return
+    }
+
+    var iv domain.Interview
+    if err := c.ShouldBindJSON(&iv); err != nil {
+        c.JSON(http.StatusBadRequest, gin.H{"error": "JSON no valid"})
+        return
+    }
+    iv.ID = id
+
+    updated, err := h.service.RescheduleInterview(iv)
+    if err != nil {
+        respondInterviewError(c, err)
+        return
+    }
+    c.JSON(http.StatusOK, updated)
+}
+
+// CancelInterview godoc
+// @Summary Cancelar una entrevista
+// @Description Borra la entrevista cuyo ID se pasa como parámetro
+// @Tags Interviews
+// @Accept  json
+// @Produce  json
+// @Param  id path int true "ID de la Entrevista"
+// @Success 200 {object} map[string]interface{} "ok"
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "Entrevista no encontrada"
+// @Router /interviews/{id} [delete]
+// @Security Bearer
+func (h *InterviewHandler) CancelInterview(c *gin.Context) {
+    id, err := strconv.Atoi(c.Param("id"))
+    if err != nil {
+        c.JSON(http.StatusBadRequest, gin.H{"error": "The ID must be an integer"})
+        return
+    }
+
+    if err := h.service.CancelInterview(id); err != nil {
+        respondInterviewError(c, err)
+        return
+    }
+    c.JSON(http.StatusOK, gin.H{"message": "Interview cancelled"})
+}
+
+// ListByCandidate godoc
+// @Summary Entrevistas de un candidato
+// @Description Retorna las entrevistas del candidato ordenadas por fecha
+// @Tags Interviews
+// @Accept  json
+// @Produce  json
+// @Param  id path int true "ID del Candidato"
+// @Success 200 {array} domain.Interview
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /candidates/{id}/interviews [get]
+// @Security Bearer
+func (h *InterviewHandler) ListByCandidate(c *gin.Context) {
+    id, err := strconv.Atoi(c.Param("id"))
+    if err != nil {
+        c.JSON(http.StatusBadRequest, gin.H{"error": "The ID must be an integer"})
+        return
+    }
+
+    interviews, err := h.service.ListByCandidate(id)
+    if err != nil {
+        c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+        return
+    }
+    c.JSON(http.StatusOK, interviews)
+}
+
+// DownloadInvite godoc
+// @Summary Descargar la invitación de una entrevista
+// @Description Retorna la invitación en formato iCalendar (RFC 5545)
+// @Tags Interviews
+// @Produce  text/calendar
+// @Param  id path int true "ID de la Entrevista"
+// @Success 200 {file} file "Invitación .ics"
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "Entrevista no encontrada"
+// @Router /interviews/{id}/invite.ics [get]
+// @Security Bearer
+func (h *InterviewHandler) DownloadInvite(c *gin.Context) {
+    id, err := strconv.Atoi(c.Param("id"))
+    if err != nil {
+        c.JSON(http.StatusBadRequest, gin.H{"error": "The ID must be an integer"})
+        return
+    }
+
+    cal, err := h.service.Invite(id)
+    if err != nil {
+        respondInterviewError(c, err)
+        return
+    }
+    c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="interview-%d.ics"`, id))
+    writeCalendar(c, cal)
+}
+
+// IssueFeed godoc
+// @Summary Generar la URL del calendario personal
+// @Description Genera una URL iCal con las entrevistas del usuario, protegida por un token. La URL anterior deja de funcionar.
+// @Tags Interviews
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} map[string]interface{} "url"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /calendar/feed [post]
+// @Security Bearer
+func (h *InterviewHandler) IssueFeed(c *gin.Context) {
+    token, err := h.service.IssueFeedToken(security.CurrentUser(c))
+    if err != nil {
+        respondInterviewError(c, err)
+        return
+    }
+
+    scheme := "http"
+    if c.Request.TLS != nil || c.GetHeader("X-Forwarded-Proto") == "https" {
+        scheme = "https"
+    }
+    c.JSON(http.StatusOK, gin.H{"url": scheme + "://" + c.Request.Host + "/calendar/" + token + "/interviews.ics"})
+}
+
+// RevokeFeed godoc
+// @Summary Revocar la URL del calendario personal
+// @Description La URL iCal del usuario deja de funcionar
+// @Tags Interviews
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} map[string]interface{} "ok"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /calendar/feed [delete]
+// @Security Bearer
+func (h *InterviewHandler) RevokeFeed(c *gin.Context) {
+    if err := h.service.RevokeFeedToken(security.CurrentUser(c)); err != nil {
+        c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+        return
+    }
+    c.JSON(http.StatusOK, gin.H{"message": "Calendar feed revoked"})
+}
+
+// Feed serves the personal iCal feed. Calendar clients cannot send the JWT, so
+// the route is public and the token in the URL identifies the user.
+func (h *InterviewHandler) Feed(c *gin.Context) {
+    cal, err := h.service.Feed(c.Param("token"))
+    if err != nil {
+        respondInterviewError(c, err)
+        return
+    }
+    c.Header("Cache-Control", "private, max-age=300")
+    writeCalendar(c, cal)
+}
+
+func writeCalendar(c *gin.Context, cal *ical.Calendar) {
+    c.Header("Content-Type", ical.ContentType)
+    c.Status(http.StatusOK)
+    if err := ical.Write(c.Writer, *cal); err != nil {
+        c.Error(err)
+    }
+}
+
+func respondInterviewError(c *gin.Context, err error) {
+    var conflict *service.InterviewConflictError
+    switch {
+    case errors.As(err, &conflict):
+        c.JSON(http.StatusConflict, gin.H{"error": service.ErrInterviewConflict.Error(), "conflicts": conflict.Conflicts})
+    case errors.Is(err, service.ErrInvalidInterview):
+        c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+    case errors.Is(err, service.ErrInterviewNotFound), errors.Is(err, service.ErrCandidateNotFound),
+        errors.Is(err, service.ErrInvalidFeedToken):
+        c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+    default:
+        c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+    }
+}
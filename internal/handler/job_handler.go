@@ -0,0 +1,183 @@
+package handler
+
+import (
+    "errors"
+    "net/http"
+    "strconv"
+
+    "github.com/gin-gonic/gin"
+    "github.com/torvictorvic/seek-v2/internal/domain"
+    "github.com/torvictorvic/seek-v2/internal/service"
+)
+
+type JobHandler struct {
+    service service.JobService
+}
+
+func NewJobHandler(s service.JobService) *JobHandler {
+    return &JobHandler{service: s}
+}
+
+// CreateJob godoc
+// @Summary Crear una vacante
+// @Description Crea una vacante con los datos enviados en el body. El estado por defecto es 'open'.
+// @Tags Jobs
+// @Accept  json
+// @Produce  json
+// @Param job body domain.Job true "Datos de la vacante"
+// @Success 200 {object} map[string]interface{} "ok"
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /jobs [post]
+// @Security Bearer
+func (h *JobHandler) CreateJob(c *gin.Context) {
+    var job domain.Job
+    if err := c.ShouldBindJSON(&job); err != nil {
+        c.JSON(http.StatusBadRequest, gin.H{"error": "JSON no valid"})
+        return
+    }
+
+    id, err := h.service.CreateJob(job)
+    if err != nil {
+        respondJobError(c, err)
+        return
+    }
+
+    c.JSON(http.StatusOK, gin.H{"message": "Job created", "id": id})
+}
+
+// GetJobByID godoc
+// @Summary Obtener vacante por ID
+// @Description Retorna la vacante cuyo ID se pasa como parámetro
+// @Tags Jobs
+// @Accept  json
+// @Produce  json
+// @Param  id path int true "ID de la Vacante"
+// @Success 200 {object} domain.Job
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 404 {object} map[string]interface{} "Vacante no encontrada"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /jobs/{id} [get]
+// @Security Bearer
+func (h *JobHandler) GetJobByID(c *gin.Context) {
+    id, err := strconv.Atoi(c.Param("id"))
+    if err != nil {
+        c.JSON(http.StatusBadRequest, gin.H{"error": "The ID must be an integer"})
+        return
+    }
+
+    job, err := h.service.GetJobByID(id)
+    if err != nil {
+        c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+        return
+    }
+    if job == nil {
+        c.JSON(http.StatusNotFound, gin.H{"error": "Job not found"})
+        return
+    }
+
+    c.JSON(http.StatusOK, job)
+}
+
+// GetAllJobs godoc
+// @Summary Listar vacantes
+// @Description Retorna la lista de vacantes, opcionalmente filtrada
+// @Tags Jobs
+// @Accept  json
+// @Produce  json
+// @Param title query string false "Título (coincidencia parcial)"
+// @Param department query string false "Departamento"
+// @Param location query string false "Ubicación"
+// @Param status query string false "Estado: open, on_hold o closed"
+// @Param hiring_manager query string false "Responsable de la contratación"
+// @Success 200 {array} domain.Job
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /jobs [get]
+// @Security Bearer
+func (h *JobHandler) GetAllJobs(c *gin.Context) {
+    var filter domain.JobFilter
+    if err := c.ShouldBindQuery(&filter); err != nil {
+        c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid filters"})
+        return
+    }
+
+    jobs, err := h.service.GetAllJobs(filter)
+    if err != nil {
+        c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+        return
+    }
+    c.JSON(http.StatusOK, jobs)
+}
+
+// UpdateJob godoc
+// @Summary Actualiza una vacante
+// @Description Actualiza una vacante con los datos enviados en el body
+// @Tags Jobs
+// @Accept  json
+// @Produce  json
+// @Param  id path int true "ID de la Vacante"
+// @Param job body domain.Job true "Datos de la vacante"
+// @Success 200 {object} map[string]interface{} "ok"
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /jobs/{id} [put]
+// @Security Bearer
+func (h *JobHandler) UpdateJob(c *gin.Context) {
+    id, err := strconv.Atoi(c.Param("id"))
+    if err != nil {
+        c.JSON(http.StatusBadRequest, gin.H{"error": "The ID must be an integer"})
+        return
+    }
+
+    var job domain.Job
+    if err := c.ShouldBindJSON(&job); err != nil {
+        c.JSON(http.StatusBadRequest, gin.H{"error": "JSON no valid"})
+        return
+    }
+    job.ID = id
+
+    if err := h.service.UpdateJob(job); err != nil {
+        respondJobError(c, err)
+        return
+    }
+
+    c.JSON(http.StatusOK, gin.H{"message": "Updated Job"})
+}
+
+// DeleteJob godoc
+// @Summary Borra una vacante por ID
+// @Description Borra una vacante cuyo ID se pasa como parámetro
+// @Tags Jobs
+// @Accept  json
+// @Produce  json
+// @Param  id path int true "ID de la Vacante"
+// @Success 200 {object} map[string]interface{} "ok"
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /jobs/{id} [delete]
+// @Security Bearer
+func (h *JobHandler) DeleteJob(c *gin.Context) {
+    id, err := strconv.Atoi(c.Param("id"))
+    if err != nil {
+        c.JSON(http.StatusBadRequest, gin.H{"error": "The ID must be an integer"})
+        return
+    }
+
+    if err := h.service.DeleteJob(id); err != nil {
+        c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+        return
+    }
+
+    c.JSON(http.StatusOK, gin.H{"message": "Job eliminated"})
+}
+
+func respondJobError(c *gin.Context, err error) {
+    if errors.Is(err, service.ErrInvalidJob) {
+        c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+        return
+    }
+    c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+}
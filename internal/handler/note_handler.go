@@ -0,0 +1,282 @@
+package handler
+
+import (
+    "errors"
+    "net/http"
+    "strconv"
+
+    "github.com/gin-gonic/gin"
+    "github.com/torvictorvic/seek-v2/internal/domain"
+    "github.com/torvictorvic/seek-v2/internal/security"
+    "github.com/torvictorvic/seek-v2/internal/service"
+)
+
+type NoteHandler struct {
+    service service.NoteService
+}
+
+func NewNoteHandler(s service.NoteService) *NoteHandler {
+    return &NoteHandler{service: s}
+}
+
+// noteIDs reads the candidate and note IDs of the path
+func noteIDs(c *gin.Context) (int, int, bool) {
+    candidateID, err := strconv.Atoi(c.Param("id"))
+    if err != nil {
+        c.JSON(http.StatusBadRequest, gin.H{"error": "The ID must be an integer"})
+        return 0, 0, false
+    }
+    noteID, err := strconv.Atoi(c.Param("noteId"))
+    if err != nil {
+        c.JSON(http.StatusBadRequest, gin.H{"error": "The note ID must be an integer"})
+        return 0, 0, false
+    }
+    return candidateID, noteID, true
+}
+
+// AddNote godoc
+// @Summary Agregar una nota a un candidato
+// @Description Crea una nota en markdown. Las menciones @usuario de las notas de equipo generan notificaciones.
+// @Tags Notes
+// @Accept  json
+// @Produce  json
+// @Param  id path int true "ID del Candidato"
+// @Param note body domain.NoteRequest true "Contenido y visibilidad (private o team)"
+// @Success 201 {object} domain.Note
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "Candidato no encontrado"
+// @Router /candidates/{id}/notes [post]
+// @Security Bearer
+func (h *NoteHandler) AddNote(c *gin.Context) {
+    id, err := strconv.Atoi(c.Param("id"))
+    if err != nil {
+        c.JSON(http.StatusBadRequest, gin.H{"error": "The ID must be an integer"})
+        return
+    }
+
+    var req domain.NoteRequest
+    if err := c.ShouldBindJSON(&req); err != nil {
+        c.JSON(http.StatusBadRequest, gin.H{"error": "JSON no valid"})
+        return
+    }
+
+    note, err := h.service.AddNote(id, req, security.CurrentUser(c))
+    if err != nil {
+        respondNoteError(c, err)
+        return
+    }
+    c.JSON(http.StatusCreated, note)
+}
+
+// ListNotes godoc
+// @Summary Notas de un candidato
+// @Description Retorna las notas de equipo y las privadas del usuario, primero las fijadas
+// @Tags Notes
+// @Accept  json
+// @Produce  json
+// @Param  id path int true "ID del Candidato"
+// @Success 200 {array} domain.Note
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /candidates/{id}/notes [get]
+// @Security Bearer
+func (h *NoteHandler) ListNotes(c *gin.Context) {
+    id, err := strconv.Atoi(c.Param("id"))
+    if err != nil {
+        c.JSON(http.StatusBadRequest, gin.H{"error": "The ID must be an integer"})
+        return
+    }
+
+    notes, err := h.service.ListNotes(id, security.CurrentUser(c))
+    if err != nil {
+        c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+        return
+    }
+    c.JSON(http.StatusOK, notes)
+}
+
+// GetNote godoc
+// @Summary Obtener una nota
+// @Description Retorna la nota si pertenece al candidato y el usuario puede verla
+// @Tags Notes
+// @Accept  json
+// @Produce  json
+// @Param  id path int true "ID del Candidato"
+// @Param  noteId path int true "ID de la Nota"
+// @Success 200 {object} domain.Note
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "Nota no encontrada"
+// @Router /candidates/{id}/notes/{noteId} [get]
+// @Security Bearer
+func (h *NoteHandler) GetNote(c *gin.Context) {
+    candidateID, noteID, ok := noteIDs(c)
+    if !ok {
+        return
+    }
+
+    note, err := h.service.GetNote(candidateID, noteID, security.CurrentUser(c))
+    if err != nil {
+        respondNoteError(c, err)
+        return
+    }
+    c.JSON(http.StatusOK, note)
+}
+
+// EditNote godoc
+// @Summary Editar una nota
+// @Description Solo el autor puede editar la nota. La versión anterior queda en el historial de ediciones.
+// @Tags Notes
+// @Accept  json
+// @Produce  json
+// @Param  id path int true "ID del Candidato"
+// @Param  noteId path int true "ID de la Nota"
+// @Param note body domain.NoteRequest true "Contenido y visibilidad (private o team)"
+// @Success 200 {object} domain.Note
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "No es el autor"
+// @Failure 404 {object} map[string]interface{} "Nota no encontrada"
+// @Router /candidates/{id}/notes/{noteId} [put]
+// @Security Bearer
+func (h *NoteHandler) EditNote(c *gin.Context) {
+    candidateID, noteID, ok := noteIDs(c)
+    if !ok {
+        return
+    }
+
+    var req domain.NoteRequest
+    if err := c.ShouldBindJSON(&req); err != nil {
+        c.JSON(http.StatusBadRequest, gin.H{"error": "JSON no valid"})
+        return
+    }
+
+    note, err := h.service.EditNote(candidateID, noteID, req, security.CurrentUser(c))
+    if err != nil {
+        respondNoteError(c, err)
+        return
+    }
+    c.JSON(http.StatusOK, note)
+}
+
+// DeleteNote godoc
+// @Summary Borrar una nota
+// @Description Solo el autor puede borrar la nota. Se conserva marcada como borrada.
+// @Tags Notes
+// @Accept  json
+// @Produce  json
+// @Param  id path int true "ID del Candidato"
+// @Param  noteId path int true "ID de la Nota"
+// @Success 200 {object} map[string]interface{} "ok"
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "No es el autor"
+// @Failure 404 {object} map[string]interface{} "Nota no encontrada"
+// @Router /candidates/{id}/notes/{noteId} [delete]
+// @Security Bearer
+func (h *NoteHandler) DeleteNote(c *gin.Context) {
+    candidateID, noteID, ok := noteIDs(c)
+    if !ok {
+        return
+    }
+
+    if err := h.service.DeleteNote(candidateID, noteID, security.CurrentUser(c)); err != nil {
+        respondNoteError(c, err)
+        return
+    }
+    c.JSON(http.StatusOK, gin.H{"message": "Note deleted"})
+}
+
+// PinNote godoc
+// @Summary Fijar una nota
+// @Description Fija la nota al inicio de la lista del candidato
+// @Tags Notes
+// @Accept  json
+// @Produce  json
+// @Param  id path int true "ID del Candidato"
+// @Param  noteId path int true "ID de la Nota"
+// @Success 200 {object} domain.Note
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "Nota no encontrada"
+// @Router /candidates/{id}/notes/{noteId}/pin [post]
+// @Security Bearer
+func (h *NoteHandler) PinNote(c *gin.Context) {
+    h.setPinned(c, true)
+}
+
+// UnpinNote godoc
+// @Summary Desfijar una nota
+// @Description La nota vuelve a su lugar por fecha
+// @Tags Notes
+// @Accept  json
+// @Produce  json
+// @Param  id path int true "ID del Candidato"
+// @Param  noteId path int true "ID de la Nota"
+// @Success 200 {object} domain.Note
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "Nota no encontrada"
+// @Router /candidates/{id}/notes/{noteId}/pin [delete]
+// @Security Bearer
+func (h *NoteHandler) UnpinNote(c *gin.Context) {
+    h.setPinned(c, false)
+}
+
+func (h *NoteHandler) setPinned(c *gin.Context, pinned bool) {
+    candidateID, noteID, ok := noteIDs(c)
+    if !ok {
+        return
+    }
+
+    note, err := h.service.PinNote(candidateID, noteID, pinned, security.CurrentUser(c))
+    if err != nil {
+        respondNoteError(c, err)
+        return
+    }
+    c.JSON(http.StatusOK, note)
+}
+
+// ListRevisions godoc
+// @Summary Historial de ediciones de una nota
+// @Description Retorna las versiones anteriores de la nota, de la más antigua a la más reciente
+// @Tags Notes
+// @Accept  json
+// @Produce  json
+// @Param  id path int true "ID del Candidato"
+// @Param  noteId path int true "ID de la Nota"
+// @Success 200 {array} domain.NoteRevision
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "Nota no encontrada"
+// @Router /candidates/{id}/notes/{noteId}/revisions [get]
+// @Security Bearer
+func (h *NoteHandler) ListRevisions(c *gin.Context) {
+    candidateID, noteID, ok := noteIDs(c)
+    if !ok {
+        return
+    }
+
+    revisions, err := h.service.ListRevisions(candidateID, noteID, security.CurrentUser(c))
+    if err != nil {
+        respondNoteError(c, err)
+        return
+    }
+    c.JSON(http.StatusOK, revisions)
+}
+
+func respondNoteError(c *gin.Context, err error) {
+    switch {
+    case errors.Is(err, service.ErrInvalidNote):
+        c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+    case errors.Is(err, service.ErrIdentityRequired):
+        c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+    case errors.Is(err, service.ErrNotNoteAuthor):
+        c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+    case errors.Is(err, service.ErrNoteNotFound), errors.Is(err, service.ErrCandidateNotFound):
+        c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+    default:
+        c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+    }
+}
@@ -0,0 +1,80 @@
+package handler
+
+import (
+    "errors"
+    "net/http"
+    "strconv"
+
+    "github.com/gin-gonic/gin"
+    "github.com/torvictorvic/seek-v2/internal/domain"
+    "github.com/torvictorvic/seek-v2/internal/security"
+    "github.com/torvictorvic/seek-v2/internal/service"
+)
+
+type NotificationHandler struct {
+    service service.NotificationService
+}
+
+func NewNotificationHandler(s service.NotificationService) *NotificationHandler {
+    return &NotificationHandler{service: s}
+}
+
+// ListNotifications godoc
+// @Summary Notificaciones del usuario
+// @Description Retorna las notificaciones del usuario del token, de la más reciente a la más antigua
+// @Tags Notifications
+// @Accept  json
+// @Produce  json
+// @Param unread query bool false "Solo las no leídas"
+// @Success 200 {array} domain.Notification
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /notifications [get]
+// @Security Bearer
+func (h *NotificationHandler) ListNotifications(c *gin.Context) {
+    unread, _ := strconv.ParseBool(c.Query("unread"))
+
+    notifications, err := h.service.ListNotifications(security.CurrentUser(c), unread)
+    if err != nil {
+        if errors.Is(err, service.ErrIdentityRequired) {
+            c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+            return
+        }
+        c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+        return
+    }
+    if notifications == nil {
+        notifications = []domain.Notification{}
+    }
+    c.JSON(http.StatusOK, notifications)
+}
+
+// MarkRead godoc
+// @Summary Marcar una notificación como leída
+// @Description Marca como leída una notificación del usuario del token
+// @Tags Notifications
+// @Accept  json
+// @Produce  json
+// @Param  id path int true "ID de la Notificación"
+// @Success 200 {object} map[string]interface{} "ok"
+// @Failure 400 {object} map[string]interface{} "Bad Request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "Notificación no encontrada"
+// @Router /notifications/{id}/read [post]
+// @Security Bearer
+func (h *NotificationHandler) MarkRead(c *gin.Context) {
+    id, err := strconv.Atoi(c.Param("id"))
+    if err != nil {
+        c.JSON(http.StatusBadRequest, gin.H{"error": "The ID must be an integer"})
+        return
+    }
+
+    if err := h.service.MarkRead(id, security.CurrentUser(c)); err != nil {
+        if errors.Is(err, service.ErrNotificationNotFound) {
+            c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+            return
+        }
+        c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+        return
+    }
+    c.JSON(http.StatusOK, gin.H{"message": "Notification read"})
+}
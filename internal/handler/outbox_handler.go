@@ -0,0 +1,40 @@
+package handler
+
+import (
+    "net/http"
+
+    "github.com/gin-gonic/gin"
+    "github.com/torvictorvic/seek-v2/internal/domain"
+)
+
+// OutboxStatsSource reports the progress of the outbox relay
+type OutboxStatsSource interface {
+    Stats() (domain.OutboxStats, error)
+}
+
+type OutboxHandler struct {
+    relay OutboxStatsSource
+}
+
+func NewOutboxHandler(relay OutboxStatsSource) *OutboxHandler {
+    return &OutboxHandler{relay: relay}
+}
+
+// GetStats godoc
+// @Summary Estado del outbox de eventos
+// @Description Retorna los eventos pendientes de publicar, el retraso del más antiguo y los contadores del relay
+// @Tags Outbox
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} domain.OutboxStats
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /outbox/stats [get]
+// @Security Bearer
+func (h *OutboxHandler) GetStats(c *gin.Context) {
+    stats, err := h.relay.Stats()
+    if err != nil {
+        c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+        return
+    }
+    c.JSON(http.StatusOK, stats)
+}
@@ -0,0 +1,164 @@
+// Package ical writes iCalendar (RFC 5545) documents for the scheduled interviews
+package ical
+
+import (
+    "bufio"
+    "io"
+    "strconv"
+    "strings"
+    "time"
+)
+
+// Methods of the calendar object (RFC 5546)
+const (
+    MethodPublish = "PUBLISH"
+    MethodRequest = "REQUEST"
+)
+
+// ContentType is the media type of the documents written by this package
+const ContentType = "text/calendar; charset=utf-8"
+
+const prodID = "-//seek-v2//Candidates//EN"
+
+// Attendee is a participant of the event. Email is optional, without it the
+// attendee is only listed in the description.
+type Attendee struct {
+    Name  string
+    Email string
+}
+
+// Event is a VEVENT. Times are written in UTC so no VTIMEZONE is needed.
+type Event struct {
+    UID         string
+    Sequence    int
+    Start       time.Time
+    End         time.Time
+    Stamp       time.Time
+    Summary     string
+    Description string
+    Location    string
+    URL         string
+    Organizer   string // email
+    Attendees   []Attendee
+}
+
+// Calendar is a VCALENDAR with its events
+type Calendar struct {
+    Name   string
+    Method string
+    Events []Event
+}
+
+// Write writes the calendar with CRLF line endings and lines folded at 75 octets
+func Write(w io.Writer, cal Calendar) error {
+    bw := bufio.NewWriter(w)
+    lw := &lineWriter{w: bw}
+
+    lw.line("BEGIN:VCALENDAR")
+    lw.line("VERSION:2.0")
+    lw.line("PRODID:" + prodID)
+    lw.line("CALSCALE:GREGORIAN")
+    if cal.Method != "" {
+        lw.line("METHOD:" + cal.Method)
+    }
+    if cal.Name != "" {
+        lw.line("X-WR-CALNAME:" + Escape(cal.Name))
+    }
+    for _, e := range cal.Events {
+        writeEvent(lw, e)
+    }
+    lw.line("END:VCALENDAR")
+
+    if lw.err != nil {
+        return lw.err
+    }
+    return bw.Flush()
+}
+
+func writeEvent(lw *lineWriter, e Event) {
+    lw.line("BEGIN:VEVENT")
+    lw.line("UID:" + e.UID)
+    lw.line("SEQUENCE:" + strconv.Itoa(e.Sequence))
+    lw.line("DTSTAMP:" + FormatTime(e.Stamp))
+    lw.line("DTSTART:" + FormatTime(e.Start))
+    lw.line("DTEND:" + FormatTime(e.End))
+    lw.line("SUMMARY:" + Escape(e.Summary))
+    if e.Description != "" {
+        lw.line("DESCRIPTION:" + Escape(e.Description))
+    }
+    if e.Location != "" {
+        lw.line("LOCATION:" + Escape(e.Location))
+    }
+    if e.URL != "" {
+        lw.line("URL:" + e.URL)
+    }
+    if e.Organizer != "" {
+        lw.line("ORGANIZER:mailto:" + e.Organizer)
+    }
+    for _, a := range e.Attendees {
+        if a.Email == "" {
+            continue
+        }
+        prop := "ATTENDEE;ROLE=REQ-PARTICIPANT;PARTSTAT=NEEDS-ACTION;RSVP=TRUE"
+        if a.Name != "" {
+            prop += ";CN=" + quoteParam(a.Name)
+        }
+        lw.line(prop + ":mailto:" + a.Email)
+    }
+    lw.line("STATUS:CONFIRMED")
+    lw.line("END:VEVENT")
+}
+
+// FormatTime returns the time in the UTC "form #2" of RFC 5545, e.g. 20261019T150000Z
+func FormatTime(t time.Time) string {
+    return t.UTC().Format("20060102T150405Z")
+}
+
+// Escape escapes a TEXT value (RFC 5545 section 3.3.11)
+func Escape(s string) string {
+    return textEscaper.Replace(s)
+}
+
+var textEscaper = strings.NewReplacer(`\`, `\\`, `;`, `\;`, `,`, `\,`, "\r\n", `\n`, "\n", `\n`, "\r", `\n`)
+
+// quoteParam quotes a parameter value, which cannot contain double quotes
+func quoteParam(s string) string {
+    s = strings.NewReplacer(`"`, "'", "\r", " ", "\n", " ").Replace(s)
+    if strings.ContainsAny(s, ":;,") {
+        return `"` + s + `"`
+    }
+    return s
+}
+
+// lineWriter folds content lines longer than 75 octets without splitting UTF-8 sequences
+type lineWriter struct {
+    w   *bufio.Writer
+    err error
+}
+
+const maxLineOctets = 75
+
+func (lw *lineWriter) line(s string) {
+    if lw.err != nil {
+        return
+    }
+    limit := maxLineOctets
+    for len(s) > limit {
+        cut := limit
+        // Back off to the start of a UTF-8 sequence
+        for cut > 0 && s[cut]&0xC0 == 0x80 {
+            cut--
+        }
+        lw.write(s[:cut] + "\r\n ")
+        s = s[cut:]
+        // Continuation lines start with a space, which counts as one octet
+        limit = maxLineOctets - 1
+    }
+    lw.write(s + "\r\n")
+}
+
+func (lw *lineWriter) write(s string) {
+    if lw.err == nil {
+        _, lw.err = lw.w.WriteString(s)
+    }
+}
@@ -0,0 +1,32 @@
+package importer
+
+import (
+    "encoding/csv"
+    "io"
+)
+
+type csvReader struct {
+    r *csv.Reader
+}
+
+// NewCSVReader reads a CSV file as a stream. The delimiter defaults to a comma when 0.
+func NewCSVReader(r io.Reader, delimiter rune) RowReader {
+    cr := csv.NewReader(r)
+    if delimiter != 0 {
+        cr.Comma = delimiter
+    }
+    cr.FieldsPerRecord = -1
+    cr.TrimLeadingSpace = true
+    cr.ReuseRecord = true
+    return &csvReader{r: cr}
+}
+
+func (c *csvReader) Read() ([]string, error) {
+    return c.r.Read()
+}
+
+// Line returns the line of the last record, blank lines are skipped by the CSV reader
+func (c *csvReader) Line() int {
+    line, _ := c.r.FieldPos(0)
+    return line
+}
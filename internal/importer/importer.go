@@ -0,0 +1,218 @@
+package importer
+
+import (
+    "errors"
+    "fmt"
+    "io"
+    "strconv"
+    "strings"
+
+    "github.com/torvictorvic/seek-v2/internal/domain"
+    "github.com/torvictorvic/seek-v2/internal/service"
+)
+
+// Supported formats
+const (
+    FormatCSV  = "csv"
+    FormatXLSX = "xlsx"
+)
+
+// ErrUnknownColumn is returned when the mapping references a column missing from the header
+var ErrUnknownColumn = errors.New("Column not found in header")
+
+// RowReader reads a tabular file one row at a time. The first row is the header.
+type RowReader interface {
+    Read() ([]string, error)
+}
+
+// lineReader is implemented by the readers that know the position of the last row in the file
+type lineReader interface {
+    Line() int
+}
+
+// Mapping maps a candidate field (name, email, gender, salary_expected) to a column header
+type Mapping map[string]string
+
+// DefaultMapping expects the headers to be named after the candidate fields
+func DefaultMapping() Mapping {
+    return Mapping{
+        "name":            "name",
+        "email":           "email",
+        "gender":          "gender",
+        "salary_expected": "salary_expected",
+    }
+}
+
+// Options controls how the rows are written
+type Options struct {
+    Mapping Mapping
+    DryRun  bool
+    Upsert  bool // update the candidate with the same email instead of failing
+}
+
+// Importer turns the rows of a file into candidates using the candidate service
+type Importer struct {
+    service service.CandidateService
+}
+
+func NewImporter(s service.CandidateService) *Importer {
+    return &Importer{service: s}
+}
+
+// Import processes the rows as they are read, without loading the whole file in memory
+func (imp *Importer) Import(r RowReader, opts Options) (*domain.ImportReport, error) {
+    report := &domain.ImportReport{DryRun: opts.DryRun, Errors: []domain.ImportRowError{}}
+
+    header, err := r.Read()
+    if err == io.EOF {
+        return report, nil
+    } else if err != nil {
+        return nil, fmt.Errorf("Error reading header: %w", err)
+    }
+
+    mapping := opts.Mapping
+    if len(mapping) == 0 {
+        mapping = DefaultMapping()
+    }
+    columns, err := resolveColumns(header, mapping)
+    if err != nil {
+        return nil, err
+    }
+
+    // The header is row 1
+    for row := 2; ; row++ {
+        record, err := r.Read()
+        if err == io.EOF {
+            break
+        } else if err != nil {
+            return nil, fmt.Errorf("Error reading row %d: %w", row, err)
+        }
+        if lr, ok := r.(lineReader); ok {
+            row = lr.Line()
+        }
+        if isBlank(record) {
+            continue
+        }
+        report.Rows++
+
+        candidate, err := toCandidate(record, columns)
+        if err == nil {
+            err = imp.write(candidate, opts, report)
+        }
+        if err != nil {
+            report.Failed++
+            report.Errors = append(report.Errors, domain.ImportRowError{Row: row, Error: err.Error()})
+        }
+    }
+    return report, nil
+}
+
+func (imp *Importer) write(candidate domain.Candidate, opts Options, report *domain.ImportReport) error {
+    if err := imp.service.ValidateCandidate(candidate); err != nil {
+        return err
+    }
+
+    if opts.DryRun {
+        existing, err := imp.service.GetCandidateByEmail(candidate.Email)
+        if err != nil {
+            return err
+        }
+        switch {
+        case existing == nil:
+            report.Created++
+        case opts.Upsert:
+            report.Updated++
+        default:
+            return fmt.Errorf("A candidate with email '%s' already exists", candidate.Email)
+        }
+        return nil
+    }
+
+    if opts.Upsert {
+        _, created, err := imp.service.UpsertCandidate(candidate)
+        if err != nil {
+            return err
+        }
+        if created {
+            report.Created++
+        } else {
+            report.Updated++
+        }
+        return nil
+    }
+
+    if _, err := imp.service.CreateCandidate(candidate); err != nil {
+        return err
+    }
+    report.Created++
+    return nil
+}
+
+// resolveColumns returns the index of every mapped field in the header (case insensitive)
+func resolveColumns(header []string, mapping Mapping) (map[string]int, error) {
+    index := make(map[string]int, len(header))
+    for i, h := range header {
+        // Excel adds a BOM to the CSV files it saves as UTF-8
+        h = strings.TrimPrefix(h, "\ufeff")
+        index[strings.ToLower(strings.TrimSpace(h))] = i
+    }
+
+    columns := make(map[string]int, len(mapping))
+    for field, column := range mapping {
+        i, ok := index[strings.ToLower(strings.TrimSpace(column))]
+        if !ok {
+            return nil, fmt.Errorf("%w: '%s' (field '%s')", ErrUnknownColumn, column, field)
+        }
+        columns[field] = i
+    }
+    return columns, nil
+}
+
+func toCandidate(record []string, columns map[string]int) (domain.Candidate, error) {
+    value := func(field string) string {
+        i, ok := columns[field]
+        if !ok || i >= len(record) {
+            return ""
+        }
+        return strings.TrimSpace(record[i])
+    }
+
+    c := domain.Candidate{
+        Name:   value("name"),
+        Email:  value("email"),
+        Gender: value("gender"),
+    }
+    if salary := value("salary_expected"); salary != "" {
+        v, err := strconv.ParseFloat(salary, 64)
+        if err != nil {
+            return c, fmt.Errorf("The field 'salary_expected' must be a number: '%s'", salary)
+        }
+        c.SalaryExpected = v
+    }
+    return c, nil
+}
+
+func isBlank(record []string) bool {
+    for _, v := range record {
+        if strings.TrimSpace(v) != "" {
+            return false
+        }
+    }
+    return true
+}
+
+// ParseMapping parses a mapping written as "field=Column,field=Column"
+func ParseMapping(s string) (Mapping, error) {
+    mapping := Mapping{}
+    for _, pair := range strings.Split(s, ",") {
+        if strings.TrimSpace(pair) == "" {
+            continue
+        }
+        field, column, ok := strings.Cut(pair, "=")
+        if !ok {
+            return nil, fmt.Errorf("Invalid mapping '%s', expected field=Column", pair)
+        }
+        mapping[strings.TrimSpace(field)] = strings.TrimSpace(column)
+    }
+    return mapping, nil
+}
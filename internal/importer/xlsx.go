@@ -0,0 +1,256 @@
+package importer
+
+import (
+    "archive/zip"
+    "encoding/xml"
+    "fmt"
+    "io"
+    "path"
+    "strconv"
+    "strings"
+)
+
+// xlsxReader streams the rows of a worksheet. Only the shared strings table is kept in
+// memory, the sheet itself is decoded token by token.
+type xlsxReader struct {
+    sheet   io.ReadCloser
+    decoder *xml.Decoder
+    strings []string
+    line    int
+}
+
+// NewXLSXReader opens the worksheet with the given name, or the first one when empty.
+// XLSX files are zip archives, so they need random access to the file.
+func NewXLSXReader(r io.ReaderAt, size int64, sheetName string) (RowReader, error) {
+    zr, err := zip.NewReader(r, size)
+    if err != nil {
+        return nil, fmt.Errorf("Error opening XLSX file: %w", err)
+    }
+    files := make(map[string]*zip.File, len(zr.File))
+    for _, f := range zr.File {
+        files[f.Name] = f
+    }
+
+    sheetPath, err := findSheet(files, sheetName)
+    if err != nil {
+        return nil, err
+    }
+
+    shared, err := readSharedStrings(files["xl/sharedStrings.xml"])
+    if err != nil {
+        return nil, err
+    }
+
+    sheet, err := files[sheetPath].Open()
+    if err != nil {
+        return nil, fmt.Errorf("Error opening sheet: %w", err)
+    }
+    return &xlsxReader{sheet: sheet, decoder: xml.NewDecoder(sheet), strings: shared}, nil
+}
+
+func (x *xlsxReader) Read() ([]string, error) {
+    for {
+        tok, err := x.decoder.Token()
+        if err == io.EOF {
+            x.sheet.Close()
+            return nil, io.EOF
+        } else if err != nil {
+            return nil, fmt.Errorf("Error reading sheet: %w", err)
+        }
+        if se, ok := tok.(xml.StartElement); ok && se.Name.Local == "row" {
+            // Empty rows are omitted from the file, the r attribute tells the row number
+            x.line++
+            for _, attr := range se.Attr {
+                if attr.Name.Local == "r" {
+                    if n, err := strconv.Atoi(attr.Value); err == nil {
+                        x.line = n
+                    }
+                }
+            }
+            return x.readRow()
+        }
+    }
+}
+
+type xlsxCell struct {
+    Ref    string `xml:"r,attr"`
+    Type   string `xml:"t,attr"`
+    Value  string `xml:"v"`
+    Inline struct {
+        Text string `xml:"t"`
+        Runs []struct {
+            Text string `xml:"t"`
+        } `xml:"r"`
+    } `xml:"is"`
+}
+
+// Line returns the number of the last row read
+func (x *xlsxReader) Line() int {
+    return x.line
+}
+
+func (x *xlsxReader) readRow() ([]string, error) {
+    var record []string
+    for {
+        tok, err := x.decoder.Token()
+        if err != nil {
+            return nil, fmt.Errorf("Error reading row: %w", err)
+        }
+        switch t := tok.(type) {
+        case xml.StartElement:
+            if t.Name.Local != "c" {
+                continue
+            }
+            var cell xlsxCell
+            if err := x.decoder.DecodeElement(&cell, &t); err != nil {
+                return nil, fmt.Errorf("Error reading cell: %w", err)
+            }
+            // Empty cells are omitted from the file, the reference tells the column
+            col := len(record)
+            if cell.Ref != "" {
+                col = columnIndex(cell.Ref)
+            }
+            for len(record) < col {
+                record = append(record, "")
+            }
+            record = append(record, x.cellValue(cell))
+        case xml.EndElement:
+            if t.Name.Local == "row" {
+                return record, nil
+            }
+        }
+    }
+}
+
+func (x *xlsxReader) cellValue(cell xlsxCell) string {
+    switch cell.Type {
+    case "s":
+        i, err := strconv.Atoi(cell.Value)
+        if err != nil || i < 0 || i >= len(x.strings) {
+            return ""
+        }
+        return x.strings[i]
+    case "inlineStr":
+        if len(cell.Inline.Runs) > 0 {
+            var sb strings.Builder
+            for _, r := range cell.Inline.Runs {
+                sb.WriteString(r.Text)
+            }
+            return sb.String()
+        }
+        return cell.Inline.Text
+    default:
+        return cell.Value
+    }
+}
+
+// columnIndex converts a cell reference such as "AB12" to a zero-based column index
+func columnIndex(ref string) int {
+    col := 0
+    for _, r := range ref {
+        if r < 'A' || r > 'Z' {
+            break
+        }
+        col = col*26 + int(r-'A'+1)
+    }
+    return col - 1
+}
+
+func findSheet(files map[string]*zip.File, name string) (string, error) {
+    var workbook struct {
+        Sheets []struct {
+            Name string `xml:"name,attr"`
+            RID  string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+        } `xml:"sheets>sheet"`
+    }
+    if err := decodeZipXML(files["xl/workbook.xml"], &workbook); err != nil {
+        return "", err
+    }
+
+    var rels struct {
+        Relationships []struct {
+            ID     string `xml:"Id,attr"`
+            Target string `xml:"Target,attr"`
+        } `xml:"Relationship"`
+    }
+    if err := decodeZipXML(files["xl/_rels/workbook.xml.rels"], &rels); err != nil {
+        return "", err
+    }
+
+    for _, s := range workbook.Sheets {
+        if name != "" && !strings.EqualFold(s.Name, name) {
+            continue
+        }
+        for _, rel := range rels.Relationships {
+            if rel.ID != s.RID {
+                continue
+            }
+            target := strings.TrimPrefix(rel.Target, "/")
+            if !strings.HasPrefix(target, "xl/") {
+                target = path.Join("xl", target)
+            }
+            if _, ok := files[target]; ok {
+                return target, nil
+            }
+        }
+    }
+    if name != "" {
+        return "", fmt.Errorf("Sheet '%s' not found", name)
+    }
+    return "", fmt.Errorf("The XLSX file has no sheets")
+}
+
+func readSharedStrings(f *zip.File) ([]string, error) {
+    if f == nil {
+        return nil, nil
+    }
+    rc, err := f.Open()
+    if err != nil {
+        return nil, fmt.Errorf("Error opening shared strings: %w", err)
+    }
+    defer rc.Close()
+
+    var shared []string
+    decoder := xml.NewDecoder(rc)
+    for {
+        tok, err := decoder.Token()
+        if err == io.EOF {
+            return shared, nil
+        } else if err != nil {
+            return nil, fmt.Errorf("Error reading shared strings: %w", err)
+        }
+        se, ok := tok.(xml.StartElement)
+        if !ok || se.Name.Local != "si" {
+            continue
+        }
+        var si struct {
+            Text string `xml:"t"`
+            Runs []struct {
+                Text string `xml:"t"`
+            } `xml:"r"`
+        }
+        if err := decoder.DecodeElement(&si, &se); err != nil {
+            return nil, fmt.Errorf("Error reading shared strings: %w", err)
+        }
+        text := si.Text
+        for _, r := range si.Runs {
+            text += r.Text
+        }
+        shared = append(shared, text)
+    }
+}
+
+func decodeZipXML(f *zip.File, v interface{}) error {
+    if f == nil {
+        return fmt.Errorf("Invalid XLSX file: missing workbook")
+    }
+    rc, err := f.Open()
+    if err != nil {
+        return fmt.Errorf("Error opening %s: %w", f.Name, err)
+    }
+    defer rc.Close()
+    if err := xml.NewDecoder(rc).Decode(v); err != nil {
+        return fmt.Errorf("Error reading %s: %w", f.Name, err)
+    }
+    return nil
+}
@@ -0,0 +1,82 @@
+package outbox
+
+import (
+    "context"
+    "sync"
+
+    "github.com/torvictorvic/seek-v2/internal/domain"
+)
+
+// Broker is the part of a message broker client the relay needs, small enough to wrap a
+// NATS JetStream or Kafka producer. The message ID lets the broker discard duplicates
+// (Nats-Msg-Id in JetStream, the record key with an idempotent Kafka producer).
+type Broker interface {
+    Publish(ctx context.Context, subject, msgID string, data []byte) error
+}
+
+type brokerSink struct {
+    broker Broker
+    prefix string
+}
+
+// NewBrokerSink publishes the events to the broker, on the subject (or topic) made of
+// the prefix and the event type, e.g. "seek.candidate.created"
+func NewBrokerSink(broker Broker, prefix string) Sink {
+    return &brokerSink{broker: broker, prefix: prefix}
+}
+
+func (s *brokerSink) Publish(ctx context.Context, event domain.OutboxEvent) error {
+    subject := event.Type
+    if s.prefix != "" {
+        subject = s.prefix + "." + subject
+    }
+    return s.broker.Publish(ctx, subject, event.IdempotencyKey, event.Payload)
+}
+
+// Message is a message received by the MemoryBroker
+type Message struct {
+    Subject string
+    ID      string
+    Data    []byte
+}
+
+// MemoryBroker is an in-memory Broker for tests and local runs. Like JetStream, it
+// discards the messages whose ID it has already received.
+type MemoryBroker struct {
+    mu       sync.Mutex
+    messages []Message
+    seen     map[string]bool
+    err      error
+}
+
+func NewMemoryBroker() *MemoryBroker {
+    return &MemoryBroker{seen: map[string]bool{}}
+}
+
+func (b *MemoryBroker) Publish(ctx context.Context, subject, msgID string, data []byte) error {
+    b.mu.Lock()
+    defer b.mu.Unlock()
+    if b.err != nil {
+        return b.err
+    }
+    if b.seen[msgID] {
+        return nil
+    }
+    b.seen[msgID] = true
+    b.messages = append(b.messages, Message{Subject: subject, ID: msgID, Data: append([]byte(nil), data...)})
+    return nil
+}
+
+// Messages returns the messages received, in order
+func (b *MemoryBroker) Messages() []Message {
+    b.mu.Lock()
+    defer b.mu.Unlock()
+    return append([]Message(nil), b.messages...)
+}
+
+// SetError makes the next publications fail with err, nil restores them
+func (b *MemoryBroker) SetError(err error) {
+    b.mu.Lock()
+    defer b.mu.Unlock()
+    b.err = err
+}
@@ -0,0 +1,174 @@
+package outbox
+
+import (
+    "context"
+    "log"
+    "sync"
+    "time"
+
+    "github.com/torvictorvic/seek-v2/internal/domain"
+    "github.com/torvictorvic/seek-v2/internal/repository"
+)
+
+// Defaults of the relay
+const (
+    DefaultBatchSize = 100
+    maxBackoff       = time.Minute
+    purgeInterval    = time.Hour
+)
+
+// Relay publishes the events of the outbox to a sink, in the order they were written.
+// An event is marked as published after the sink accepts it, so a crash in between
+// publishes it again (at-least-once). A failed event stops the batch and is retried,
+// the ones after it wait so the order is kept. Only one relay should run per database.
+type Relay struct {
+    repo      repository.OutboxRepository
+    sink      Sink
+    batchSize int
+    retention time.Duration
+    now       func() time.Time
+
+    mu        sync.Mutex
+    stats     domain.OutboxStats
+    lastPurge time.Time
+}
+
+// RelayOption customizes the relay
+type RelayOption func(*Relay)
+
+// WithBatchSize sets the maximum number of events read per poll
+func WithBatchSize(n int) RelayOption {
+    return func(r *Relay) {
+        if n > 0 {
+            r.batchSize = n
+        }
+    }
+}
+
+// WithRetention sets how long the published events are kept, 0 keeps them forever
+func WithRetention(d time.Duration) RelayOption {
+    return func(r *Relay) {
+        r.retention = d
+    }
+}
+
+// WithClock sets the clock of the relay, used by the tests
+func WithClock(now func() time.Time) RelayOption {
+    return func(r *Relay) {
+        r.now = now
+    }
+}
+
+func NewRelay(repo repository.OutboxRepository, sink Sink, opts ...RelayOption) *Relay {
+    r := &Relay{repo: repo, sink: sink, batchSize: DefaultBatchSize, now: time.Now}
+    for _, opt := range opts {
+        opt(r)
+    }
+    return r
+}
+
+// Run polls the outbox every interval until the context is done. After a failure it
+// waits twice as long each time, up to a minute.
+func (r *Relay) Run(ctx context.Context, interval time.Duration) {
+    wait := interval
+    for {
+        n, err := r.Tick(ctx)
+        switch {
+        case err != nil:
+            log.Printf("Error publishing outbox events: %v\n", err)
+            wait *= 2
+            if wait > maxBackoff {
+                wait = maxBackoff
+            }
+        case n == r.batchSize:
+            // There may be more events waiting
+            wait = 0
+        default:
+            wait = interval
+        }
+        r.purge()
+
+        select {
+        case <-ctx.Done():
+            return
+        case <-time.After(wait):
+        }
+    }
+}
+
+// Tick publishes one batch of pending events and returns how many were published
+func (r *Relay) Tick(ctx context.Context) (int, error) {
+    events, err := r.repo.Pending(r.batchSize)
+    if err != nil {
+        return 0, err
+    }
+    published := make([]int64, 0, len(events))
+    var publishErr error
+    for _, e := range events {
+        if err := r.sink.Publish(ctx, e); err != nil {
+            publishErr = err
+            r.fail(e, err)
+            break
+        }
+        published = append(published, e.ID)
+    }
+    if err := r.repo.MarkPublished(published); err != nil {
+        return 0, err
+    }
+    if len(published) > 0 {
+        r.mu.Lock()
+        now := r.now()
+        r.stats.Published += int64(len(published))
+        r.stats.LastPublishedAt = &now
+        if publishErr == nil {
+            r.stats.LastError = ""
+        }
+        r.mu.Unlock()
+    }
+    return len(published), publishErr
+}
+
+func (r *Relay) fail(e domain.OutboxEvent, err error) {
+    if markErr := r.repo.MarkFailed(e.ID, err.Error()); markErr != nil {
+        log.Printf("Error recording outbox failure: %v\n", markErr)
+    }
+    r.mu.Lock()
+    r.stats.Failures++
+    r.stats.LastError = err.Error()
+    r.mu.Unlock()
+}
+
+// purge removes the events published before the retention, at most once per hour
+func (r *Relay) purge() {
+    if r.retention <= 0 || r.now().Sub(r.lastPurge) < purgeInterval {
+        return
+    }
+    r.lastPurge = r.now()
+    n, err := r.repo.PurgePublished(r.now().Add(-r.retention))
+    if err != nil {
+        log.Printf("Error purging outbox events: %v\n", err)
+        return
+    }
+    if n > 0 {
+        log.Printf("Purged %d published outbox events\n", n)
+    }
+}
+
+// Stats returns the pending events, the lag and the counters of the relay
+func (r *Relay) Stats() (domain.OutboxStats, error) {
+    pending, oldest, err := r.repo.Lag()
+    if err != nil {
+        return domain.OutboxStats{}, err
+    }
+    r.mu.Lock()
+    stats := r.stats
+    r.mu.Unlock()
+    stats.Pending = pending
+    if oldest != nil {
+        stats.LagSeconds = r.now().Sub(*oldest).Seconds()
+        if stats.LagSeconds < 0 {
+            stats.LagSeconds = 0
+        }
+    }
+    return stats, nil
+}
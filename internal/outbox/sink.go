@@ -0,0 +1,93 @@
+package outbox
+
+import (
+    "bytes"
+    "context"
+    "encoding/json"
+    "fmt"
+    "io"
+    "log"
+    "net/http"
+
+    "github.com/torvictorvic/seek-v2/internal/domain"
+)
+
+// Sink receives the events published by the relay. An event may be published more than
+// once, sinks pass its idempotency key on so consumers can discard the repeated ones.
+type Sink interface {
+    Publish(ctx context.Context, event domain.OutboxEvent) error
+}
+
+type logSink struct {
+    logger *log.Logger
+}
+
+// NewLogSink writes the events to the logger, nil means the standard logger
+func NewLogSink(logger *log.Logger) Sink {
+    if logger == nil {
+        logger = log.Default()
+    }
+    return &logSink{logger: logger}
+}
+
+func (s *logSink) Publish(ctx context.Context, event domain.OutboxEvent) error {
+    s.logger.Printf("Event %d %s candidate=%d key=%s %s\n", event.ID, event.Type, event.AggregateID, event.IdempotencyKey, event.Payload)
+    return nil
+}
+
+type httpSink struct {
+    url    string
+    client *http.Client
+}
+
+// NewHTTPSink POSTs every event as JSON to the URL, with its idempotency key in the
+// Idempotency-Key header. Any answer other than 2xx is a failure and the event is retried.
+func NewHTTPSink(url string, client *http.Client) Sink {
+    if client == nil {
+        client = http.DefaultClient
+    }
+    return &httpSink{url: url, client: client}
+}
+
+func (s *httpSink) Publish(ctx context.Context, event domain.OutboxEvent) error {
+    body, err := json.Marshal(event)
+    if err != nil {
+        return err
+    }
+    req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+    if err != nil {
+        return err
+    }
+    req.Header.Set("Content-Type", "application/json")
+    req.Header.Set("Idempotency-Key", event.IdempotencyKey)
+    req.Header.Set("X-Event-Type", event.Type)
+    resp, err := s.client.Do(req)
+    if err != nil {
+        return fmt.Errorf("Error posting event %d: %w", event.ID, err)
+    }
+    defer resp.Body.Close()
+    io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+    if resp.StatusCode < 200 || resp.StatusCode > 299 {
+        return fmt.Errorf("Error posting event %d: status %d", event.ID, resp.StatusCode)
+    }
+    return nil
+}
+
+type multiSink struct {
+    sinks []Sink
+}
+
+// NewMultiSink publishes every event to all the sinks, in order. When one fails the event
+// is retried on all of them, so each sink must accept repeated events.
+func NewMultiSink(sinks ...Sink) Sink {
+    return &multiSink{sinks: sinks}
+}
+
+func (s *multiSink) Publish(ctx context.Context, event domain.OutboxEvent) error {
+    for _, sink := range s.sinks {
+        if err := sink.Publish(ctx, event); err != nil {
+            return err
+        }
+    }
+    return nil
+}
@@ -0,0 +1,79 @@
+package outbox
+
+import (
+    "context"
+    "log"
+    "time"
+
+    "github.com/torvictorvic/seek-v2/internal/repository"
+)
+
+// Tail follows the outbox with its own cursor and sends every new event to a sink, such
+// as the event stream hub of this process. Unlike the relay it does not mark the events
+// as published, so each instance runs its own tail and its clients see the events written
+// by any instance. It starts at the newest event, the older ones are not sent.
+type Tail struct {
+    repo      repository.OutboxRepository
+    sink      Sink
+    batchSize int
+    cursor    int64
+    started   bool
+}
+
+func NewTail(repo repository.OutboxRepository, sink Sink, batchSize int) *Tail {
+    if batchSize <= 0 {
+        batchSize = DefaultBatchSize
+    }
+    return &Tail{repo: repo, sink: sink, batchSize: batchSize}
+}
+
+// Run polls the outbox every interval until the context is done. After a failure it
+// waits twice as long each time, up to a minute.
+func (t *Tail) Run(ctx context.Context, interval time.Duration) {
+    wait := interval
+    for {
+        n, err := t.Tick(ctx)
+        switch {
+        case err != nil:
+            log.Printf("Error tailing outbox events: %v\n", err)
+            wait *= 2
+            if wait > maxBackoff {
+                wait = maxBackoff
+            }
+        case n == t.batchSize:
+            wait = 0
+        default:
+            wait = interval
+        }
+
+        select {
+        case <-ctx.Done():
+            return
+        case <-time.After(wait):
+        }
+    }
+}
+
+// Tick sends the events written since the previous one and returns how many were sent.
+// The first tick only places the cursor at the newest event.
+func (t *Tail) Tick(ctx context.Context) (int, error) {
+    if !t.started {
+        id, err := t.repo.LastID()
+        if err != nil {
+            return 0, err
+        }
+        t.cursor, t.started = id, true
+        return 0, nil
+    }
+    events, err := t.repo.After(t.cursor, t.batchSize)
+    if err != nil {
+        return 0, err
+    }
+    for i, e := range events {
+        if err := t.sink.Publish(ctx, e); err != nil {
+            return i, err
+        }
+        t.cursor = e.ID
+    }
+    return len(events), nil
+}
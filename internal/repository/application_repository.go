@@ -0,0 +1,168 @@
+package repository
+
+import (
+    "database/sql"
+    "errors"
+    "fmt"
+
+    "github.com/torvictorvic/seek-v2/internal/domain"
+)
+
+// ErrStageChanged is returned by MoveStage when the application is no longer in the expected stage
+var ErrStageChanged = errors.New("The application stage changed in the meantime")
+
+type ApplicationRepository interface {
+    Create(app domain.Application, transition domain.StageTransition) (int, error)
+    GetByID(id int) (*domain.Application, error)
+    GetByCandidateAndJob(candidateID, jobID int) (*domain.Application, error)
+    ListByCandidate(candidateID int) ([]domain.Application, error)
+    ListByJob(jobID int) ([]domain.Application, error)
+    MoveStage(app domain.Application, transition domain.StageTransition) error
+    ListTransitions(applicationID int) ([]domain.StageTransition, error)
+}
+
+type applicationRepositoryImpl struct {
+    db      *sql.DB
+    dialect Dialect
+}
+
+func NewApplicationRepository(db *sql.DB) ApplicationRepository {
+    return NewApplicationRepositoryFor(db, MySQL)
+}
+
+// NewApplicationRepositoryFor returns the repository for a database of the dialect
+func NewApplicationRepositoryFor(db *sql.DB, dialect Dialect) ApplicationRepository {
+    return &applicationRepositoryImpl{db: db, dialect: dialect}
+}
+
+// conn rebinds the queries run outside a transaction
+func (r *applicationRepositoryImpl) conn() dbConn {
+    return rebindConn{conn: r.db, dialect: r.dialect}
+}
+
+const applicationColumns = `id, candidate_id, job_id, stage, rejection_reason, created_at, updated_at`
+
+func insertTransition(db execer, t domain.StageTransition) error {
+    query := `INSERT INTO application_transitions (application_id, from_stage, to_stage, reason, actor) VALUES (?, ?, ?, ?, ?)`
+    if _, err := db.Exec(query, t.ApplicationID, t.FromStage, t.ToStage, t.Reason, t.Actor); err != nil {
+        return fmt.Errorf("Error adding stage transition: %w", err)
+    }
+    return nil
+}
+
+// Create saves the application together with the transition to its initial stage
+func (r *applicationRepositoryImpl) Create(app domain.Application, transition domain.StageTransition) (int, error) {
+    tx, err := r.db.Begin()
+    if err != nil {
+        return 0, fmt.Errorf("Error starting transaction: %w", err)
+    }
+    defer tx.Rollback()
+    exec := rebindConn{conn: tx, dialect: r.dialect}
+
+    query := `INSERT INTO applications (candidate_id, job_id, stage) VALUES (?, ?, ?)`
+    insertID, err := insertReturningID(exec, r.dialect, query, app.CandidateID, app.JobID, app.Stage)
+    if err != nil {
+        return 0, fmt.Errorf("Error creating application: %w", err)
+    }
+
+    transition.ApplicationID = insertID
+    if err := insertTransition(exec, transition); err != nil {
+        return 0, err
+    }
+    if err := tx.Commit(); err != nil {
+        return 0, fmt.Errorf("Error committing application: %w", err)
+    }
+    return int(insertID), nil
+}
+
+func (r *applicationRepositoryImpl) getOne(query string, args ...interface{}) (*domain.Application, error) {
+    var a domain.Application
+    err := r.conn().QueryRow(query, args...).Scan(&a.ID, &a.CandidateID, &a.JobID, &a.Stage, &a.RejectionReason, &a.CreatedAt, &a.UpdatedAt)
+    if err == sql.ErrNoRows {
+        return nil, nil
+    } else if err != nil {
+        return nil, fmt.Errorf("Error getting application: %w", err)
+    }
+    return &a, nil
+}
+
+func (r *applicationRepositoryImpl) GetByID(id int) (*domain.Application, error) {
+    return r.getOne(`SELECT `+applicationColumns+` FROM applications WHERE id = ?`, id)
+}
+
+func (r *applicationRepositoryImpl) GetByCandidateAndJob(candidateID, jobID int) (*domain.Application, error) {
+    return r.getOne(`SELECT `+applicationColumns+` FROM applications WHERE candidate_id = ? AND job_id = ?`, candidateID, jobID)
+}
+
+func (r *applicationRepositoryImpl) list(query string, arg int) ([]domain.Application, error) {
+    rows, err := r.conn().Query(query, arg)
+    if err != nil {
+        return nil, fmt.Errorf("Error getting application list: %w", err)
+    }
+    defer rows.Close()
+
+    apps := []domain.Application{}
+    for rows.Next() {
+        var a domain.Application
+        if err := rows.Scan(&a.ID, &a.CandidateID, &a.JobID, &a.Stage, &a.RejectionReason, &a.CreatedAt, &a.UpdatedAt); err != nil {
+            return nil, err
+        }
+        apps = append(apps, a)
+    }
+    return apps, rows.Err()
+}
+
+func (r *applicationRepositoryImpl) ListByCandidate(candidateID int) ([]domain.Application, error) {
+    return r.list(`SELECT `+applicationColumns+` FROM applications WHERE candidate_id = ? ORDER BY id`, candidateID)
+}
+
+func (r *applicationRepositoryImpl) ListByJob(jobID int) ([]domain.Application, error) {
+    return r.list(`SELECT `+applicationColumns+` FROM applications WHERE job_id = ? ORDER BY id`, jobID)
+}
+
+// MoveStage sets the new stage of the application and records the transition in a single
+// transaction. The update only applies if the application is still in transition.FromStage.
+func (r *applicationRepositoryImpl) MoveStage(app domain.Application, transition domain.StageTransition) error {
+    tx, err := r.db.Begin()
+    if err != nil {
+        return fmt.Errorf("Error starting transaction: %w", err)
+    }
+    defer tx.Rollback()
+    exec := rebindConn{conn: tx, dialect: r.dialect}
+
+    query := `UPDATE applications SET stage = ?, rejection_reason = ? WHERE id = ? AND stage = ?`
+    result, err := exec.Exec(query, app.Stage, app.RejectionReason, app.ID, transition.FromStage)
+    if err != nil {
+        return fmt.Errorf("Error updating application stage: %w", err)
+    }
+    if n, _ := result.RowsAffected(); n == 0 {
+        return ErrStageChanged
+    }
+    transition.ApplicationID = app.ID
+    if err := insertTransition(exec, transition); err != nil {
+        return err
+    }
+    if err := tx.Commit(); err != nil {
+        return fmt.Errorf("Error committing stage change: %w", err)
+    }
+    return nil
+}
+
+func (r *applicationRepositoryImpl) ListTransitions(applicationID int) ([]domain.StageTransition, error) {
+    query := `SELECT id, application_id, from_stage, to_stage, reason, actor, created_at FROM application_transitions WHERE application_id = ? ORDER BY created_at, id`
+    rows, err := r.conn().Query(query, applicationID)
+    if err != nil {
+        return nil, fmt.Errorf("Error getting stage transitions: %w", err)
+    }
+    defer rows.Close()
+
+    transitions := []domain.StageTransition{}
+    for rows.Next() {
+        var t domain.StageTransition
+        if err := rows.Scan(&t.ID, &t.ApplicationID, &t.FromStage, &t.ToStage, &t.Reason, &t.Actor, &t.CreatedAt); err != nil {
+            return nil, err
+        }
+        transitions = append(transitions, t)
+    }
+    return transitions, rows.Err()
+}
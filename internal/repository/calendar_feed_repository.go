@@ -0,0 +1,60 @@
+package repository
+
+import (
+    "database/sql"
+    "fmt"
+)
+
+// CalendarFeedRepository keeps the token of the personal iCal feed of each user.
+// Only a hash of the token is stored.
+type CalendarFeedRepository interface {
+    Save(user, tokenHash string) error
+    GetUser(tokenHash string) (string, error)
+    Delete(user string) error
+}
+
+type calendarFeedRepositoryImpl struct {
+    db      dbConn
+    dialect Dialect
+    user    string // quoted name of the user column, a reserved word in PostgreSQL
+}
+
+func NewCalendarFeedRepository(db *sql.DB) CalendarFeedRepository {
+    return NewCalendarFeedRepositoryFor(db, MySQL)
+}
+
+// NewCalendarFeedRepositoryFor returns the repository for a database of the dialect
+func NewCalendarFeedRepositoryFor(db *sql.DB, dialect Dialect) CalendarFeedRepository {
+    return &calendarFeedRepositoryImpl{db: rebindConn{conn: db, dialect: dialect}, dialect: dialect, user: dialect.quote("user")}
+}
+
+// Save sets the token of the user, replacing the previous one
+func (r *calendarFeedRepositoryImpl) Save(user, tokenHash string) error {
+    query := `INSERT INTO calendar_feeds (` + r.user + `, token_hash) VALUES (?, ?) ON DUPLICATE KEY UPDATE token_hash = VALUES(token_hash), created_at = CURRENT_TIMESTAMP`
+    if r.dialect.onConflict {
+        query = `INSERT INTO calendar_feeds (` + r.user + `, token_hash) VALUES (?, ?) ON CONFLICT (` + r.user + `) DO UPDATE SET token_hash = excluded.token_hash, created_at = CURRENT_TIMESTAMP`
+    }
+    if _, err := r.db.Exec(query, user, tokenHash); err != nil {
+        return fmt.Errorf("Error saving calendar feed: %w", err)
+    }
+    return nil
+}
+
+// GetUser returns the owner of the token, or "" if there is none
+func (r *calendarFeedRepositoryImpl) GetUser(tokenHash string) (string, error) {
+    var user string
+    err := r.db.QueryRow(`SELECT `+r.user+` FROM calendar_feeds WHERE token_hash = ?`, tokenHash).Scan(&user)
+    if err == sql.ErrNoRows {
+        return "", nil
+    } else if err != nil {
+        return "", fmt.Errorf("Error getting calendar feed: %w", err)
+    }
+    return user, nil
+}
+
+func (r *calendarFeedRepositoryImpl) Delete(user string) error {
+    if _, err := r.db.Exec(`DELETE FROM calendar_feeds WHERE `+r.user+` = ?`, user); err != nil {
+        return fmt.Errorf("Error deleting calendar feed: %w", err)
+    }
+    return nil
+}
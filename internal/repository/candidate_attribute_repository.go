@@ -0,0 +1,201 @@
+package repository
+
+import (
+    "context"
+    "database/sql"
+    "fmt"
+    "strconv"
+    "strings"
+
+    "github.com/torvictorvic/seek-v2/internal/domain"
+)
+
+// CandidateAttributeRepository stores the tags and custom field values of the candidates
+type CandidateAttributeRepository interface {
+    SaveTags(candidateID int, tags []string) error
+    SaveFieldValues(candidateID int, values []domain.FieldValue) error
+    Load(candidateIDs []int) (map[int]domain.CandidateAttributes, error)
+    ListTags() ([]domain.TagCount, error)
+}
+
+type candidateAttributeRepositoryImpl struct {
+    db      *sql.DB
+    dialect Dialect
+    tx      *txState // set when bound to a unit of work
+}
+
+func NewCandidateAttributeRepository(db *sql.DB) CandidateAttributeRepository {
+    return NewCandidateAttributeRepositoryFor(db, MySQL)
+}
+
+// NewCandidateAttributeRepositoryFor returns the repository for a database of the dialect
+func NewCandidateAttributeRepositoryFor(db *sql.DB, dialect Dialect) CandidateAttributeRepository {
+    return &candidateAttributeRepositoryImpl{db: db, dialect: dialect}
+}
+
+func (r *candidateAttributeRepositoryImpl) bind(ctx context.Context) interface{} {
+    return CandidateAttributeRepository(&candidateAttributeRepositoryImpl{db: r.db, dialect: r.dialect, tx: txFrom(ctx)})
+}
+
+func (r *candidateAttributeRepositoryImpl) conn() dbConn {
+    if r.tx != nil {
+        return rebindConn{conn: r.tx.tx, dialect: r.dialect}
+    }
+    return rebindConn{conn: r.db, dialect: r.dialect}
+}
+
+// SaveTags replaces the tags of the candidate
+func (r *candidateAttributeRepositoryImpl) SaveTags(candidateID int, tags []string) error {
+    tx, err := beginTx(r.db, r.tx)
+    if err != nil {
+        return fmt.Errorf("Error starting transaction: %w", err)
+    }
+    defer tx.Rollback()
+    exec := rebindConn{conn: tx, dialect: r.dialect}
+
+    if _, err := exec.Exec(`DELETE FROM candidate_tags WHERE candidate_id = ?`, candidateID); err != nil {
+        return fmt.Errorf("Error deleting tags: %w", err)
+    }
+    if len(tags) > 0 {
+        placeholders := make([]string, len(tags))
+        args := make([]interface{}, 0, len(tags)*2)
+        for i, tag := range tags {
+            placeholders[i] = "(?, ?)"
+            args = append(args, candidateID, tag)
+        }
+        query := `INSERT INTO candidate_tags (candidate_id, tag) VALUES ` + strings.Join(placeholders, ", ")
+        if _, err := exec.Exec(query, args...); err != nil {
+            return fmt.Errorf("Error saving tags: %w", err)
+        }
+    }
+    if err := tx.Commit(); err != nil {
+        return fmt.Errorf("Error committing tags: %w", err)
+    }
+    return nil
+}
+
+// SaveFieldValues replaces the custom field values of the candidate
+func (r *candidateAttributeRepositoryImpl) SaveFieldValues(candidateID int, values []domain.FieldValue) error {
+    tx, err := beginTx(r.db, r.tx)
+    if err != nil {
+        return fmt.Errorf("Error starting transaction: %w", err)
+    }
+    defer tx.Rollback()
+    exec := rebindConn{conn: tx, dialect: r.dialect}
+
+    if _, err := exec.Exec(`DELETE FROM candidate_field_values WHERE candidate_id = ?`, candidateID); err != nil {
+        return fmt.Errorf("Error deleting custom field values: %w", err)
+    }
+    if len(values) > 0 {
+        placeholders := make([]string, len(values))
+        args := make([]interface{}, 0, len(values)*5)
+        for i, v := range values {
+            placeholders[i] = "(?, ?, ?, ?, ?)"
+            var date interface{}
+            if v.Date != nil {
+                date = v.Date.Format(domain.DateLayout)
+            }
+            var number interface{}
+            if v.Number != nil {
+                number = *v.Number
+            }
+            args = append(args, candidateID, v.Key, v.Text, number, date)
+        }
+        query := `INSERT INTO candidate_field_values (candidate_id, field_key, value_text, value_number, value_date) VALUES ` +
+            strings.Join(placeholders, ", ")
+        if _, err := exec.Exec(query, args...); err != nil {
+            return fmt.Errorf("Error saving custom field values: %w", err)
+        }
+    }
+    if err := tx.Commit(); err != nil {
+        return fmt.Errorf("Error committing custom field values: %w", err)
+    }
+    return nil
+}
+
+// Load returns the tags and custom field values of the candidates, by candidate ID.
+// Candidates without any have no entry.
+func (r *candidateAttributeRepositoryImpl) Load(candidateIDs []int) (map[int]domain.CandidateAttributes, error) {
+    attrs := map[int]domain.CandidateAttributes{}
+    if len(candidateIDs) == 0 {
+        return attrs, nil
+    }
+    placeholders := make([]string, len(candidateIDs))
+    args := make([]interface{}, len(candidateIDs))
+    for i, id := range candidateIDs {
+        placeholders[i] = "?"
+        args[i] = id
+    }
+    in := `IN (` + strings.Join(placeholders, ", ") + `)`
+
+    rows, err := r.conn().Query(`SELECT candidate_id, tag FROM candidate_tags WHERE candidate_id `+in+` ORDER BY candidate_id, tag`, args...)
+    if err != nil {
+        return nil, fmt.Errorf("Error getting tags: %w", err)
+    }
+    defer rows.Close()
+    for rows.Next() {
+        var id int
+        var tag string
+        if err := rows.Scan(&id, &tag); err != nil {
+            return nil, err
+        }
+        a := attrs[id]
+        a.Tags = append(a.Tags, tag)
+        attrs[id] = a
+    }
+    if err := rows.Err(); err != nil {
+        return nil, err
+    }
+
+    // The type of the field tells how to read the value back
+    query := `SELECT v.candidate_id, v.field_key, f.type, v.value_text FROM candidate_field_values v
+        JOIN custom_fields f ON f.field_key = v.field_key
+        WHERE v.candidate_id ` + in + ` ORDER BY v.candidate_id, v.field_key, v.value_text`
+    valueRows, err := r.conn().Query(query, args...)
+    if err != nil {
+        return nil, fmt.Errorf("Error getting custom field values: %w", err)
+    }
+    defer valueRows.Close()
+    for valueRows.Next() {
+        var id int
+        var key, fieldType, text string
+        if err := valueRows.Scan(&id, &key, &fieldType, &text); err != nil {
+            return nil, err
+        }
+        a := attrs[id]
+        if a.CustomFields == nil {
+            a.CustomFields = map[string]interface{}{}
+        }
+        switch fieldType {
+        case domain.FieldNumber:
+            n, _ := strconv.ParseFloat(text, 64)
+            a.CustomFields[key] = n
+        case domain.FieldMultiSelect:
+            selected, _ := a.CustomFields[key].([]string)
+            a.CustomFields[key] = append(selected, text)
+        default:
+            a.CustomFields[key] = text
+        }
+        attrs[id] = a
+    }
+    return attrs, valueRows.Err()
+}
+
+// ListTags returns the tags in use with the number of candidates that have them
+func (r *candidateAttributeRepositoryImpl) ListTags() ([]domain.TagCount, error) {
+    rows, err := r.conn().Query(`SELECT tag, COUNT(*) FROM candidate_tags GROUP BY tag ORDER BY tag`)
+    if err != nil {
+        return nil, fmt.Errorf("Error getting tags: %w", err)
+    }
+    defer rows.Close()
+
+    tags := []domain.TagCount{}
+    for rows.Next() {
+        var t domain.TagCount
+        if err := rows.Scan(&t.Tag, &t.Candidates); err != nil {
+            return nil, err
+        }
+        tags = append(tags, t)
+    }
+    return tags, rows.Err()
+}
@@ -0,0 +1,341 @@
+package repository
+
+import (
+    "context"
+    "encoding/json"
+    "sort"
+    "strconv"
+    "strings"
+    "sync/atomic"
+    "time"
+
+    "golang.org/x/sync/singleflight"
+
+    "github.com/torvictorvic/seek-v2/internal/cache"
+    "github.com/torvictorvic/seek-v2/internal/domain"
+)
+
+// Cached operations of the candidate repository
+const (
+    CacheGetByID    = "get_by_id"
+    CacheGetByEmail = "get_by_email"
+    CacheGetByIDs   = "get_by_ids"
+)
+
+// DefaultCacheTTL is used for the operations with no TTL configured
+const DefaultCacheTTL = time.Minute
+
+// CacheOption customizes the candidate cache
+type CacheOption func(*candidateCache)
+
+// WithCacheTTL sets how long the results of the operation are kept, 0 disables its cache
+func WithCacheTTL(operation string, ttl time.Duration) CacheOption {
+    return func(c *candidateCache) {
+        if _, ok := c.ttl[operation]; ok && ttl >= 0 {
+            c.ttl[operation] = ttl
+        }
+    }
+}
+
+// CachedCandidateRepository reads the candidates by ID and by email through a cache.
+// A miss is loaded once for all the concurrent callers, from the repository it wraps
+// without any binding, so the cache is only filled from the primary and committed data.
+// The writes remove the candidates they touch; inside a unit of work they also do it
+// after the commit, and the reads skip the cache. The lists and other calls go straight
+// to the wrapped repository.
+//
+// A load that started before a write of the same process is not cached. The writes made
+// by other processes sharing the store are seen when they remove the keys, and the ones
+// made outside the repository (SQL scripts, the importer) when the TTL expires.
+type CachedCandidateRepository struct {
+    CandidateRepository // bound like the cache, see Bind
+    cache               *candidateCache
+    tx                  *txState
+}
+
+// candidateCache is shared by the repository and its bound copies
+type candidateCache struct {
+    store              cache.Store
+    primary            CandidateRepository
+    ttl                map[string]time.Duration
+    counters           map[string]*cacheCounters
+    flights            singleflight.Group
+    generation         atomic.Uint64 // incremented by every write
+    invalidations      atomic.Int64
+    invalidationErrors atomic.Int64 // failed removals, those candidates may be stale until their TTL
+}
+
+type cacheCounters struct {
+    hits, misses, shared, errors atomic.Int64
+}
+
+func NewCachedCandidateRepository(repo CandidateRepository, store cache.Store, opts ...CacheOption) *CachedCandidateRepository {
+    c := &candidateCache{store: store, primary: repo, ttl: map[string]time.Duration{}, counters: map[string]*cacheCounters{}}
+    for _, op := range []string{CacheGetByID, CacheGetByEmail, CacheGetByIDs} {
+        c.ttl[op] = DefaultCacheTTL
+        c.counters[op] = &cacheCounters{}
+    }
+    for _, opt := range opts {
+        opt(c)
+    }
+    return &CachedCandidateRepository{CandidateRepository: repo, cache: c}
+}
+
+func (r *CachedCandidateRepository) bind(ctx context.Context) interface{} {
+    bound := *r
+    bound.CandidateRepository = Bind(ctx, r.CandidateRepository)
+    bound.tx = txFrom(ctx)
+    return CandidateRepository(&bound)
+}
+
+// Stats returns the counters of every cached operation
+func (r *CachedCandidateRepository) Stats() domain.CacheStats {
+    stats := domain.CacheStats{
+        Operations:         map[string]domain.CacheOperationStats{},
+        Invalidations:      r.cache.invalidations.Load(),
+        InvalidationErrors: r.cache.invalidationErrors.Load(),
+    }
+    for op, c := range r.cache.counters {
+        s := domain.CacheOperationStats{
+            TTLSeconds: r.cache.ttl[op].Seconds(),
+            Hits:       c.hits.Load(),
+            Misses:     c.misses.Load(),
+            Shared:     c.shared.Load(),
+            Errors:     c.errors.Load(),
+        }
+        if total := s.Hits + s.Misses; total > 0 {
+            s.HitRatio = float64(s.Hits) / float64(total)
+        }
+        stats.Operations[op] = s
+    }
+    return stats
+}
+
+func candidateCacheKey(id int) string {
+    return "candidate:" + strconv.Itoa(id)
+}
+
+func candidateEmailCacheKey(email string) string {
+    return "candidate:email:" + strings.ToLower(email)
+}
+
+// cached tells whether the operation reads through the cache
+func (r *CachedCandidateRepository) cached(op string) bool {
+    return r.tx == nil && r.cache.ttl[op] > 0
+}
+
+func (r *CachedCandidateRepository) GetByID(id int) (*domain.Candidate, error) {
+    if !r.cached(CacheGetByID) {
+        return r.CandidateRepository.GetByID(id)
+    }
+    counters := r.cache.counters[CacheGetByID]
+    if c, ok := r.cache.get(counters, id); ok {
+        counters.hits.Add(1)
+        return &c, nil
+    }
+    counters.misses.Add(1)
+    candidates, err := r.cache.load(CacheGetByID, candidateCacheKey(id), func() ([]domain.Candidate, error) {
+        c, err := r.cache.primary.GetByID(id)
+        if err != nil || c == nil {
+            return nil, err
+        }
+        return []domain.Candidate{*c}, nil
+    })
+    if err != nil || len(candidates) == 0 {
+        return nil, err
+    }
+    return &candidates[0], nil
+}
+
+// GetByEmail keeps the ID of the email and reads the candidate under its ID, which is
+// checked to still have the email
+func (r *CachedCandidateRepository) GetByEmail(email string) (*domain.Candidate, error) {
+    if !r.cached(CacheGetByEmail) {
+        return r.CandidateRepository.GetByEmail(email)
+    }
+    counters := r.cache.counters[CacheGetByEmail]
+    value, ok, err := r.cache.store.Get(context.Background(), candidateEmailCacheKey(email))
+    if err != nil {
+        counters.errors.Add(1)
+    }
+    if id, convErr := strconv.Atoi(string(value)); ok && convErr == nil {
+        if c, ok := r.cache.get(counters, id); ok && strings.EqualFold(c.Email, email) {
+            counters.hits.Add(1)
+            return &c, nil
+        }
+    }
+    counters.misses.Add(1)
+    candidates, err := r.cache.load(CacheGetByEmail, candidateEmailCacheKey(email), func() ([]domain.Candidate, error) {
+        c, err := r.cache.primary.GetByEmail(email)
+        if err != nil || c == nil {
+            return nil, err
+        }
+        return []domain.Candidate{*c}, nil
+    })
+    if err != nil || len(candidates) == 0 {
+        return nil, err
+    }
+    return &candidates[0], nil
+}
+
+// GetByIDs reads every ID from the cache and loads the missing ones in a single query
+func (r *CachedCandidateRepository) GetByIDs(ids []int) ([]domain.Candidate, error) {
+    if !r.cached(CacheGetByIDs) {
+        return r.CandidateRepository.GetByIDs(ids)
+    }
+    counters := r.cache.counters[CacheGetByIDs]
+    var candidates []domain.Candidate
+    var missing []int
+    seen := map[int]bool{}
+    for _, id := range ids {
+        if seen[id] {
+            continue
+        }
+        seen[id] = true
+        if c, ok := r.cache.get(counters, id); ok {
+            counters.hits.Add(1)
+            candidates = append(candidates, c)
+        } else {
+            counters.misses.Add(1)
+            missing = append(missing, id)
+        }
+    }
+    if len(missing) > 0 {
+        sort.Ints(missing)
+        keys := make([]string, len(missing))
+        for i, id := range missing {
+            keys[i] = strconv.Itoa(id)
+        }
+        key := "candidates:" + strings.Join(keys, ",")
+        loaded, err := r.cache.load(CacheGetByIDs, key, func() ([]domain.Candidate, error) {
+            return r.cache.primary.GetByIDs(missing)
+        })
+        if err != nil {
+            return nil, err
+        }
+        candidates = append(candidates, loaded...)
+    }
+    sort.Slice(candidates, func(i, j int) bool { return candidates[i].ID < candidates[j].ID })
+    return candidates, nil
+}
+
+// get reads the candidate of the ID from the store, a failed call counts as a miss
+func (c *candidateCache) get(counters *cacheCounters, id int) (domain.Candidate, bool) {
+    var candidate domain.Candidate
+    value, ok, err := c.store.Get(context.Background(), candidateCacheKey(id))
+    if err != nil {
+        counters.errors.Add(1)
+        return candidate, false
+    }
+    if !ok || json.Unmarshal(value, &candidate) != nil {
+        return candidate, false
+    }
+    return candidate, true
+}
+
+// load runs fn once for the concurrent misses of the key and caches the candidates it
+// returns, unless a write happened meanwhile. The callers arriving after a write start
+// a new load, so they do not get what was read before it.
+func (c *candidateCache) load(op, key string, fn func() ([]domain.Candidate, error)) ([]domain.Candidate, error) {
+    generation := c.generation.Load()
+    loaded := false
+    v, err, _ := c.flights.Do(key+"@"+strconv.FormatUint(generation, 10), func() (interface{}, error) {
+        loaded = true
+        candidates, err := fn()
+        if err != nil {
+            return nil, err
+        }
+        for i := range candidates {
+            candidates[i] = candidateRow(candidates[i])
+        }
+        if c.generation.Load() == generation {
+            c.save(op, candidates)
+        }
+        return candidates, nil
+    })
+    if !loaded {
+        c.counters[op].shared.Add(1)
+    }
+    if err != nil {
+        return nil, err
+    }
+    // The callers get their own slice, the candidates only hold values
+    return append([]domain.Candidate(nil), v.([]domain.Candidate)...), nil
+}
+
+// save caches the candidates by ID and, for the email lookups, the ID by email
+func (c *candidateCache) save(op string, candidates []domain.Candidate) {
+    ctx := context.Background()
+    ttl := c.ttl[op]
+    for _, candidate := range candidates {
+        value, err := json.Marshal(candidate)
+        if err == nil {
+            err = c.store.Set(ctx, candidateCacheKey(candidate.ID), value, ttl)
+        }
+        if err == nil && op == CacheGetByEmail {
+            err = c.store.Set(ctx, candidateEmailCacheKey(candidate.Email), []byte(strconv.Itoa(candidate.ID)), ttl)
+        }
+        if err != nil {
+            c.counters[op].errors.Add(1)
+        }
+    }
+}
+
+// invalidate removes the candidates from the cache. The email keys are left, the
+// candidate they point to is checked on read.
+func (r *CachedCandidateRepository) invalidate(ids ...int) {
+    c := r.cache
+    remove := func() {
+        c.generation.Add(1)
+        keys := make([]string, len(ids))
+        for i, id := range ids {
+            keys[i] = candidateCacheKey(id)
+        }
+        c.invalidations.Add(int64(len(ids)))
+        if err := c.store.Delete(context.Background(), keys...); err != nil {
+            c.invalidationErrors.Add(1)
+        }
+    }
+    remove()
+    if r.tx != nil {
+        // A read before the commit would cache the previous data again
+        r.tx.afterCommit(remove)
+    }
+}
+
+func (r *CachedCandidateRepository) Update(candidate domain.Candidate) error {
+    defer r.invalidate(candidate.ID)
+    return r.CandidateRepository.Update(candidate)
+}
+
+func (r *CachedCandidateRepository) Delete(id int) error {
+    defer r.invalidate(id)
+    return r.CandidateRepository.Delete(id)
+}
+
+func (r *CachedCandidateRepository) Upsert(candidate domain.Candidate) (int, bool, error) {
+    id, created, err := r.CandidateRepository.Upsert(candidate)
+    if id != 0 {
+        r.invalidate(id)
+    }
+    return id, created, err
+}
+
+func (r *CachedCandidateRepository) Merge(target domain.Candidate, sourceID int, entry domain.CandidateHistoryEntry) error {
+    defer r.invalidate(target.ID, sourceID)
+    return r.CandidateRepository.Merge(target, sourceID, entry)
+}
+
+func (r *CachedCandidateRepository) UpdateBatch(candidates []domain.Candidate) error {
+    ids := make([]int, len(candidates))
+    for i, c := range candidates {
+        ids[i] = c.ID
+    }
+    defer r.invalidate(ids...)
+    return r.CandidateRepository.UpdateBatch(candidates)
+}
+
+func (r *CachedCandidateRepository) DeleteBatch(ids []int) error {
+    defer r.invalidate(ids...)
+    return r.CandidateRepository.DeleteBatch(ids)
+}
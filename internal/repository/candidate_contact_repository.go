@@ -0,0 +1,144 @@
+package repository
+
+import (
+    "context"
+    "database/sql"
+    "fmt"
+    "strings"
+
+    "github.com/torvictorvic/seek-v2/internal/domain"
+)
+
+// CandidateContactRepository stores the contact details of the candidates
+type CandidateContactRepository interface {
+    Save(candidateID int, contact domain.ContactDetails) error
+    Load(candidateIDs []int) (map[int]domain.ContactDetails, error)
+}
+
+type candidateContactRepositoryImpl struct {
+    db      *sql.DB
+    dialect Dialect
+    tx      *txState // set when bound to a unit of work
+}
+
+func NewCandidateContactRepository(db *sql.DB) CandidateContactRepository {
+    return NewCandidateContactRepositoryFor(db, MySQL)
+}
+
+// NewCandidateContactRepositoryFor returns the repository for a database of the dialect
+func NewCandidateContactRepositoryFor(db *sql.DB, dialect Dialect) CandidateContactRepository {
+    return &candidateContactRepositoryImpl{db: db, dialect: dialect}
+}
+
+func (r *candidateContactRepositoryImpl) bind(ctx context.Context) interface{} {
+    return CandidateContactRepository(&candidateContactRepositoryImpl{db: r.db, dialect: r.dialect, tx: txFrom(ctx)})
+}
+
+func (r *candidateContactRepositoryImpl) conn() dbConn {
+    if r.tx != nil {
+        return rebindConn{conn: r.tx.tx, dialect: r.dialect}
+    }
+    return rebindConn{conn: r.db, dialect: r.dialect}
+}
+
+// Save replaces the contact details of the candidate
+func (r *candidateContactRepositoryImpl) Save(candidateID int, contact domain.ContactDetails) error {
+    tx, err := beginTx(r.db, r.tx)
+    if err != nil {
+        return fmt.Errorf("Error starting transaction: %w", err)
+    }
+    defer tx.Rollback()
+    exec := rebindConn{conn: tx, dialect: r.dialect}
+
+    if _, err := exec.Exec(`DELETE FROM candidate_phones WHERE candidate_id = ?`, candidateID); err != nil {
+        return fmt.Errorf("Error deleting phones: %w", err)
+    }
+    var address domain.Address
+    if contact.Address != nil {
+        address = *contact.Address
+    }
+    if _, err := exec.Exec(`DELETE FROM candidate_contacts WHERE candidate_id = ?`, candidateID); err != nil {
+        return fmt.Errorf("Error deleting contact details: %w", err)
+    }
+    query := `INSERT INTO candidate_contacts (candidate_id, address_line1, address_line2, city, region, postal_code,
+        country, time_zone, linkedin_url, github_url, portfolio_url, preferred_channel) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+    _, err = exec.Exec(query, candidateID, address.Line1, address.Line2, address.City, address.Region, address.PostalCode,
+        contact.Country, contact.TimeZone, contact.LinkedInURL, contact.GitHubURL, contact.PortfolioURL, contact.PreferredChannel)
+    if err != nil {
+        return fmt.Errorf("Error saving contact details: %w", err)
+    }
+    if len(contact.Phones) > 0 {
+        placeholders := make([]string, len(contact.Phones))
+        args := make([]interface{}, 0, len(contact.Phones)*5)
+        for i, p := range contact.Phones {
+            placeholders[i] = "(?, ?, ?, ?, ?)"
+            args = append(args, candidateID, p.Number, p.Type, p.Primary, i)
+        }
+        query := `INSERT INTO candidate_phones (candidate_id, number, type, is_primary, position) VALUES ` + strings.Join(placeholders, ", ")
+        if _, err := exec.Exec(query, args...); err != nil {
+            return fmt.Errorf("Error saving phones: %w", err)
+        }
+    }
+    if err := tx.Commit(); err != nil {
+        return fmt.Errorf("Error committing contact details: %w", err)
+    }
+    return nil
+}
+
+// Load returns the contact details of the candidates, by candidate ID. Candidates
+// without them have no entry.
+func (r *candidateContactRepositoryImpl) Load(candidateIDs []int) (map[int]domain.ContactDetails, error) {
+    contacts := map[int]domain.ContactDetails{}
+    if len(candidateIDs) == 0 {
+        return contacts, nil
+    }
+    placeholders := make([]string, len(candidateIDs))
+    args := make([]interface{}, len(candidateIDs))
+    for i, id := range candidateIDs {
+        placeholders[i] = "?"
+        args[i] = id
+    }
+    in := `IN (` + strings.Join(placeholders, ", ") + `)`
+
+    query := `SELECT candidate_id, address_line1, address_line2, city, region, postal_code, country, time_zone,
+        linkedin_url, github_url, portfolio_url, preferred_channel FROM candidate_contacts WHERE candidate_id ` + in
+    rows, err := r.conn().Query(query, args...)
+    if err != nil {
+        return nil, fmt.Errorf("Error getting contact details: %w", err)
+    }
+    defer rows.Close()
+    for rows.Next() {
+        var id int
+        var c domain.ContactDetails
+        var a domain.Address
+        if err := rows.Scan(&id, &a.Line1, &a.Line2, &a.City, &a.Region, &a.PostalCode, &c.Country, &c.TimeZone,
+            &c.LinkedInURL, &c.GitHubURL, &c.PortfolioURL, &c.PreferredChannel); err != nil {
+            return nil, err
+        }
+        if a != (domain.Address{}) {
+            c.Address = &a
+        }
+        contacts[id] = c
+    }
+    if err := rows.Err(); err != nil {
+        return nil, err
+    }
+
+    phoneRows, err := r.conn().Query(`SELECT candidate_id, number, type, is_primary FROM candidate_phones WHERE candidate_id `+in+
+        ` ORDER BY candidate_id, position`, args...)
+    if err != nil {
+        return nil, fmt.Errorf("Error getting phones: %w", err)
+    }
+    defer phoneRows.Close()
+    for phoneRows.Next() {
+        var id int
+        var p domain.Phone
+        if err := phoneRows.Scan(&id, &p.Number, &p.Type, &p.Primary); err != nil {
+            return nil, err
+        }
+        c := contacts[id]
+        c.Phones = append(c.Phones, p)
+        contacts[id] = c
+    }
+    return contacts, phoneRows.Err()
+}
@@ -0,0 +1,304 @@
+package repository
+
+import (
+    "errors"
+    "fmt"
+    "sort"
+    "strings"
+    "sync"
+    "time"
+
+    "github.com/torvictorvic/seek-v2/internal/domain"
+)
+
+// ErrDuplicateEmail is returned by the in-memory repository when the email belongs to another candidate
+var ErrDuplicateEmail = errors.New("duplicate candidate email")
+
+// memoryCandidateRepository keeps the candidates in the process, for the tests and the
+// demo mode. It follows the MySQL table: auto-increment IDs, unique emails compared
+// without case, timestamps in seconds and rows in ID order. Only the candidates are
+// kept, the related data of the other tables (history, notes, events...) is not.
+type memoryCandidateRepository struct {
+    mu         sync.RWMutex
+    nextID     int
+    candidates map[int]domain.Candidate
+    byEmail    map[string]int
+}
+
+func NewMemoryCandidateRepository() CandidateRepository {
+    return &memoryCandidateRepository{nextID: 1, candidates: map[int]domain.Candidate{}, byEmail: map[string]int{}}
+}
+
+func memoryEmailKey(email string) string {
+    return strings.ToLower(email)
+}
+
+// memoryNow has the precision of the MySQL TIMESTAMP columns
+func memoryNow() time.Time {
+    return time.Now().UTC().Truncate(time.Second)
+}
+
+// candidateRow keeps only the columns of the candidates table
+func candidateRow(c domain.Candidate) domain.Candidate {
+    return domain.Candidate{
+        ID:             c.ID,
+        Name:           c.Name,
+        Email:          c.Email,
+        Gender:         c.Gender,
+        SalaryExpected: c.SalaryExpected,
+        CreatedAt:      c.CreatedAt,
+        UpdatedAt:      c.UpdatedAt,
+    }
+}
+
+// checkEmail fails when the email belongs to a candidate other than id. Must hold the lock.
+func (r *memoryCandidateRepository) checkEmail(email string, id int) error {
+    if owner, ok := r.byEmail[memoryEmailKey(email)]; ok && owner != id {
+        return fmt.Errorf("%w: '%s'", ErrDuplicateEmail, email)
+    }
+    return nil
+}
+
+// insert adds the candidate with the next ID. Must hold the lock.
+func (r *memoryCandidateRepository) insert(c domain.Candidate) int {
+    c.ID = r.nextID
+    r.nextID++
+    c.CreatedAt = memoryNow()
+    c.UpdatedAt = c.CreatedAt
+    r.candidates[c.ID] = candidateRow(c)
+    r.byEmail[memoryEmailKey(c.Email)] = c.ID
+    return c.ID
+}
+
+// update saves an existing candidate, missing IDs are ignored like an UPDATE. Must hold the lock.
+func (r *memoryCandidateRepository) update(c domain.Candidate) {
+    existing, ok := r.candidates[c.ID]
+    if !ok {
+        return
+    }
+    if r.byEmail[memoryEmailKey(existing.Email)] == c.ID {
+        delete(r.byEmail, memoryEmailKey(existing.Email))
+    }
+    c.CreatedAt = existing.CreatedAt
+    c.UpdatedAt = memoryNow()
+    r.candidates[c.ID] = candidateRow(c)
+    r.byEmail[memoryEmailKey(c.Email)] = c.ID
+}
+
+// remove deletes the candidate if it exists. Must hold the lock.
+func (r *memoryCandidateRepository) remove(id int) {
+    if existing, ok := r.candidates[id]; ok {
+        delete(r.byEmail, memoryEmailKey(existing.Email))
+        delete(r.candidates, id)
+    }
+}
+
+func (r *memoryCandidateRepository) Create(candidate domain.Candidate) (int, error) {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+    if err := r.checkEmail(candidate.Email, 0); err != nil {
+        return 0, fmt.Errorf("Error creating candidate: %w", err)
+    }
+    return r.insert(candidate), nil
+}
+
+func (r *memoryCandidateRepository) GetByID(id int) (*domain.Candidate, error) {
+    r.mu.RLock()
+    defer r.mu.RUnlock()
+    c, ok := r.candidates[id]
+    if !ok {
+        return nil, nil
+    }
+    return &c, nil
+}
+
+func (r *memoryCandidateRepository) GetByIDs(ids []int) ([]domain.Candidate, error) {
+    r.mu.RLock()
+    defer r.mu.RUnlock()
+    var candidates []domain.Candidate
+    seen := map[int]bool{}
+    for _, id := range ids {
+        if c, ok := r.candidates[id]; ok && !seen[id] {
+            seen[id] = true
+            candidates = append(candidates, c)
+        }
+    }
+    sort.Slice(candidates, func(i, j int) bool { return candidates[i].ID < candidates[j].ID })
+    return candidates, nil
+}
+
+func (r *memoryCandidateRepository) GetByEmail(email string) (*domain.Candidate, error) {
+    r.mu.RLock()
+    defer r.mu.RUnlock()
+    id, ok := r.byEmail[memoryEmailKey(email)]
+    if !ok {
+        return nil, nil
+    }
+    c := r.candidates[id]
+    return &c, nil
+}
+
+func (r *memoryCandidateRepository) GetAll(filter domain.CandidateFilter) ([]domain.Candidate, error) {
+    var candidates []domain.Candidate
+    err := r.Stream(filter, func(c domain.Candidate) error {
+        candidates = append(candidates, c)
+        return nil
+    })
+    if err != nil {
+        return nil, err
+    }
+    return candidates, nil
+}
+
+// Stream calls fn on a snapshot taken when it starts, so fn may use the repository.
+// The tags, contact and custom field conditions match no candidate, those are kept by
+// their own repositories.
+func (r *memoryCandidateRepository) Stream(filter domain.CandidateFilter, fn func(domain.Candidate) error) error {
+    for _, c := range r.matching(filter, 0) {
+        if err := fn(c); err != nil {
+            return err
+        }
+    }
+    return nil
+}
+
+func (r *memoryCandidateRepository) Page(filter domain.CandidateFilter, afterID, limit int) ([]domain.Candidate, error) {
+    candidates := r.matching(filter, afterID)
+    if len(candidates) > limit {
+        candidates = candidates[:limit]
+    }
+    return candidates, nil
+}
+
+func (r *memoryCandidateRepository) Count(filter domain.CandidateFilter) (int, error) {
+    return len(r.matching(filter, 0)), nil
+}
+
+// matching returns the candidates of the filter with an ID greater than afterID, in ID order
+func (r *memoryCandidateRepository) matching(filter domain.CandidateFilter, afterID int) []domain.Candidate {
+    r.mu.RLock()
+    candidates := make([]domain.Candidate, 0, len(r.candidates))
+    for _, c := range r.candidates {
+        if c.ID > afterID && filter.Matches(c) {
+            candidates = append(candidates, c)
+        }
+    }
+    r.mu.RUnlock()
+
+    sort.Slice(candidates, func(i, j int) bool { return candidates[i].ID < candidates[j].ID })
+    return candidates
+}
+
+func (r *memoryCandidateRepository) Update(candidate domain.Candidate) error {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+    if _, ok := r.candidates[candidate.ID]; !ok {
+        return ErrCandidateNotFound
+    }
+    if err := r.checkEmail(candidate.Email, candidate.ID); err != nil {
+        return fmt.Errorf("Error updating candidate: %w", err)
+    }
+    r.update(candidate)
+    return nil
+}
+
+func (r *memoryCandidateRepository) Delete(id int) error {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+    if _, ok := r.candidates[id]; !ok {
+        return ErrCandidateNotFound
+    }
+    r.remove(id)
+    return nil
+}
+
+func (r *memoryCandidateRepository) Upsert(candidate domain.Candidate) (int, bool, error) {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+    if id, ok := r.byEmail[memoryEmailKey(candidate.Email)]; ok {
+        // Like ON DUPLICATE KEY UPDATE, the stored email is kept
+        candidate.ID = id
+        candidate.Email = r.candidates[id].Email
+        r.update(candidate)
+        return id, false, nil
+    }
+    return r.insert(candidate), true, nil
+}
+
+// Merge removes the source and saves the target. There is no related data to move.
+func (r *memoryCandidateRepository) Merge(target domain.Candidate, sourceID int, entry domain.CandidateHistoryEntry) error {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+    owner, taken := r.byEmail[memoryEmailKey(target.Email)]
+    if taken && owner != target.ID && owner != sourceID {
+        return fmt.Errorf("Error updating candidate: %w: '%s'", ErrDuplicateEmail, target.Email)
+    }
+    r.remove(sourceID)
+    r.update(target)
+    return nil
+}
+
+// CreateBatch inserts all the candidates or none
+func (r *memoryCandidateRepository) CreateBatch(candidates []domain.Candidate) ([]int, error) {
+    if len(candidates) == 0 {
+        return nil, nil
+    }
+    r.mu.Lock()
+    defer r.mu.Unlock()
+    emails := map[string]bool{}
+    for _, c := range candidates {
+        if err := r.checkEmail(c.Email, 0); err != nil || emails[memoryEmailKey(c.Email)] {
+            return nil, fmt.Errorf("Error creating candidates: %w: '%s'", ErrDuplicateEmail, c.Email)
+        }
+        emails[memoryEmailKey(c.Email)] = true
+    }
+    ids := make([]int, len(candidates))
+    for i, c := range candidates {
+        ids[i] = r.insert(c)
+    }
+    return ids, nil
+}
+
+// UpdateBatch updates all the candidates or none. Like the statements of the SQL batch,
+// each update is checked against the emails left by the previous ones.
+func (r *memoryCandidateRepository) UpdateBatch(candidates []domain.Candidate) error {
+    if len(candidates) == 0 {
+        return nil
+    }
+    r.mu.Lock()
+    defer r.mu.Unlock()
+    owners := make(map[string]int, len(r.byEmail))
+    emails := make(map[int]string, len(r.candidates))
+    for email, id := range r.byEmail {
+        owners[email] = id
+        emails[id] = email
+    }
+    for _, c := range candidates {
+        if _, ok := r.candidates[c.ID]; !ok {
+            continue
+        }
+        if owner, ok := owners[memoryEmailKey(c.Email)]; ok && owner != c.ID {
+            return fmt.Errorf("Error updating candidate %d: %w: '%s'", c.ID, ErrDuplicateEmail, c.Email)
+        }
+        delete(owners, emails[c.ID])
+        owners[memoryEmailKey(c.Email)], emails[c.ID] = c.ID, memoryEmailKey(c.Email)
+    }
+    for _, c := range candidates {
+        r.update(c)
+    }
+    return nil
+}
+
+func (r *memoryCandidateRepository) DeleteBatch(ids []int) error {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+    for _, id := range ids {
+        if _, ok := r.candidates[id]; !ok {
+            return ErrCandidateNotFound
+        }
+    }
+    for _, id := range ids {
+        r.remove(id)
+    }
+    return nil
+}
@@ -1,41 +1,113 @@
 package repository
 
 import (
+    "context"
     "database/sql"
+    "errors"
     "fmt"
+    "strings"
 
     "github.com/torvictorvic/seek-v2/internal/domain"
 )
 
+// ErrCandidateNotFound is returned by the writes of a candidate that does not exist, which
+// leave nothing written
+var ErrCandidateNotFound = errors.New("Candidate not found")
+
 type CandidateRepository interface {
     Create(candidate domain.Candidate) (int, error)
     GetByID(id int) (*domain.Candidate, error)
-    GetAll() ([]domain.Candidate, error)
+    GetByIDs(ids []int) ([]domain.Candidate, error)
+    GetByEmail(email string) (*domain.Candidate, error)
+    GetAll(filter domain.CandidateFilter) ([]domain.Candidate, error)
+    Stream(filter domain.CandidateFilter, fn func(domain.Candidate) error) error
+    Page(filter domain.CandidateFilter, afterID, limit int) ([]domain.Candidate, error)
+    Count(filter domain.CandidateFilter) (int, error)
     Update(candidate domain.Candidate) error
     Delete(id int) error
+    Upsert(candidate domain.Candidate) (int, bool, error)
+    Merge(target domain.Candidate, sourceID int, entry domain.CandidateHistoryEntry) error
+    CreateBatch(candidates []domain.Candidate) ([]int, error)
+    UpdateBatch(candidates []domain.Candidate) error
+    DeleteBatch(ids []int) error
 }
 
 type candidateRepositoryImpl struct {
-    db *sql.DB
+    db      *sql.DB
+    dialect Dialect
+    tx      *txState // set when bound to a unit of work
+    readRouting
 }
 
 func NewCandidateRepository(db *sql.DB) CandidateRepository {
-    return &candidateRepositoryImpl{db: db}
+    return NewCandidateRepositoryFor(db, MySQL)
+}
+
+// NewCandidateRepositoryFor returns the candidate repository for a database of the dialect.
+// db is the primary when the reads are routed to replicas.
+func NewCandidateRepositoryFor(db *sql.DB, dialect Dialect, opts ...ReadOption) CandidateRepository {
+    return &candidateRepositoryImpl{db: db, dialect: dialect, readRouting: newReadRouting(opts)}
+}
+
+func (r *candidateRepositoryImpl) bind(ctx context.Context) interface{} {
+    bound := *r
+    bound.tx = txFrom(ctx)
+    bound.reader = readsFrom(ctx)
+    return CandidateRepository(&bound)
+}
+
+// conn runs the reads in the transaction of the unit of work, if bound to one, or where
+// the read routing sends them
+func (r *candidateRepositoryImpl) conn() dbConn {
+    if r.tx != nil {
+        return r.tx.tx
+    }
+    return r.readConn(r.db)
+}
+
+// begin starts the transaction of a write, which pins the user of the request to the primary
+func (r *candidateRepositoryImpl) begin() (*localTx, error) {
+    r.recordWrite()
+    return beginTx(r.db, r.tx)
+}
+
+// inTx runs fn in a transaction, committed when fn succeeds
+func (r *candidateRepositoryImpl) inTx(fn func(tx *localTx) error) error {
+    tx, err := r.begin()
+    if err != nil {
+        return fmt.Errorf("Error starting transaction: %w", err)
+    }
+    defer tx.Rollback()
+
+    if err := fn(tx); err != nil {
+        return err
+    }
+    if err := tx.Commit(); err != nil {
+        return fmt.Errorf("Error committing candidate: %w", err)
+    }
+    return nil
 }
 
+// Create inserts the candidate and its candidate.created event
 func (r *candidateRepositoryImpl) Create(candidate domain.Candidate) (int, error) {
-    query := `INSERT INTO candidates (name, email, gender, salary_expected) VALUES (?, ?, ?, ?)`
-    result, err := r.db.Exec(query, candidate.Name, candidate.Email, candidate.Gender, candidate.SalaryExpected)
+    err := r.inTx(func(tx *localTx) error {
+        query := `INSERT INTO candidates (name, email, gender, salary_expected) VALUES (?, ?, ?, ?)`
+        id, err := r.insertID(tx, query, candidate.Name, candidate.Email, candidate.Gender, candidate.SalaryExpected)
+        if err != nil {
+            return fmt.Errorf("Error creating candidate: %w", err)
+        }
+        candidate.ID = id
+        return insertEvents(r.execer(tx), candidateEvent(domain.EventCandidateCreated, candidate, 0))
+    })
     if err != nil {
-        return 0, fmt.Errorf("Error creating candidate: %w", err)
+        return 0, err
     }
-    insertID, _ := result.LastInsertId()
-    return int(insertID), nil
+    return candidate.ID, nil
 }
 
 func (r *candidateRepositoryImpl) GetByID(id int) (*domain.Candidate, error) {
     query := `SELECT id, name, email, gender, salary_expected, created_at, updated_at FROM candidates WHERE id = ?`
-    row := r.db.QueryRow(query, id)
+    row := r.conn().QueryRow(r.dialect.Rebind(query), id)
 
     var c domain.Candidate
     err := row.Scan(&c.ID, &c.Name, &c.Email, &c.Gender, &c.SalaryExpected, &c.CreatedAt, &c.UpdatedAt)
@@ -47,11 +119,20 @@ func (r *candidateRepositoryImpl) GetByID(id int) (*domain.Candidate, error) {
     return &c, nil
 }
 
-func (r *candidateRepositoryImpl) GetAll() ([]domain.Candidate, error) {
-    query := `SELECT id, name, email, gender, salary_expected, created_at, updated_at FROM candidates`
-    rows, err := r.db.Query(query)
+// GetByIDs reads the candidates with the given IDs in one query. Missing IDs are skipped.
+func (r *candidateRepositoryImpl) GetByIDs(ids []int) ([]domain.Candidate, error) {
+    if len(ids) == 0 {
+        return nil, nil
+    }
+    placeholders := strings.TrimSuffix(strings.Repeat("?,", len(ids)), ",")
+    args := make([]interface{}, len(ids))
+    for i, id := range ids {
+        args[i] = id
+    }
+    query := `SELECT id, name, email, gender, salary_expected, created_at, updated_at FROM candidates WHERE id IN (` + placeholders + `)`
+    rows, err := r.conn().Query(r.dialect.Rebind(query), args...)
     if err != nil {
-        return nil, fmt.Errorf("Error getting candidate list: %w", err)
+        return nil, fmt.Errorf("Error getting candidates by ID: %w", err)
     }
     defer rows.Close()
 
@@ -63,23 +144,500 @@ func (r *candidateRepositoryImpl) GetAll() ([]domain.Candidate, error) {
         }
         candidates = append(candidates, c)
     }
+    return candidates, rows.Err()
+}
+
+func (r *candidateRepositoryImpl) GetByEmail(email string) (*domain.Candidate, error) {
+    query := `SELECT id, name, email, gender, salary_expected, created_at, updated_at FROM candidates WHERE email = ?`
+    row := r.conn().QueryRow(r.dialect.Rebind(query), email)
+
+    var c domain.Candidate
+    err := row.Scan(&c.ID, &c.Name, &c.Email, &c.Gender, &c.SalaryExpected, &c.CreatedAt, &c.UpdatedAt)
+    if err == sql.ErrNoRows {
+        return nil, nil
+    } else if err != nil {
+        return nil, fmt.Errorf("Error getting candidate by email: %w", err)
+    }
+    return &c, nil
+}
+
+func (r *candidateRepositoryImpl) GetAll(filter domain.CandidateFilter) ([]domain.Candidate, error) {
+    var candidates []domain.Candidate
+    err := r.Stream(filter, func(c domain.Candidate) error {
+        candidates = append(candidates, c)
+        return nil
+    })
+    if err != nil {
+        return nil, err
+    }
+    return candidates, nil
+}
+
+// Stream calls fn for every candidate matching the filter as the rows are read, in ID
+// order, so large result sets are never held in memory
+func (r *candidateRepositoryImpl) Stream(filter domain.CandidateFilter, fn func(domain.Candidate) error) error {
+    conds, args := candidateFilterConditions(filter, r.dialect)
+    query := `SELECT id, name, email, gender, salary_expected, created_at, updated_at FROM candidates` + whereClause(conds) + ` ORDER BY id`
+    return r.queryCandidates(query, args, fn)
+}
+
+// Page returns up to limit candidates matching the filter with an ID greater than afterID,
+// in ID order. The APIs page with it from the last ID they returned, so every page is a
+// range of the primary key whatever the writes between them.
+func (r *candidateRepositoryImpl) Page(filter domain.CandidateFilter, afterID, limit int) ([]domain.Candidate, error) {
+    conds, args := candidateFilterConditions(filter, r.dialect)
+    conds = append(conds, "id > ?")
+    query := `SELECT id, name, email, gender, salary_expected, created_at, updated_at FROM candidates` + whereClause(conds) + ` ORDER BY id LIMIT ?`
+    candidates := []domain.Candidate{}
+    err := r.queryCandidates(query, append(args, afterID, limit), func(c domain.Candidate) error {
+        candidates = append(candidates, c)
+        return nil
+    })
+    if err != nil {
+        return nil, err
+    }
     return candidates, nil
 }
 
+// Count returns the number of candidates matching the filter
+func (r *candidateRepositoryImpl) Count(filter domain.CandidateFilter) (int, error) {
+    conds, args := candidateFilterConditions(filter, r.dialect)
+    var n int
+    if err := r.conn().QueryRow(r.dialect.Rebind(`SELECT COUNT(*) FROM candidates`+whereClause(conds)), args...).Scan(&n); err != nil {
+        return 0, fmt.Errorf("Error counting candidates: %w", err)
+    }
+    return n, nil
+}
+
+// queryCandidates calls fn for every candidate the query returns
+func (r *candidateRepositoryImpl) queryCandidates(query string, args []interface{}, fn func(domain.Candidate) error) error {
+    rows, err := r.conn().Query(r.dialect.Rebind(query), args...)
+    if err != nil {
+        return fmt.Errorf("Error getting candidate list: %w", err)
+    }
+    defer rows.Close()
+
+    for rows.Next() {
+        var c domain.Candidate
+        if err := rows.Scan(&c.ID, &c.Name, &c.Email, &c.Gender, &c.SalaryExpected, &c.CreatedAt, &c.UpdatedAt); err != nil {
+            return err
+        }
+        if err := fn(c); err != nil {
+            return err
+        }
+    }
+    return rows.Err()
+}
+
+// candidateFilterConditions returns the conditions of the filter. The partial matches
+// are case-insensitive, as with the default MySQL collation.
+func candidateFilterConditions(filter domain.CandidateFilter, dialect Dialect) ([]string, []interface{}) {
+    var conds []string
+    var args []interface{}
+    if filter.Name != "" {
+        conds = append(conds, "name "+dialect.like+" ?")
+        args = append(args, "%"+filter.Name+"%")
+    }
+    if filter.Email != "" {
+        conds = append(conds, "email "+dialect.like+" ?")
+        args = append(args, "%"+filter.Email+"%")
+    }
+    if filter.Gender != "" {
+        conds = append(conds, "gender = ?")
+        args = append(args, filter.Gender)
+    }
+    if filter.SalaryMin != nil {
+        conds = append(conds, "salary_expected >= ?")
+        args = append(args, *filter.SalaryMin)
+    }
+    if filter.SalaryMax != nil {
+        conds = append(conds, "salary_expected <= ?")
+        args = append(args, *filter.SalaryMax)
+    }
+    for _, tag := range filter.Tags {
+        conds = append(conds, "EXISTS (SELECT 1 FROM candidate_tags t WHERE t.candidate_id = candidates.id AND t.tag = ?)")
+        args = append(args, strings.ToLower(tag))
+    }
+    if filter.Country != "" {
+        conds = append(conds, "EXISTS (SELECT 1 FROM candidate_contacts ct WHERE ct.candidate_id = candidates.id AND ct.country = ?)")
+        args = append(args, strings.ToUpper(filter.Country))
+    }
+    for _, cond := range filter.Fields {
+        valueConds, valueArgs := fieldConditions(cond, dialect)
+        conds = append(conds, "EXISTS (SELECT 1 FROM candidate_field_values v WHERE v.candidate_id = candidates.id AND v.field_key = ? AND "+
+            strings.Join(valueConds, " AND ")+")")
+        args = append(append(args, cond.Key), valueArgs...)
+    }
+    return conds, args
+}
+
+// fieldConditions returns the conditions on the stored value of a custom field
+func fieldConditions(cond domain.FieldCondition, dialect Dialect) ([]string, []interface{}) {
+    switch cond.Type {
+    case domain.FieldNumber:
+        conds, args := []string{"v.value_number IS NOT NULL"}, []interface{}{}
+        if cond.Min != nil {
+            conds, args = append(conds, "v.value_number >= ?"), append(args, *cond.Min)
+        }
+        if cond.Max != nil {
+            conds, args = append(conds, "v.value_number <= ?"), append(args, *cond.Max)
+        }
+        return conds, args
+    case domain.FieldDate:
+        conds, args := []string{"v.value_date IS NOT NULL"}, []interface{}{}
+        if cond.From != "" {
+            conds, args = append(conds, "v.value_date >= ?"), append(args, cond.From)
+        }
+        if cond.To != "" {
+            conds, args = append(conds, "v.value_date <= ?"), append(args, cond.To)
+        }
+        return conds, args
+    case domain.FieldText:
+        return []string{"v.value_text " + dialect.like + " ?"}, []interface{}{"%" + cond.Value + "%"}
+    default:
+        return []string{"v.value_text = ?"}, []interface{}{cond.Value}
+    }
+}
+
+func whereClause(conds []string) string {
+    if len(conds) == 0 {
+        return ""
+    }
+    return " WHERE " + strings.Join(conds, " AND ")
+}
+
+// Update saves the candidate and its candidate.updated event
 func (r *candidateRepositoryImpl) Update(candidate domain.Candidate) error {
-    query := `UPDATE candidates SET name = ?, email = ?, gender = ?, salary_expected = ? WHERE id = ?`
-    _, err := r.db.Exec(query, candidate.Name, candidate.Email, candidate.Gender, candidate.SalaryExpected, candidate.ID)
+    return r.inTx(func(tx *localTx) error {
+        // RowsAffected cannot tell a missing candidate apart: MySQL counts only the changed rows
+        var exists int
+        err := tx.QueryRow(r.dialect.Rebind(`SELECT 1 FROM candidates WHERE id = ?`), candidate.ID).Scan(&exists)
+        if err == sql.ErrNoRows {
+            return ErrCandidateNotFound
+        } else if err != nil {
+            return fmt.Errorf("Error updating candidate: %w", err)
+        }
+
+        query := `UPDATE candidates SET name = ?, email = ?, gender = ?, salary_expected = ? WHERE id = ?`
+        _, err = tx.Exec(r.dialect.Rebind(query), candidate.Name, candidate.Email, candidate.Gender, candidate.SalaryExpected, candidate.ID)
+        if err != nil {
+            return fmt.Errorf("Error updating candidate: %w", err)
+        }
+        return insertEvents(r.execer(tx), candidateEvent(domain.EventCandidateUpdated, candidate, 0))
+    })
+}
+
+// softDeletedTables hold data that is kept, marked as deleted, when its candidate is deleted
+var softDeletedTables = []string{"notes", "documents"}
+
+// attributeTables hold the tags, custom field values and contact details, removed with their candidate
+var attributeTables = []string{"candidate_tags", "candidate_field_values", "candidate_contacts", "candidate_phones"}
+
+// Delete removes the candidate and soft-deletes its dependent data in a single transaction
+func (r *candidateRepositoryImpl) Delete(id int) error {
+    return r.deleteWhere("= ?", id)
+}
+
+// deleteWhere deletes the candidates whose id matches cond and soft-deletes their dependent data.
+// The args are the IDs of the candidates, each one gets a candidate.deleted event. If any of
+// them does not exist nothing is deleted and ErrCandidateNotFound is returned.
+func (r *candidateRepositoryImpl) deleteWhere(cond string, args ...interface{}) error {
+    tx, err := r.begin()
     if err != nil {
+        return fmt.Errorf("Error starting transaction: %w", err)
+    }
+    defer tx.Rollback()
+
+    for _, table := range softDeletedTables {
+        query := `UPDATE ` + table + ` SET deleted_at = CURRENT_TIMESTAMP WHERE deleted_at IS NULL AND candidate_id ` + cond
+        if _, err := tx.Exec(r.dialect.Rebind(query), args...); err != nil {
+            return fmt.Errorf("Error deleting %s: %w", table, err)
+        }
+    }
+    for _, table := range attributeTables {
+        if _, err := tx.Exec(r.dialect.Rebind(`DELETE FROM `+table+` WHERE candidate_id `+cond), args...); err != nil {
+            return fmt.Errorf("Error deleting %s: %w", table, err)
+        }
+    }
+    result, err := tx.Exec(r.dialect.Rebind(`DELETE FROM candidates WHERE id `+cond), args...)
+    if err != nil {
+        return fmt.Errorf("Error deleting candidate: %w", err)
+    }
+    // A missing candidate rolls back the whole delete, so no event is sent for it
+    if n, err := result.RowsAffected(); err != nil {
+        return fmt.Errorf("Error deleting candidate: %w", err)
+    } else if int(n) < len(args) {
+        return ErrCandidateNotFound
+    }
+    events := make([]domain.OutboxEvent, len(args))
+    for i, id := range args {
+        events[i] = candidateEvent(domain.EventCandidateDeleted, domain.Candidate{ID: id.(int)}, 0)
+    }
+    if err := insertEvents(r.execer(tx), events...); err != nil {
+        return err
+    }
+    if err := tx.Commit(); err != nil {
+        return fmt.Errorf("Error committing delete: %w", err)
+    }
+    return nil
+}
+
+// Upsert inserts the candidate or updates the one with the same email.
+// It returns the ID and whether the candidate was created.
+func (r *candidateRepositoryImpl) Upsert(candidate domain.Candidate) (int, bool, error) {
+    if r.dialect.returning {
+        return r.upsertReturning(candidate)
+    }
+    var created bool
+    err := r.inTx(func(tx *localTx) error {
+        // LAST_INSERT_ID(id) makes LastInsertId return the existing ID on update
+        query := `INSERT INTO candidates (name, email, gender, salary_expected) VALUES (?, ?, ?, ?)
+            ON DUPLICATE KEY UPDATE id = LAST_INSERT_ID(id), name = VALUES(name), gender = VALUES(gender), salary_expected = VALUES(salary_expected)`
+        result, err := tx.Exec(query, candidate.Name, candidate.Email, candidate.Gender, candidate.SalaryExpected)
+        if err != nil {
+            return fmt.Errorf("Error upserting candidate: %w", err)
+        }
+        id, _ := result.LastInsertId()
+        candidate.ID = int(id)
+        // MySQL reports 1 affected row for an insert and 2 for an update
+        affected, _ := result.RowsAffected()
+        created = affected == 1
+        eventType := domain.EventCandidateUpdated
+        if created {
+            eventType = domain.EventCandidateCreated
+        }
+        return insertEvents(tx, candidateEvent(eventType, candidate, 0))
+    })
+    if err != nil {
+        return 0, false, err
+    }
+    return candidate.ID, created, nil
+}
+
+// upsertReturning looks the email up first to tell a create from an update, since
+// ON CONFLICT reports the same for both
+func (r *candidateRepositoryImpl) upsertReturning(candidate domain.Candidate) (int, bool, error) {
+    var created bool
+    err := r.inTx(func(tx *localTx) error {
+        var existing int
+        err := tx.QueryRow(r.dialect.Rebind(`SELECT id FROM candidates WHERE email = ?`), candidate.Email).Scan(&existing)
+        if err != nil && err != sql.ErrNoRows {
+            return fmt.Errorf("Error upserting candidate: %w", err)
+        }
+        created = err == sql.ErrNoRows
+        query := `INSERT INTO candidates (name, email, gender, salary_expected) VALUES (?, ?, ?, ?)
+            ON CONFLICT (email) DO UPDATE SET name = excluded.name, gender = excluded.gender, salary_expected = excluded.salary_expected`
+        candidate.ID, err = r.insertID(tx, query, candidate.Name, candidate.Email, candidate.Gender, candidate.SalaryExpected)
+        if err != nil {
+            return fmt.Errorf("Error upserting candidate: %w", err)
+        }
+        eventType := domain.EventCandidateUpdated
+        if created {
+            eventType = domain.EventCandidateCreated
+        }
+        return insertEvents(r.execer(tx), candidateEvent(eventType, candidate, 0))
+    })
+    if err != nil {
+        return 0, false, err
+    }
+    return candidate.ID, created, nil
+}
+
+// relatedTables hold the data that belongs to a candidate and follows it on a merge
+var relatedTables = []string{"candidate_history", "applications", "interviews", "feedback", "notes", "documents"}
+
+// Merge moves the related data of the source candidate to the target, removes the source
+// with its tags and custom field values, saves the merged target and records the merge
+// with its candidate.merged event, all in a single transaction. The merged attributes of the target are saved by the caller.
+func (r *candidateRepositoryImpl) Merge(target domain.Candidate, sourceID int, entry domain.CandidateHistoryEntry) error {
+    tx, err := r.begin()
+    if err != nil {
+        return fmt.Errorf("Error starting transaction: %w", err)
+    }
+    defer tx.Rollback()
+
+    for _, table := range relatedTables {
+        if _, err := tx.Exec(r.dialect.Rebind(`UPDATE `+table+` SET candidate_id = ? WHERE candidate_id = ?`), target.ID, sourceID); err != nil {
+            return fmt.Errorf("Error moving %s: %w", table, err)
+        }
+    }
+    for _, table := range attributeTables {
+        if _, err := tx.Exec(r.dialect.Rebind(`DELETE FROM `+table+` WHERE candidate_id = ?`), sourceID); err != nil {
+            return fmt.Errorf("Error deleting %s: %w", table, err)
+        }
+    }
+    // The source goes first so the target can take its email
+    if _, err := tx.Exec(r.dialect.Rebind(`DELETE FROM candidates WHERE id = ?`), sourceID); err != nil {
+        return fmt.Errorf("Error deleting merged candidate: %w", err)
+    }
+    if _, err := tx.Exec(r.dialect.Rebind(`UPDATE candidates SET name = ?, email = ?, gender = ?, salary_expected = ? WHERE id = ?`),
+        target.Name, target.Email, target.Gender, target.SalaryExpected, target.ID); err != nil {
         return fmt.Errorf("Error updating candidate: %w", err)
     }
+    if _, err := insertHistory(r.execer(tx), entry); err != nil {
+        return err
+    }
+    if err := insertEvents(r.execer(tx), candidateEvent(domain.EventCandidateMerged, target, sourceID)); err != nil {
+        return err
+    }
+    if err := tx.Commit(); err != nil {
+        return fmt.Errorf("Error committing merge: %w", err)
+    }
     return nil
 }
 
-func (r *candidateRepositoryImpl) Delete(id int) error {
-    query := `DELETE FROM candidates WHERE id = ?`
-    _, err := r.db.Exec(query, id)
+// CreateBatch inserts all the candidates with a single multi-row INSERT inside a transaction.
+// The IDs are read back by email, which is unique, since neither LastInsertId nor RETURNING
+// guarantee which ID went to each row.
+func (r *candidateRepositoryImpl) CreateBatch(candidates []domain.Candidate) ([]int, error) {
+    if len(candidates) == 0 {
+        return nil, nil
+    }
+
+    placeholders := make([]string, len(candidates))
+    args := make([]interface{}, 0, len(candidates)*4)
+    for i, c := range candidates {
+        placeholders[i] = "(?, ?, ?, ?)"
+        args = append(args, c.Name, c.Email, c.Gender, c.SalaryExpected)
+    }
+    query := `INSERT INTO candidates (name, email, gender, salary_expected) VALUES ` + strings.Join(placeholders, ", ")
+
+    tx, err := r.begin()
     if err != nil {
-        return fmt.Errorf("Error deleting candidate: %w", err)
+        return nil, fmt.Errorf("Error starting transaction: %w", err)
+    }
+    defer tx.Rollback()
+
+    if _, err := tx.Exec(r.dialect.Rebind(query), args...); err != nil {
+        return nil, fmt.Errorf("Error creating candidates: %w", err)
+    }
+    ids, err := r.idsByEmail(tx, candidates)
+    if err != nil {
+        return nil, fmt.Errorf("Error reading the IDs of the candidates: %w", err)
+    }
+    events := make([]domain.OutboxEvent, len(candidates))
+    for i, c := range candidates {
+        c.ID = ids[i]
+        events[i] = candidateEvent(domain.EventCandidateCreated, c, 0)
+    }
+    if err := insertEvents(r.execer(tx), events...); err != nil {
+        return nil, err
+    }
+    if err := tx.Commit(); err != nil {
+        return nil, fmt.Errorf("Error committing candidates: %w", err)
+    }
+    return ids, nil
+}
+
+// UpdateBatch updates all the candidates inside a single transaction using a prepared statement
+func (r *candidateRepositoryImpl) UpdateBatch(candidates []domain.Candidate) error {
+    if len(candidates) == 0 {
+        return nil
+    }
+
+    tx, err := r.begin()
+    if err != nil {
+        return fmt.Errorf("Error starting transaction: %w", err)
+    }
+    defer tx.Rollback()
+
+    stmt, err := tx.Prepare(r.dialect.Rebind(`UPDATE candidates SET name = ?, email = ?, gender = ?, salary_expected = ? WHERE id = ?`))
+    if err != nil {
+        return fmt.Errorf("Error preparing update: %w", err)
+    }
+    defer stmt.Close()
+
+    events := make([]domain.OutboxEvent, len(candidates))
+    for i, c := range candidates {
+        if _, err := stmt.Exec(c.Name, c.Email, c.Gender, c.SalaryExpected, c.ID); err != nil {
+            return fmt.Errorf("Error updating candidate %d: %w", c.ID, err)
+        }
+        events[i] = candidateEvent(domain.EventCandidateUpdated, c, 0)
+    }
+    if err := insertEvents(r.execer(tx), events...); err != nil {
+        return err
+    }
+    if err := tx.Commit(); err != nil {
+        return fmt.Errorf("Error committing candidates: %w", err)
     }
     return nil
 }
+
+// DeleteBatch deletes all the candidates with a single DELETE ... IN statement, soft-deleting
+// their dependent data in the same transaction
+func (r *candidateRepositoryImpl) DeleteBatch(ids []int) error {
+    if len(ids) == 0 {
+        return nil
+    }
+
+    placeholders := make([]string, len(ids))
+    args := make([]interface{}, len(ids))
+    for i, id := range ids {
+        placeholders[i] = "?"
+        args[i] = id
+    }
+    return r.deleteWhere(`IN (`+strings.Join(placeholders, ", ")+`)`, args...)
+}
+
+// execer rebinds the queries of the shared insert helpers run in tx
+func (r *candidateRepositoryImpl) execer(tx *localTx) execer {
+    if !r.dialect.numbered {
+        return tx
+    }
+    return rebindExecer{execer: tx, dialect: r.dialect}
+}
+
+// insertID runs the INSERT of one row and returns its ID
+func (r *candidateRepositoryImpl) insertID(tx *localTx, query string, args ...interface{}) (int, error) {
+    if !r.dialect.returning {
+        result, err := tx.Exec(query, args...)
+        if err != nil {
+            return 0, err
+        }
+        id, err := result.LastInsertId()
+        return int(id), err
+    }
+
+    var id int
+    if err := tx.QueryRow(r.dialect.Rebind(query+` RETURNING id`), args...).Scan(&id); err != nil {
+        return 0, err
+    }
+    return id, nil
+}
+
+// idsByEmail returns the IDs of the candidates, in their order, looking them up by email
+func (r *candidateRepositoryImpl) idsByEmail(tx *localTx, candidates []domain.Candidate) ([]int, error) {
+    placeholders := strings.TrimSuffix(strings.Repeat("?,", len(candidates)), ",")
+    args := make([]interface{}, len(candidates))
+    for i, c := range candidates {
+        args[i] = c.Email
+    }
+    rows, err := tx.Query(r.dialect.Rebind(`SELECT id, email FROM candidates WHERE email IN (`+placeholders+`)`), args...)
+    if err != nil {
+        return nil, err
+    }
+    defer rows.Close()
+
+    byEmail := make(map[string]int, len(candidates))
+    for rows.Next() {
+        var id int
+        var email string
+        if err := rows.Scan(&id, &email); err != nil {
+            return nil, err
+        }
+        byEmail[email] = id
+    }
+    if err := rows.Err(); err != nil {
+        return nil, err
+    }
+
+    ids := make([]int, len(candidates))
+    for i, c := range candidates {
+        id, ok := byEmail[c.Email]
+        if !ok {
+            return nil, fmt.Errorf("Candidate %s not found after insert", c.Email)
+        }
+        ids[i] = id
+    }
+    return ids, nil
+}
@@ -0,0 +1,95 @@
+package repository
+
+import (
+    "context"
+    "database/sql"
+    "fmt"
+    "strings"
+
+    "github.com/torvictorvic/seek-v2/internal/domain"
+    "github.com/torvictorvic/seek-v2/internal/search"
+)
+
+// searchCandidatesLimit bounds the rows fetched from MySQL before ranking
+const searchCandidatesLimit = 500
+
+type candidateSearchMySQL struct {
+    db *sql.DB
+    readRouting
+}
+
+// NewCandidateSearcher searches with the FULLTEXT indexes of the candidates and of the
+// text of their documents. FULLTEXT has no typo tolerance, so the query is widened with short prefixes of every
+// term and the rows are ranked with the same scoring as the in-memory index.
+func NewCandidateSearcher(db *sql.DB, opts ...ReadOption) search.Searcher {
+    return &candidateSearchMySQL{db: db, readRouting: newReadRouting(opts)}
+}
+
+func (s *candidateSearchMySQL) bind(ctx context.Context) interface{} {
+    bound := *s
+    bound.reader = readsFrom(ctx)
+    return search.Searcher(&bound)
+}
+
+func (s *candidateSearchMySQL) Search(query string, filter domain.CandidateFilter, limit int) ([]domain.CandidateSearchHit, error) {
+    against := booleanQuery(search.Terms(query))
+    if against == "" {
+        return nil, nil
+    }
+
+    conds, args := candidateFilterConditions(filter, MySQL)
+    conds = append([]string{"(MATCH(name, email) AGAINST (? IN BOOLEAN MODE) OR id IN " +
+        "(SELECT candidate_id FROM documents WHERE deleted_at IS NULL AND MATCH(text_content) AGAINST (? IN BOOLEAN MODE)))"}, conds...)
+    args = append([]interface{}{against, against}, args...)
+    sqlQuery := `SELECT id, name, email, gender, salary_expected, created_at, updated_at FROM candidates` +
+        whereClause(conds) + fmt.Sprintf(" LIMIT %d", searchCandidatesLimit)
+
+    conn := s.readConn(s.db)
+    rows, err := conn.Query(sqlQuery, args...)
+    if err != nil {
+        return nil, fmt.Errorf("Error searching candidates: %w", err)
+    }
+    defer rows.Close()
+
+    var candidates []domain.Candidate
+    for rows.Next() {
+        var c domain.Candidate
+        if err := rows.Scan(&c.ID, &c.Name, &c.Email, &c.Gender, &c.SalaryExpected, &c.CreatedAt, &c.UpdatedAt); err != nil {
+            return nil, err
+        }
+        candidates = append(candidates, c)
+    }
+    if err := rows.Err(); err != nil {
+        return nil, err
+    }
+    rows.Close()
+
+    // The resume text is ranked like the other fields
+    ids := make([]int, len(candidates))
+    for i, c := range candidates {
+        ids[i] = c.ID
+    }
+    texts, err := resumeTexts(conn, ids)
+    if err != nil {
+        return nil, err
+    }
+    for i := range candidates {
+        candidates[i].ResumeText = texts[candidates[i].ID]
+    }
+    return search.Rank(query, candidates, limit), nil
+}
+
+// booleanQuery builds "+(anna* ann*) +(walkr* wal*)": every term is required, either as a
+// prefix or through its first three letters, which is what leaves room for typos
+func booleanQuery(terms []string) string {
+    var parts []string
+    for _, term := range terms {
+        // The boolean mode operators are not letters or digits, so the terms are already safe
+        alternatives := []string{term + "*"}
+        if r := []rune(term); len(r) > 3 {
+            alternatives = append(alternatives, string(r[:3])+"*")
+        }
+        parts = append(parts, "+("+strings.Join(alternatives, " ")+")")
+    }
+    return strings.Join(parts, " ")
+}
@@ -0,0 +1,134 @@
+package repository
+
+import (
+    "database/sql"
+    "encoding/json"
+    "fmt"
+
+    "github.com/torvictorvic/seek-v2/internal/domain"
+)
+
+type CustomFieldRepository interface {
+    Create(field domain.CustomField) (int, error)
+    GetByKey(key string) (*domain.CustomField, error)
+    List() ([]domain.CustomField, error)
+    Update(field domain.CustomField) error
+    Delete(key string) error
+}
+
+type customFieldRepositoryImpl struct {
+    db      *sql.DB
+    dialect Dialect
+}
+
+func NewCustomFieldRepository(db *sql.DB) CustomFieldRepository {
+    return NewCustomFieldRepositoryFor(db, MySQL)
+}
+
+// NewCustomFieldRepositoryFor returns the repository for a database of the dialect
+func NewCustomFieldRepositoryFor(db *sql.DB, dialect Dialect) CustomFieldRepository {
+    return &customFieldRepositoryImpl{db: db, dialect: dialect}
+}
+
+func (r *customFieldRepositoryImpl) conn() dbConn {
+    return rebindConn{conn: r.db, dialect: r.dialect}
+}
+
+func encodeOptions(options []string) string {
+    if len(options) == 0 {
+        return "[]"
+    }
+    data, _ := json.Marshal(options)
+    return string(data)
+}
+
+func scanCustomField(scan func(dest ...interface{}) error) (domain.CustomField, error) {
+    var f domain.CustomField
+    var options string
+    if err := scan(&f.ID, &f.Key, &f.Label, &f.Type, &options, &f.Required, &f.CreatedAt); err != nil {
+        return f, err
+    }
+    if err := json.Unmarshal([]byte(options), &f.Options); err != nil {
+        return f, fmt.Errorf("Error reading options of custom field '%s': %w", f.Key, err)
+    }
+    return f, nil
+}
+
+func (r *customFieldRepositoryImpl) Create(field domain.CustomField) (int, error) {
+    query := `INSERT INTO custom_fields (field_key, label, type, options, required) VALUES (?, ?, ?, ?, ?)`
+    args := []interface{}{field.Key, field.Label, field.Type, encodeOptions(field.Options), field.Required}
+    if r.dialect.returning {
+        var id int
+        if err := r.conn().QueryRow(query+` RETURNING id`, args...).Scan(&id); err != nil {
+            return 0, fmt.Errorf("Error creating custom field: %w", err)
+        }
+        return id, nil
+    }
+    result, err := r.conn().Exec(query, args...)
+    if err != nil {
+        return 0, fmt.Errorf("Error creating custom field: %w", err)
+    }
+    insertID, _ := result.LastInsertId()
+    return int(insertID), nil
+}
+
+func (r *customFieldRepositoryImpl) GetByKey(key string) (*domain.CustomField, error) {
+    query := `SELECT id, field_key, label, type, options, required, created_at FROM custom_fields WHERE field_key = ?`
+    f, err := scanCustomField(r.conn().QueryRow(query, key).Scan)
+    if err == sql.ErrNoRows {
+        return nil, nil
+    } else if err != nil {
+        return nil, fmt.Errorf("Error getting custom field: %w", err)
+    }
+    return &f, nil
+}
+
+func (r *customFieldRepositoryImpl) List() ([]domain.CustomField, error) {
+    query := `SELECT id, field_key, label, type, options, required, created_at FROM custom_fields ORDER BY id`
+    rows, err := r.conn().Query(query)
+    if err != nil {
+        return nil, fmt.Errorf("Error getting custom fields: %w", err)
+    }
+    defer rows.Close()
+
+    fields := []domain.CustomField{}
+    for rows.Next() {
+        f, err := scanCustomField(rows.Scan)
+        if err != nil {
+            return nil, err
+        }
+        fields = append(fields, f)
+    }
+    return fields, rows.Err()
+}
+
+// Update changes label, options and required. Key and type cannot change.
+func (r *customFieldRepositoryImpl) Update(field domain.CustomField) error {
+    query := `UPDATE custom_fields SET label = ?, options = ?, required = ? WHERE field_key = ?`
+    _, err := r.conn().Exec(query, field.Label, encodeOptions(field.Options), field.Required, field.Key)
+    if err != nil {
+        return fmt.Errorf("Error updating custom field: %w", err)
+    }
+    return nil
+}
+
+// Delete removes the field and the values of all the candidates in a single transaction
+func (r *customFieldRepositoryImpl) Delete(key string) error {
+    tx, err := r.db.Begin()
+    if err != nil {
+        return fmt.Errorf("Error starting transaction: %w", err)
+    }
+    defer tx.Rollback()
+    exec := rebindConn{conn: tx, dialect: r.dialect}
+
+    if _, err := exec.Exec(`DELETE FROM candidate_field_values WHERE field_key = ?`, key); err != nil {
+        return fmt.Errorf("Error deleting custom field values: %w", err)
+    }
+    if _, err := exec.Exec(`DELETE FROM custom_fields WHERE field_key = ?`, key); err != nil {
+        return fmt.Errorf("Error deleting custom field: %w", err)
+    }
+    if err := tx.Commit(); err != nil {
+        return fmt.Errorf("Error committing delete: %w", err)
+    }
+    return nil
+}
@@ -0,0 +1,183 @@
+package repository
+
+import (
+    "database/sql"
+    "fmt"
+    "io/fs"
+    "regexp"
+    "sort"
+    "strconv"
+    "strings"
+)
+
+// Dialect holds what changes in the SQL of the candidate repository between databases.
+// The queries are written with ? placeholders and rebound for the dialect.
+type Dialect struct {
+    Name       string // driver name and directory of its migrations
+    numbered   bool   // $1, $2... placeholders
+    returning  bool   // INSERT ... RETURNING id instead of LastInsertId
+    onConflict bool   // INSERT ... ON CONFLICT instead of INSERT IGNORE and ON DUPLICATE KEY UPDATE
+    like       string // case-insensitive LIKE operator
+}
+
+var (
+    MySQL    = Dialect{Name: "mysql", like: "LIKE"}
+    Postgres = Dialect{Name: "postgres", numbered: true, returning: true, onConflict: true, like: "ILIKE"}
+    SQLite   = Dialect{Name: "sqlite", returning: true, onConflict: true, like: "LIKE"}
+)
+
+// DialectFor returns the dialect of a database driver
+func DialectFor(driver string) (Dialect, error) {
+    switch driver {
+    case MySQL.Name:
+        return MySQL, nil
+    case Postgres.Name:
+        return Postgres, nil
+    case SQLite.Name:
+        return SQLite, nil
+    default:
+        return Dialect{}, fmt.Errorf("Unsupported database driver '%s'", driver)
+    }
+}
+
+// Rebind converts the ? placeholders of query to the ones of the dialect.
+// Question marks inside quoted strings are kept.
+func (d Dialect) Rebind(query string) string {
+    if !d.numbered {
+        return query
+    }
+    var b strings.Builder
+    n := 0
+    var quote rune
+    for _, ch := range query {
+        switch {
+        case quote != 0:
+            if ch == quote {
+                quote = 0
+            }
+        case ch == '\'' || ch == '"':
+            quote = ch
+        case ch == '?':
+            n++
+            b.WriteString("$" + strconv.Itoa(n))
+            continue
+        }
+        b.WriteRune(ch)
+    }
+    return b.String()
+}
+
+// rebindExecer rebinds the queries run by the helpers shared with the MySQL repositories
+type rebindExecer struct {
+    execer
+    dialect Dialect
+}
+
+func (e rebindExecer) Exec(query string, args ...interface{}) (sql.Result, error) {
+    return e.execer.Exec(e.dialect.Rebind(query), args...)
+}
+
+// rebindConn rebinds the queries of the repositories written for MySQL, so they run on
+// any dialect
+type rebindConn struct {
+    conn    dbConn
+    dialect Dialect
+}
+
+func (c rebindConn) Exec(query string, args ...interface{}) (sql.Result, error) {
+    return c.conn.Exec(c.dialect.Rebind(query), args...)
+}
+
+func (c rebindConn) Query(query string, args ...interface{}) (*sql.Rows, error) {
+    return c.conn.Query(c.dialect.Rebind(query), args...)
+}
+
+func (c rebindConn) QueryRow(query string, args ...interface{}) *sql.Row {
+    return c.conn.QueryRow(c.dialect.Rebind(query), args...)
+}
+
+// insertReturningID runs the INSERT of one row and returns its ID: with RETURNING on the
+// dialects that have it, with LastInsertId on MySQL. db rebinds the query.
+func insertReturningID(db dbConn, dialect Dialect, query string, args ...interface{}) (int, error) {
+    if dialect.returning {
+        var id int
+        err := db.QueryRow(query+` RETURNING id`, args...).Scan(&id)
+        return id, err
+    }
+    result, err := db.Exec(query, args...)
+    if err != nil {
+        return 0, err
+    }
+    id, err := result.LastInsertId()
+    return int(id), err
+}
+
+// quote quotes a column name that is a reserved word in some dialect, such as user
+func (d Dialect) quote(name string) string {
+    if d.Name == MySQL.Name {
+        return "`" + name + "`"
+    }
+    return `"` + name + `"`
+}
+
+var migrationFile = regexp.MustCompile(`^V(\d+)__.+\.sql$`)
+
+// Migrate applies the V<n>__*.sql migrations in the directory of the dialect that are not
+// recorded in schema_migrations yet, in version order. Every file runs as a single
+// statement batch, so MySQL needs multiStatements=true in its DSN.
+func Migrate(db *sql.DB, dialect Dialect, migrations fs.FS) error {
+    entries, err := fs.ReadDir(migrations, dialect.Name)
+    if err != nil {
+        return fmt.Errorf("Error reading migrations: %w", err)
+    }
+    versions := map[int]string{}
+    var order []int
+    for _, e := range entries {
+        m := migrationFile.FindStringSubmatch(e.Name())
+        if m == nil {
+            continue
+        }
+        v, _ := strconv.Atoi(m[1])
+        versions[v] = e.Name()
+        order = append(order, v)
+    }
+    sort.Ints(order)
+
+    if _, err := db.Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (version INT PRIMARY KEY, name VARCHAR(255) NOT NULL)`); err != nil {
+        return fmt.Errorf("Error creating schema_migrations: %w", err)
+    }
+    applied := map[int]bool{}
+    rows, err := db.Query(`SELECT version FROM schema_migrations`)
+    if err != nil {
+        return fmt.Errorf("Error reading schema_migrations: %w", err)
+    }
+    for rows.Next() {
+        var v int
+        if err := rows.Scan(&v); err != nil {
+            rows.Close()
+            return err
+        }
+        applied[v] = true
+    }
+    rows.Close()
+    if err := rows.Err(); err != nil {
+        return err
+    }
+
+    for _, v := range order {
+        if applied[v] {
+            continue
+        }
+        script, err := fs.ReadFile(migrations, dialect.Name+"/"+versions[v])
+        if err != nil {
+            return fmt.Errorf("Error reading migration %s: %w", versions[v], err)
+        }
+        if _, err := db.Exec(string(script)); err != nil {
+            return fmt.Errorf("Error applying migration %s: %w", versions[v], err)
+        }
+        if _, err := db.Exec(dialect.Rebind(`INSERT INTO schema_migrations (version, name) VALUES (?, ?)`), v, versions[v]); err != nil {
+            return fmt.Errorf("Error recording migration %s: %w", versions[v], err)
+        }
+    }
+    return nil
+}
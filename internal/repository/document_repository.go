@@ -0,0 +1,202 @@
+package repository
+
+import (
+    "database/sql"
+    "encoding/json"
+    "fmt"
+    "strings"
+
+    "github.com/torvictorvic/seek-v2/internal/domain"
+)
+
+// DocumentRepository stores the metadata of the candidate documents, the content lives
+// in the storage. Deleted documents are kept until their content is purged.
+type DocumentRepository interface {
+    Create(doc domain.Document) (int, error)
+    GetByID(id int) (*domain.Document, error)
+    ListByCandidate(candidateID int) ([]domain.Document, error)
+    Delete(doc domain.Document, actor string) error
+    // ListDeleted returns up to limit deleted documents whose content is still stored
+    ListDeleted(limit int) ([]domain.Document, error)
+    // Purge removes the row of a deleted document once its content is gone
+    Purge(id int) error
+    // GetText returns the text extracted from the document, empty when it has none
+    GetText(id int) (string, error)
+    // ResumeTexts returns the text of the documents of each candidate, joined
+    ResumeTexts(candidateIDs []int) (map[int]string, error)
+}
+
+type documentRepositoryImpl struct {
+    db      *sql.DB
+    dialect Dialect
+}
+
+func NewDocumentRepository(db *sql.DB) DocumentRepository {
+    return NewDocumentRepositoryFor(db, MySQL)
+}
+
+// NewDocumentRepositoryFor returns the repository for a database of the dialect
+func NewDocumentRepositoryFor(db *sql.DB, dialect Dialect) DocumentRepository {
+    return &documentRepositoryImpl{db: db, dialect: dialect}
+}
+
+// conn rebinds the queries run outside a transaction
+func (r *documentRepositoryImpl) conn() dbConn {
+    return rebindConn{conn: r.db, dialect: r.dialect}
+}
+
+const documentColumns = `id, candidate_id, file_name, content_type, kind, size, sha256, storage_key, uploaded_by, created_at`
+
+func documentHistory(action string, doc domain.Document, actor string) domain.CandidateHistoryEntry {
+    details, _ := json.Marshal(map[string]interface{}{"document_id": doc.ID, "file_name": doc.FileName})
+    return domain.CandidateHistoryEntry{CandidateID: doc.CandidateID, Action: action, Details: details, Actor: actor}
+}
+
+// inTx runs fn in a transaction
+func (r *documentRepositoryImpl) inTx(fn func(tx dbConn) error) error {
+    tx, err := r.db.Begin()
+    if err != nil {
+        return fmt.Errorf("Error starting transaction: %w", err)
+    }
+    defer tx.Rollback()
+
+    if err := fn(rebindConn{conn: tx, dialect: r.dialect}); err != nil {
+        return err
+    }
+    if err := tx.Commit(); err != nil {
+        return fmt.Errorf("Error committing document: %w", err)
+    }
+    return nil
+}
+
+func (r *documentRepositoryImpl) Create(doc domain.Document) (int, error) {
+    err := r.inTx(func(tx dbConn) error {
+        // Images have no text, NULL keeps them out of the FULLTEXT index
+        var text interface{}
+        if doc.Text != "" {
+            text = doc.Text
+        }
+        query := `INSERT INTO documents (candidate_id, file_name, content_type, kind, size, sha256, storage_key, uploaded_by, text_content) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`
+        insertID, err := insertReturningID(tx, r.dialect, query, doc.CandidateID, doc.FileName, doc.ContentType, doc.Kind, doc.Size, doc.SHA256, doc.StorageKey, doc.UploadedBy, text)
+        if err != nil {
+            return fmt.Errorf("Error creating document: %w", err)
+        }
+        doc.ID = insertID
+        _, err = insertHistory(tx, documentHistory(domain.HistoryDocumentAdded, doc, doc.UploadedBy))
+        return err
+    })
+    if err != nil {
+        return 0, err
+    }
+    return doc.ID, nil
+}
+
+func scanDocument(scan func(dest ...interface{}) error) (domain.Document, error) {
+    var d domain.Document
+    err := scan(&d.ID, &d.CandidateID, &d.FileName, &d.ContentType, &d.Kind, &d.Size, &d.SHA256, &d.StorageKey, &d.UploadedBy, &d.CreatedAt)
+    return d, err
+}
+
+func (r *documentRepositoryImpl) list(query string, args ...interface{}) ([]domain.Document, error) {
+    rows, err := r.conn().Query(query, args...)
+    if err != nil {
+        return nil, fmt.Errorf("Error getting documents: %w", err)
+    }
+    defer rows.Close()
+
+    docs := []domain.Document{}
+    for rows.Next() {
+        d, err := scanDocument(rows.Scan)
+        if err != nil {
+            return nil, err
+        }
+        docs = append(docs, d)
+    }
+    return docs, rows.Err()
+}
+
+// GetByID returns the document unless it was deleted
+func (r *documentRepositoryImpl) GetByID(id int) (*domain.Document, error) {
+    row := r.conn().QueryRow(`SELECT `+documentColumns+` FROM documents WHERE id = ? AND deleted_at IS NULL`, id)
+    d, err := scanDocument(row.Scan)
+    if err == sql.ErrNoRows {
+        return nil, nil
+    } else if err != nil {
+        return nil, fmt.Errorf("Error getting document: %w", err)
+    }
+    return &d, nil
+}
+
+// ListByCandidate returns the documents of the candidate, newest first
+func (r *documentRepositoryImpl) ListByCandidate(candidateID int) ([]domain.Document, error) {
+    return r.list(`SELECT `+documentColumns+` FROM documents WHERE candidate_id = ? AND deleted_at IS NULL ORDER BY created_at DESC, id DESC`, candidateID)
+}
+
+// Delete soft-deletes the document, its content is removed by the purge
+func (r *documentRepositoryImpl) Delete(doc domain.Document, actor string) error {
+    return r.inTx(func(tx dbConn) error {
+        if _, err := tx.Exec(`UPDATE documents SET deleted_at = CURRENT_TIMESTAMP WHERE id = ?`, doc.ID); err != nil {
+            return fmt.Errorf("Error deleting document: %w", err)
+        }
+        _, err := insertHistory(tx, documentHistory(domain.HistoryDocumentDeleted, doc, actor))
+        return err
+    })
+}
+
+func (r *documentRepositoryImpl) ListDeleted(limit int) ([]domain.Document, error) {
+    return r.list(`SELECT `+documentColumns+` FROM documents WHERE deleted_at IS NOT NULL ORDER BY deleted_at, id LIMIT ?`, limit)
+}
+
+func (r *documentRepositoryImpl) Purge(id int) error {
+    if _, err := r.conn().Exec(`DELETE FROM documents WHERE id = ? AND deleted_at IS NOT NULL`, id); err != nil {
+        return fmt.Errorf("Error purging document: %w", err)
+    }
+    return nil
+}
+
+func (r *documentRepositoryImpl) GetText(id int) (string, error) {
+    var text sql.NullString
+    err := r.conn().QueryRow(`SELECT text_content FROM documents WHERE id = ? AND deleted_at IS NULL`, id).Scan(&text)
+    if err != nil && err != sql.ErrNoRows {
+        return "", fmt.Errorf("Error getting document text: %w", err)
+    }
+    return text.String, nil
+}
+
+func (r *documentRepositoryImpl) ResumeTexts(candidateIDs []int) (map[int]string, error) {
+    return resumeTexts(r.conn(), candidateIDs)
+}
+
+// resumeTexts joins the text of the documents of each candidate, oldest first
+func resumeTexts(db dbConn, candidateIDs []int) (map[int]string, error) {
+    texts := map[int]string{}
+    if len(candidateIDs) == 0 {
+        return texts, nil
+    }
+    placeholders := make([]string, len(candidateIDs))
+    args := make([]interface{}, len(candidateIDs))
+    for i, id := range candidateIDs {
+        placeholders[i] = "?"
+        args[i] = id
+    }
+    query := `SELECT candidate_id, text_content FROM documents WHERE candidate_id IN (` + strings.Join(placeholders, ", ") +
+        `) AND deleted_at IS NULL AND text_content IS NOT NULL ORDER BY candidate_id, id`
+    rows, err := db.Query(query, args...)
+    if err != nil {
+        return nil, fmt.Errorf("Error getting resume texts: %w", err)
+    }
+    defer rows.Close()
+
+    for rows.Next() {
+        var id int
+        var text string
+        if err := rows.Scan(&id, &text); err != nil {
+            return nil, err
+        }
+        if texts[id] != "" {
+            text = texts[id] + "\n" + text
+        }
+        texts[id] = text
+    }
+    return texts, rows.Err()
+}
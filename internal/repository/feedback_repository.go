@@ -0,0 +1,125 @@
+package repository
+
+import (
+    "database/sql"
+    "fmt"
+    "strings"
+
+    "github.com/torvictorvic/seek-v2/internal/domain"
+)
+
+type FeedbackRepository interface {
+    Create(fb domain.Feedback) (int, error)
+    HasSubmitted(candidateID int, interviewer string) (bool, error)
+    ListByCandidate(candidateID int) ([]domain.Feedback, error)
+}
+
+type feedbackRepositoryImpl struct {
+    db      *sql.DB
+    dialect Dialect
+}
+
+func NewFeedbackRepository(db *sql.DB) FeedbackRepository {
+    return NewFeedbackRepositoryFor(db, MySQL)
+}
+
+// NewFeedbackRepositoryFor returns the repository for a database of the dialect
+func NewFeedbackRepositoryFor(db *sql.DB, dialect Dialect) FeedbackRepository {
+    return &feedbackRepositoryImpl{db: db, dialect: dialect}
+}
+
+// conn rebinds the queries run outside a transaction
+func (r *feedbackRepositoryImpl) conn() dbConn {
+    return rebindConn{conn: r.db, dialect: r.dialect}
+}
+
+// Create saves the feedback and its ratings in a single transaction
+func (r *feedbackRepositoryImpl) Create(fb domain.Feedback) (int, error) {
+    tx, err := r.db.Begin()
+    if err != nil {
+        return 0, fmt.Errorf("Error starting transaction: %w", err)
+    }
+    defer tx.Rollback()
+    exec := rebindConn{conn: tx, dialect: r.dialect}
+
+    query := `INSERT INTO feedback (candidate_id, interview_id, template_id, interviewer, recommendation, notes, score) VALUES (?, ?, ?, ?, ?, ?, ?)`
+    insertID, err := insertReturningID(exec, r.dialect, query, fb.CandidateID, nullableInt(fb.InterviewID), fb.TemplateID, fb.Interviewer, fb.Recommendation, fb.Notes, fb.Score)
+    if err != nil {
+        return 0, fmt.Errorf("Error creating feedback: %w", err)
+    }
+
+    placeholders := make([]string, len(fb.Ratings))
+    args := make([]interface{}, 0, 4*len(fb.Ratings))
+    for i, rt := range fb.Ratings {
+        placeholders[i] = "(?, ?, ?, ?)"
+        args = append(args, insertID, rt.CompetencyID, rt.Score, rt.Comment)
+    }
+    if _, err := exec.Exec(`INSERT INTO feedback_ratings (feedback_id, competency_id, score, comment) VALUES `+strings.Join(placeholders, ", "), args...); err != nil {
+        return 0, fmt.Errorf("Error creating ratings: %w", err)
+    }
+    if err := tx.Commit(); err != nil {
+        return 0, fmt.Errorf("Error committing feedback: %w", err)
+    }
+    return int(insertID), nil
+}
+
+func (r *feedbackRepositoryImpl) HasSubmitted(candidateID int, interviewer string) (bool, error) {
+    var n int
+    err := r.conn().QueryRow(`SELECT COUNT(*) FROM feedback WHERE candidate_id = ? AND interviewer = ?`, candidateID, interviewer).Scan(&n)
+    if err != nil {
+        return false, fmt.Errorf("Error checking feedback: %w", err)
+    }
+    return n > 0, nil
+}
+
+// ListByCandidate returns the feedback of the candidate with its ratings, oldest first
+func (r *feedbackRepositoryImpl) ListByCandidate(candidateID int) ([]domain.Feedback, error) {
+    query := `SELECT id, candidate_id, interview_id, template_id, interviewer, recommendation, notes, score, submitted_at FROM feedback WHERE candidate_id = ? ORDER BY submitted_at, id`
+    rows, err := r.conn().Query(query, candidateID)
+    if err != nil {
+        return nil, fmt.Errorf("Error getting feedback: %w", err)
+    }
+    defer rows.Close()
+
+    list := []domain.Feedback{}
+    byID := map[int]int{}
+    for rows.Next() {
+        var fb domain.Feedback
+        var interviewID sql.NullInt64
+        var notes sql.NullString
+        if err := rows.Scan(&fb.ID, &fb.CandidateID, &interviewID, &fb.TemplateID, &fb.Interviewer, &fb.Recommendation, &notes, &fb.Score, &fb.SubmittedAt); err != nil {
+            return nil, err
+        }
+        if interviewID.Valid {
+            id := int(interviewID.Int64)
+            fb.InterviewID = &id
+        }
+        fb.Notes = notes.String
+        fb.Ratings = []domain.Rating{}
+        byID[fb.ID] = len(list)
+        list = append(list, fb)
+    }
+    if err := rows.Err(); err != nil {
+        return nil, err
+    }
+    if len(list) == 0 {
+        return list, nil
+    }
+
+    rrows, err := r.conn().Query(`SELECT r.feedback_id, r.competency_id, r.score, r.comment FROM feedback_ratings r JOIN feedback f ON f.id = r.feedback_id WHERE f.candidate_id = ? ORDER BY r.feedback_id, r.competency_id`, candidateID)
+    if err != nil {
+        return nil, fmt.Errorf("Error getting ratings: %w", err)
+    }
+    defer rrows.Close()
+    for rrows.Next() {
+        var feedbackID int
+        var rt domain.Rating
+        if err := rrows.Scan(&feedbackID, &rt.CompetencyID, &rt.Score, &rt.Comment); err != nil {
+            return nil, err
+        }
+        if i, ok := byID[feedbackID]; ok {
+            list[i].Ratings = append(list[i].Ratings, rt)
+        }
+    }
+    return list, rrows.Err()
+}
@@ -0,0 +1,100 @@
+package repository
+
+import (
+    "context"
+    "database/sql"
+    "fmt"
+
+    "github.com/torvictorvic/seek-v2/internal/domain"
+)
+
+type CandidateHistoryRepository interface {
+    Add(entry domain.CandidateHistoryEntry) (int, error)
+    ListByCandidate(candidateID int) ([]domain.CandidateHistoryEntry, error)
+}
+
+type candidateHistoryRepositoryImpl struct {
+    db      *sql.DB
+    dialect Dialect
+    tx      *txState // set when bound to a unit of work
+}
+
+func NewCandidateHistoryRepository(db *sql.DB) CandidateHistoryRepository {
+    return NewCandidateHistoryRepositoryFor(db, MySQL)
+}
+
+// NewCandidateHistoryRepositoryFor returns the repository for a database of the dialect
+func NewCandidateHistoryRepositoryFor(db *sql.DB, dialect Dialect) CandidateHistoryRepository {
+    return &candidateHistoryRepositoryImpl{db: db, dialect: dialect}
+}
+
+func (r *candidateHistoryRepositoryImpl) bind(ctx context.Context) interface{} {
+    return CandidateHistoryRepository(&candidateHistoryRepositoryImpl{db: r.db, dialect: r.dialect, tx: txFrom(ctx)})
+}
+
+func (r *candidateHistoryRepositoryImpl) conn() dbConn {
+    if r.tx != nil {
+        return rebindConn{conn: r.tx.tx, dialect: r.dialect}
+    }
+    return rebindConn{conn: r.db, dialect: r.dialect}
+}
+
+// execer is satisfied by *sql.DB and *sql.Tx
+type execer interface {
+    Exec(query string, args ...interface{}) (sql.Result, error)
+}
+
+func insertHistory(db execer, entry domain.CandidateHistoryEntry) (int, error) {
+    query := `INSERT INTO candidate_history (candidate_id, action, details, actor) VALUES (?, ?, ?, ?)`
+    var details interface{}
+    if len(entry.Details) > 0 {
+        details = string(entry.Details)
+    }
+    result, err := db.Exec(query, entry.CandidateID, entry.Action, details, entry.Actor)
+    if err != nil {
+        return 0, fmt.Errorf("Error adding candidate history: %w", err)
+    }
+    id, _ := result.LastInsertId()
+    return int(id), nil
+}
+
+func (r *candidateHistoryRepositoryImpl) Add(entry domain.CandidateHistoryEntry) (int, error) {
+    if !r.dialect.returning {
+        return insertHistory(r.conn(), entry)
+    }
+    var details interface{}
+    if len(entry.Details) > 0 {
+        details = string(entry.Details)
+    }
+    var id int
+    err := r.conn().QueryRow(`INSERT INTO candidate_history (candidate_id, action, details, actor) VALUES (?, ?, ?, ?) RETURNING id`,
+        entry.CandidateID, entry.Action, details, entry.Actor).Scan(&id)
+    if err != nil {
+        return 0, fmt.Errorf("Error adding candidate history: %w", err)
+    }
+    return id, nil
+}
+
+func (r *candidateHistoryRepositoryImpl) ListByCandidate(candidateID int) ([]domain.CandidateHistoryEntry, error) {
+    query := `SELECT id, candidate_id, action, details, actor, created_at FROM candidate_history WHERE candidate_id = ? ORDER BY created_at, id`
+    rows, err := r.conn().Query(query, candidateID)
+    if err != nil {
+        return nil, fmt.Errorf("Error getting candidate history: %w", err)
+    }
+    defer rows.Close()
+
+    entries := []domain.CandidateHistoryEntry{}
+    for rows.Next() {
+        var e domain.CandidateHistoryEntry
+        var details, actor sql.NullString
+        if err := rows.Scan(&e.ID, &e.CandidateID, &e.Action, &details, &actor, &e.CreatedAt); err != nil {
+            return nil, err
+        }
+        if details.Valid {
+            e.Details = []byte(details.String)
+        }
+        e.Actor = actor.String
+        entries = append(entries, e)
+    }
+    return entries, rows.Err()
+}
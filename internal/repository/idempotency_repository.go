@@ -0,0 +1,152 @@
+package repository
+
+import (
+    "database/sql"
+    "fmt"
+    "sync"
+    "time"
+
+    "github.com/torvictorvic/seek-v2/internal/domain"
+)
+
+// IdempotencyRepository stores the responses of the requests sent with an Idempotency-Key
+type IdempotencyRepository interface {
+    // Begin saves the record as in flight unless the key has a record that has not
+    // expired at now, which is returned with created false
+    Begin(record domain.IdempotencyRecord, now time.Time) (*domain.IdempotencyRecord, bool, error)
+    // Complete stores the response of the key, kept until expiresAt
+    Complete(key string, statusCode int, contentType string, body []byte, expiresAt time.Time) error
+    // Release removes a key still in flight, so the request can be retried
+    Release(key string) error
+    PurgeExpired(now time.Time) (int64, error)
+}
+
+type idempotencyRepositoryImpl struct {
+    db      dbConn
+    dialect Dialect
+}
+
+func NewIdempotencyRepository(db *sql.DB) IdempotencyRepository {
+    return NewIdempotencyRepositoryFor(db, MySQL)
+}
+
+// NewIdempotencyRepositoryFor returns the repository for a database of the dialect
+func NewIdempotencyRepositoryFor(db *sql.DB, dialect Dialect) IdempotencyRepository {
+    return &idempotencyRepositoryImpl{db: rebindConn{conn: db, dialect: dialect}, dialect: dialect}
+}
+
+// Begin relies on the primary key, so only one of the concurrent requests with the
+// same key inserts its record
+func (r *idempotencyRepositoryImpl) Begin(record domain.IdempotencyRecord, now time.Time) (*domain.IdempotencyRecord, bool, error) {
+    _, err := r.db.Exec(`DELETE FROM idempotency_keys WHERE idempotency_key = ? AND expires_at < ?`, record.Key, now)
+    if err != nil {
+        return nil, false, fmt.Errorf("Error removing expired idempotency key: %w", err)
+    }
+    query := `INSERT IGNORE INTO idempotency_keys (idempotency_key, fingerprint, expires_at) VALUES (?, ?, ?)`
+    if r.dialect.onConflict {
+        query = `INSERT INTO idempotency_keys (idempotency_key, fingerprint, expires_at) VALUES (?, ?, ?) ON CONFLICT (idempotency_key) DO NOTHING`
+    }
+    result, err := r.db.Exec(query, record.Key, record.Fingerprint, record.ExpiresAt)
+    if err != nil {
+        return nil, false, fmt.Errorf("Error saving idempotency key: %w", err)
+    }
+    if n, _ := result.RowsAffected(); n == 1 {
+        return &record, true, nil
+    }
+
+    var existing domain.IdempotencyRecord
+    var body []byte
+    err = r.db.QueryRow(`SELECT idempotency_key, fingerprint, status_code, content_type, response_body, expires_at FROM idempotency_keys WHERE idempotency_key = ?`, record.Key).
+        Scan(&existing.Key, &existing.Fingerprint, &existing.StatusCode, &existing.ContentType, &body, &existing.ExpiresAt)
+    if err == sql.ErrNoRows {
+        // Released between the insert and the read, the caller may retry
+        existing = domain.IdempotencyRecord{Key: record.Key, Fingerprint: record.Fingerprint}
+        return &existing, false, nil
+    } else if err != nil {
+        return nil, false, fmt.Errorf("Error getting idempotency key: %w", err)
+    }
+    existing.Body = body
+    return &existing, false, nil
+}
+
+func (r *idempotencyRepositoryImpl) Complete(key string, statusCode int, contentType string, body []byte, expiresAt time.Time) error {
+    _, err := r.db.Exec(`UPDATE idempotency_keys SET status_code = ?, content_type = ?, response_body = ?, expires_at = ? WHERE idempotency_key = ?`,
+        statusCode, contentType, body, expiresAt, key)
+    if err != nil {
+        return fmt.Errorf("Error saving idempotent response: %w", err)
+    }
+    return nil
+}
+
+func (r *idempotencyRepositoryImpl) Release(key string) error {
+    _, err := r.db.Exec(`DELETE FROM idempotency_keys WHERE idempotency_key = ? AND status_code = 0`, key)
+    if err != nil {
+        return fmt.Errorf("Error releasing idempotency key: %w", err)
+    }
+    return nil
+}
+
+func (r *idempotencyRepositoryImpl) PurgeExpired(now time.Time) (int64, error) {
+    result, err := r.db.Exec(`DELETE FROM idempotency_keys WHERE expires_at < ?`, now)
+    if err != nil {
+        return 0, fmt.Errorf("Error purging idempotency keys: %w", err)
+    }
+    return result.RowsAffected()
+}
+
+// memoryIdempotencyRepository keeps the records in the process, for a single instance
+// or the tests
+type memoryIdempotencyRepository struct {
+    mu      sync.Mutex
+    records map[string]domain.IdempotencyRecord
+}
+
+func NewMemoryIdempotencyRepository() IdempotencyRepository {
+    return &memoryIdempotencyRepository{records: map[string]domain.IdempotencyRecord{}}
+}
+
+func (r *memoryIdempotencyRepository) Begin(record domain.IdempotencyRecord, now time.Time) (*domain.IdempotencyRecord, bool, error) {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+    if existing, ok := r.records[record.Key]; ok && !existing.ExpiresAt.Before(now) {
+        existing.Body = append([]byte(nil), existing.Body...)
+        return &existing, false, nil
+    }
+    r.records[record.Key] = record
+    return &record, true, nil
+}
+
+func (r *memoryIdempotencyRepository) Complete(key string, statusCode int, contentType string, body []byte, expiresAt time.Time) error {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+    record, ok := r.records[key]
+    if !ok {
+        return nil
+    }
+    record.StatusCode, record.ContentType, record.ExpiresAt = statusCode, contentType, expiresAt
+    record.Body = append([]byte(nil), body...)
+    r.records[key] = record
+    return nil
+}
+
+func (r *memoryIdempotencyRepository) Release(key string) error {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+    if record, ok := r.records[key]; ok && record.InFlight() {
+        delete(r.records, key)
+    }
+    return nil
+}
+
+func (r *memoryIdempotencyRepository) PurgeExpired(now time.Time) (int64, error) {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+    var n int64
+    for key, record := range r.records {
+        if record.ExpiresAt.Before(now) {
+            delete(r.records, key)
+            n++
+        }
+    }
+    return n, nil
+}
@@ -0,0 +1,227 @@
+package repository
+
+import (
+    "database/sql"
+    "fmt"
+    "strings"
+    "time"
+
+    "github.com/torvictorvic/seek-v2/internal/domain"
+)
+
+type InterviewRepository interface {
+    Create(iv domain.Interview) (int, error)
+    GetByID(id int) (*domain.Interview, error)
+    ListByCandidate(candidateID int) ([]domain.Interview, error)
+    ListByInterviewer(interviewer string, from time.Time) ([]domain.Interview, error)
+    FindOverlapping(iv domain.Interview) ([]domain.Interview, error)
+    Update(iv domain.Interview) error
+    Delete(id int) error
+}
+
+type interviewRepositoryImpl struct {
+    db      *sql.DB
+    dialect Dialect
+}
+
+func NewInterviewRepository(db *sql.DB) InterviewRepository {
+    return NewInterviewRepositoryFor(db, MySQL)
+}
+
+// NewInterviewRepositoryFor returns the repository for a database of the dialect
+func NewInterviewRepositoryFor(db *sql.DB, dialect Dialect) InterviewRepository {
+    return &interviewRepositoryImpl{db: db, dialect: dialect}
+}
+
+// conn rebinds the queries run outside a transaction
+func (r *interviewRepositoryImpl) conn() dbConn {
+    return rebindConn{conn: r.db, dialect: r.dialect}
+}
+
+const interviewColumns = `i.id, i.candidate_id, i.application_id, i.starts_at, i.ends_at, i.time_zone, i.location, i.video_link, i.type, i.sequence, i.created_by, i.created_at, i.updated_at`
+
+func nullableInt(v *int) interface{} {
+    if v == nil {
+        return nil
+    }
+    return *v
+}
+
+func insertInterviewers(db execer, interviewID int, interviewers []string) error {
+    if len(interviewers) == 0 {
+        return nil
+    }
+    placeholders := make([]string, len(interviewers))
+    args := make([]interface{}, 0, 2*len(interviewers))
+    for i, name := range interviewers {
+        placeholders[i] = "(?, ?)"
+        args = append(args, interviewID, name)
+    }
+    query := `INSERT INTO interview_interviewers (interview_id, interviewer) VALUES ` + strings.Join(placeholders, ", ")
+    if _, err := db.Exec(query, args...); err != nil {
+        return fmt.Errorf("Error saving interviewers: %w", err)
+    }
+    return nil
+}
+
+// Create saves the interview and its interviewers in a single transaction
+func (r *interviewRepositoryImpl) Create(iv domain.Interview) (int, error) {
+    tx, err := r.db.Begin()
+    if err != nil {
+        return 0, fmt.Errorf("Error starting transaction: %w", err)
+    }
+    defer tx.Rollback()
+    exec := rebindConn{conn: tx, dialect: r.dialect}
+
+    query := `INSERT INTO interviews (candidate_id, application_id, starts_at, ends_at, time_zone, location, video_link, type, created_by) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`
+    insertID, err := insertReturningID(exec, r.dialect, query, iv.CandidateID, nullableInt(iv.ApplicationID), iv.StartsAt.UTC(), iv.EndsAt.UTC(),
+        iv.TimeZone, iv.Location, iv.VideoLink, iv.Type, iv.CreatedBy)
+    if err != nil {
+        return 0, fmt.Errorf("Error creating interview: %w", err)
+    }
+
+    if err := insertInterviewers(exec, int(insertID), iv.Interviewers); err != nil {
+        return 0, err
+    }
+    if err := tx.Commit(); err != nil {
+        return 0, fmt.Errorf("Error committing interview: %w", err)
+    }
+    return int(insertID), nil
+}
+
+func (r *interviewRepositoryImpl) GetByID(id int) (*domain.Interview, error) {
+    interviews, err := r.query(`SELECT `+interviewColumns+` FROM interviews i WHERE i.id = ?`, id)
+    if err != nil {
+        return nil, err
+    }
+    if len(interviews) == 0 {
+        return nil, nil
+    }
+    return &interviews[0], nil
+}
+
+func (r *interviewRepositoryImpl) ListByCandidate(candidateID int) ([]domain.Interview, error) {
+    return r.query(`SELECT `+interviewColumns+` FROM interviews i WHERE i.candidate_id = ? ORDER BY i.starts_at, i.id`, candidateID)
+}
+
+// ListByInterviewer returns the interviews of the interviewer that end after from
+func (r *interviewRepositoryImpl) ListByInterviewer(interviewer string, from time.Time) ([]domain.Interview, error) {
+    query := `SELECT ` + interviewColumns + ` FROM interviews i JOIN interview_interviewers ii ON ii.interview_id = i.id WHERE ii.interviewer = ? AND i.ends_at > ? ORDER BY i.starts_at, i.id`
+    return r.query(query, interviewer, from.UTC())
+}
+
+// FindOverlapping returns the other interviews that share part of the time range of iv
+// with the same candidate or with any of its interviewers
+func (r *interviewRepositoryImpl) FindOverlapping(iv domain.Interview) ([]domain.Interview, error) {
+    conds := "i.candidate_id = ?"
+    // Two ranges overlap when each one starts before the other ends
+    args := []interface{}{iv.EndsAt.UTC(), iv.StartsAt.UTC(), iv.ID, iv.CandidateID}
+    if len(iv.Interviewers) > 0 {
+        conds += " OR ii.interviewer IN (?" + strings.Repeat(", ?", len(iv.Interviewers)-1) + ")"
+        for _, name := range iv.Interviewers {
+            args = append(args, name)
+        }
+    }
+    query := `SELECT DISTINCT ` + interviewColumns + ` FROM interviews i LEFT JOIN interview_interviewers ii ON ii.interview_id = i.id` +
+        ` WHERE i.starts_at < ? AND i.ends_at > ? AND i.id <> ? AND (` + conds + `) ORDER BY i.starts_at, i.id`
+    return r.query(query, args...)
+}
+
+// Update saves the interview, replaces its interviewers and increments its sequence
+func (r *interviewRepositoryImpl) Update(iv domain.Interview) error {
+    tx, err := r.db.Begin()
+    if err != nil {
+        return fmt.Errorf("Error starting transaction: %w", err)
+    }
+    defer tx.Rollback()
+    exec := rebindConn{conn: tx, dialect: r.dialect}
+
+    query := `UPDATE interviews SET application_id = ?, starts_at = ?, ends_at = ?, time_zone = ?, location = ?, video_link = ?, type = ?, sequence = sequence + 1 WHERE id = ?`
+    if _, err := exec.Exec(query, nullableInt(iv.ApplicationID), iv.StartsAt.UTC(), iv.EndsAt.UTC(),
+        iv.TimeZone, iv.Location, iv.VideoLink, iv.Type, iv.ID); err != nil {
+        return fmt.Errorf("Error updating interview: %w", err)
+    }
+    if _, err := exec.Exec(`DELETE FROM interview_interviewers WHERE interview_id = ?`, iv.ID); err != nil {
+        return fmt.Errorf("Error updating interviewers: %w", err)
+    }
+    if err := insertInterviewers(exec, iv.ID, iv.Interviewers); err != nil {
+        return err
+    }
+    if err := tx.Commit(); err != nil {
+        return fmt.Errorf("Error committing interview: %w", err)
+    }
+    return nil
+}
+
+func (r *interviewRepositoryImpl) Delete(id int) error {
+    tx, err := r.db.Begin()
+    if err != nil {
+        return fmt.Errorf("Error starting transaction: %w", err)
+    }
+    defer tx.Rollback()
+    exec := rebindConn{conn: tx, dialect: r.dialect}
+
+    if _, err := exec.Exec(`DELETE FROM interview_interviewers WHERE interview_id = ?`, id); err != nil {
+        return fmt.Errorf("Error deleting interviewers: %w", err)
+    }
+    if _, err := exec.Exec(`DELETE FROM interviews WHERE id = ?`, id); err != nil {
+        return fmt.Errorf("Error deleting interview: %w", err)
+    }
+    return tx.Commit()
+}
+
+// query runs a select of interviewColumns and loads the interviewers of the result
+func (r *interviewRepositoryImpl) query(query string, args ...interface{}) ([]domain.Interview, error) {
+    rows, err := r.conn().Query(query, args...)
+    if err != nil {
+        return nil, fmt.Errorf("Error getting interviews: %w", err)
+    }
+    defer rows.Close()
+
+    interviews := []domain.Interview{}
+    byID := map[int]int{}
+    for rows.Next() {
+        var iv domain.Interview
+        var applicationID sql.NullInt64
+        if err := rows.Scan(&iv.ID, &iv.CandidateID, &applicationID, &iv.StartsAt, &iv.EndsAt, &iv.TimeZone,
+            &iv.Location, &iv.VideoLink, &iv.Type, &iv.Sequence, &iv.CreatedBy, &iv.CreatedAt, &iv.UpdatedAt); err != nil {
+            return nil, err
+        }
+        if applicationID.Valid {
+            id := int(applicationID.Int64)
+            iv.ApplicationID = &id
+        }
+        iv.StartsAt, iv.EndsAt = iv.StartsAt.UTC(), iv.EndsAt.UTC()
+        iv.Interviewers = []string{}
+        byID[iv.ID] = len(interviews)
+        interviews = append(interviews, iv)
+    }
+    if err := rows.Err(); err != nil {
+        return nil, err
+    }
+    if len(interviews) == 0 {
+        return interviews, nil
+    }
+
+    ids := make([]interface{}, 0, len(interviews))
+    for _, iv := range interviews {
+        ids = append(ids, iv.ID)
+    }
+    irows, err := r.conn().Query(`SELECT interview_id, interviewer FROM interview_interviewers WHERE interview_id IN (?`+
+        strings.Repeat(", ?", len(ids)-1)+`) ORDER BY interview_id, interviewer`, ids...)
+    if err != nil {
+        return nil, fmt.Errorf("Error getting interviewers: %w", err)
+    }
+    defer irows.Close()
+    for irows.Next() {
+        var id int
+        var name string
+        if err := irows.Scan(&id, &name); err != nil {
+            return nil, err
+        }
+        if i, ok := byID[id]; ok {
+            interviews[i].Interviewers = append(interviews[i].Interviewers, name)
+        }
+    }
+    return interviews, irows.Err()
+}
@@ -0,0 +1,108 @@
+package repository
+
+import (
+    "database/sql"
+    "fmt"
+
+    "github.com/torvictorvic/seek-v2/internal/domain"
+)
+
+type JobRepository interface {
+    Create(job domain.Job) (int, error)
+    GetByID(id int) (*domain.Job, error)
+    GetAll(filter domain.JobFilter) ([]domain.Job, error)
+    Update(job domain.Job) error
+    Delete(id int) error
+}
+
+type jobRepositoryImpl struct {
+    db      dbConn
+    dialect Dialect
+}
+
+func NewJobRepository(db *sql.DB) JobRepository {
+    return NewJobRepositoryFor(db, MySQL)
+}
+
+// NewJobRepositoryFor returns the repository for a database of the dialect
+func NewJobRepositoryFor(db *sql.DB, dialect Dialect) JobRepository {
+    return &jobRepositoryImpl{db: rebindConn{conn: db, dialect: dialect}, dialect: dialect}
+}
+
+func (r *jobRepositoryImpl) Create(job domain.Job) (int, error) {
+    query := `INSERT INTO jobs (title, department, location, salary_min, salary_max, status, hiring_manager) VALUES (?, ?, ?, ?, ?, ?, ?)`
+    id, err := insertReturningID(r.db, r.dialect, query, job.Title, job.Department, job.Location, job.SalaryMin, job.SalaryMax, job.Status, job.HiringManager)
+    if err != nil {
+        return 0, fmt.Errorf("Error creating job: %w", err)
+    }
+    return id, nil
+}
+
+func (r *jobRepositoryImpl) GetByID(id int) (*domain.Job, error) {
+    query := `SELECT id, title, department, location, salary_min, salary_max, status, hiring_manager, created_at, updated_at FROM jobs WHERE id = ?`
+    row := r.db.QueryRow(query, id)
+
+    var j domain.Job
+    err := row.Scan(&j.ID, &j.Title, &j.Department, &j.Location, &j.SalaryMin, &j.SalaryMax, &j.Status, &j.HiringManager, &j.CreatedAt, &j.UpdatedAt)
+    if err == sql.ErrNoRows {
+        return nil, nil
+    } else if err != nil {
+        return nil, fmt.Errorf("Error getting job by ID: %w", err)
+    }
+    return &j, nil
+}
+
+func (r *jobRepositoryImpl) GetAll(filter domain.JobFilter) ([]domain.Job, error) {
+    var conds []string
+    var args []interface{}
+    if filter.Title != "" {
+        conds = append(conds, "title "+r.dialect.like+" ?")
+        args = append(args, "%"+filter.Title+"%")
+    }
+    for _, f := range []struct{ column, value string }{
+        {"department", filter.Department},
+        {"location", filter.Location},
+        {"status", filter.Status},
+        {"hiring_manager", filter.HiringManager},
+    } {
+        if f.value != "" {
+            conds = append(conds, f.column+" = ?")
+            args = append(args, f.value)
+        }
+    }
+
+    query := `SELECT id, title, department, location, salary_min, salary_max, status, hiring_manager, created_at, updated_at FROM jobs` + whereClause(conds)
+    rows, err := r.db.Query(query, args...)
+    if err != nil {
+        return nil, fmt.Errorf("Error getting job list: %w", err)
+    }
+    defer rows.Close()
+
+    jobs := []domain.Job{}
+    for rows.Next() {
+        var j domain.Job
+        if err := rows.Scan(&j.ID, &j.Title, &j.Department, &j.Location, &j.SalaryMin, &j.SalaryMax, &j.Status, &j.HiringManager, &j.CreatedAt, &j.UpdatedAt); err != nil {
+            return nil, err
+        }
+        jobs = append(jobs, j)
+    }
+    return jobs, rows.Err()
+}
+
+func (r *jobRepositoryImpl) Update(job domain.Job) error {
+    query := `UPDATE jobs SET title = ?, department = ?, location = ?, salary_min = ?, salary_max = ?, status = ?, hiring_manager = ? WHERE id = ?`
+    _, err := r.db.Exec(query, job.Title, job.Department, job.Location, job.SalaryMin, job.SalaryMax, job.Status, job.HiringManager, job.ID)
+    if err != nil {
+        return fmt.Errorf("Error updating job: %w", err)
+    }
+    return nil
+}
+
+func (r *jobRepositoryImpl) Delete(id int) error {
+    query := `DELETE FROM jobs WHERE id = ?`
+    _, err := r.db.Exec(query, id)
+    if err != nil {
+        return fmt.Errorf("Error deleting job: %w", err)
+    }
+    return nil
+}
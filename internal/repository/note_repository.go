@@ -0,0 +1,198 @@
+package repository
+
+import (
+    "database/sql"
+    "encoding/json"
+    "fmt"
+    "strings"
+
+    "github.com/torvictorvic/seek-v2/internal/domain"
+)
+
+// NoteRepository stores the notes of the candidates. Every write records its entry in the
+// candidate history, and the mention notifications, in the same transaction.
+type NoteRepository interface {
+    Create(note domain.Note, notifications []domain.Notification) (int, error)
+    GetByID(id int) (*domain.Note, error)
+    ListByCandidate(candidateID int, viewer string) ([]domain.Note, error)
+    Update(note domain.Note, previous domain.NoteRevision, notifications []domain.Notification) error
+    SetPinned(note domain.Note, actor string) error
+    Delete(note domain.Note, actor string) error
+    ListRevisions(noteID int) ([]domain.NoteRevision, error)
+}
+
+type noteRepositoryImpl struct {
+    db      *sql.DB
+    dialect Dialect
+}
+
+func NewNoteRepository(db *sql.DB) NoteRepository {
+    return NewNoteRepositoryFor(db, MySQL)
+}
+
+// NewNoteRepositoryFor returns the repository for a database of the dialect
+func NewNoteRepositoryFor(db *sql.DB, dialect Dialect) NoteRepository {
+    return &noteRepositoryImpl{db: db, dialect: dialect}
+}
+
+// conn rebinds the queries run outside a transaction
+func (r *noteRepositoryImpl) conn() dbConn {
+    return rebindConn{conn: r.db, dialect: r.dialect}
+}
+
+const noteColumns = `id, candidate_id, author, body, visibility, pinned, mentions, edited, created_at, updated_at`
+
+// noteHistory builds the audit entry of a note change. The body is left out so private
+// notes do not leak through the history.
+func noteHistory(action string, note domain.Note, actor string) domain.CandidateHistoryEntry {
+    details, _ := json.Marshal(map[string]interface{}{"note_id": note.ID, "visibility": note.Visibility})
+    return domain.CandidateHistoryEntry{CandidateID: note.CandidateID, Action: action, Details: details, Actor: actor}
+}
+
+// inTx runs fn in a transaction
+func (r *noteRepositoryImpl) inTx(fn func(tx dbConn) error) error {
+    tx, err := r.db.Begin()
+    if err != nil {
+        return fmt.Errorf("Error starting transaction: %w", err)
+    }
+    defer tx.Rollback()
+
+    if err := fn(rebindConn{conn: tx, dialect: r.dialect}); err != nil {
+        return err
+    }
+    if err := tx.Commit(); err != nil {
+        return fmt.Errorf("Error committing note: %w", err)
+    }
+    return nil
+}
+
+func (r *noteRepositoryImpl) Create(note domain.Note, notifications []domain.Notification) (int, error) {
+    err := r.inTx(func(tx dbConn) error {
+        query := `INSERT INTO notes (candidate_id, author, body, visibility, mentions) VALUES (?, ?, ?, ?, ?)`
+        insertID, err := insertReturningID(tx, r.dialect, query, note.CandidateID, note.Author, note.Body, note.Visibility, strings.Join(note.Mentions, ","))
+        if err != nil {
+            return fmt.Errorf("Error creating note: %w", err)
+        }
+        note.ID = insertID
+
+        if _, err := insertHistory(tx, noteHistory(domain.HistoryNoteAdded, note, note.Author)); err != nil {
+            return err
+        }
+        for i := range notifications {
+            notifications[i].NoteID = note.ID
+        }
+        return insertNotifications(tx, r.dialect, notifications)
+    })
+    if err != nil {
+        return 0, err
+    }
+    return note.ID, nil
+}
+
+func scanNote(scan func(dest ...interface{}) error) (domain.Note, error) {
+    var n domain.Note
+    var mentions string
+    if err := scan(&n.ID, &n.CandidateID, &n.Author, &n.Body, &n.Visibility, &n.Pinned, &mentions, &n.Edited, &n.CreatedAt, &n.UpdatedAt); err != nil {
+        return n, err
+    }
+    n.Mentions = []string{}
+    if mentions != "" {
+        n.Mentions = strings.Split(mentions, ",")
+    }
+    return n, nil
+}
+
+// GetByID returns the note unless it was deleted
+func (r *noteRepositoryImpl) GetByID(id int) (*domain.Note, error) {
+    row := r.conn().QueryRow(`SELECT `+noteColumns+` FROM notes WHERE id = ? AND deleted_at IS NULL`, id)
+    n, err := scanNote(row.Scan)
+    if err == sql.ErrNoRows {
+        return nil, nil
+    } else if err != nil {
+        return nil, fmt.Errorf("Error getting note: %w", err)
+    }
+    return &n, nil
+}
+
+// ListByCandidate returns the team notes and the private notes of the viewer, pinned first
+func (r *noteRepositoryImpl) ListByCandidate(candidateID int, viewer string) ([]domain.Note, error) {
+    query := `SELECT ` + noteColumns + ` FROM notes WHERE candidate_id = ? AND deleted_at IS NULL AND (visibility = ? OR author = ?) ORDER BY pinned DESC, created_at DESC, id DESC`
+    rows, err := r.conn().Query(query, candidateID, domain.NoteTeam, viewer)
+    if err != nil {
+        return nil, fmt.Errorf("Error getting notes: %w", err)
+    }
+    defer rows.Close()
+
+    notes := []domain.Note{}
+    for rows.Next() {
+        n, err := scanNote(rows.Scan)
+        if err != nil {
+            return nil, err
+        }
+        notes = append(notes, n)
+    }
+    return notes, rows.Err()
+}
+
+// Update saves the new version of the note and keeps the previous one as a revision
+func (r *noteRepositoryImpl) Update(note domain.Note, previous domain.NoteRevision, notifications []domain.Notification) error {
+    return r.inTx(func(tx dbConn) error {
+        if _, err := tx.Exec(`INSERT INTO note_revisions (note_id, body, visibility, editor) VALUES (?, ?, ?, ?)`,
+            note.ID, previous.Body, previous.Visibility, previous.Editor); err != nil {
+            return fmt.Errorf("Error saving note revision: %w", err)
+        }
+        query := `UPDATE notes SET body = ?, visibility = ?, mentions = ?, edited = TRUE WHERE id = ?`
+        if _, err := tx.Exec(query, note.Body, note.Visibility, strings.Join(note.Mentions, ","), note.ID); err != nil {
+            return fmt.Errorf("Error updating note: %w", err)
+        }
+        if _, err := insertHistory(tx, noteHistory(domain.HistoryNoteEdited, note, previous.Editor)); err != nil {
+            return err
+        }
+        return insertNotifications(tx, r.dialect, notifications)
+    })
+}
+
+// SetPinned saves note.Pinned
+func (r *noteRepositoryImpl) SetPinned(note domain.Note, actor string) error {
+    action := domain.HistoryNoteUnpinned
+    if note.Pinned {
+        action = domain.HistoryNotePinned
+    }
+    return r.inTx(func(tx dbConn) error {
+        if _, err := tx.Exec(`UPDATE notes SET pinned = ? WHERE id = ?`, note.Pinned, note.ID); err != nil {
+            return fmt.Errorf("Error pinning note: %w", err)
+        }
+        _, err := insertHistory(tx, noteHistory(action, note, actor))
+        return err
+    })
+}
+
+// Delete soft-deletes the note, it stays in the database with its revisions
+func (r *noteRepositoryImpl) Delete(note domain.Note, actor string) error {
+    return r.inTx(func(tx dbConn) error {
+        if _, err := tx.Exec(`UPDATE notes SET deleted_at = CURRENT_TIMESTAMP WHERE id = ?`, note.ID); err != nil {
+            return fmt.Errorf("Error deleting note: %w", err)
+        }
+        _, err := insertHistory(tx, noteHistory(domain.HistoryNoteDeleted, note, actor))
+        return err
+    })
+}
+
+func (r *noteRepositoryImpl) ListRevisions(noteID int) ([]domain.NoteRevision, error) {
+    query := `SELECT id, note_id, body, visibility, editor, created_at FROM note_revisions WHERE note_id = ? ORDER BY created_at, id`
+    rows, err := r.conn().Query(query, noteID)
+    if err != nil {
+        return nil, fmt.Errorf("Error getting note revisions: %w", err)
+    }
+    defer rows.Close()
+
+    revisions := []domain.NoteRevision{}
+    for rows.Next() {
+        var rv domain.NoteRevision
+        if err := rows.Scan(&rv.ID, &rv.NoteID, &rv.Body, &rv.Visibility, &rv.Editor, &rv.CreatedAt); err != nil {
+            return nil, err
+        }
+        revisions = append(revisions, rv)
+    }
+    return revisions, rows.Err()
+}
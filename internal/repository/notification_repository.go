@@ -0,0 +1,98 @@
+package repository
+
+import (
+    "database/sql"
+    "fmt"
+    "strings"
+    "time"
+
+    "github.com/torvictorvic/seek-v2/internal/domain"
+)
+
+type NotificationRepository interface {
+    ListByUser(user string, unreadOnly bool) ([]domain.Notification, error)
+    MarkRead(id int, user string) (bool, error)
+}
+
+type notificationRepositoryImpl struct {
+    db   dbConn
+    user string // quoted name of the user column, a reserved word in PostgreSQL
+}
+
+func NewNotificationRepository(db *sql.DB) NotificationRepository {
+    return NewNotificationRepositoryFor(db, MySQL)
+}
+
+// NewNotificationRepositoryFor returns the repository for a database of the dialect
+func NewNotificationRepositoryFor(db *sql.DB, dialect Dialect) NotificationRepository {
+    return &notificationRepositoryImpl{db: rebindConn{conn: db, dialect: dialect}, user: dialect.quote("user")}
+}
+
+// insertNotifications is used by the writes that notify, inside their own transaction. db rebinds the query.
+func insertNotifications(db execer, dialect Dialect, notifications []domain.Notification) error {
+    if len(notifications) == 0 {
+        return nil
+    }
+    placeholders := make([]string, len(notifications))
+    args := make([]interface{}, 0, 6*len(notifications))
+    for i, n := range notifications {
+        placeholders[i] = "(?, ?, ?, ?, ?, ?)"
+        var noteID interface{}
+        if n.NoteID != 0 {
+            noteID = n.NoteID
+        }
+        args = append(args, n.User, n.Type, n.CandidateID, noteID, n.Actor, n.Message)
+    }
+    query := `INSERT INTO notifications (` + dialect.quote("user") + `, type, candidate_id, note_id, actor, message) VALUES ` + strings.Join(placeholders, ", ")
+    if _, err := db.Exec(query, args...); err != nil {
+        return fmt.Errorf("Error creating notifications: %w", err)
+    }
+    return nil
+}
+
+func (r *notificationRepositoryImpl) ListByUser(user string, unreadOnly bool) ([]domain.Notification, error) {
+    query := `SELECT id, ` + r.user + `, type, candidate_id, note_id, actor, message, read_at, created_at FROM notifications WHERE ` + r.user + ` = ?`
+    if unreadOnly {
+        query += ` AND read_at IS NULL`
+    }
+    query += ` ORDER BY created_at DESC, id DESC LIMIT 200`
+    rows, err := r.db.Query(query, user)
+    if err != nil {
+        return nil, fmt.Errorf("Error getting notifications: %w", err)
+    }
+    defer rows.Close()
+
+    list := []domain.Notification{}
+    for rows.Next() {
+        var n domain.Notification
+        var noteID sql.NullInt64
+        var readAt sql.NullTime
+        if err := rows.Scan(&n.ID, &n.User, &n.Type, &n.CandidateID, &noteID, &n.Actor, &n.Message, &readAt, &n.CreatedAt); err != nil {
+            return nil, err
+        }
+        n.NoteID = int(noteID.Int64)
+        if readAt.Valid {
+            t := readAt.Time
+            n.ReadAt = &t
+        }
+        list = append(list, n)
+    }
+    return list, rows.Err()
+}
+
+// MarkRead marks the notification of the user as read. It reports whether it exists.
+func (r *notificationRepositoryImpl) MarkRead(id int, user string) (bool, error) {
+    result, err := r.db.Exec(`UPDATE notifications SET read_at = ? WHERE id = ? AND `+r.user+` = ? AND read_at IS NULL`, time.Now().UTC(), id, user)
+    if err != nil {
+        return false, fmt.Errorf("Error updating notification: %w", err)
+    }
+    if n, _ := result.RowsAffected(); n > 0 {
+        return true, nil
+    }
+    // MySQL does not count unchanged rows, it may exist and be already read
+    var count int
+    if err := r.db.QueryRow(`SELECT COUNT(*) FROM notifications WHERE id = ? AND `+r.user+` = ?`, id, user).Scan(&count); err != nil {
+        return false, fmt.Errorf("Error getting notification: %w", err)
+    }
+    return count > 0, nil
+}
@@ -0,0 +1,170 @@
+package repository
+
+import (
+    "crypto/rand"
+    "database/sql"
+    "encoding/hex"
+    "encoding/json"
+    "fmt"
+    "strings"
+    "time"
+
+    "github.com/torvictorvic/seek-v2/internal/domain"
+)
+
+// OutboxRepository reads the pending events of the outbox for the relay
+type OutboxRepository interface {
+    Pending(limit int) ([]domain.OutboxEvent, error)
+    // After returns the events written after the given ID, published or not, in order
+    After(id int64, limit int) ([]domain.OutboxEvent, error)
+    // LastID returns the ID of the newest event, 0 when there are none
+    LastID() (int64, error)
+    MarkPublished(ids []int64) error
+    MarkFailed(id int64, reason string) error
+    Lag() (int, *time.Time, error)
+    PurgePublished(before time.Time) (int64, error)
+}
+
+type outboxRepositoryImpl struct {
+    db dbConn
+}
+
+func NewOutboxRepository(db *sql.DB) OutboxRepository {
+    return NewOutboxRepositoryFor(db, MySQL)
+}
+
+// NewOutboxRepositoryFor returns the repository for a database of the dialect
+func NewOutboxRepositoryFor(db *sql.DB, dialect Dialect) OutboxRepository {
+    return &outboxRepositoryImpl{db: rebindConn{conn: db, dialect: dialect}}
+}
+
+// candidateEvent builds the event of a change to a candidate, with a new idempotency key
+func candidateEvent(eventType string, c domain.Candidate, sourceID int) domain.OutboxEvent {
+    payload := domain.CandidateEventPayload{ID: c.ID, SourceID: sourceID}
+    if eventType != domain.EventCandidateDeleted {
+        payload.Name, payload.Email, payload.Gender, payload.SalaryExpected = c.Name, c.Email, c.Gender, c.SalaryExpected
+    }
+    data, _ := json.Marshal(payload)
+    key := make([]byte, 16)
+    rand.Read(key)
+    return domain.OutboxEvent{
+        Type:           eventType,
+        AggregateID:    c.ID,
+        IdempotencyKey: hex.EncodeToString(key),
+        Payload:        data,
+    }
+}
+
+// insertEvents adds the events to the outbox. It is called with the transaction of the
+// change, so the events exist if and only if the change is committed. The query uses ?
+// placeholders, so db rebinds it on the other dialects.
+func insertEvents(db execer, events ...domain.OutboxEvent) error {
+    if len(events) == 0 {
+        return nil
+    }
+    placeholders := make([]string, len(events))
+    args := make([]interface{}, 0, len(events)*4)
+    for i, e := range events {
+        placeholders[i] = "(?, ?, ?, ?)"
+        args = append(args, e.Type, e.AggregateID, e.IdempotencyKey, string(e.Payload))
+    }
+    query := `INSERT INTO outbox (event_type, aggregate_id, idempotency_key, payload) VALUES ` + strings.Join(placeholders, ", ")
+    if _, err := db.Exec(query, args...); err != nil {
+        return fmt.Errorf("Error adding outbox events: %w", err)
+    }
+    return nil
+}
+
+// Pending returns the oldest events not published yet, in the order they were written
+func (r *outboxRepositoryImpl) Pending(limit int) ([]domain.OutboxEvent, error) {
+    query := `SELECT id, event_type, aggregate_id, idempotency_key, payload, created_at, attempts
+        FROM outbox WHERE published_at IS NULL ORDER BY id LIMIT ?`
+    return r.list(query, limit)
+}
+
+func (r *outboxRepositoryImpl) After(id int64, limit int) ([]domain.OutboxEvent, error) {
+    query := `SELECT id, event_type, aggregate_id, idempotency_key, payload, created_at, attempts
+        FROM outbox WHERE id > ? ORDER BY id LIMIT ?`
+    return r.list(query, id, limit)
+}
+
+func (r *outboxRepositoryImpl) LastID() (int64, error) {
+    var id int64
+    if err := r.db.QueryRow(`SELECT COALESCE(MAX(id), 0) FROM outbox`).Scan(&id); err != nil {
+        return 0, fmt.Errorf("Error getting the last outbox event: %w", err)
+    }
+    return id, nil
+}
+
+func (r *outboxRepositoryImpl) list(query string, args ...interface{}) ([]domain.OutboxEvent, error) {
+    rows, err := r.db.Query(query, args...)
+    if err != nil {
+        return nil, fmt.Errorf("Error getting outbox events: %w", err)
+    }
+    defer rows.Close()
+
+    events := []domain.OutboxEvent{}
+    for rows.Next() {
+        var e domain.OutboxEvent
+        var payload string
+        if err := rows.Scan(&e.ID, &e.Type, &e.AggregateID, &e.IdempotencyKey, &payload, &e.CreatedAt, &e.Attempts); err != nil {
+            return nil, err
+        }
+        e.Payload = json.RawMessage(payload)
+        events = append(events, e)
+    }
+    return events, rows.Err()
+}
+
+func (r *outboxRepositoryImpl) MarkPublished(ids []int64) error {
+    if len(ids) == 0 {
+        return nil
+    }
+    placeholders := make([]string, len(ids))
+    args := make([]interface{}, len(ids))
+    for i, id := range ids {
+        placeholders[i] = "?"
+        args[i] = id
+    }
+    query := `UPDATE outbox SET published_at = CURRENT_TIMESTAMP, attempts = attempts + 1, last_error = NULL
+        WHERE id IN (` + strings.Join(placeholders, ", ") + `)`
+    if _, err := r.db.Exec(query, args...); err != nil {
+        return fmt.Errorf("Error marking outbox events as published: %w", err)
+    }
+    return nil
+}
+
+// MarkFailed records a failed publish attempt, the event stays pending
+func (r *outboxRepositoryImpl) MarkFailed(id int64, reason string) error {
+    if len(reason) > 255 {
+        reason = reason[:255]
+    }
+    if _, err := r.db.Exec(`UPDATE outbox SET attempts = attempts + 1, last_error = ? WHERE id = ?`, reason, id); err != nil {
+        return fmt.Errorf("Error marking outbox event as failed: %w", err)
+    }
+    return nil
+}
+
+// Lag returns the number of pending events and the creation time of the oldest one, nil when there are none
+func (r *outboxRepositoryImpl) Lag() (int, *time.Time, error) {
+    var pending int
+    var oldest sql.NullTime
+    err := r.db.QueryRow(`SELECT COUNT(*), MIN(created_at) FROM outbox WHERE published_at IS NULL`).Scan(&pending, &oldest)
+    if err != nil {
+        return 0, nil, fmt.Errorf("Error getting outbox lag: %w", err)
+    }
+    if !oldest.Valid {
+        return pending, nil, nil
+    }
+    return pending, &oldest.Time, nil
+}
+
+// PurgePublished removes the events published before the given time
+func (r *outboxRepositoryImpl) PurgePublished(before time.Time) (int64, error) {
+    result, err := r.db.Exec(`DELETE FROM outbox WHERE published_at IS NOT NULL AND published_at < ?`, before)
+    if err != nil {
+        return 0, fmt.Errorf("Error purging outbox events: %w", err)
+    }
+    n, _ := result.RowsAffected()
+    return n, nil
+}
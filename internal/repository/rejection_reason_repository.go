@@ -0,0 +1,83 @@
+package repository
+
+import (
+    "database/sql"
+    "fmt"
+
+    "github.com/torvictorvic/seek-v2/internal/domain"
+)
+
+type RejectionReasonRepository interface {
+    List() ([]domain.RejectionReason, error)
+    GetByCode(code string) (*domain.RejectionReason, error)
+    Save(reason domain.RejectionReason) error
+    Deactivate(code string) error
+}
+
+type rejectionReasonRepositoryImpl struct {
+    db      *sql.DB
+    dialect Dialect
+}
+
+func NewRejectionReasonRepository(db *sql.DB) RejectionReasonRepository {
+    return NewRejectionReasonRepositoryFor(db, MySQL)
+}
+
+// NewRejectionReasonRepositoryFor returns the repository for a database of the dialect
+func NewRejectionReasonRepositoryFor(db *sql.DB, dialect Dialect) RejectionReasonRepository {
+    return &rejectionReasonRepositoryImpl{db: db, dialect: dialect}
+}
+
+// conn rebinds the queries run outside a transaction
+func (r *rejectionReasonRepositoryImpl) conn() dbConn {
+    return rebindConn{conn: r.db, dialect: r.dialect}
+}
+
+func (r *rejectionReasonRepositoryImpl) List() ([]domain.RejectionReason, error) {
+    rows, err := r.conn().Query(`SELECT code, label, active FROM rejection_reasons ORDER BY code`)
+    if err != nil {
+        return nil, fmt.Errorf("Error getting rejection reasons: %w", err)
+    }
+    defer rows.Close()
+
+    reasons := []domain.RejectionReason{}
+    for rows.Next() {
+        var rr domain.RejectionReason
+        if err := rows.Scan(&rr.Code, &rr.Label, &rr.Active); err != nil {
+            return nil, err
+        }
+        reasons = append(reasons, rr)
+    }
+    return reasons, rows.Err()
+}
+
+func (r *rejectionReasonRepositoryImpl) GetByCode(code string) (*domain.RejectionReason, error) {
+    var rr domain.RejectionReason
+    err := r.conn().QueryRow(`SELECT code, label, active FROM rejection_reasons WHERE code = ?`, code).Scan(&rr.Code, &rr.Label, &rr.Active)
+    if err == sql.ErrNoRows {
+        return nil, nil
+    } else if err != nil {
+        return nil, fmt.Errorf("Error getting rejection reason: %w", err)
+    }
+    return &rr, nil
+}
+
+// Save creates the reason or updates the label of an existing one, reactivating it
+func (r *rejectionReasonRepositoryImpl) Save(reason domain.RejectionReason) error {
+    query := `INSERT INTO rejection_reasons (code, label, active) VALUES (?, ?, TRUE) ON DUPLICATE KEY UPDATE label = VALUES(label), active = TRUE`
+    if r.dialect.onConflict {
+        query = `INSERT INTO rejection_reasons (code, label, active) VALUES (?, ?, TRUE) ON CONFLICT (code) DO UPDATE SET label = excluded.label, active = TRUE`
+    }
+    if _, err := r.conn().Exec(query, reason.Code, reason.Label); err != nil {
+        return fmt.Errorf("Error saving rejection reason: %w", err)
+    }
+    return nil
+}
+
+// Deactivate hides the reason from new rejections. It is kept for the existing applications.
+func (r *rejectionReasonRepositoryImpl) Deactivate(code string) error {
+    if _, err := r.conn().Exec(`UPDATE rejection_reasons SET active = FALSE WHERE code = ?`, code); err != nil {
+        return fmt.Errorf("Error deactivating rejection reason: %w", err)
+    }
+    return nil
+}
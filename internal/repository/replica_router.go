@@ -0,0 +1,247 @@
+package repository
+
+import (
+    "context"
+    "database/sql"
+    "errors"
+    "sync"
+    "sync/atomic"
+    "time"
+
+    "github.com/go-sql-driver/mysql"
+    "github.com/lib/pq"
+)
+
+// DefaultReadYourWritesWindow is used when no window is configured
+const DefaultReadYourWritesWindow = 5 * time.Second
+
+// DefaultReplicaCheckInterval is used by Run when the interval is not positive
+const DefaultReplicaCheckInterval = 10 * time.Second
+
+// ReplicaRouter spreads the reads over the read replicas in round robin, skipping the ones
+// that failed their health check or their last query. When no replica is healthy the reads
+// go to the primary. A user who wrote is pinned to the primary for a window, so they read
+// their own writes whatever the replication lag.
+type ReplicaRouter struct {
+    primary  *sql.DB
+    replicas []*replica
+    next     atomic.Uint64
+    window   time.Duration
+    now      func() time.Time
+
+    mu     sync.Mutex
+    writes map[string]time.Time // last write of every pinned user
+    swept  time.Time
+}
+
+type replica struct {
+    db   *sql.DB
+    down atomic.Bool
+}
+
+// ReplicaOption customizes the replica router
+type ReplicaOption func(*ReplicaRouter)
+
+// WithReadYourWritesWindow sets how long a user reads from the primary after a write
+func WithReadYourWritesWindow(d time.Duration) ReplicaOption {
+    return func(r *ReplicaRouter) {
+        if d >= 0 {
+            r.window = d
+        }
+    }
+}
+
+// WithReplicaClock replaces the clock of the read-your-writes window, for the tests
+func WithReplicaClock(now func() time.Time) ReplicaOption {
+    return func(r *ReplicaRouter) {
+        r.now = now
+    }
+}
+
+// NewReplicaRouter returns the router of the reads. The replicas start as healthy.
+func NewReplicaRouter(primary *sql.DB, replicas []*sql.DB, opts ...ReplicaOption) *ReplicaRouter {
+    r := &ReplicaRouter{primary: primary, window: DefaultReadYourWritesWindow, now: time.Now, writes: map[string]time.Time{}}
+    for _, db := range replicas {
+        r.replicas = append(r.replicas, &replica{db: db})
+    }
+    for _, opt := range opts {
+        opt(r)
+    }
+    return r
+}
+
+// Primary returns the database of the writes
+func (r *ReplicaRouter) Primary() *sql.DB {
+    return r.primary
+}
+
+// Reader returns the database for the next read of the user
+func (r *ReplicaRouter) Reader(user string) *sql.DB {
+    db, _ := r.reader(user)
+    return db
+}
+
+// reader returns the database for the next read of the user and its replica, nil for the primary
+func (r *ReplicaRouter) reader(user string) (*sql.DB, *replica) {
+    if len(r.replicas) == 0 || r.pinned(user) {
+        return r.primary, nil
+    }
+    start := r.next.Add(1) - 1
+    for i := range r.replicas {
+        rep := r.replicas[(start+uint64(i))%uint64(len(r.replicas))]
+        if !rep.down.Load() {
+            return rep.db, rep
+        }
+    }
+    return r.primary, nil
+}
+
+// RecordWrite pins the user to the primary for the read-your-writes window
+func (r *ReplicaRouter) RecordWrite(user string) {
+    if user == "" || r.window == 0 {
+        return
+    }
+    now := r.now()
+    r.mu.Lock()
+    defer r.mu.Unlock()
+    r.writes[user] = now
+    // The users whose window is over are dropped once per window
+    if now.Sub(r.swept) >= r.window {
+        for u, at := range r.writes {
+            if now.Sub(at) >= r.window {
+                delete(r.writes, u)
+            }
+        }
+        r.swept = now
+    }
+}
+
+func (r *ReplicaRouter) pinned(user string) bool {
+    if user == "" {
+        return false
+    }
+    r.mu.Lock()
+    defer r.mu.Unlock()
+    at, ok := r.writes[user]
+    return ok && r.now().Sub(at) < r.window
+}
+
+// CheckReplicas pings every replica and marks it healthy or not
+func (r *ReplicaRouter) CheckReplicas(ctx context.Context) {
+    for _, rep := range r.replicas {
+        rep.down.Store(rep.db.PingContext(ctx) != nil)
+    }
+}
+
+// Run checks the replicas every interval until ctx is done. An interval that is not
+// positive falls back to DefaultReplicaCheckInterval.
+func (r *ReplicaRouter) Run(ctx context.Context, interval time.Duration) {
+    if interval <= 0 {
+        interval = DefaultReplicaCheckInterval
+    }
+    ticker := time.NewTicker(interval)
+    defer ticker.Stop()
+    for {
+        select {
+        case <-ctx.Done():
+            return
+        case <-ticker.C:
+            r.CheckReplicas(ctx)
+        }
+    }
+}
+
+type readKey struct{}
+
+// readState is the user of the request a repository is bound to
+type readState struct {
+    user string
+}
+
+func readsFrom(ctx context.Context) *readState {
+    rs, _ := ctx.Value(readKey{}).(*readState)
+    return rs
+}
+
+// ReadAs marks ctx as a request of the user. The repositories bound to it with Bind send
+// their reads to the replicas, or to the primary during the window after the user wrote,
+// and their writes start that window. Unbound repositories read from the primary.
+func ReadAs(ctx context.Context, user string) context.Context {
+    return context.WithValue(ctx, readKey{}, &readState{user: user})
+}
+
+// readRouting is embedded by the repositories whose reads can go to the replicas
+type readRouting struct {
+    replicas *ReplicaRouter
+    reader   *readState // set when bound to a request
+}
+
+// ReadOption customizes the read routing of a repository
+type ReadOption func(*readRouting)
+
+// WithReplicas routes the reads of the repositories bound to a request with the router
+func WithReplicas(router *ReplicaRouter) ReadOption {
+    return func(rr *readRouting) {
+        rr.replicas = router
+    }
+}
+
+func newReadRouting(opts []ReadOption) readRouting {
+    var rr readRouting
+    for _, opt := range opts {
+        opt(&rr)
+    }
+    return rr
+}
+
+// readConn returns where the reads go: primary when not bound to a request
+func (rr readRouting) readConn(primary dbConn) dbConn {
+    if rr.replicas == nil || rr.reader == nil {
+        return primary
+    }
+    return replicaConn{router: rr.replicas, user: rr.reader.user}
+}
+
+// recordWrite starts the read-your-writes window of the user of the request
+func (rr readRouting) recordWrite() {
+    if rr.replicas != nil && rr.reader != nil {
+        rr.replicas.RecordWrite(rr.reader.user)
+    }
+}
+
+// replicaConn runs the reads of a user on the database chosen by the router. A query that
+// cannot reach a replica marks it down until its next health check and runs again on the
+// next one, or on the primary after all of them failed. The error of QueryRow only shows
+// on Scan, so it relies on the health checks.
+type replicaConn struct {
+    router *ReplicaRouter
+    user   string
+}
+
+func (c replicaConn) Exec(query string, args ...interface{}) (sql.Result, error) {
+    return c.router.primary.Exec(query, args...)
+}
+
+func (c replicaConn) Query(query string, args ...interface{}) (*sql.Rows, error) {
+    for range c.router.replicas {
+        db, rep := c.router.reader(c.user)
+        rows, err := db.Query(query, args...)
+        if err == nil || rep == nil || isServerError(err) {
+            return rows, err
+        }
+        rep.down.Store(true)
+    }
+    return c.router.primary.Query(query, args...)
+}
+
+// isServerError tells whether the database answered with an error, which the primary would
+// give as well, instead of being unreachable
+func isServerError(err error) bool {
+    var myErr *mysql.MySQLError
+    var pqErr *pq.Error
+    return errors.As(err, &myErr) || errors.As(err, &pqErr)
+}
+
+func (c replicaConn) QueryRow(query string, args ...interface{}) *sql.Row {
+    return c.router.Reader(c.user).QueryRow(query, args...)
+}
@@ -0,0 +1,128 @@
+package repository
+
+import (
+    "database/sql"
+    "fmt"
+    "strings"
+
+    "github.com/torvictorvic/seek-v2/internal/domain"
+)
+
+type ScorecardRepository interface {
+    CreateTemplate(t domain.ScorecardTemplate) (int, error)
+    GetTemplate(id int) (*domain.ScorecardTemplate, error)
+    ListTemplates() ([]domain.ScorecardTemplate, error)
+}
+
+type scorecardRepositoryImpl struct {
+    db      *sql.DB
+    dialect Dialect
+}
+
+func NewScorecardRepository(db *sql.DB) ScorecardRepository {
+    return NewScorecardRepositoryFor(db, MySQL)
+}
+
+// NewScorecardRepositoryFor returns the repository for a database of the dialect
+func NewScorecardRepositoryFor(db *sql.DB, dialect Dialect) ScorecardRepository {
+    return &scorecardRepositoryImpl{db: db, dialect: dialect}
+}
+
+// conn rebinds the queries run outside a transaction
+func (r *scorecardRepositoryImpl) conn() dbConn {
+    return rebindConn{conn: r.db, dialect: r.dialect}
+}
+
+// CreateTemplate saves the template and its competencies in a single transaction
+func (r *scorecardRepositoryImpl) CreateTemplate(t domain.ScorecardTemplate) (int, error) {
+    tx, err := r.db.Begin()
+    if err != nil {
+        return 0, fmt.Errorf("Error starting transaction: %w", err)
+    }
+    defer tx.Rollback()
+    exec := rebindConn{conn: tx, dialect: r.dialect}
+
+    insertID, err := insertReturningID(exec, r.dialect, `INSERT INTO scorecard_templates (name, scale_min, scale_max) VALUES (?, ?, ?)`, t.Name, t.ScaleMin, t.ScaleMax)
+    if err != nil {
+        return 0, fmt.Errorf("Error creating scorecard template: %w", err)
+    }
+
+    placeholders := make([]string, len(t.Competencies))
+    args := make([]interface{}, 0, 4*len(t.Competencies))
+    for i, c := range t.Competencies {
+        placeholders[i] = "(?, ?, ?, ?)"
+        args = append(args, insertID, c.Name, c.Description, c.Weight)
+    }
+    query := `INSERT INTO scorecard_competencies (template_id, name, description, weight) VALUES ` + strings.Join(placeholders, ", ")
+    if _, err := exec.Exec(query, args...); err != nil {
+        return 0, fmt.Errorf("Error creating competencies: %w", err)
+    }
+    if err := tx.Commit(); err != nil {
+        return 0, fmt.Errorf("Error committing scorecard template: %w", err)
+    }
+    return int(insertID), nil
+}
+
+func (r *scorecardRepositoryImpl) GetTemplate(id int) (*domain.ScorecardTemplate, error) {
+    templates, err := r.query(`SELECT id, name, scale_min, scale_max, created_at FROM scorecard_templates WHERE id = ?`, id)
+    if err != nil {
+        return nil, err
+    }
+    if len(templates) == 0 {
+        return nil, nil
+    }
+    return &templates[0], nil
+}
+
+func (r *scorecardRepositoryImpl) ListTemplates() ([]domain.ScorecardTemplate, error) {
+    return r.query(`SELECT id, name, scale_min, scale_max, created_at FROM scorecard_templates ORDER BY id`)
+}
+
+// query loads the templates and their competencies, in the order they were defined
+func (r *scorecardRepositoryImpl) query(query string, args ...interface{}) ([]domain.ScorecardTemplate, error) {
+    rows, err := r.conn().Query(query, args...)
+    if err != nil {
+        return nil, fmt.Errorf("Error getting scorecard templates: %w", err)
+    }
+    defer rows.Close()
+
+    templates := []domain.ScorecardTemplate{}
+    byID := map[int]int{}
+    for rows.Next() {
+        var t domain.ScorecardTemplate
+        if err := rows.Scan(&t.ID, &t.Name, &t.ScaleMin, &t.ScaleMax, &t.CreatedAt); err != nil {
+            return nil, err
+        }
+        t.Competencies = []domain.Competency{}
+        byID[t.ID] = len(templates)
+        templates = append(templates, t)
+    }
+    if err := rows.Err(); err != nil {
+        return nil, err
+    }
+    if len(templates) == 0 {
+        return templates, nil
+    }
+
+    ids := make([]interface{}, 0, len(templates))
+    for _, t := range templates {
+        ids = append(ids, t.ID)
+    }
+    crows, err := r.conn().Query(`SELECT id, template_id, name, description, weight FROM scorecard_competencies WHERE template_id IN (?`+
+        strings.Repeat(", ?", len(ids)-1)+`) ORDER BY template_id, id`, ids...)
+    if err != nil {
+        return nil, fmt.Errorf("Error getting competencies: %w", err)
+    }
+    defer crows.Close()
+    for crows.Next() {
+        var c domain.Competency
+        var templateID int
+        if err := crows.Scan(&c.ID, &templateID, &c.Name, &c.Description, &c.Weight); err != nil {
+            return nil, err
+        }
+        if i, ok := byID[templateID]; ok {
+            templates[i].Competencies = append(templates[i].Competencies, c)
+        }
+    }
+    return templates, crows.Err()
+}
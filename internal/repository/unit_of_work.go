@@ -0,0 +1,241 @@
+package repository
+
+import (
+    "context"
+    "database/sql"
+    "errors"
+    "fmt"
+    "time"
+
+    "github.com/go-sql-driver/mysql"
+    "github.com/lib/pq"
+)
+
+// UnitOfWork runs several repository calls in one transaction
+type UnitOfWork interface {
+    // Do runs fn in a transaction carried by the ctx passed to it; the repositories bound
+    // to that ctx with Bind run their queries in it. Calling Do inside fn runs the nested
+    // fn in a savepoint. The transaction is rolled back when fn returns an error or
+    // panics, and fn runs again when the database aborts it on a deadlock or a
+    // serialization failure, so fn must not have other side effects.
+    Do(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// UnitOfWorkOption customizes the unit of work
+type UnitOfWorkOption func(*unitOfWork)
+
+// WithTxRetries sets how many times a transaction aborted by the database is retried,
+// waiting backoff times the attempt number between them
+func WithTxRetries(n int, backoff time.Duration) UnitOfWorkOption {
+    return func(u *unitOfWork) {
+        if n >= 0 {
+            u.maxRetries = n
+        }
+        u.backoff = backoff
+    }
+}
+
+// DefaultTxRetries is used when no retries are configured
+const DefaultTxRetries = 3
+
+type unitOfWork struct {
+    db         *sql.DB
+    maxRetries int
+    backoff    time.Duration
+}
+
+func NewUnitOfWork(db *sql.DB, opts ...UnitOfWorkOption) UnitOfWork {
+    u := &unitOfWork{db: db, maxRetries: DefaultTxRetries, backoff: 50 * time.Millisecond}
+    for _, opt := range opts {
+        opt(u)
+    }
+    return u
+}
+
+type txKey struct{}
+
+// txState is the transaction of a unit of work. It is not safe for concurrent use, like *sql.Tx.
+type txState struct {
+    tx         *sql.Tx
+    savepoints int
+    onCommit   []func()
+}
+
+// afterCommit runs fn once the transaction is committed, also when it was registered in a
+// savepoint rolled back since
+func (st *txState) afterCommit(fn func()) {
+    st.onCommit = append(st.onCommit, fn)
+}
+
+func txFrom(ctx context.Context) *txState {
+    st, _ := ctx.Value(txKey{}).(*txState)
+    return st
+}
+
+func (u *unitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+    if st := txFrom(ctx); st != nil {
+        return st.nested(ctx, fn)
+    }
+    for attempt := 1; ; attempt++ {
+        err := u.run(ctx, fn)
+        if err == nil || !isRetryableTxError(err) || attempt > u.maxRetries {
+            return err
+        }
+        select {
+        case <-ctx.Done():
+            return err
+        case <-time.After(u.backoff * time.Duration(attempt)):
+        }
+    }
+}
+
+// run runs fn in a new transaction, committed when fn succeeds
+func (u *unitOfWork) run(ctx context.Context, fn func(ctx context.Context) error) error {
+    tx, err := u.db.BeginTx(ctx, nil)
+    if err != nil {
+        return fmt.Errorf("Error starting transaction: %w", err)
+    }
+    defer func() {
+        if p := recover(); p != nil {
+            tx.Rollback()
+            panic(p)
+        }
+    }()
+
+    st := &txState{tx: tx}
+    if err := fn(context.WithValue(ctx, txKey{}, st)); err != nil {
+        tx.Rollback()
+        return err
+    }
+    if err := tx.Commit(); err != nil {
+        return fmt.Errorf("Error committing transaction: %w", err)
+    }
+    for _, fn := range st.onCommit {
+        fn()
+    }
+    return nil
+}
+
+// nested runs fn in a savepoint of the transaction, so only its changes are rolled back
+func (st *txState) nested(ctx context.Context, fn func(ctx context.Context) error) error {
+    sp, err := st.savepoint()
+    if err != nil {
+        return err
+    }
+    defer func() {
+        if p := recover(); p != nil {
+            sp.Rollback()
+            panic(p)
+        }
+    }()
+
+    if err := fn(ctx); err != nil {
+        if rbErr := sp.Rollback(); rbErr != nil {
+            return fmt.Errorf("%w (%v)", err, rbErr)
+        }
+        return err
+    }
+    return sp.Commit()
+}
+
+// savepoint starts a savepoint in the transaction
+func (st *txState) savepoint() (*localTx, error) {
+    st.savepoints++
+    name := fmt.Sprintf("sp_%d", st.savepoints)
+    if _, err := st.tx.Exec(`SAVEPOINT ` + name); err != nil {
+        return nil, fmt.Errorf("Error starting savepoint: %w", err)
+    }
+    return &localTx{Tx: st.tx, savepoint: name}, nil
+}
+
+// localTx is the transaction of a repository method: its own one, or a savepoint when the
+// repository is bound to a unit of work. Commit and Rollback end only the savepoint.
+type localTx struct {
+    *sql.Tx
+    savepoint string
+    done      bool
+}
+
+// beginTx starts the transaction of a repository method
+func beginTx(db *sql.DB, st *txState) (*localTx, error) {
+    if st != nil {
+        return st.savepoint()
+    }
+    tx, err := db.Begin()
+    if err != nil {
+        return nil, err
+    }
+    return &localTx{Tx: tx}, nil
+}
+
+func (t *localTx) Commit() error {
+    if t.savepoint == "" {
+        return t.Tx.Commit()
+    }
+    if t.done {
+        return sql.ErrTxDone
+    }
+    t.done = true
+    if _, err := t.Tx.Exec(`RELEASE SAVEPOINT ` + t.savepoint); err != nil {
+        return fmt.Errorf("Error releasing savepoint: %w", err)
+    }
+    return nil
+}
+
+func (t *localTx) Rollback() error {
+    if t.savepoint == "" {
+        return t.Tx.Rollback()
+    }
+    if t.done {
+        return sql.ErrTxDone
+    }
+    t.done = true
+    if _, err := t.Tx.Exec(`ROLLBACK TO SAVEPOINT ` + t.savepoint); err != nil {
+        return fmt.Errorf("Error rolling back savepoint: %w", err)
+    }
+    return nil
+}
+
+// dbConn is satisfied by *sql.DB and *sql.Tx
+type dbConn interface {
+    execer
+    Query(query string, args ...interface{}) (*sql.Rows, error)
+    QueryRow(query string, args ...interface{}) *sql.Row
+}
+
+// binder is implemented by the repositories that can join the transaction of a unit of work
+// or route the reads of a request (see ReadAs)
+type binder interface {
+    bind(ctx context.Context) interface{}
+}
+
+// Bind returns the repository running its queries in the transaction of the unit of work
+// in ctx, and routing its reads for the user of the request in ctx. Without any of them, or
+// for the repositories that cannot be bound (in memory, test doubles), repo is returned as is.
+func Bind[R any](ctx context.Context, repo R) R {
+    if txFrom(ctx) == nil && readsFrom(ctx) == nil {
+        return repo
+    }
+    if b, ok := any(repo).(binder); ok {
+        if bound, ok := b.bind(ctx).(R); ok {
+            return bound
+        }
+    }
+    return repo
+}
+
+// isRetryableTxError tells whether the database aborted the transaction and it may succeed
+// if run again
+func isRetryableTxError(err error) bool {
+    var myErr *mysql.MySQLError
+    if errors.As(err, &myErr) {
+        // ER_LOCK_DEADLOCK, ER_LOCK_WAIT_TIMEOUT
+        return myErr.Number == 1213 || myErr.Number == 1205
+    }
+    var pqErr *pq.Error
+    if errors.As(err, &pqErr) {
+        // serialization_failure, deadlock_detected
+        return pqErr.Code == "40001" || pqErr.Code == "40P01"
+    }
+    return false
+}
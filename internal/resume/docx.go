@@ -0,0 +1,72 @@
+package resume
+
+import (
+    "archive/zip"
+    "encoding/xml"
+    "fmt"
+    "io"
+    "strings"
+)
+
+// maxDocumentXML bounds the size of word/document.xml once inflated
+const maxDocumentXML = 32 << 20
+
+// DOCXText extracts the text of the body of a Word document, a paragraph per line
+func DOCXText(content io.ReaderAt, size int64) (string, error) {
+    archive, err := zip.NewReader(content, size)
+    if err != nil {
+        return "", fmt.Errorf("Invalid DOCX: %w", err)
+    }
+    for _, f := range archive.File {
+        if f.Name != "word/document.xml" {
+            continue
+        }
+        rc, err := f.Open()
+        if err != nil {
+            return "", err
+        }
+        defer rc.Close()
+        return documentXMLText(io.LimitReader(rc, maxDocumentXML))
+    }
+    return "", fmt.Errorf("Invalid DOCX: word/document.xml not found")
+}
+
+// documentXMLText keeps the runs of text (w:t) and turns paragraphs, breaks and tabs
+// into whitespace
+func documentXMLText(r io.Reader) (string, error) {
+    var sb strings.Builder
+    dec := xml.NewDecoder(r)
+    inText := false
+    for {
+        tok, err := dec.Token()
+        if err == io.EOF {
+            break
+        } else if err != nil {
+            // A damaged document still gives the text read so far
+            break
+        }
+        switch t := tok.(type) {
+        case xml.StartElement:
+            switch t.Name.Local {
+            case "t":
+                inText = true
+            case "tab":
+                sb.WriteByte('\t')
+            case "br", "cr":
+                sb.WriteByte('\n')
+            }
+        case xml.EndElement:
+            switch t.Name.Local {
+            case "t":
+                inText = false
+            case "p":
+                sb.WriteByte('\n')
+            }
+        case xml.CharData:
+            if inText {
+                sb.Write(t)
+            }
+        }
+    }
+    return cleanText(sb.String()), nil
+}
@@ -0,0 +1,190 @@
+package resume
+
+import (
+    "net/url"
+    "regexp"
+    "strings"
+    "unicode"
+
+    "github.com/torvictorvic/seek-v2/internal/domain"
+    "github.com/torvictorvic/seek-v2/internal/search"
+)
+
+// nameLines is the number of lines at the top of the resume where the name is looked for
+const nameLines = 6
+
+var (
+    emailPattern = regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9-]+(?:\.[A-Za-z0-9-]+)*\.[A-Za-z]{2,}`)
+    phonePattern = regexp.MustCompile(`\+?\(?\d[\d\s().-]{5,}\d`)
+    linkPattern  = regexp.MustCompile(`(?i)(?:https?://|www\.)[^\s<>"'()]+|(?:[a-z]{2,3}\.)?linkedin\.com/[^\s<>"'()]+|github\.com/[^\s<>"'()]+`)
+    yearsPattern = regexp.MustCompile(`^(?:19|20)\d{2}\s*[-–]\s*(?:19|20)\d{2}$`)
+)
+
+// headings are the words of the lines that are titles, never the name of the candidate
+var headings = map[string]bool{
+    "curriculum": true, "vitae": true, "resume": true, "cv": true, "perfil": true, "profile": true,
+    "summary": true, "resumen": true, "experience": true, "experiencia": true, "contact": true,
+    "contacto": true, "education": true, "educacion": true, "skills": true, "habilidades": true,
+    "datos": true, "personales": true, "objective": true, "objetivo": true,
+}
+
+// Parser finds the data of the candidate in the text of a resume
+type Parser struct {
+    skills []skillMatcher
+}
+
+// NewParser recognizes the default skills plus the extra ones
+func NewParser(extra ...Skill) *Parser {
+    return &Parser{skills: append(append([]skillMatcher{}, defaultMatchers...), compileSkills(extra)...)}
+}
+
+// Parse returns the suggested candidate, tags, phones and links of the text
+func (p *Parser) Parse(text string) domain.ResumeSuggestion {
+    suggestion := domain.ResumeSuggestion{
+        Tags:   p.findSkills(text),
+        Phones: findPhones(text),
+        Links:  findLinks(text),
+    }
+    if email := emailPattern.FindString(text); email != "" {
+        suggestion.Candidate.Email = strings.ToLower(email)
+    }
+    suggestion.Candidate.Name = findName(text)
+    return suggestion
+}
+
+// findName takes the first line near the top that looks like a person name: two to
+// four capitalized words made of letters
+func findName(text string) string {
+    lines := strings.Split(text, "\n")
+    if len(lines) > nameLines {
+        lines = lines[:nameLines]
+    }
+    for _, line := range lines {
+        words := strings.Fields(line)
+        if len(words) < 2 || len(words) > 4 {
+            continue
+        }
+        ok := true
+        for _, w := range words {
+            if !isNameWord(w) || headings[search.Normalize(w)] {
+                ok = false
+                break
+            }
+        }
+        if ok {
+            return titleCase(words)
+        }
+    }
+    return ""
+}
+
+func isNameWord(w string) bool {
+    for i, r := range w {
+        if i == 0 && !unicode.IsUpper(r) {
+            return false
+        }
+        if !unicode.IsLetter(r) && r != '\'' && r != '-' && r != '.' {
+            return false
+        }
+    }
+    return true
+}
+
+// titleCase turns "JANE DOE" into "Jane Doe" and leaves mixed case names as written
+func titleCase(words []string) string {
+    for i, w := range words {
+        if strings.ToUpper(w) != w {
+            continue
+        }
+        r := []rune(strings.ToLower(w))
+        r[0] = unicode.ToUpper(r[0])
+        words[i] = string(r)
+    }
+    return strings.Join(words, " ")
+}
+
+// findPhones keeps the numbers with an international prefix or at least nine digits, so
+// dates and years are not taken as phones
+func findPhones(text string) []string {
+    phones := []string{}
+    for _, line := range strings.Split(text, "\n") {
+        for _, m := range phonePattern.FindAllString(line, -1) {
+            m = strings.TrimSpace(m)
+            if yearsPattern.MatchString(m) {
+                continue
+            }
+            var digits strings.Builder
+            for _, r := range m {
+                if r >= '0' && r <= '9' {
+                    digits.WriteRune(r)
+                }
+            }
+            n := digits.Len()
+            international := strings.HasPrefix(m, "+")
+            if n > 15 || n < 7 || (!international && n < 9) {
+                continue
+            }
+            phone := digits.String()
+            if international {
+                phone = "+" + phone
+            }
+            if !containsString(phones, phone) {
+                phones = append(phones, phone)
+            }
+        }
+    }
+    return phones
+}
+
+func findLinks(text string) []domain.ResumeLink {
+    links := []domain.ResumeLink{}
+    seen := map[string]bool{}
+    for _, m := range linkPattern.FindAllString(text, -1) {
+        m = strings.TrimRight(m, ".,;:")
+        if !strings.Contains(strings.ToLower(m), "://") {
+            m = "https://" + m
+        }
+        u, err := url.Parse(m)
+        if err != nil || u.Host == "" || seen[strings.ToLower(m)] {
+            continue
+        }
+        seen[strings.ToLower(m)] = true
+
+        kind := domain.LinkPortfolio
+        host := strings.TrimPrefix(strings.ToLower(u.Host), "www.")
+        switch {
+        case host == "linkedin.com" || strings.HasSuffix(host, ".linkedin.com"):
+            kind = domain.LinkLinkedIn
+        case host == "github.com":
+            kind = domain.LinkGitHub
+        }
+        links = append(links, domain.ResumeLink{Kind: kind, URL: m})
+    }
+    return links
+}
+
+// findSkills returns the tags of the skills mentioned, normalized and sorted
+func (p *Parser) findSkills(text string) []string {
+    normalized := search.Normalize(text)
+    var found []string
+    for _, s := range p.skills {
+        if (s.pattern != nil && s.pattern.MatchString(normalized)) ||
+            (s.caseSensitive != nil && s.caseSensitive.MatchString(text)) {
+            found = append(found, s.tag)
+        }
+    }
+    tags, err := domain.NormalizeTags(found)
+    if err != nil {
+        return []string{}
+    }
+    return tags
+}
+
+func containsString(list []string, s string) bool {
+    for _, item := range list {
+        if item == s {
+            return true
+        }
+    }
+    return false
+}
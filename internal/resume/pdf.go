@@ -0,0 +1,387 @@
+package resume
+
+import (
+    "bytes"
+    "compress/zlib"
+    "io"
+    "math"
+    "regexp"
+    "strconv"
+    "strings"
+    "unicode/utf16"
+
+    "golang.org/x/text/encoding/charmap"
+)
+
+// maxStreamSize bounds the inflated size of a single PDF stream
+const maxStreamSize = 16 << 20
+
+var (
+    streamPattern = regexp.MustCompile(`>>\s*stream\r?\n`)
+    objKeyword    = []byte(" obj")
+    endStream     = []byte("endstream")
+)
+
+// PDFText extracts the text drawn by the content streams of the PDF. It understands
+// uncompressed and FlateDecode streams with simple fonts (WinAnsi or UTF-16 strings),
+// which covers the resumes exported by word processors; scanned PDFs have no text.
+func PDFText(data []byte) string {
+    var out strings.Builder
+    for _, m := range streamPattern.FindAllSubmatchIndex(data, -1) {
+        // The dictionary of the stream goes from its object header to the keyword
+        dictStart := bytes.LastIndex(data[:m[0]], objKeyword)
+        if dictStart < 0 {
+            dictStart = 0
+        }
+        dict := string(data[dictStart:m[0]])
+        start := m[1]
+        end := bytes.Index(data[start:], endStream)
+        if end < 0 {
+            break
+        }
+        raw := data[start : start+end]
+        // Images, fonts and embedded files hold no page text
+        if strings.Contains(dict, "/Subtype") || strings.Contains(dict, "/Length1") || strings.Contains(dict, "/ObjStm") {
+            continue
+        }
+        content := raw
+        if strings.Contains(dict, "/FlateDecode") {
+            zr, err := zlib.NewReader(bytes.NewReader(raw))
+            if err != nil {
+                continue
+            }
+            // A truncated stream still gives the text read so far
+            content, _ = io.ReadAll(io.LimitReader(zr, maxStreamSize))
+            zr.Close()
+        } else if strings.Contains(dict, "/Filter") {
+            continue
+        }
+        if !bytes.Contains(content, []byte("BT")) {
+            continue
+        }
+        contentText(&out, content)
+        out.WriteByte('\n')
+    }
+    return cleanText(out.String())
+}
+
+// textState follows the position of the text to break lines and words
+type textState struct {
+    out          *strings.Builder
+    x, y         float64
+    lastX, lastY float64
+    started      bool
+}
+
+func (ts *textState) moveTo(x, y float64) {
+    ts.x, ts.y = x, y
+}
+
+func (ts *textState) newLine() {
+    ts.out.WriteByte('\n')
+    ts.started = false
+}
+
+func (ts *textState) write(s string) {
+    if s == "" {
+        return
+    }
+    if ts.started {
+        if math.Abs(ts.y-ts.lastY) > 1 {
+            ts.out.WriteByte('\n')
+        } else if ts.x != ts.lastX {
+            ts.out.WriteByte(' ')
+        }
+    }
+    ts.out.WriteString(s)
+    ts.started = true
+    ts.lastX, ts.lastY = ts.x, ts.y
+}
+
+// contentText runs the text operators of a content stream
+func contentText(out *strings.Builder, content []byte) {
+    ts := &textState{out: out}
+    var operands []interface{}
+    number := func(i int) float64 {
+        if i < 0 || i >= len(operands) {
+            return 0
+        }
+        n, _ := operands[i].(float64)
+        return n
+    }
+    lx, ly := 0.0, 0.0 // start of the current line
+    lex := lexer{data: content}
+    for {
+        tok, ok := lex.next()
+        if !ok {
+            return
+        }
+        op, isOp := tok.(operator)
+        if !isOp {
+            operands = append(operands, tok)
+            continue
+        }
+        n := len(operands)
+        switch op {
+        case "BT":
+            lx, ly = 0, 0
+            ts.moveTo(0, 0)
+        case "Td", "TD":
+            lx += number(n - 2)
+            ly += number(n - 1)
+            ts.moveTo(lx, ly)
+        case "Tm":
+            lx, ly = number(n-2), number(n-1)
+            ts.moveTo(lx, ly)
+        case "T*":
+            ts.newLine()
+        case "Tj":
+            if n > 0 {
+                ts.write(decodeString(operands[n-1]))
+            }
+        case "'", "\"":
+            ts.newLine()
+            if n > 0 {
+                ts.write(decodeString(operands[n-1]))
+            }
+        case "TJ":
+            if n == 0 {
+                break
+            }
+            items, _ := operands[n-1].([]interface{})
+            var sb strings.Builder
+            for _, item := range items {
+                switch v := item.(type) {
+                case float64:
+                    // A large negative adjustment is a space between words
+                    if v < -200 {
+                        sb.WriteByte(' ')
+                    }
+                default:
+                    sb.WriteString(decodeString(v))
+                }
+            }
+            ts.write(sb.String())
+        }
+        operands = operands[:0]
+    }
+}
+
+// pdfString is the raw bytes of a literal or hexadecimal string
+type pdfString []byte
+
+type operator string
+
+type lexer struct {
+    data []byte
+    pos  int
+}
+
+func isDelimiter(c byte) bool {
+    return strings.IndexByte("()<>[]{}/%", c) >= 0
+}
+
+func isSpace(c byte) bool {
+    return c == ' ' || c == '\n' || c == '\r' || c == '\t' || c == '\f' || c == 0
+}
+
+// next returns the next operand (float64, pdfString, []interface{} or a name as string)
+// or operator. Dictionaries are skipped.
+func (l *lexer) next() (interface{}, bool) {
+    for l.pos < len(l.data) {
+        c := l.data[l.pos]
+        switch {
+        case isSpace(c):
+            l.pos++
+        case c == '%':
+            for l.pos < len(l.data) && l.data[l.pos] != '\n' && l.data[l.pos] != '\r' {
+                l.pos++
+            }
+        case c == '(':
+            return l.literal(), true
+        case c == '<' && l.pos+1 < len(l.data) && l.data[l.pos+1] == '<':
+            l.pos += 2
+            l.skipDict()
+        case c == '<':
+            return l.hex(), true
+        case c == '[':
+            l.pos++
+            var items []interface{}
+            for {
+                item, ok := l.next()
+                if !ok || item == operator("]") {
+                    return items, true
+                }
+                items = append(items, item)
+            }
+        case c == ']':
+            l.pos++
+            return operator("]"), true
+        case c == '/':
+            start := l.pos
+            l.pos++
+            for l.pos < len(l.data) && !isSpace(l.data[l.pos]) && !isDelimiter(l.data[l.pos]) {
+                l.pos++
+            }
+            return string(l.data[start:l.pos]), true
+        case isDelimiter(c):
+            l.pos++
+        default:
+            start := l.pos
+            for l.pos < len(l.data) && !isSpace(l.data[l.pos]) && !isDelimiter(l.data[l.pos]) {
+                l.pos++
+            }
+            word := string(l.data[start:l.pos])
+            if n, err := strconv.ParseFloat(word, 64); err == nil {
+                return n, true
+            }
+            if word == "BI" {
+                l.skipInlineImage()
+                continue
+            }
+            return operator(word), true
+        }
+    }
+    return nil, false
+}
+
+func (l *lexer) skipDict() {
+    for depth := 1; l.pos < len(l.data) && depth > 0; {
+        switch {
+        case bytes.HasPrefix(l.data[l.pos:], []byte("<<")):
+            depth++
+            l.pos += 2
+        case bytes.HasPrefix(l.data[l.pos:], []byte(">>")):
+            depth--
+            l.pos += 2
+        case l.data[l.pos] == '(':
+            l.literal()
+        default:
+            l.pos++
+        }
+    }
+}
+
+// skipInlineImage jumps over the binary data of an inline image, up to "EI"
+func (l *lexer) skipInlineImage() {
+    if i := bytes.Index(l.data[l.pos:], []byte("EI")); i >= 0 {
+        l.pos += i + 2
+    } else {
+        l.pos = len(l.data)
+    }
+}
+
+func (l *lexer) literal() pdfString {
+    l.pos++ // (
+    var s []byte
+    for depth := 1; l.pos < len(l.data); l.pos++ {
+        c := l.data[l.pos]
+        switch c {
+        case '(':
+            depth++
+        case ')':
+            depth--
+            if depth == 0 {
+                l.pos++
+                return s
+            }
+        case '\\':
+            l.pos++
+            if l.pos >= len(l.data) {
+                return s
+            }
+            e := l.data[l.pos]
+            switch e {
+            case 'n':
+                s = append(s, '\n')
+            case 'r':
+                s = append(s, '\r')
+            case 't':
+                s = append(s, '\t')
+            case 'b':
+                s = append(s, '\b')
+            case 'f':
+                s = append(s, '\f')
+            case '\r', '\n':
+                // Line continuation
+            default:
+                if e >= '0' && e <= '7' {
+                    v := 0
+                    for i := 0; i < 3 && l.pos < len(l.data) && l.data[l.pos] >= '0' && l.data[l.pos] <= '7'; i++ {
+                        v = v*8 + int(l.data[l.pos]-'0')
+                        l.pos++
+                    }
+                    l.pos--
+                    s = append(s, byte(v))
+                } else {
+                    s = append(s, e)
+                }
+            }
+            continue
+        }
+        s = append(s, c)
+    }
+    return s
+}
+
+func (l *lexer) hex() pdfString {
+    l.pos++ // <
+    var digits []byte
+    for l.pos < len(l.data) && l.data[l.pos] != '>' {
+        if c := l.data[l.pos]; !isSpace(c) {
+            digits = append(digits, c)
+        }
+        l.pos++
+    }
+    l.pos++ // >
+    if len(digits)%2 == 1 {
+        digits = append(digits, '0')
+    }
+    s := make([]byte, 0, len(digits)/2)
+    for i := 0; i < len(digits); i += 2 {
+        v, err := strconv.ParseUint(string(digits[i:i+2]), 16, 8)
+        if err != nil {
+            return s
+        }
+        s = append(s, byte(v))
+    }
+    return s
+}
+
+// decodeString converts the bytes of a string to text: UTF-16 when marked with a BOM or
+// when it looks like two-byte codes, WinAnsi otherwise
+func decodeString(v interface{}) string {
+    s, ok := v.(pdfString)
+    if !ok || len(s) == 0 {
+        return ""
+    }
+    if len(s) >= 2 && s[0] == 0xfe && s[1] == 0xff {
+        return utf16String(s[2:])
+    }
+    if len(s)%2 == 0 && looksUTF16(s) {
+        return utf16String(s)
+    }
+    text, err := charmap.Windows1252.NewDecoder().Bytes(s)
+    if err != nil {
+        return ""
+    }
+    return string(text)
+}
+
+func looksUTF16(s []byte) bool {
+    zeros := 0
+    for i := 0; i < len(s); i += 2 {
+        if s[i] == 0 {
+            zeros++
+        }
+    }
+    return zeros*2 > len(s)/2
+}
+
+func utf16String(s []byte) string {
+    units := make([]uint16, len(s)/2)
+    for i := range units {
+        units[i] = uint16(s[2*i])<<8 | uint16(s[2*i+1])
+    }
+    return string(utf16.Decode(units))
+}
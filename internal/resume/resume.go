@@ -0,0 +1,49 @@
+// Package resume extracts the text of the uploaded resumes and finds in it the data of
+// the candidate with heuristics. Everything runs in process, no external service is used.
+package resume
+
+import (
+    "fmt"
+    "io"
+    "strings"
+
+    "github.com/torvictorvic/seek-v2/internal/domain"
+)
+
+// MaxTextLength is the maximum size of the text kept for a document
+const MaxTextLength = 1 << 20
+
+// Extract returns the text of a PDF or DOCX document. Images have no text.
+func Extract(content io.ReaderAt, size int64, kind string) (string, error) {
+    var text string
+    switch kind {
+    case domain.DocumentPDF:
+        data := make([]byte, size)
+        if _, err := content.ReadAt(data, 0); err != nil && err != io.EOF {
+            return "", err
+        }
+        text = PDFText(data)
+    case domain.DocumentDOCX:
+        var err error
+        if text, err = DOCXText(content, size); err != nil {
+            return "", err
+        }
+    default:
+        return "", fmt.Errorf("No text can be extracted from %s documents", kind)
+    }
+    if len(text) > MaxTextLength {
+        text = strings.ToValidUTF8(text[:MaxTextLength], "")
+    }
+    return text, nil
+}
+
+// cleanText collapses the spaces of every line and drops the empty lines
+func cleanText(s string) string {
+    var lines []string
+    for _, line := range strings.Split(strings.ReplaceAll(s, "\r", "\n"), "\n") {
+        if line = strings.Join(strings.Fields(line), " "); line != "" {
+            lines = append(lines, line)
+        }
+    }
+    return strings.Join(lines, "\n")
+}
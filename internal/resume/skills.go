@@ -0,0 +1,117 @@
+package resume
+
+import (
+    "regexp"
+    "strings"
+
+    "github.com/torvictorvic/seek-v2/internal/search"
+)
+
+// Skill is a tag suggested when any of its terms appears in the resume. Terms are
+// matched ignoring case and accents, CaseSensitive terms exactly as written.
+type Skill struct {
+    Tag           string
+    Terms         []string
+    CaseSensitive []string
+}
+
+// DefaultSkills are the skills recognized without configuration
+var DefaultSkills = []Skill{
+    {Tag: "go", Terms: []string{"golang"}, CaseSensitive: []string{"Go"}},
+    {Tag: "python", Terms: []string{"python"}},
+    {Tag: "java", Terms: []string{"java"}},
+    {Tag: "javascript", Terms: []string{"javascript", "ecmascript"}},
+    {Tag: "typescript", Terms: []string{"typescript"}},
+    {Tag: "node.js", Terms: []string{"node.js", "nodejs"}},
+    {Tag: "react", Terms: []string{"react", "react.js", "reactjs"}},
+    {Tag: "angular", Terms: []string{"angular"}},
+    {Tag: "vue", Terms: []string{"vue", "vue.js"}},
+    {Tag: "c#", Terms: []string{"c#", ".net"}},
+    {Tag: "c++", Terms: []string{"c++"}},
+    {Tag: "php", Terms: []string{"php"}},
+    {Tag: "ruby", Terms: []string{"ruby", "rails"}},
+    {Tag: "rust", Terms: []string{"rust"}},
+    {Tag: "kotlin", Terms: []string{"kotlin"}},
+    {Tag: "swift", Terms: []string{"swift"}},
+    {Tag: "scala", Terms: []string{"scala"}},
+    {Tag: "sql", Terms: []string{"sql"}},
+    {Tag: "mysql", Terms: []string{"mysql"}},
+    {Tag: "postgresql", Terms: []string{"postgresql", "postgres"}},
+    {Tag: "mongodb", Terms: []string{"mongodb", "mongo"}},
+    {Tag: "redis", Terms: []string{"redis"}},
+    {Tag: "kafka", Terms: []string{"kafka"}},
+    {Tag: "docker", Terms: []string{"docker"}},
+    {Tag: "kubernetes", Terms: []string{"kubernetes", "k8s"}},
+    {Tag: "terraform", Terms: []string{"terraform"}},
+    {Tag: "aws", Terms: []string{"aws", "amazon web services"}},
+    {Tag: "gcp", Terms: []string{"gcp", "google cloud"}},
+    {Tag: "azure", Terms: []string{"azure"}},
+    {Tag: "linux", Terms: []string{"linux"}},
+    {Tag: "git", Terms: []string{"git"}},
+    {Tag: "graphql", Terms: []string{"graphql"}},
+    {Tag: "grpc", Terms: []string{"grpc"}},
+    {Tag: "html", Terms: []string{"html", "html5"}},
+    {Tag: "css", Terms: []string{"css", "css3"}},
+    {Tag: "machine learning", Terms: []string{"machine learning", "aprendizaje automatico"}},
+    {Tag: "data analysis", Terms: []string{"data analysis", "analisis de datos"}},
+    {Tag: "excel", Terms: []string{"excel"}},
+    {Tag: "scrum", Terms: []string{"scrum"}},
+    {Tag: "agile", Terms: []string{"agile", "agil"}},
+}
+
+// A term must not be glued to other word characters, "java" does not match "javascript"
+// and "c" does not match "c++"
+const wordChars = `\p{L}\p{N}_+#`
+
+type skillMatcher struct {
+    tag           string
+    pattern       *regexp.Regexp // on the normalized text
+    caseSensitive *regexp.Regexp // on the original text
+}
+
+func termsPattern(terms []string) *regexp.Regexp {
+    if len(terms) == 0 {
+        return nil
+    }
+    quoted := make([]string, len(terms))
+    for i, t := range terms {
+        quoted[i] = regexp.QuoteMeta(t)
+    }
+    return regexp.MustCompile(`(?:^|[^` + wordChars + `.])(?:` + strings.Join(quoted, "|") + `)(?:$|[^` + wordChars + `])`)
+}
+
+func compileSkills(skills []Skill) []skillMatcher {
+    matchers := make([]skillMatcher, 0, len(skills))
+    for _, s := range skills {
+        terms := make([]string, len(s.Terms))
+        for i, t := range s.Terms {
+            terms[i] = search.Normalize(t)
+        }
+        matchers = append(matchers, skillMatcher{
+            tag:           s.Tag,
+            pattern:       termsPattern(terms),
+            caseSensitive: termsPattern(s.CaseSensitive),
+        })
+    }
+    return matchers
+}
+
+var defaultMatchers = compileSkills(DefaultSkills)
+
+// TagSkills turns tags in use into skills, so the resumes suggest the tags the team
+// already has. The tags of the default skills are skipped.
+func TagSkills(tags []string) []Skill {
+    known := map[string]bool{}
+    for _, s := range DefaultSkills {
+        known[s.Tag] = true
+    }
+    var skills []Skill
+    for _, t := range tags {
+        // Very short tags would match everywhere
+        if known[t] || len([]rune(t)) < 3 {
+            continue
+        }
+        skills = append(skills, Skill{Tag: t, Terms: []string{t}})
+    }
+    return skills
+}
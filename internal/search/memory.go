@@ -0,0 +1,143 @@
+package search
+
+import (
+    "sync"
+
+    "github.com/torvictorvic/seek-v2/internal/domain"
+)
+
+// MemoryIndex is an in-process inverted index over name, email and resume text. It is used in the
+// tests and with the databases without full-text support.
+type MemoryIndex struct {
+    mu       sync.RWMutex
+    docs     map[int]domain.Candidate
+    postings map[string]map[int]struct{} // word -> candidate IDs
+}
+
+func NewMemoryIndex() *MemoryIndex {
+    return &MemoryIndex{
+        docs:     map[int]domain.Candidate{},
+        postings: map[string]map[int]struct{}{},
+    }
+}
+
+// Index adds or replaces the candidate in the index. The resume text indexed before is
+// kept when the candidate has none.
+func (idx *MemoryIndex) Index(c domain.Candidate) {
+    idx.mu.Lock()
+    defer idx.mu.Unlock()
+
+    if old, ok := idx.docs[c.ID]; ok && c.ResumeText == "" {
+        c.ResumeText = old.ResumeText
+    }
+    idx.index(c)
+}
+
+// Rebuild replaces the indexed candidates with the given ones, so the index catches up
+// with the writes it did not see. The resume texts indexed before are kept.
+func (idx *MemoryIndex) Rebuild(candidates []domain.Candidate) {
+    idx.mu.Lock()
+    defer idx.mu.Unlock()
+
+    old := idx.docs
+    idx.docs = map[int]domain.Candidate{}
+    idx.postings = map[string]map[int]struct{}{}
+    for _, c := range candidates {
+        if prev, ok := old[c.ID]; ok && c.ResumeText == "" {
+            c.ResumeText = prev.ResumeText
+        }
+        idx.index(c)
+    }
+}
+
+// IndexResume replaces the resume text of the candidate, if it is indexed
+func (idx *MemoryIndex) IndexResume(candidateID int, text string) {
+    idx.mu.Lock()
+    defer idx.mu.Unlock()
+
+    if c, ok := idx.docs[candidateID]; ok {
+        c.ResumeText = text
+        idx.index(c)
+    }
+}
+
+func (idx *MemoryIndex) index(c domain.Candidate) {
+    idx.remove(c.ID)
+    idx.docs[c.ID] = c
+    for _, f := range fieldWeights {
+        for _, tok := range tokenize(f.value(c)) {
+            ids, ok := idx.postings[tok.text]
+            if !ok {
+                ids = map[int]struct{}{}
+                idx.postings[tok.text] = ids
+            }
+            ids[c.ID] = struct{}{}
+        }
+    }
+}
+
+// Remove deletes the candidate from the index
+func (idx *MemoryIndex) Remove(id int) {
+    idx.mu.Lock()
+    defer idx.mu.Unlock()
+    idx.remove(id)
+}
+
+func (idx *MemoryIndex) remove(id int) {
+    c, ok := idx.docs[id]
+    if !ok {
+        return
+    }
+    delete(idx.docs, id)
+    for _, f := range fieldWeights {
+        for _, tok := range tokenize(f.value(c)) {
+            if ids, ok := idx.postings[tok.text]; ok {
+                delete(ids, id)
+                if len(ids) == 0 {
+                    delete(idx.postings, tok.text)
+                }
+            }
+        }
+    }
+}
+
+// Search looks up the words matching every term (exact, prefix or fuzzy), keeps the
+// candidates found for all the terms and ranks them
+func (idx *MemoryIndex) Search(query string, filter domain.CandidateFilter, limit int) ([]domain.CandidateSearchHit, error) {
+    terms := Terms(query)
+    if len(terms) == 0 {
+        return nil, nil
+    }
+
+    idx.mu.RLock()
+    defer idx.mu.RUnlock()
+
+    var found map[int]struct{}
+    for _, term := range terms {
+        ids := map[int]struct{}{}
+        for word, postings := range idx.postings {
+            if MatchTerm(term, word) == 0 {
+                continue
+            }
+            for id := range postings {
+                if found == nil {
+                    ids[id] = struct{}{}
+                } else if _, ok := found[id]; ok {
+                    ids[id] = struct{}{}
+                }
+            }
+        }
+        found = ids
+        if len(found) == 0 {
+            return nil, nil
+        }
+    }
+
+    candidates := make([]domain.Candidate, 0, len(found))
+    for id := range found {
+        if c := idx.docs[id]; filter.Matches(c) {
+            candidates = append(candidates, c)
+        }
+    }
+    return Rank(query, candidates, limit), nil
+}
@@ -0,0 +1,248 @@
+package search
+
+import (
+    "html"
+    "math"
+    "strings"
+    "unicode"
+    "unicode/utf8"
+
+    "golang.org/x/text/unicode/norm"
+
+    "github.com/torvictorvic/seek-v2/internal/domain"
+)
+
+// Weight of a match in each field. Long fields are highlighted as a snippet around
+// the first match.
+var fieldWeights = []struct {
+    name    string
+    weight  float64
+    value   func(c domain.Candidate) string
+    snippet bool
+}{
+    {"name", 1.0, func(c domain.Candidate) string { return c.Name }, false},
+    {"email", 0.6, func(c domain.Candidate) string { return c.Email }, false},
+    {"resume", 0.4, func(c domain.Candidate) string { return c.ResumeText }, true},
+}
+
+// snippetContext is the number of bytes kept on each side of the first match of a snippet
+const snippetContext = 60
+
+// token is a normalized word with its position in the original text
+type token struct {
+    text       string
+    start, end int
+}
+
+// Terms normalizes the query into its search terms
+func Terms(query string) []string {
+    tokens := tokenize(query)
+    terms := make([]string, len(tokens))
+    for i, t := range tokens {
+        terms[i] = t.text
+    }
+    return terms
+}
+
+// Normalize lowercases the text and removes the accents, so "Pérez" matches "perez"
+func Normalize(s string) string {
+    var sb strings.Builder
+    for _, r := range norm.NFD.String(s) {
+        if unicode.Is(unicode.Mn, r) {
+            continue
+        }
+        sb.WriteRune(unicode.ToLower(r))
+    }
+    return sb.String()
+}
+
+// tokenize splits the text on anything that is not a letter or a digit,
+// which also breaks emails into their parts
+func tokenize(s string) []token {
+    var tokens []token
+    start := -1
+    for i, r := range s {
+        isWord := unicode.IsLetter(r) || unicode.IsDigit(r)
+        if isWord && start < 0 {
+            start = i
+        } else if !isWord && start >= 0 {
+            tokens = append(tokens, token{text: Normalize(s[start:i]), start: start, end: i})
+            start = -1
+        }
+    }
+    if start >= 0 {
+        tokens = append(tokens, token{text: Normalize(s[start:]), start: start, end: len(s)})
+    }
+    return tokens
+}
+
+// MatchTerm scores how well a query term matches a word of the document:
+// exact match, prefix (the user is still typing) or within a few typos
+func MatchTerm(term, word string) float64 {
+    if term == word {
+        return 1.0
+    }
+    termLen, wordLen := utf8.RuneCountInString(term), utf8.RuneCountInString(word)
+    if termLen >= 2 && strings.HasPrefix(word, term) {
+        return 0.7 + 0.2*float64(termLen)/float64(wordLen)
+    }
+
+    maxDist := maxTypos(termLen)
+    if maxDist == 0 {
+        return 0
+    }
+    if d := Levenshtein(term, word); d <= maxDist {
+        return 0.8 - 0.15*float64(d)
+    }
+    // Typo in a word that is still being typed
+    if wordLen > termLen {
+        prefix := string([]rune(word)[:termLen])
+        if d := Levenshtein(term, prefix); d <= maxDist {
+            return 0.6 - 0.15*float64(d)
+        }
+    }
+    return 0
+}
+
+// maxTypos is the number of edits tolerated for a term of the given length
+func maxTypos(n int) int {
+    switch {
+    case n < 3:
+        return 0
+    case n < 8:
+        return 1
+    default:
+        return 2
+    }
+}
+
+// Score computes the relevance of a candidate and the highlighted fields.
+// It returns false when some term matches nothing.
+func Score(terms []string, c domain.Candidate) (domain.CandidateSearchHit, bool) {
+    hit := domain.CandidateSearchHit{Candidate: c, Highlights: map[string]string{}}
+
+    type fieldTokens struct {
+        text    string
+        tokens  []token
+        matched []bool
+    }
+    fields := make([]fieldTokens, len(fieldWeights))
+    for i, f := range fieldWeights {
+        text := f.value(c)
+        tokens := tokenize(text)
+        fields[i] = fieldTokens{text: text, tokens: tokens, matched: make([]bool, len(tokens))}
+    }
+
+    for _, term := range terms {
+        best := 0.0
+        for i, f := range fields {
+            for j, tok := range f.tokens {
+                s := MatchTerm(term, tok.text)
+                if s == 0 {
+                    continue
+                }
+                f.matched[j] = true
+                best = math.Max(best, s*fieldWeights[i].weight)
+            }
+        }
+        if best == 0 {
+            return hit, false
+        }
+        hit.Score += best
+    }
+    hit.Score = math.Round(hit.Score/float64(len(terms))*1000) / 1000
+
+    for i, f := range fields {
+        text, tokens, matched := f.text, f.tokens, f.matched
+        prefix, suffix := "", ""
+        if fieldWeights[i].snippet {
+            var start, end int
+            if start, end = snippetBounds(text, tokens, matched); start > 0 {
+                prefix = "…"
+            }
+            if end < len(text) {
+                suffix = "…"
+            }
+            text, tokens, matched = window(text, tokens, matched, start, end)
+        }
+        if h, ok := highlight(text, tokens, matched); ok {
+            hit.Highlights[fieldWeights[i].name] = prefix + h + suffix
+        }
+    }
+    return hit, true
+}
+
+func highlight(text string, tokens []token, matched []bool) (string, bool) {
+    var sb strings.Builder
+    last, any := 0, false
+    for i, tok := range tokens {
+        if !matched[i] {
+            continue
+        }
+        any = true
+        sb.WriteString(html.EscapeString(text[last:tok.start]))
+        sb.WriteString("<mark>")
+        sb.WriteString(html.EscapeString(text[tok.start:tok.end]))
+        sb.WriteString("</mark>")
+        last = tok.end
+    }
+    sb.WriteString(html.EscapeString(text[last:]))
+    return sb.String(), any
+}
+
+// snippetBounds returns the part of the text around its first match, cut at word boundaries
+func snippetBounds(text string, tokens []token, matched []bool) (int, int) {
+    for i, tok := range tokens {
+        if !matched[i] {
+            continue
+        }
+        start, end := 0, len(text)
+        for _, t := range tokens {
+            if t.start <= tok.start-snippetContext {
+                start = t.start
+            }
+        }
+        for j := len(tokens) - 1; j >= 0; j-- {
+            if tokens[j].end >= tok.end+snippetContext {
+                end = tokens[j].end
+            }
+        }
+        return start, end
+    }
+    return 0, 0
+}
+
+// window returns the text between start and end with the tokens inside it
+func window(text string, tokens []token, matched []bool, start, end int) (string, []token, []bool) {
+    var wTokens []token
+    var wMatched []bool
+    for i, t := range tokens {
+        if t.start >= start && t.end <= end {
+            wTokens = append(wTokens, token{text: t.text, start: t.start - start, end: t.end - start})
+            wMatched = append(wMatched, matched[i])
+        }
+    }
+    return text[start:end], wTokens, wMatched
+}
+
+// Levenshtein returns the edit distance between two strings, counted in runes
+func Levenshtein(a, b string) int {
+    ra, rb := []rune(a), []rune(b)
+    prev := make([]int, len(rb)+1)
+    curr := make([]int, len(rb)+1)
+    for j := range prev {
+        prev[j] = j
+    }
+    for i := 1; i <= len(ra); i++ {
+        curr[0] = i
+        for j := 1; j <= len(rb); j++ {
+            cost := 1
+            if ra[i-1] == rb[j-1] {
+                cost = 0
+            }
+            curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+        }
+        prev, curr = curr, prev
+    }
+    return prev[len(rb)]
+}
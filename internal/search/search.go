@@ -0,0 +1,58 @@
+package search
+
+import (
+    "sort"
+
+    "github.com/torvictorvic/seek-v2/internal/domain"
+)
+
+// DefaultLimit is the number of hits returned when no limit is given
+const DefaultLimit = 20
+
+// Searcher finds candidates by free text over name and email, combined with the list filters
+type Searcher interface {
+    Search(query string, filter domain.CandidateFilter, limit int) ([]domain.CandidateSearchHit, error)
+}
+
+// Indexer is implemented by the searchers that keep their own index and need to be told
+// about the writes. The MySQL FULLTEXT index is maintained by the database itself.
+type Indexer interface {
+    Index(c domain.Candidate)
+    Remove(id int)
+}
+
+// ResumeIndexer is implemented by the indexers that also search the text of the resumes.
+// The MySQL searcher reads it from the documents table instead.
+type ResumeIndexer interface {
+    // IndexResume replaces the resume text of an indexed candidate
+    IndexResume(candidateID int, text string)
+}
+
+// Rank scores the candidates against the query, drops the ones that do not match every
+// term and returns the best hits first
+func Rank(query string, candidates []domain.Candidate, limit int) []domain.CandidateSearchHit {
+    terms := Terms(query)
+    if len(terms) == 0 {
+        return nil
+    }
+    if limit <= 0 {
+        limit = DefaultLimit
+    }
+
+    hits := make([]domain.CandidateSearchHit, 0, len(candidates))
+    for _, c := range candidates {
+        if hit, ok := Score(terms, c); ok {
+            hits = append(hits, hit)
+        }
+    }
+    sort.SliceStable(hits, func(i, j int) bool {
+        if hits[i].Score != hits[j].Score {
+            return hits[i].Score > hits[j].Score
+        }
+        return hits[i].Candidate.ID < hits[j].Candidate.ID
+    })
+    if len(hits) > limit {
+        hits = hits[:limit]
+    }
+    return hits
+}
@@ -1,6 +1,7 @@
 package security
 
 import (
+    "errors"
     "net/http"
     "os"
     "strings"
@@ -9,6 +10,9 @@ import (
     "github.com/golang-jwt/jwt/v4"
 )
 
+// UserKey is the context key where the middleware stores the user of the token
+const UserKey = "user"
+
 func AuthMiddleware() gin.HandlerFunc {
     return func(c *gin.Context) {
         authHeader := c.GetHeader("Authorization")
@@ -16,21 +20,42 @@ func AuthMiddleware() gin.HandlerFunc {
             c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Missing token in header 'Authorization'"})
             return
         }
-        tokenString := strings.Replace(authHeader, "Bearer ", "", 1)
-
-        secret := os.Getenv("JWT_SECRET")
-        if secret == "" {
-            secret = ""
-        }
-
-        token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-            return []byte(secret), nil
-        })
 
-        if err != nil || !token.Valid {
+        user, err := ValidateToken(strings.Replace(authHeader, "Bearer ", "", 1))
+        if err != nil {
             c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired token"})
             return
         }
+        if user != "" {
+            c.Set(UserKey, user)
+        }
         c.Next()
     }
 }
+
+// ErrInvalidToken is returned for a token that is malformed, badly signed or expired
+var ErrInvalidToken = errors.New("Invalid or expired token")
+
+// ValidateToken checks the JWT signed with JWT_SECRET and returns the user of its claims,
+// empty when it has none. The REST middleware and the gRPC interceptors share it.
+func ValidateToken(tokenString string) (string, error) {
+    secret := os.Getenv("JWT_SECRET")
+
+    token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+        return []byte(secret), nil
+    })
+    if err != nil || !token.Valid {
+        return "", ErrInvalidToken
+    }
+    if claims, ok := token.Claims.(jwt.MapClaims); ok {
+        if user, ok := claims["user"].(string); ok {
+            return user, nil
+        }
+    }
+    return "", nil
+}
+
+// CurrentUser returns the user of the token validated by AuthMiddleware
+func CurrentUser(c *gin.Context) string {
+    return c.GetString(UserKey)
+}
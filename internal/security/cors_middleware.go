@@ -0,0 +1,73 @@
+package security
+
+import (
+    "net/http"
+    "strconv"
+    "strings"
+
+    "github.com/gin-gonic/gin"
+
+    "github.com/torvictorvic/seek-v2/internal/config"
+)
+
+// CORSMiddleware answers preflight requests and adds the CORS headers for the allowed origins
+func CORSMiddleware(cfg config.CORSConfig) gin.HandlerFunc {
+    allowAll := false
+    allowed := make(map[string]bool, len(cfg.AllowedOrigins))
+    for _, o := range cfg.AllowedOrigins {
+        if o == "*" {
+            allowAll = true
+        }
+        allowed[strings.ToLower(o)] = true
+    }
+    methods := strings.Join(cfg.AllowedMethods, ", ")
+    headers := strings.Join(cfg.AllowedHeaders, ", ")
+    exposed := strings.Join(cfg.ExposedHeaders, ", ")
+    maxAge := strconv.Itoa(cfg.MaxAge)
+
+    return func(c *gin.Context) {
+        origin := c.GetHeader("Origin")
+        if origin == "" {
+            c.Next()
+            return
+        }
+        c.Writer.Header().Add("Vary", "Origin")
+
+        if !allowAll && !allowed[strings.ToLower(origin)] {
+            if c.Request.Method == http.MethodOptions {
+                c.AbortWithStatus(http.StatusForbidden)
+                return
+            }
+            c.Next()
+            return
+        }
+
+        // With credentials the wildcard is not accepted by browsers, the origin is echoed
+        if allowAll && !cfg.AllowCredentials {
+            c.Header("Access-Control-Allow-Origin", "*")
+        } else {
+            c.Header("Access-Control-Allow-Origin", origin)
+        }
+        if cfg.AllowCredentials {
+            c.Header("Access-Control-Allow-Credentials", "true")
+        }
+
+        // Preflight
+        if c.Request.Method == http.MethodOptions && c.GetHeader("Access-Control-Request-Method") != "" {
+            c.Writer.Header().Add("Vary", "Access-Control-Request-Method")
+            c.Writer.Header().Add("Vary", "Access-Control-Request-Headers")
+            c.Header("Access-Control-Allow-Methods", methods)
+            c.Header("Access-Control-Allow-Headers", headers)
+            if cfg.MaxAge > 0 {
+                c.Header("Access-Control-Max-Age", maxAge)
+            }
+            c.AbortWithStatus(http.StatusNoContent)
+            return
+        }
+
+        if exposed != "" {
+            c.Header("Access-Control-Expose-Headers", exposed)
+        }
+        c.Next()
+    }
+}
@@ -0,0 +1,75 @@
+package security
+
+import (
+    "net"
+    "strconv"
+    "strings"
+
+    "github.com/gin-gonic/gin"
+
+    "github.com/torvictorvic/seek-v2/internal/config"
+)
+
+// SecurityHeadersMiddleware adds the standard security headers to every response.
+// The Swagger UI needs inline scripts and styles, so it gets its own CSP. X-Forwarded-Proto
+// is only read from the trusted proxies, the same ones gin trusts for the client IP.
+func SecurityHeadersMiddleware(cfg config.SecurityHeadersConfig, trustedProxies []string) gin.HandlerFunc {
+    hsts := "max-age=" + strconv.Itoa(cfg.HSTSMaxAge) + "; includeSubDomains"
+    proxies := parseProxies(trustedProxies)
+
+    return func(c *gin.Context) {
+        h := c.Writer.Header()
+        h.Set("X-Content-Type-Options", "nosniff")
+        if cfg.FrameOptions != "" {
+            h.Set("X-Frame-Options", cfg.FrameOptions)
+        }
+        if cfg.ReferrerPolicy != "" {
+            h.Set("Referrer-Policy", cfg.ReferrerPolicy)
+        }
+
+        csp := cfg.CSP
+        if strings.HasPrefix(c.Request.URL.Path, "/swagger/") {
+            csp = cfg.SwaggerCSP
+        }
+        if csp != "" {
+            h.Set("Content-Security-Policy", csp)
+        }
+
+        // HSTS only makes sense over HTTPS (directly or behind a trusted proxy)
+        if cfg.HSTSMaxAge > 0 && (c.Request.TLS != nil || (c.GetHeader("X-Forwarded-Proto") == "https" && trusted(proxies, c.RemoteIP()))) {
+            h.Set("Strict-Transport-Security", hsts)
+        }
+        c.Next()
+    }
+}
+
+// parseProxies reads the IPs and CIDRs of the trusted proxies, as gin.SetTrustedProxies does
+func parseProxies(list []string) []*net.IPNet {
+    var nets []*net.IPNet
+    for _, p := range list {
+        if !strings.Contains(p, "/") {
+            if ip := net.ParseIP(p); ip != nil && ip.To4() != nil {
+                p += "/32"
+            } else {
+                p += "/128"
+            }
+        }
+        if _, n, err := net.ParseCIDR(p); err == nil {
+            nets = append(nets, n)
+        }
+    }
+    return nets
+}
+
+func trusted(proxies []*net.IPNet, remoteIP string) bool {
+    ip := net.ParseIP(remoteIP)
+    if ip == nil {
+        return false
+    }
+    for _, n := range proxies {
+        if n.Contains(ip) {
+            return true
+        }
+    }
+    return false
+}
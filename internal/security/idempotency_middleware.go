@@ -0,0 +1,125 @@
+package security
+
+import (
+    "bytes"
+    "crypto/sha256"
+    "encoding/hex"
+    "io"
+    "log"
+    "net/http"
+    "time"
+
+    "github.com/gin-gonic/gin"
+
+    "github.com/torvictorvic/seek-v2/internal/config"
+    "github.com/torvictorvic/seek-v2/internal/domain"
+    "github.com/torvictorvic/seek-v2/internal/repository"
+)
+
+// MaxIdempotencyKeyLength is the longest Idempotency-Key accepted
+const MaxIdempotencyKeyLength = 255
+
+// IdempotencyMiddleware makes the POST requests sent with an Idempotency-Key safe to
+// retry: the first response is stored and replayed to the requests with the same key
+// and body. A key reused with another body answers 422, and while the first request
+// is in flight 409. Responses with status 5xx are not stored, so the request can be
+// retried. The keys are scoped to the user of the token.
+func IdempotencyMiddleware(store repository.IdempotencyRepository, cfg config.IdempotencyConfig) gin.HandlerFunc {
+    ttl := time.Duration(cfg.TTL) * time.Second
+    lockTimeout := time.Duration(cfg.LockTimeout) * time.Second
+
+    return func(c *gin.Context) {
+        key := c.GetHeader("Idempotency-Key")
+        if key == "" || c.Request.Method != http.MethodPost {
+            c.Next()
+            return
+        }
+        if len(key) > MaxIdempotencyKeyLength {
+            c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "The header 'Idempotency-Key' is too long"})
+            return
+        }
+
+        body, err := io.ReadAll(io.LimitReader(c.Request.Body, int64(cfg.MaxBodyBytes)+1))
+        if err != nil {
+            c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Error reading the request body"})
+            return
+        }
+        if len(body) > cfg.MaxBodyBytes {
+            c.AbortWithStatusJSON(http.StatusRequestEntityTooLarge, gin.H{"error": "The request is too large to be sent with an Idempotency-Key"})
+            return
+        }
+        c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+        now := time.Now()
+        record := domain.IdempotencyRecord{
+            Key:         CurrentUser(c) + "|" + key,
+            Fingerprint: fingerprint(c.Request, body),
+            ExpiresAt:   now.Add(lockTimeout),
+        }
+        existing, created, err := store.Begin(record, now)
+        if err != nil {
+            c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+            return
+        }
+        if !created {
+            switch {
+            case existing.Fingerprint != record.Fingerprint:
+                c.AbortWithStatusJSON(http.StatusUnprocessableEntity, gin.H{"error": "The Idempotency-Key was already used with a different request"})
+            case existing.InFlight():
+                c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": "A request with this Idempotency-Key is still in progress"})
+            default:
+                c.Header("Idempotent-Replayed", "true")
+                c.Data(existing.StatusCode, existing.ContentType, existing.Body)
+                c.Abort()
+            }
+            return
+        }
+
+        // A panic releases the key before the recovery middleware answers
+        completed := false
+        defer func() {
+            if !completed {
+                if err := store.Release(record.Key); err != nil {
+                    log.Printf("Error releasing idempotency key: %v\n", err)
+                }
+            }
+        }()
+
+        recorder := &responseRecorder{ResponseWriter: c.Writer}
+        c.Writer = recorder
+        c.Next()
+
+        if status := recorder.Status(); status < http.StatusInternalServerError {
+            if err := store.Complete(record.Key, status, recorder.Header().Get("Content-Type"), recorder.body.Bytes(), time.Now().Add(ttl)); err != nil {
+                log.Printf("Error saving idempotent response: %v\n", err)
+                return
+            }
+            completed = true
+        }
+    }
+}
+
+// fingerprint identifies the request sent with a key, so the key cannot be reused for
+// another endpoint or payload
+func fingerprint(r *http.Request, body []byte) string {
+    h := sha256.New()
+    h.Write([]byte(r.Method + " " + r.URL.RequestURI() + "\n"))
+    h.Write(body)
+    return hex.EncodeToString(h.Sum(nil))
+}
+
+// responseRecorder keeps a copy of the body written to the client
+type responseRecorder struct {
+    gin.ResponseWriter
+    body bytes.Buffer
+}
+
+func (w *responseRecorder) Write(data []byte) (int, error) {
+    w.body.Write(data)
+    return w.ResponseWriter.Write(data)
+}
+
+func (w *responseRecorder) WriteString(s string) (int, error) {
+    w.body.WriteString(s)
+    return w.ResponseWriter.WriteString(s)
+}
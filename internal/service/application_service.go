@@ -0,0 +1,161 @@
+package service
+
+import (
+    "errors"
+    "fmt"
+
+    "github.com/torvictorvic/seek-v2/internal/domain"
+    "github.com/torvictorvic/seek-v2/internal/repository"
+)
+
+type ApplicationService interface {
+    Apply(req domain.ApplyRequest, actor string) (*domain.Application, error)
+    GetApplication(id int) (*domain.Application, error)
+    MoveStage(id int, req domain.StageChangeRequest, actor string) (*domain.Application, error)
+    ListByCandidate(candidateID int) ([]domain.Application, error)
+    ListByJob(jobID int) ([]domain.Application, error)
+    ListRejectionReasons() ([]domain.RejectionReason, error)
+    SaveRejectionReason(reason domain.RejectionReason) error
+    DeactivateRejectionReason(code string) error
+}
+
+var (
+    ErrApplicationNotFound     = errors.New("Application not found")
+    ErrApplicationExists       = errors.New("The candidate already applied to this job")
+    ErrJobNotFound             = errors.New("Job not found")
+    ErrJobNotOpen              = errors.New("The job is not open for applications")
+    ErrInvalidStage            = errors.New("Unknown pipeline stage")
+    ErrInvalidTransition       = errors.New("Stage transition not allowed")
+    ErrRejectionReasonRequired = errors.New("A rejection reason is required to reject an application")
+    ErrUnknownRejectionReason  = errors.New("Unknown rejection reason")
+)
+
+type applicationServiceImpl struct {
+    apps       repository.ApplicationRepository
+    reasons    repository.RejectionReasonRepository
+    candidates repository.CandidateRepository
+    jobs       repository.JobRepository
+    pipeline   domain.Pipeline
+}
+
+func NewApplicationService(apps repository.ApplicationRepository, reasons repository.RejectionReasonRepository,
+    candidates repository.CandidateRepository, jobs repository.JobRepository, pipeline domain.Pipeline) ApplicationService {
+    return &applicationServiceImpl{apps: apps, reasons: reasons, candidates: candidates, jobs: jobs, pipeline: pipeline}
+}
+
+// Apply creates the application of a candidate to an open job in the first stage of the pipeline
+func (s *applicationServiceImpl) Apply(req domain.ApplyRequest, actor string) (*domain.Application, error) {
+    candidate, err := s.candidates.GetByID(req.CandidateID)
+    if err != nil {
+        return nil, err
+    }
+    if candidate == nil {
+        return nil, ErrCandidateNotFound
+    }
+    job, err := s.jobs.GetByID(req.JobID)
+    if err != nil {
+        return nil, err
+    }
+    if job == nil {
+        return nil, ErrJobNotFound
+    }
+    if job.Status != domain.JobOpen {
+        return nil, ErrJobNotOpen
+    }
+    existing, err := s.apps.GetByCandidateAndJob(req.CandidateID, req.JobID)
+    if err != nil {
+        return nil, err
+    }
+    if existing != nil {
+        return nil, ErrApplicationExists
+    }
+
+    app := domain.Application{CandidateID: req.CandidateID, JobID: req.JobID, Stage: s.pipeline.Initial()}
+    id, err := s.apps.Create(app, domain.StageTransition{ToStage: app.Stage, Actor: actor})
+    if err != nil {
+        return nil, err
+    }
+    return s.GetApplication(id)
+}
+
+// GetApplication returns the application with its stage transitions
+func (s *applicationServiceImpl) GetApplication(id int) (*domain.Application, error) {
+    app, err := s.apps.GetByID(id)
+    if err != nil {
+        return nil, err
+    }
+    if app == nil {
+        return nil, ErrApplicationNotFound
+    }
+    if app.Transitions, err = s.apps.ListTransitions(id); err != nil {
+        return nil, err
+    }
+    return app, nil
+}
+
+// MoveStage moves the application to another stage if the pipeline allows it
+func (s *applicationServiceImpl) MoveStage(id int, req domain.StageChangeRequest, actor string) (*domain.Application, error) {
+    if !s.pipeline.HasStage(req.Stage) {
+        return nil, fmt.Errorf("%w: '%s'", ErrInvalidStage, req.Stage)
+    }
+    app, err := s.apps.GetByID(id)
+    if err != nil {
+        return nil, err
+    }
+    if app == nil {
+        return nil, ErrApplicationNotFound
+    }
+    if !s.pipeline.CanMove(app.Stage, req.Stage) {
+        return nil, fmt.Errorf("%w: from '%s' to '%s'", ErrInvalidTransition, app.Stage, req.Stage)
+    }
+
+    rejectionReason := ""
+    if req.Stage == domain.StageRejected {
+        if req.RejectionReason == "" {
+            return nil, ErrRejectionReasonRequired
+        }
+        reason, err := s.reasons.GetByCode(req.RejectionReason)
+        if err != nil {
+            return nil, err
+        }
+        if reason == nil || !reason.Active {
+            return nil, fmt.Errorf("%w: '%s'", ErrUnknownRejectionReason, req.RejectionReason)
+        }
+        rejectionReason = reason.Code
+    }
+
+    transition := domain.StageTransition{FromStage: app.Stage, ToStage: req.Stage, Reason: req.Reason, Actor: actor}
+    app.Stage = req.Stage
+    app.RejectionReason = rejectionReason
+    if err := s.apps.MoveStage(*app, transition); err != nil {
+        return nil, err
+    }
+    return s.GetApplication(id)
+}
+
+func (s *applicationServiceImpl) ListByCandidate(candidateID int) ([]domain.Application, error) {
+    return s.apps.ListByCandidate(candidateID)
+}
+
+func (s *applicationServiceImpl) ListByJob(jobID int) ([]domain.Application, error) {
+    return s.apps.ListByJob(jobID)
+}
+
+func (s *applicationServiceImpl) ListRejectionReasons() ([]domain.RejectionReason, error) {
+    return s.reasons.List()
+}
+
+func (s *applicationServiceImpl) SaveRejectionReason(reason domain.RejectionReason) error {
+    return s.reasons.Save(reason)
+}
+
+func (s *applicationServiceImpl) DeactivateRejectionReason(code string) error {
+    reason, err := s.reasons.GetByCode(code)
+    if err != nil {
+        return err
+    }
+    if reason == nil {
+        return fmt.Errorf("%w: '%s'", ErrUnknownRejectionReason, code)
+    }
+    return s.reasons.Deactivate(code)
+}
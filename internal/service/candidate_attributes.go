@@ -0,0 +1,246 @@
+package service
+
+import (
+    "errors"
+    "fmt"
+    "sort"
+
+    "github.com/torvictorvic/seek-v2/internal/domain"
+    "github.com/torvictorvic/seek-v2/internal/repository"
+    "github.com/torvictorvic/seek-v2/internal/search"
+)
+
+var (
+    ErrInvalidAttributes  = errors.New("Invalid tags or custom fields")
+    ErrUnknownCustomField = errors.New("Unknown custom field")
+)
+
+// attributeChunk is the number of streamed candidates whose attributes are loaded at once
+const attributeChunk = 100
+
+// WithCustomFields enables the tags and custom fields of the candidates
+func WithCustomFields(fields repository.CustomFieldRepository, attrs repository.CandidateAttributeRepository) Option {
+    return func(s *candidateServiceImpl) {
+        s.fields = fields
+        s.attrs = attrs
+    }
+}
+
+// checkAttributes normalizes the tags and custom field values of the candidate. The
+// required fields are only enforced on creation.
+func (s *candidateServiceImpl) checkAttributes(candidate *domain.Candidate, creating bool) error {
+    if err := s.checkContact(candidate); err != nil {
+        return err
+    }
+    if s.attrs == nil {
+        return nil
+    }
+    if candidate.Tags != nil {
+        tags, err := domain.NormalizeTags(candidate.Tags)
+        if err != nil {
+            return fmt.Errorf("%w: %v", ErrInvalidAttributes, err)
+        }
+        candidate.Tags = tags
+    }
+    if candidate.CustomFields == nil && !creating {
+        return nil
+    }
+
+    defs, err := s.fields.List()
+    if err != nil {
+        return err
+    }
+    known := map[string]bool{}
+    values := map[string]interface{}{}
+    for _, f := range defs {
+        known[f.Key] = true
+        value, ok := candidate.CustomFields[f.Key]
+        if !ok || value == nil {
+            if f.Required && creating {
+                return fmt.Errorf("%w: the custom field '%s' is required", ErrInvalidAttributes, f.Key)
+            }
+            continue
+        }
+        normalized, err := f.Normalize(value)
+        if err != nil {
+            return fmt.Errorf("%w: %v", ErrInvalidAttributes, err)
+        }
+        values[f.Key] = normalized
+    }
+    for key := range candidate.CustomFields {
+        if !known[key] {
+            return fmt.Errorf("%w: '%s'", ErrUnknownCustomField, key)
+        }
+    }
+    if candidate.CustomFields != nil {
+        candidate.CustomFields = values
+    }
+    return nil
+}
+
+// saveAttributes replaces the tags, custom field values and contact details given in
+// the candidate. The omitted ones are left unchanged.
+func (s *candidateServiceImpl) saveAttributes(candidate domain.Candidate) error {
+    if s.contacts != nil && candidate.Contact != nil {
+        if err := s.contacts.Save(candidate.ID, *candidate.Contact); err != nil {
+            return err
+        }
+    }
+    if s.attrs == nil {
+        return nil
+    }
+    if candidate.Tags != nil {
+        if err := s.attrs.SaveTags(candidate.ID, candidate.Tags); err != nil {
+            return err
+        }
+    }
+    if candidate.CustomFields != nil {
+        defs, err := s.fields.List()
+        if err != nil {
+            return err
+        }
+        var values []domain.FieldValue
+        for _, f := range defs {
+            if value, ok := candidate.CustomFields[f.Key]; ok {
+                values = append(values, f.Values(value)...)
+            }
+        }
+        if err := s.attrs.SaveFieldValues(candidate.ID, values); err != nil {
+            return err
+        }
+    }
+    return nil
+}
+
+// loadAttributes fills the tags, custom field values and contact details of the candidates
+func (s *candidateServiceImpl) loadAttributes(candidates []domain.Candidate) error {
+    if !s.hasAttributes() || len(candidates) == 0 {
+        return nil
+    }
+    ids := make([]int, len(candidates))
+    for i, c := range candidates {
+        ids[i] = c.ID
+    }
+    if err := s.loadContacts(candidates, ids); err != nil {
+        return err
+    }
+    if s.attrs == nil {
+        return nil
+    }
+    attrs, err := s.attrs.Load(ids)
+    if err != nil {
+        return err
+    }
+    for i := range candidates {
+        a := attrs[candidates[i].ID]
+        candidates[i].Tags = a.Tags
+        candidates[i].CustomFields = a.CustomFields
+    }
+    return nil
+}
+
+// withAttributes completes the omitted attributes of a saved candidate, so the
+// search index keeps them
+func (s *candidateServiceImpl) withAttributes(candidate domain.Candidate) domain.Candidate {
+    if _, ok := s.searcher.(search.Indexer); !ok || !s.hasAttributes() {
+        return candidate
+    }
+    if candidate.Tags != nil && candidate.CustomFields != nil && candidate.Contact != nil {
+        return candidate
+    }
+    loaded := []domain.Candidate{{ID: candidate.ID}}
+    if err := s.loadAttributes(loaded); err != nil {
+        return candidate
+    }
+    if candidate.Tags == nil {
+        candidate.Tags = loaded[0].Tags
+    }
+    if candidate.CustomFields == nil {
+        candidate.CustomFields = loaded[0].CustomFields
+    }
+    if candidate.Contact == nil {
+        candidate.Contact = loaded[0].Contact
+    }
+    return candidate
+}
+
+// streamWithAttributes calls fn for every candidate with its attributes, loading
+// them in chunks as the candidates are read
+func (s *candidateServiceImpl) streamWithAttributes(filter domain.CandidateFilter, fn func(domain.Candidate) error) error {
+    if !s.hasAttributes() {
+        return s.repo.Stream(filter, fn)
+    }
+    chunk := make([]domain.Candidate, 0, attributeChunk)
+    flush := func() error {
+        if err := s.loadAttributes(chunk); err != nil {
+            return err
+        }
+        for _, c := range chunk {
+            if err := fn(c); err != nil {
+                return err
+            }
+        }
+        chunk = chunk[:0]
+        return nil
+    }
+    err := s.repo.Stream(filter, func(c domain.Candidate) error {
+        chunk = append(chunk, c)
+        if len(chunk) == attributeChunk {
+            return flush()
+        }
+        return nil
+    })
+    if err != nil {
+        return err
+    }
+    return flush()
+}
+
+// ParseFieldFilters builds the custom field conditions of the list filters from the
+// cf[key]=value query parameters
+func (s *candidateServiceImpl) ParseFieldFilters(raw map[string]string) ([]domain.FieldCondition, error) {
+    if len(raw) == 0 {
+        return nil, nil
+    }
+    if s.fields == nil {
+        return nil, ErrUnknownCustomField
+    }
+    defs, err := s.fields.List()
+    if err != nil {
+        return nil, err
+    }
+    byKey := map[string]domain.CustomField{}
+    for _, f := range defs {
+        byKey[f.Key] = f
+    }
+
+    // Sorted so the query is the same for the same filters
+    keys := make([]string, 0, len(raw))
+    for key := range raw {
+        keys = append(keys, key)
+    }
+    sort.Strings(keys)
+
+    var conds []domain.FieldCondition
+    for _, key := range keys {
+        value := raw[key]
+        f, ok := byKey[key]
+        if !ok {
+            return nil, fmt.Errorf("%w: '%s'", ErrUnknownCustomField, key)
+        }
+        cond, err := domain.ParseFieldCondition(f, value)
+        if err != nil {
+            return nil, fmt.Errorf("%w: %v", ErrInvalidAttributes, err)
+        }
+        conds = append(conds, cond)
+    }
+    return conds, nil
+}
+
+// CustomFields returns the definitions of the custom fields, empty when they are disabled
+func (s *candidateServiceImpl) CustomFields() ([]domain.CustomField, error) {
+    if s.fields == nil {
+        return []domain.CustomField{}, nil
+    }
+    return s.fields.List()
+}
@@ -0,0 +1,266 @@
+package service
+
+import (
+    "fmt"
+
+    "github.com/torvictorvic/seek-v2/internal/domain"
+)
+
+// CreateCandidates validates every item and inserts the valid ones with a single multi-row insert.
+// In partial mode, if the insert fails, the items are retried one by one to find the failing ones.
+func (s *candidateServiceImpl) CreateCandidates(candidates []domain.Candidate, mode domain.BatchMode) (*domain.BatchResult, error) {
+    mode, err := s.checkBatch(len(candidates), mode)
+    if err != nil {
+        return nil, err
+    }
+
+    result := newBatchResult(mode, len(candidates))
+    valid := validateItems(result, len(candidates), func(i int) error {
+        if err := validateCandidate(candidates[i]); err != nil {
+            return err
+        }
+        return s.checkAttributes(&candidates[i], true)
+    })
+    if mode == domain.BatchAllOrNothing && result.Failed > 0 {
+        return skipItems(result, valid), nil
+    }
+
+    batch := make([]domain.Candidate, len(valid))
+    for j, i := range valid {
+        batch[j] = candidates[i]
+    }
+    ids, err := s.repo.CreateBatch(batch)
+    if err == nil {
+        for j, i := range valid {
+            created := candidates[i]
+            created.ID = ids[j]
+            s.created(result, i, created)
+        }
+        return result, nil
+    }
+    if mode == domain.BatchAllOrNothing {
+        return failItems(result, valid, err), nil
+    }
+
+    for _, i := range valid {
+        id, err := s.repo.Create(candidates[i])
+        if err != nil {
+            fail(result, i, err)
+            continue
+        }
+        created := candidates[i]
+        created.ID = id
+        s.created(result, i, created)
+    }
+    return result, nil
+}
+
+// UpdateCandidates validates every item and updates the valid ones inside a single transaction.
+// In partial mode, if the transaction fails, the items are retried one by one.
+func (s *candidateServiceImpl) UpdateCandidates(candidates []domain.Candidate, mode domain.BatchMode) (*domain.BatchResult, error) {
+    mode, err := s.checkBatch(len(candidates), mode)
+    if err != nil {
+        return nil, err
+    }
+
+    ids := make([]int, len(candidates))
+    for i, c := range candidates {
+        ids[i] = c.ID
+    }
+    found, err := s.existing(ids)
+    if err != nil {
+        return nil, err
+    }
+
+    result := newBatchResult(mode, len(candidates))
+    valid := validateItems(result, len(candidates), func(i int) error {
+        if candidates[i].ID <= 0 {
+            return fmt.Errorf("The field 'ID' is required")
+        }
+        if !found[candidates[i].ID] {
+            return ErrCandidateNotFound
+        }
+        if err := validateCandidate(candidates[i]); err != nil {
+            return err
+        }
+        return s.checkAttributes(&candidates[i], false)
+    })
+    if mode == domain.BatchAllOrNothing && result.Failed > 0 {
+        return skipItems(result, valid), nil
+    }
+
+    batch := make([]domain.Candidate, len(valid))
+    for j, i := range valid {
+        batch[j] = candidates[i]
+    }
+    err = s.repo.UpdateBatch(batch)
+    if err == nil {
+        for _, i := range valid {
+            s.updated(result, i, candidates[i])
+        }
+        return result, nil
+    }
+    if mode == domain.BatchAllOrNothing {
+        return failItems(result, valid, err), nil
+    }
+
+    for _, i := range valid {
+        if err := s.repo.Update(candidates[i]); err != nil {
+            fail(result, i, err)
+            continue
+        }
+        s.updated(result, i, candidates[i])
+    }
+    return result, nil
+}
+
+// DeleteCandidates deletes the candidates with a single statement
+func (s *candidateServiceImpl) DeleteCandidates(ids []int, mode domain.BatchMode) (*domain.BatchResult, error) {
+    mode, err := s.checkBatch(len(ids), mode)
+    if err != nil {
+        return nil, err
+    }
+    found, err := s.existing(ids)
+    if err != nil {
+        return nil, err
+    }
+
+    result := newBatchResult(mode, len(ids))
+    valid := validateItems(result, len(ids), func(i int) error {
+        if ids[i] <= 0 {
+            return fmt.Errorf("The ID must be a positive integer")
+        }
+        if !found[ids[i]] {
+            return ErrCandidateNotFound
+        }
+        return nil
+    })
+    if mode == domain.BatchAllOrNothing && result.Failed > 0 {
+        return skipItems(result, valid), nil
+    }
+
+    batch := make([]int, len(valid))
+    for j, i := range valid {
+        batch[j] = ids[i]
+    }
+    err = s.repo.DeleteBatch(batch)
+    if err == nil {
+        for _, i := range valid {
+            succeed(result, i, ids[i], domain.BatchStatusDeleted)
+            s.unindex(ids[i])
+        }
+        return result, nil
+    }
+    if mode == domain.BatchAllOrNothing {
+        return failItems(result, valid, err), nil
+    }
+
+    for _, i := range valid {
+        if err := s.repo.Delete(ids[i]); err != nil {
+            fail(result, i, err)
+            continue
+        }
+        succeed(result, i, ids[i], domain.BatchStatusDeleted)
+        s.unindex(ids[i])
+    }
+    return result, nil
+}
+
+// created saves the attributes of a created candidate and records the result. The
+// candidate exists even if the attributes fail, so the item reports its ID with the error.
+func (s *candidateServiceImpl) created(result *domain.BatchResult, i int, candidate domain.Candidate) {
+    if err := s.saveAttributes(candidate); err != nil {
+        fail(result, i, err)
+        result.Items[i].ID = candidate.ID
+        return
+    }
+    succeed(result, i, candidate.ID, domain.BatchStatusCreated)
+    s.index(candidate)
+}
+
+// updated saves the attributes of an updated candidate and records the result
+func (s *candidateServiceImpl) updated(result *domain.BatchResult, i int, candidate domain.Candidate) {
+    if err := s.saveAttributes(candidate); err != nil {
+        fail(result, i, err)
+        return
+    }
+    succeed(result, i, candidate.ID, domain.BatchStatusUpdated)
+    s.index(s.withAttributes(candidate))
+}
+
+// existing tells which of the IDs belong to a candidate, so the missing ones fail as
+// items instead of being reported as written
+func (s *candidateServiceImpl) existing(ids []int) (map[int]bool, error) {
+    candidates, err := s.repo.GetByIDs(ids)
+    if err != nil {
+        return nil, err
+    }
+    found := make(map[int]bool, len(candidates))
+    for _, c := range candidates {
+        found[c.ID] = true
+    }
+    return found, nil
+}
+
+func (s *candidateServiceImpl) checkBatch(n int, mode domain.BatchMode) (domain.BatchMode, error) {
+    if mode == "" {
+        mode = domain.BatchAllOrNothing
+    }
+    if mode != domain.BatchAllOrNothing && mode != domain.BatchPartial {
+        return mode, ErrInvalidBatchMode
+    }
+    if n == 0 {
+        return mode, ErrBatchEmpty
+    }
+    if n > s.batchMaxItems {
+        return mode, fmt.Errorf("%w (%d)", ErrBatchTooLarge, s.batchMaxItems)
+    }
+    return mode, nil
+}
+
+func newBatchResult(mode domain.BatchMode, n int) *domain.BatchResult {
+    result := &domain.BatchResult{Mode: mode, Items: make([]domain.BatchItemResult, n)}
+    for i := range result.Items {
+        result.Items[i].Index = i
+    }
+    return result
+}
+
+// validateItems marks the invalid items as failed and returns the indexes of the valid ones
+func validateItems(result *domain.BatchResult, n int, validate func(i int) error) []int {
+    valid := make([]int, 0, n)
+    for i := 0; i < n; i++ {
+        if err := validate(i); err != nil {
+            fail(result, i, err)
+            continue
+        }
+        valid = append(valid, i)
+    }
+    return valid
+}
+
+func succeed(result *domain.BatchResult, i, id int, status string) {
+    result.Items[i].ID = id
+    result.Items[i].Status = status
+    result.Succeeded++
+}
+
+func fail(result *domain.BatchResult, i int, err error) {
+    result.Items[i].Status = domain.BatchStatusFailed
+    result.Items[i].Error = err.Error()
+    result.Failed++
+}
+
+func skipItems(result *domain.BatchResult, indexes []int) *domain.BatchResult {
+    for _, i := range indexes {
+        result.Items[i].Status = domain.BatchStatusSkipped
+    }
+    return result
+}
+
+func failItems(result *domain.BatchResult, indexes []int, err error) *domain.BatchResult {
+    for _, i := range indexes {
+        fail(result, i, err)
+    }
+    return result
+}
@@ -0,0 +1,61 @@
+package service
+
+import (
+    "errors"
+    "fmt"
+
+    "github.com/torvictorvic/seek-v2/internal/domain"
+    "github.com/torvictorvic/seek-v2/internal/repository"
+)
+
+var ErrInvalidContact = errors.New("Invalid contact details")
+
+// WithContactDetails enables the contact details of the candidates
+func WithContactDetails(contacts repository.CandidateContactRepository) Option {
+    return func(s *candidateServiceImpl) {
+        s.contacts = contacts
+    }
+}
+
+// checkContact normalizes the contact details of the candidate. They are copied first,
+// so the caller's value is left as it was.
+func (s *candidateServiceImpl) checkContact(candidate *domain.Candidate) error {
+    if s.contacts == nil || candidate.Contact == nil {
+        return nil
+    }
+    contact := *candidate.Contact
+    contact.Phones = append([]domain.Phone(nil), contact.Phones...)
+    if contact.Address != nil {
+        address := *contact.Address
+        contact.Address = &address
+    }
+    if err := contact.Normalize(); err != nil {
+        return fmt.Errorf("%w: %v", ErrInvalidContact, err)
+    }
+    candidate.Contact = &contact
+    return nil
+}
+
+// loadContacts fills the contact details of the candidates
+func (s *candidateServiceImpl) loadContacts(candidates []domain.Candidate, ids []int) error {
+    if s.contacts == nil {
+        return nil
+    }
+    contacts, err := s.contacts.Load(ids)
+    if err != nil {
+        return err
+    }
+    for i := range candidates {
+        if c, ok := contacts[candidates[i].ID]; ok {
+            candidates[i].Contact = &c
+        } else {
+            candidates[i].Contact = nil
+        }
+    }
+    return nil
+}
+
+// hasAttributes tells whether the candidates have data stored outside their table
+func (s *candidateServiceImpl) hasAttributes() bool {
+    return s.attrs != nil || s.contacts != nil
+}
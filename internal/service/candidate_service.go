@@ -1,49 +1,434 @@
 package service
 
 import (
+    "context"
+    "encoding/json"
+    "errors"
     "fmt"
 
     "github.com/torvictorvic/seek-v2/internal/domain"
     "github.com/torvictorvic/seek-v2/internal/repository"
+    "github.com/torvictorvic/seek-v2/internal/search"
 )
 
 type CandidateService interface {
     CreateCandidate(candidate domain.Candidate) (int, error)
     GetCandidateByID(id int) (*domain.Candidate, error)
-    GetAllCandidates() ([]domain.Candidate, error)
+    GetCandidatesByIDs(ids []int) ([]domain.Candidate, error)
+    GetCandidateByEmail(email string) (*domain.Candidate, error)
+    GetAllCandidates(filter domain.CandidateFilter) ([]domain.Candidate, error)
+    StreamCandidates(filter domain.CandidateFilter, fn func(domain.Candidate) error) error
+    ListCandidatesPage(filter domain.CandidateFilter, afterID, limit int) ([]domain.Candidate, error)
+    CountCandidates(filter domain.CandidateFilter) (int, error)
     UpdateCandidate(candidate domain.Candidate) error
     DeleteCandidate(id int) error
+    ValidateCandidate(candidate domain.Candidate) error
+    UpsertCandidate(candidate domain.Candidate) (int, bool, error)
+    MergeCandidates(targetID int, req domain.MergeRequest, actor string) (*domain.Candidate, error)
+    SearchCandidates(query string, filter domain.CandidateFilter, limit int) ([]domain.CandidateSearchHit, error)
+    CreateCandidates(candidates []domain.Candidate, mode domain.BatchMode) (*domain.BatchResult, error)
+    UpdateCandidates(candidates []domain.Candidate, mode domain.BatchMode) (*domain.BatchResult, error)
+    DeleteCandidates(ids []int, mode domain.BatchMode) (*domain.BatchResult, error)
+    ParseFieldFilters(raw map[string]string) ([]domain.FieldCondition, error)
+    CustomFields() ([]domain.CustomField, error)
 }
 
+var (
+    ErrBatchEmpty        = errors.New("The batch has no items")
+    ErrBatchTooLarge     = errors.New("The batch exceeds the maximum number of items")
+    ErrInvalidBatchMode  = errors.New("The batch mode must be 'all_or_nothing' or 'partial'")
+    ErrSearchDisabled    = errors.New("Search is not configured")
+    ErrCandidateNotFound = repository.ErrCandidateNotFound
+    ErrInvalidCandidate  = errors.New("The fields 'Name' and 'Email' are required")
+    ErrSameCandidate     = errors.New("A candidate cannot be merged with itself")
+    ErrInvalidMergeField = errors.New("Invalid merge field")
+    ErrEmptyQuery        = errors.New("The query 'q' is required")
+)
+
+// DefaultBatchMaxItems is used when no limit is configured
+const DefaultBatchMaxItems = 100
+
 type candidateServiceImpl struct {
-    repo repository.CandidateRepository
+    repo          repository.CandidateRepository
+    batchMaxItems int
+    searcher      search.Searcher
+    fields        repository.CustomFieldRepository
+    attrs         repository.CandidateAttributeRepository
+    contacts      repository.CandidateContactRepository
+    uow           repository.UnitOfWork
+    ctx           context.Context // request the repositories are bound to, see ForUser
 }
 
-func NewCandidateService(repo repository.CandidateRepository) CandidateService {
-    return &candidateServiceImpl{repo: repo}
+// Option customizes the candidate service
+type Option func(*candidateServiceImpl)
+
+// WithBatchMaxItems sets the maximum number of items accepted by the batch operations
+func WithBatchMaxItems(n int) Option {
+    return func(s *candidateServiceImpl) {
+        if n > 0 {
+            s.batchMaxItems = n
+        }
+    }
 }
 
-func (s *candidateServiceImpl) CreateCandidate(candidate domain.Candidate) (int, error) {
-    // Ejemplo de validaciones
+// WithSearcher sets the search backend. If it keeps its own index, the service
+// updates it on every write.
+func WithSearcher(searcher search.Searcher) Option {
+    return func(s *candidateServiceImpl) {
+        s.searcher = searcher
+    }
+}
+
+// WithUnitOfWork writes each candidate and its attributes in a single transaction
+func WithUnitOfWork(uow repository.UnitOfWork) Option {
+    return func(s *candidateServiceImpl) {
+        s.uow = uow
+    }
+}
+
+func NewCandidateService(repo repository.CandidateRepository, opts ...Option) CandidateService {
+    s := &candidateServiceImpl{repo: repo, batchMaxItems: DefaultBatchMaxItems, ctx: context.Background()}
+    for _, opt := range opts {
+        opt(s)
+    }
+    return s
+}
+
+func validateCandidate(candidate domain.Candidate) error {
     if candidate.Name == "" || candidate.Email == "" {
-        return 0, fmt.Errorf("The fields 'Name' and 'Email' are required")
+        return ErrInvalidCandidate
+    }
+    return nil
+}
+
+func (s *candidateServiceImpl) CreateCandidate(candidate domain.Candidate) (int, error) {
+    if err := validateCandidate(candidate); err != nil {
+        return 0, err
+    }
+    if err := s.checkAttributes(&candidate, true); err != nil {
+        return 0, err
+    }
+    err := s.atomic(func(tx *candidateServiceImpl) error {
+        id, err := tx.repo.Create(candidate)
+        if err != nil {
+            return err
+        }
+        candidate.ID = id
+        return tx.saveAttributes(candidate)
+    })
+    if err != nil {
+        if s.uow != nil {
+            // The candidate was rolled back with its attributes
+            return 0, err
+        }
+        return candidate.ID, err
+    }
+    s.index(candidate)
+    return candidate.ID, nil
+}
+
+// ForUser returns the service for a request of the user: the reads of the candidates go to
+// the read replicas, or to the primary for a while after the user wrote. Services built
+// elsewhere (test doubles) are returned as is.
+func ForUser(svc CandidateService, user string) CandidateService {
+    s, ok := svc.(*candidateServiceImpl)
+    if !ok {
+        return svc
+    }
+    bound := *s
+    bound.ctx = repository.ReadAs(s.ctx, user)
+    bound.repo = repository.Bind(bound.ctx, s.repo)
+    bound.searcher = repository.Bind(bound.ctx, s.searcher)
+    return &bound
+}
+
+// atomic runs fn with the repositories bound to the transaction of the unit of work, so
+// its writes are rolled back together. Without a unit of work fn runs with s.
+func (s *candidateServiceImpl) atomic(fn func(tx *candidateServiceImpl) error) error {
+    if s.uow == nil {
+        return fn(s)
     }
-    // Luego llama al repositorio
-    return s.repo.Create(candidate)
+    return s.uow.Do(s.ctx, func(ctx context.Context) error {
+        tx := *s
+        tx.repo = repository.Bind(ctx, s.repo)
+        tx.attrs = repository.Bind(ctx, s.attrs)
+        tx.contacts = repository.Bind(ctx, s.contacts)
+        return fn(&tx)
+    })
 }
 
 func (s *candidateServiceImpl) GetCandidateByID(id int) (*domain.Candidate, error) {
-    return s.repo.GetByID(id)
+    return s.getOne(s.repo.GetByID(id))
+}
+
+// GetCandidatesByIDs reads several candidates with their attributes in one call, used
+// to batch the lookups of the GraphQL queries
+func (s *candidateServiceImpl) GetCandidatesByIDs(ids []int) ([]domain.Candidate, error) {
+    candidates, err := s.repo.GetByIDs(ids)
+    if err != nil {
+        return nil, err
+    }
+    if err := s.loadAttributes(candidates); err != nil {
+        return nil, err
+    }
+    return candidates, nil
+}
+
+func (s *candidateServiceImpl) GetCandidateByEmail(email string) (*domain.Candidate, error) {
+    return s.getOne(s.repo.GetByEmail(email))
+}
+
+// getOne adds the attributes to a candidate read from the repository
+func (s *candidateServiceImpl) getOne(candidate *domain.Candidate, err error) (*domain.Candidate, error) {
+    if err != nil || candidate == nil {
+        return candidate, err
+    }
+    loaded := []domain.Candidate{*candidate}
+    if err := s.loadAttributes(loaded); err != nil {
+        return nil, err
+    }
+    return &loaded[0], nil
+}
+
+func (s *candidateServiceImpl) GetAllCandidates(filter domain.CandidateFilter) ([]domain.Candidate, error) {
+    candidates, err := s.repo.GetAll(filter)
+    if err != nil {
+        return nil, err
+    }
+    if err := s.loadAttributes(candidates); err != nil {
+        return nil, err
+    }
+    return candidates, nil
+}
+
+// ListCandidatesPage returns the next limit candidates of the filter after the ID, in ID
+// order, with their attributes
+func (s *candidateServiceImpl) ListCandidatesPage(filter domain.CandidateFilter, afterID, limit int) ([]domain.Candidate, error) {
+    candidates, err := s.repo.Page(filter, afterID, limit)
+    if err != nil {
+        return nil, err
+    }
+    if err := s.loadAttributes(candidates); err != nil {
+        return nil, err
+    }
+    return candidates, nil
+}
+
+func (s *candidateServiceImpl) CountCandidates(filter domain.CandidateFilter) (int, error) {
+    return s.repo.Count(filter)
 }
 
-func (s *candidateServiceImpl) GetAllCandidates() ([]domain.Candidate, error) {
-    return s.repo.GetAll()
+func (s *candidateServiceImpl) StreamCandidates(filter domain.CandidateFilter, fn func(domain.Candidate) error) error {
+    return s.streamWithAttributes(filter, fn)
 }
 
 func (s *candidateServiceImpl) UpdateCandidate(candidate domain.Candidate) error {
-    return s.repo.Update(candidate)
+    if err := validateCandidate(candidate); err != nil {
+        return err
+    }
+    if err := s.checkAttributes(&candidate, false); err != nil {
+        return err
+    }
+    err := s.atomic(func(tx *candidateServiceImpl) error {
+        if err := tx.repo.Update(candidate); err != nil {
+            return err
+        }
+        return tx.saveAttributes(candidate)
+    })
+    if err != nil {
+        return err
+    }
+    s.index(s.withAttributes(candidate))
+    return nil
 }
 
 func (s *candidateServiceImpl) DeleteCandidate(id int) error {
-    return s.repo.Delete(id)
+    if err := s.repo.Delete(id); err != nil {
+        return err
+    }
+    s.unindex(id)
+    return nil
+}
+
+// ValidateCandidate runs the same validation as CreateCandidate without writing anything
+func (s *candidateServiceImpl) ValidateCandidate(candidate domain.Candidate) error {
+    if err := validateCandidate(candidate); err != nil {
+        return err
+    }
+    return s.checkAttributes(&candidate, false)
+}
+
+// UpsertCandidate creates the candidate or updates the one with the same email
+func (s *candidateServiceImpl) UpsertCandidate(candidate domain.Candidate) (int, bool, error) {
+    if err := validateCandidate(candidate); err != nil {
+        return 0, false, err
+    }
+    if err := s.checkAttributes(&candidate, false); err != nil {
+        return 0, false, err
+    }
+    var created bool
+    err := s.atomic(func(tx *candidateServiceImpl) error {
+        id, isNew, err := tx.repo.Upsert(candidate)
+        if err != nil {
+            return err
+        }
+        candidate.ID, created = id, isNew
+        return tx.saveAttributes(candidate)
+    })
+    if err != nil {
+        if s.uow != nil || candidate.ID == 0 {
+            return 0, false, err
+        }
+        return candidate.ID, created, err
+    }
+    s.index(s.withAttributes(candidate))
+    return candidate.ID, created, nil
+}
+
+// SearchCandidates finds candidates by free text, combined with the list filters
+func (s *candidateServiceImpl) SearchCandidates(query string, filter domain.CandidateFilter, limit int) ([]domain.CandidateSearchHit, error) {
+    if s.searcher == nil {
+        return nil, ErrSearchDisabled
+    }
+    if len(search.Terms(query)) == 0 {
+        return nil, ErrEmptyQuery
+    }
+    hits, err := s.searcher.Search(query, filter, limit)
+    if err != nil || !s.hasAttributes() {
+        return hits, err
+    }
+    candidates := make([]domain.Candidate, len(hits))
+    for i, hit := range hits {
+        candidates[i] = hit.Candidate
+    }
+    if err := s.loadAttributes(candidates); err != nil {
+        return nil, err
+    }
+    for i := range hits {
+        hits[i].Candidate = candidates[i]
+    }
+    return hits, nil
+}
+
+// index puts the candidate in the search index as stored, with the timestamps and any
+// column set by the database, and the attributes it was saved with
+func (s *candidateServiceImpl) index(candidate domain.Candidate) {
+    idx, ok := s.searcher.(search.Indexer)
+    if !ok {
+        return
+    }
+    if stored, err := s.repo.GetByID(candidate.ID); err == nil && stored != nil {
+        stored.Tags, stored.CustomFields, stored.Contact = candidate.Tags, candidate.CustomFields, candidate.Contact
+        stored.ResumeText = candidate.ResumeText
+        candidate = *stored
+    }
+    idx.Index(candidate)
+}
+
+func (s *candidateServiceImpl) unindex(id int) {
+    if idx, ok := s.searcher.(search.Indexer); ok {
+        idx.Remove(id)
+    }
+}
+
+// MergeCandidates merges the source candidate into the target, choosing per attribute which
+// side keeps its value. The source is removed and its related data moves to the target.
+func (s *candidateServiceImpl) MergeCandidates(targetID int, req domain.MergeRequest, actor string) (*domain.Candidate, error) {
+    if targetID == req.SourceID {
+        return nil, ErrSameCandidate
+    }
+    target, err := s.repo.GetByID(targetID)
+    if err != nil {
+        return nil, err
+    }
+    source, err := s.repo.GetByID(req.SourceID)
+    if err != nil {
+        return nil, err
+    }
+    if target == nil || source == nil {
+        return nil, ErrCandidateNotFound
+    }
+    pair := []domain.Candidate{*target, *source}
+    if err := s.loadAttributes(pair); err != nil {
+        return nil, err
+    }
+    target, source = &pair[0], &pair[1]
+
+    merged := *target
+    for field, side := range req.Fields {
+        if side != domain.MergeKeepTarget && side != domain.MergeKeepSource {
+            return nil, fmt.Errorf("%w: '%s' must be 'target' or 'source'", ErrInvalidMergeField, field)
+        }
+        keepSource := side == domain.MergeKeepSource
+        switch field {
+        case "name":
+            if keepSource {
+                merged.Name = source.Name
+            }
+        case "email":
+            if keepSource {
+                merged.Email = source.Email
+            }
+        case "gender":
+            if keepSource {
+                merged.Gender = source.Gender
+            }
+        case "salary_expected":
+            if keepSource {
+                merged.SalaryExpected = source.SalaryExpected
+            }
+        default:
+            return nil, fmt.Errorf("%w: unknown field '%s'", ErrInvalidMergeField, field)
+        }
+    }
+    if err := validateCandidate(merged); err != nil {
+        return nil, err
+    }
+    mergeAttributes(&merged, *source)
+
+    details, _ := json.Marshal(map[string]interface{}{
+        "source_id": source.ID,
+        "source":    source,
+        "fields":    req.Fields,
+    })
+    entry := domain.CandidateHistoryEntry{
+        CandidateID: merged.ID,
+        Action:      domain.HistoryMerged,
+        Details:     details,
+        Actor:       actor,
+    }
+    err = s.atomic(func(tx *candidateServiceImpl) error {
+        if err := tx.repo.Merge(merged, source.ID, entry); err != nil {
+            return err
+        }
+        return tx.saveAttributes(merged)
+    })
+    if err != nil {
+        return nil, err
+    }
+    s.unindex(source.ID)
+    s.index(merged)
+    return &merged, nil
+}
+
+// mergeAttributes keeps the tags of both candidates and the custom field values of the
+// target, completed with the ones only the source has. The contact details are the
+// target's, or the source's when the target has none.
+func mergeAttributes(merged *domain.Candidate, source domain.Candidate) {
+    if merged.Contact == nil {
+        merged.Contact = source.Contact
+    }
+    if len(source.Tags) > 0 {
+        merged.Tags, _ = domain.NormalizeTags(append(append([]string{}, merged.Tags...), source.Tags...))
+    }
+    if len(source.CustomFields) > 0 {
+        fields := map[string]interface{}{}
+        for key, value := range source.CustomFields {
+            fields[key] = value
+        }
+        for key, value := range merged.CustomFields {
+            fields[key] = value
+        }
+        merged.CustomFields = fields
+    }
 }
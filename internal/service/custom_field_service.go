@@ -0,0 +1,99 @@
+package service
+
+import (
+    "errors"
+    "fmt"
+
+    "github.com/torvictorvic/seek-v2/internal/domain"
+    "github.com/torvictorvic/seek-v2/internal/repository"
+)
+
+type CustomFieldService interface {
+    CreateField(field domain.CustomField) (*domain.CustomField, error)
+    ListFields() ([]domain.CustomField, error)
+    GetField(key string) (*domain.CustomField, error)
+    UpdateField(field domain.CustomField) (*domain.CustomField, error)
+    DeleteField(key string) error
+    ListTags() ([]domain.TagCount, error)
+}
+
+var (
+    ErrInvalidCustomField  = errors.New("Invalid custom field")
+    ErrCustomFieldNotFound = errors.New("Custom field not found")
+    ErrCustomFieldExists   = errors.New("A custom field with this key already exists")
+)
+
+type customFieldServiceImpl struct {
+    fields repository.CustomFieldRepository
+    attrs  repository.CandidateAttributeRepository
+}
+
+func NewCustomFieldService(fields repository.CustomFieldRepository, attrs repository.CandidateAttributeRepository) CustomFieldService {
+    return &customFieldServiceImpl{fields: fields, attrs: attrs}
+}
+
+func (s *customFieldServiceImpl) CreateField(field domain.CustomField) (*domain.CustomField, error) {
+    if err := field.Validate(); err != nil {
+        return nil, fmt.Errorf("%w: %v", ErrInvalidCustomField, err)
+    }
+    existing, err := s.fields.GetByKey(field.Key)
+    if err != nil {
+        return nil, err
+    }
+    if existing != nil {
+        return nil, ErrCustomFieldExists
+    }
+    if _, err := s.fields.Create(field); err != nil {
+        return nil, err
+    }
+    return s.fields.GetByKey(field.Key)
+}
+
+func (s *customFieldServiceImpl) ListFields() ([]domain.CustomField, error) {
+    return s.fields.List()
+}
+
+func (s *customFieldServiceImpl) GetField(key string) (*domain.CustomField, error) {
+    field, err := s.fields.GetByKey(key)
+    if err != nil {
+        return nil, err
+    }
+    if field == nil {
+        return nil, ErrCustomFieldNotFound
+    }
+    return field, nil
+}
+
+// UpdateField changes label, options and required. The key and the type cannot change
+// since the stored values depend on them.
+func (s *customFieldServiceImpl) UpdateField(field domain.CustomField) (*domain.CustomField, error) {
+    existing, err := s.GetField(field.Key)
+    if err != nil {
+        return nil, err
+    }
+    if field.Type == "" {
+        field.Type = existing.Type
+    }
+    if field.Type != existing.Type {
+        return nil, fmt.Errorf("%w: the type cannot change", ErrInvalidCustomField)
+    }
+    if err := field.Validate(); err != nil {
+        return nil, fmt.Errorf("%w: %v", ErrInvalidCustomField, err)
+    }
+    if err := s.fields.Update(field); err != nil {
+        return nil, err
+    }
+    return s.fields.GetByKey(field.Key)
+}
+
+// DeleteField removes the field and its values from all the candidates
+func (s *customFieldServiceImpl) DeleteField(key string) error {
+    if _, err := s.GetField(key); err != nil {
+        return err
+    }
+    return s.fields.Delete(key)
+}
+
+func (s *customFieldServiceImpl) ListTags() ([]domain.TagCount, error) {
+    return s.attrs.ListTags()
+}
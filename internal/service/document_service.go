@@ -0,0 +1,407 @@
+package service
+
+import (
+    "archive/zip"
+    "context"
+    "crypto/hmac"
+    "crypto/rand"
+    "crypto/sha256"
+    "encoding/hex"
+    "errors"
+    "fmt"
+    "io"
+    "log"
+    "net/http"
+    "path/filepath"
+    "strconv"
+    "strings"
+    "time"
+    "unicode"
+    "unicode/utf8"
+
+    "github.com/torvictorvic/seek-v2/internal/domain"
+    "github.com/torvictorvic/seek-v2/internal/repository"
+    "github.com/torvictorvic/seek-v2/internal/resume"
+    "github.com/torvictorvic/seek-v2/internal/search"
+    "github.com/torvictorvic/seek-v2/internal/storage"
+)
+
+type DocumentService interface {
+    // Upload checks the type and size of the content and stores it. content must
+    // allow random access to detect the DOCX files, like a multipart.File does.
+    Upload(ctx context.Context, candidateID int, fileName string, content io.ReaderAt, size int64, user string) (*domain.Document, error)
+    ListDocuments(candidateID int) ([]domain.Document, error)
+    GetDocument(candidateID, docID int) (*domain.Document, error)
+    // Open returns the content of the document, seekable for range requests
+    Open(ctx context.Context, doc domain.Document) *storage.ReadSeeker
+    SignURL(doc domain.Document) domain.DocumentURL
+    // VerifyURL returns the document of a signed URL that has not expired
+    VerifyURL(docID int, expires, signature string) (*domain.Document, error)
+    DeleteDocument(ctx context.Context, candidateID, docID int, user string) error
+    // ParseDocument suggests the candidate data found in a stored PDF or DOCX document
+    ParseDocument(candidateID, docID int) (*domain.ResumeSuggestion, error)
+    // ParseResume suggests the candidate data of a resume that is not stored
+    ParseResume(content io.ReaderAt, size int64) (*domain.ResumeSuggestion, error)
+    // PurgeDeleted removes the content of the deleted documents, including the ones of
+    // deleted candidates, and returns how many were purged
+    PurgeDeleted(ctx context.Context) (int, error)
+}
+
+var (
+    ErrDocumentNotFound    = errors.New("Document not found")
+    ErrInvalidDocument     = errors.New("Invalid document")
+    ErrDocumentTooLarge    = errors.New("The document exceeds the maximum size")
+    ErrUnsupportedDocument = errors.New("Unsupported document type, only PDF, DOCX and images are allowed")
+    ErrInvalidDocumentURL  = errors.New("The download link is invalid or expired")
+    ErrNoResumeText        = errors.New("No text could be extracted from the document")
+)
+
+// DocumentSettings are the limits of the uploads and the signing of the download URLs
+type DocumentSettings struct {
+    MaxBytes  int64
+    URLTTL    time.Duration
+    URLSecret []byte
+}
+
+// DOCXContentType is the media type of the Word documents
+const DOCXContentType = "application/vnd.openxmlformats-officedocument.wordprocessingml.document"
+
+// purgeBatch is the number of deleted documents purged per query
+const purgeBatch = 100
+
+// allowedImages are the image types accepted, as reported by http.DetectContentType
+var allowedImages = map[string]bool{"image/png": true, "image/jpeg": true, "image/gif": true, "image/webp": true}
+
+type documentServiceImpl struct {
+    docs       repository.DocumentRepository
+    candidates repository.CandidateRepository
+    store      storage.Storage
+    settings   DocumentSettings
+    now        func() time.Time
+    index      search.ResumeIndexer
+    tags       repository.CandidateAttributeRepository
+}
+
+// DocumentOption configures the optional features of the document service
+type DocumentOption func(*documentServiceImpl)
+
+// WithResumeIndex keeps the text of the resumes in an index that does not read it from
+// the database, like search.MemoryIndex
+func WithResumeIndex(index search.ResumeIndexer) DocumentOption {
+    return func(s *documentServiceImpl) {
+        s.index = index
+    }
+}
+
+// WithKnownTags makes the resume parser also suggest the tags already in use
+func WithKnownTags(attrs repository.CandidateAttributeRepository) DocumentOption {
+    return func(s *documentServiceImpl) {
+        s.tags = attrs
+    }
+}
+
+func NewDocumentService(docs repository.DocumentRepository, candidates repository.CandidateRepository, store storage.Storage, settings DocumentSettings, opts ...DocumentOption) DocumentService {
+    s := &documentServiceImpl{docs: docs, candidates: candidates, store: store, settings: settings, now: time.Now}
+    for _, opt := range opts {
+        opt(s)
+    }
+    return s
+}
+
+// SniffDocument detects the type of the content, ignoring the name and the type sent by
+// the client. It returns the content type and the document kind, or ErrUnsupportedDocument.
+func SniffDocument(content io.ReaderAt, size int64) (string, string, error) {
+    head := make([]byte, 512)
+    n, err := content.ReadAt(head, 0)
+    if err != nil && err != io.EOF {
+        return "", "", err
+    }
+    contentType := http.DetectContentType(head[:n])
+    switch {
+    case contentType == "application/pdf":
+        return contentType, domain.DocumentPDF, nil
+    case allowedImages[contentType]:
+        return contentType, domain.DocumentImage, nil
+    case contentType == "application/zip" && isDOCX(content, size):
+        return DOCXContentType, domain.DocumentDOCX, nil
+    }
+    return "", "", fmt.Errorf("%w (detected %s)", ErrUnsupportedDocument, contentType)
+}
+
+// isDOCX tells whether the zip archive is a Word document
+func isDOCX(content io.ReaderAt, size int64) bool {
+    archive, err := zip.NewReader(content, size)
+    if err != nil {
+        return false
+    }
+    var types, document bool
+    for _, f := range archive.File {
+        switch f.Name {
+        case "[Content_Types].xml":
+            types = true
+        case "word/document.xml":
+            document = true
+        }
+    }
+    return types && document
+}
+
+// cleanFileName keeps the base name of the uploaded file without control characters
+func cleanFileName(name string) string {
+    name = filepath.Base(strings.ReplaceAll(name, "\\", "/"))
+    name = strings.Map(func(r rune) rune {
+        if unicode.IsControl(r) || r == '"' {
+            return -1
+        }
+        return r
+    }, name)
+    name = strings.TrimSpace(name)
+    if name == "" || name == "." || name == "/" {
+        return "document"
+    }
+    // The end keeps the extension; the cut must not split a multi-byte character
+    if len(name) > 255 {
+        start := len(name) - 255
+        for !utf8.RuneStart(name[start]) {
+            start++
+        }
+        name = name[start:]
+    }
+    return name
+}
+
+func randomKey() (string, error) {
+    b := make([]byte, 16)
+    if _, err := rand.Read(b); err != nil {
+        return "", err
+    }
+    return hex.EncodeToString(b), nil
+}
+
+func (s *documentServiceImpl) Upload(ctx context.Context, candidateID int, fileName string, content io.ReaderAt, size int64, user string) (*domain.Document, error) {
+    if user == "" {
+        return nil, ErrIdentityRequired
+    }
+    if size <= 0 {
+        return nil, fmt.Errorf("%w: the file is empty", ErrInvalidDocument)
+    }
+    if s.settings.MaxBytes > 0 && size > s.settings.MaxBytes {
+        return nil, fmt.Errorf("%w of %d bytes", ErrDocumentTooLarge, s.settings.MaxBytes)
+    }
+    candidate, err := s.candidates.GetByID(candidateID)
+    if err != nil {
+        return nil, err
+    }
+    if candidate == nil {
+        return nil, ErrCandidateNotFound
+    }
+    contentType, kind, err := SniffDocument(content, size)
+    if err != nil {
+        return nil, err
+    }
+
+    name, err := randomKey()
+    if err != nil {
+        return nil, err
+    }
+    // The text is searchable and can be parsed later. A document whose text cannot be
+    // read is stored all the same.
+    var text string
+    if kind != domain.DocumentImage {
+        if text, err = resume.Extract(content, size, kind); err != nil {
+            log.Printf("No text extracted from '%s': %v\n", fileName, err)
+        }
+    }
+    doc := domain.Document{
+        Text:        text,
+        CandidateID: candidateID,
+        FileName:    cleanFileName(fileName),
+        ContentType: contentType,
+        Kind:        kind,
+        Size:        size,
+        StorageKey:  fmt.Sprintf("candidates/%d/%s", candidateID, name),
+        UploadedBy:  user,
+    }
+    hash := sha256.New()
+    body := io.TeeReader(io.NewSectionReader(content, 0, size), hash)
+    if err := s.store.Put(ctx, doc.StorageKey, body, size, contentType); err != nil {
+        return nil, fmt.Errorf("Error storing document: %w", err)
+    }
+    doc.SHA256 = hex.EncodeToString(hash.Sum(nil))
+
+    id, err := s.docs.Create(doc)
+    if err != nil {
+        // Nothing references the object yet
+        s.store.Delete(ctx, doc.StorageKey)
+        return nil, err
+    }
+    if text != "" {
+        s.reindex(candidateID)
+    }
+    return s.docs.GetByID(id)
+}
+
+// reindex updates the resume text of the candidate in the index, when there is one
+func (s *documentServiceImpl) reindex(candidateID int) {
+    if s.index == nil {
+        return
+    }
+    texts, err := s.docs.ResumeTexts([]int{candidateID})
+    if err != nil {
+        log.Printf("Error indexing the resumes of candidate %d: %v\n", candidateID, err)
+        return
+    }
+    s.index.IndexResume(candidateID, texts[candidateID])
+}
+
+func (s *documentServiceImpl) ListDocuments(candidateID int) ([]domain.Document, error) {
+    return s.docs.ListByCandidate(candidateID)
+}
+
+func (s *documentServiceImpl) GetDocument(candidateID, docID int) (*domain.Document, error) {
+    doc, err := s.docs.GetByID(docID)
+    if err != nil {
+        return nil, err
+    }
+    if doc == nil || doc.CandidateID != candidateID {
+        return nil, ErrDocumentNotFound
+    }
+    return doc, nil
+}
+
+func (s *documentServiceImpl) Open(ctx context.Context, doc domain.Document) *storage.ReadSeeker {
+    return storage.NewReadSeeker(ctx, s.store, doc.StorageKey, doc.Size)
+}
+
+func (s *documentServiceImpl) sign(docID int, expires string) string {
+    mac := hmac.New(sha256.New, s.settings.URLSecret)
+    mac.Write([]byte(strconv.Itoa(docID) + ":" + expires))
+    return hex.EncodeToString(mac.Sum(nil))
+}
+
+// SignURL returns a link, relative to the server root, valid for the configured TTL
+func (s *documentServiceImpl) SignURL(doc domain.Document) domain.DocumentURL {
+    expiresAt := s.now().Add(s.settings.URLTTL).Truncate(time.Second)
+    expires := strconv.FormatInt(expiresAt.Unix(), 10)
+    return domain.DocumentURL{
+        URL:       fmt.Sprintf("/documents/%d/download?expires=%s&signature=%s", doc.ID, expires, s.sign(doc.ID, expires)),
+        ExpiresAt: expiresAt,
+    }
+}
+
+func (s *documentServiceImpl) VerifyURL(docID int, expires, signature string) (*domain.Document, error) {
+    unix, err := strconv.ParseInt(expires, 10, 64)
+    if err != nil || !hmac.Equal([]byte(signature), []byte(s.sign(docID, expires))) {
+        return nil, ErrInvalidDocumentURL
+    }
+    if s.now().Unix() > unix {
+        return nil, ErrInvalidDocumentURL
+    }
+    doc, err := s.docs.GetByID(docID)
+    if err != nil {
+        return nil, err
+    }
+    if doc == nil {
+        return nil, ErrDocumentNotFound
+    }
+    return doc, nil
+}
+
+// DeleteDocument soft-deletes the document and removes its content right away. If the
+// storage fails the content is left for PurgeDeleted.
+func (s *documentServiceImpl) DeleteDocument(ctx context.Context, candidateID, docID int, user string) error {
+    doc, err := s.GetDocument(candidateID, docID)
+    if err != nil {
+        return err
+    }
+    if err := s.docs.Delete(*doc, user); err != nil {
+        return err
+    }
+    s.reindex(candidateID)
+    if err := s.purge(ctx, *doc); err != nil {
+        log.Printf("Document %d will be purged later: %v\n", doc.ID, err)
+    }
+    return nil
+}
+
+func (s *documentServiceImpl) purge(ctx context.Context, doc domain.Document) error {
+    if err := s.store.Delete(ctx, doc.StorageKey); err != nil {
+        return err
+    }
+    return s.docs.Purge(doc.ID)
+}
+
+func (s *documentServiceImpl) PurgeDeleted(ctx context.Context) (int, error) {
+    purged := 0
+    for {
+        docs, err := s.docs.ListDeleted(purgeBatch)
+        if err != nil {
+            return purged, err
+        }
+        for _, doc := range docs {
+            // A failure stops the run, so the same documents are not retried in a loop
+            if err := s.purge(ctx, doc); err != nil {
+                return purged, err
+            }
+            purged++
+        }
+        if len(docs) < purgeBatch {
+            return purged, nil
+        }
+    }
+}
+
+// parser recognizes the default skills and the tags in use
+func (s *documentServiceImpl) parser() *resume.Parser {
+    if s.tags == nil {
+        return resume.NewParser()
+    }
+    counts, err := s.tags.ListTags()
+    if err != nil {
+        return resume.NewParser()
+    }
+    tags := make([]string, len(counts))
+    for i, t := range counts {
+        tags[i] = t.Tag
+    }
+    return resume.NewParser(resume.TagSkills(tags)...)
+}
+
+func (s *documentServiceImpl) ParseDocument(candidateID, docID int) (*domain.ResumeSuggestion, error) {
+    doc, err := s.GetDocument(candidateID, docID)
+    if err != nil {
+        return nil, err
+    }
+    text, err := s.docs.GetText(doc.ID)
+    if err != nil {
+        return nil, err
+    }
+    if strings.TrimSpace(text) == "" {
+        return nil, ErrNoResumeText
+    }
+    suggestion := s.parser().Parse(text)
+    suggestion.DocumentID = doc.ID
+    return &suggestion, nil
+}
+
+func (s *documentServiceImpl) ParseResume(content io.ReaderAt, size int64) (*domain.ResumeSuggestion, error) {
+    if size <= 0 {
+        return nil, fmt.Errorf("%w: the file is empty", ErrInvalidDocument)
+    }
+    if s.settings.MaxBytes > 0 && size > s.settings.MaxBytes {
+        return nil, fmt.Errorf("%w of %d bytes", ErrDocumentTooLarge, s.settings.MaxBytes)
+    }
+    _, kind, err := SniffDocument(content, size)
+    if err != nil {
+        return nil, err
+    }
+    if kind == domain.DocumentImage {
+        return nil, ErrNoResumeText
+    }
+    text, err := resume.Extract(content, size, kind)
+    if err != nil || strings.TrimSpace(text) == "" {
+        return nil, ErrNoResumeText
+    }
+    suggestion := s.parser().Parse(text)
+    return &suggestion, nil
+}
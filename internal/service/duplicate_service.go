@@ -0,0 +1,69 @@
+package service
+
+import (
+    "context"
+    "sort"
+
+    "github.com/torvictorvic/seek-v2/internal/dedupe"
+    "github.com/torvictorvic/seek-v2/internal/domain"
+    "github.com/torvictorvic/seek-v2/internal/repository"
+)
+
+type DuplicateService interface {
+    FindDuplicates(id int, threshold float64, limit int) ([]domain.DuplicateCandidate, error)
+}
+
+type duplicateServiceImpl struct {
+    repo repository.CandidateRepository
+}
+
+func NewDuplicateService(repo repository.CandidateRepository) DuplicateService {
+    return &duplicateServiceImpl{repo: repo}
+}
+
+// DuplicatesForUser returns the service for a request of the user, reading the candidates
+// like ForUser does. Services built elsewhere (test doubles) are returned as is.
+func DuplicatesForUser(svc DuplicateService, user string) DuplicateService {
+    s, ok := svc.(*duplicateServiceImpl)
+    if !ok {
+        return svc
+    }
+    return &duplicateServiceImpl{repo: repository.Bind(repository.ReadAs(context.Background(), user), s.repo)}
+}
+
+// FindDuplicates scores the candidate against every other one and returns the ones
+// above the threshold, best first. Candidates are streamed, never loaded all at once.
+func (s *duplicateServiceImpl) FindDuplicates(id int, threshold float64, limit int) ([]domain.DuplicateCandidate, error) {
+    candidate, err := s.repo.GetByID(id)
+    if err != nil {
+        return nil, err
+    }
+    if candidate == nil {
+        return nil, ErrCandidateNotFound
+    }
+    if threshold <= 0 {
+        threshold = dedupe.DefaultThreshold
+    }
+
+    duplicates := []domain.DuplicateCandidate{}
+    err = s.repo.Stream(domain.CandidateFilter{}, func(other domain.Candidate) error {
+        if other.ID == candidate.ID {
+            return nil
+        }
+        if score, reasons := dedupe.Score(*candidate, other); score >= threshold {
+            duplicates = append(duplicates, domain.DuplicateCandidate{Candidate: other, Score: score, Reasons: reasons})
+        }
+        return nil
+    })
+    if err != nil {
+        return nil, err
+    }
+
+    sort.SliceStable(duplicates, func(i, j int) bool {
+        return duplicates[i].Score > duplicates[j].Score
+    })
+    if limit > 0 && len(duplicates) > limit {
+        duplicates = duplicates[:limit]
+    }
+    return duplicates, nil
+}
@@ -0,0 +1,299 @@
+package service
+
+import (
+    "errors"
+    "fmt"
+    "math"
+    "strings"
+
+    "github.com/torvictorvic/seek-v2/internal/domain"
+    "github.com/torvictorvic/seek-v2/internal/repository"
+)
+
+type FeedbackService interface {
+    CreateTemplate(t domain.ScorecardTemplate) (*domain.ScorecardTemplate, error)
+    GetTemplate(id int) (*domain.ScorecardTemplate, error)
+    ListTemplates() ([]domain.ScorecardTemplate, error)
+    SubmitFeedback(candidateID int, fb domain.Feedback, interviewer string) (*domain.Feedback, error)
+    ListFeedback(candidateID int, viewer string) (*domain.FeedbackList, error)
+    Summary(candidateID int, viewer string) (*domain.ScorecardSummary, error)
+}
+
+var (
+    ErrTemplateNotFound    = errors.New("Scorecard template not found")
+    ErrInvalidTemplate     = errors.New("Invalid scorecard template")
+    ErrInvalidFeedback     = errors.New("Invalid feedback")
+    ErrFeedbackExists      = errors.New("The interviewer already submitted feedback for this candidate")
+    ErrIdentityRequired    = errors.New("The token does not identify a user")
+    ErrNotOnInterviewPanel = errors.New("The user is not an interviewer of this interview")
+)
+
+type feedbackServiceImpl struct {
+    feedback   repository.FeedbackRepository
+    templates  repository.ScorecardRepository
+    candidates repository.CandidateRepository
+    interviews repository.InterviewRepository
+}
+
+func NewFeedbackService(feedback repository.FeedbackRepository, templates repository.ScorecardRepository,
+    candidates repository.CandidateRepository, interviews repository.InterviewRepository) FeedbackService {
+    return &feedbackServiceImpl{feedback: feedback, templates: templates, candidates: candidates, interviews: interviews}
+}
+
+// validateTemplate defaults the scale to 1-5 and the weights to 1
+func validateTemplate(t *domain.ScorecardTemplate) error {
+    if strings.TrimSpace(t.Name) == "" {
+        return fmt.Errorf("%w: the field 'name' is required", ErrInvalidTemplate)
+    }
+    if t.ScaleMin == 0 && t.ScaleMax == 0 {
+        t.ScaleMin, t.ScaleMax = 1, 5
+    }
+    if t.ScaleMin >= t.ScaleMax {
+        return fmt.Errorf("%w: 'scale_min' must be lower than 'scale_max'", ErrInvalidTemplate)
+    }
+    if len(t.Competencies) == 0 {
+        return fmt.Errorf("%w: at least one competency is required", ErrInvalidTemplate)
+    }
+    for i := range t.Competencies {
+        c := &t.Competencies[i]
+        if strings.TrimSpace(c.Name) == "" {
+            return fmt.Errorf("%w: every competency needs a name", ErrInvalidTemplate)
+        }
+        if c.Weight == 0 {
+            c.Weight = 1
+        }
+        if c.Weight < 0 {
+            return fmt.Errorf("%w: the weight of '%s' cannot be negative", ErrInvalidTemplate, c.Name)
+        }
+    }
+    return nil
+}
+
+func (s *feedbackServiceImpl) CreateTemplate(t domain.ScorecardTemplate) (*domain.ScorecardTemplate, error) {
+    if err := validateTemplate(&t); err != nil {
+        return nil, err
+    }
+    id, err := s.templates.CreateTemplate(t)
+    if err != nil {
+        return nil, err
+    }
+    return s.GetTemplate(id)
+}
+
+func (s *feedbackServiceImpl) GetTemplate(id int) (*domain.ScorecardTemplate, error) {
+    t, err := s.templates.GetTemplate(id)
+    if err != nil {
+        return nil, err
+    }
+    if t == nil {
+        return nil, ErrTemplateNotFound
+    }
+    return t, nil
+}
+
+func (s *feedbackServiceImpl) ListTemplates() ([]domain.ScorecardTemplate, error) {
+    return s.templates.ListTemplates()
+}
+
+// normalize converts a rating to a percentage of the template scale
+func normalize(t domain.ScorecardTemplate, score int) float64 {
+    return float64(score-t.ScaleMin) / float64(t.ScaleMax-t.ScaleMin) * 100
+}
+
+func round2(v float64) float64 {
+    return math.Round(v*100) / 100
+}
+
+// SubmitFeedback saves the evaluation of the interviewer. Every competency of the template
+// must be rated exactly once, and the feedback cannot be changed afterwards.
+func (s *feedbackServiceImpl) SubmitFeedback(candidateID int, fb domain.Feedback, interviewer string) (*domain.Feedback, error) {
+    if interviewer == "" {
+        return nil, ErrIdentityRequired
+    }
+    candidate, err := s.candidates.GetByID(candidateID)
+    if err != nil {
+        return nil, err
+    }
+    if candidate == nil {
+        return nil, ErrCandidateNotFound
+    }
+    t, err := s.GetTemplate(fb.TemplateID)
+    if err != nil {
+        return nil, err
+    }
+    if fb.InterviewID != nil {
+        iv, err := s.interviews.GetByID(*fb.InterviewID)
+        if err != nil {
+            return nil, err
+        }
+        if iv == nil || iv.CandidateID != candidateID {
+            return nil, fmt.Errorf("%w: the interview does not belong to the candidate", ErrInvalidFeedback)
+        }
+        if !containsString(iv.Interviewers, interviewer) {
+            return nil, ErrNotOnInterviewPanel
+        }
+    }
+
+    switch fb.Recommendation {
+    case domain.RecommendStrongNo, domain.RecommendNo, domain.RecommendYes, domain.RecommendStrongYes:
+    default:
+        return nil, fmt.Errorf("%w: the recommendation must be 'strong_no', 'no', 'yes' or 'strong_yes'", ErrInvalidFeedback)
+    }
+
+    ratings := map[int]domain.Rating{}
+    for _, r := range fb.Ratings {
+        if _, dup := ratings[r.CompetencyID]; dup {
+            return nil, fmt.Errorf("%w: competency %d is rated twice", ErrInvalidFeedback, r.CompetencyID)
+        }
+        if r.Score < t.ScaleMin || r.Score > t.ScaleMax {
+            return nil, fmt.Errorf("%w: the scores must be between %d and %d", ErrInvalidFeedback, t.ScaleMin, t.ScaleMax)
+        }
+        ratings[r.CompetencyID] = r
+    }
+    var weighted, weights float64
+    for _, c := range t.Competencies {
+        r, ok := ratings[c.ID]
+        if !ok {
+            return nil, fmt.Errorf("%w: the competency '%s' is not rated", ErrInvalidFeedback, c.Name)
+        }
+        delete(ratings, c.ID)
+        weighted += c.Weight * normalize(*t, r.Score)
+        weights += c.Weight
+    }
+    if len(ratings) > 0 {
+        return nil, fmt.Errorf("%w: the ratings include competencies outside the template", ErrInvalidFeedback)
+    }
+
+    submitted, err := s.feedback.HasSubmitted(candidateID, interviewer)
+    if err != nil {
+        return nil, err
+    }
+    if submitted {
+        return nil, ErrFeedbackExists
+    }
+
+    fb.ID = 0
+    fb.CandidateID = candidateID
+    fb.Interviewer = interviewer
+    if weights > 0 {
+        fb.Score = round2(weighted / weights)
+    }
+    id, err := s.feedback.Create(fb)
+    if err != nil {
+        return nil, err
+    }
+    fb.ID = id
+    return &fb, nil
+}
+
+// canSee reports whether the viewer may see the feedback of others: interviewers of the
+// candidate only once they have submitted their own, everybody else always
+func (s *feedbackServiceImpl) canSee(candidateID int, viewer string) (submitted, visible bool, err error) {
+    if viewer != "" {
+        if submitted, err = s.feedback.HasSubmitted(candidateID, viewer); err != nil {
+            return false, false, err
+        }
+    }
+    if submitted {
+        return true, true, nil
+    }
+    interviews, err := s.interviews.ListByCandidate(candidateID)
+    if err != nil {
+        return false, false, err
+    }
+    for _, iv := range interviews {
+        if containsString(iv.Interviewers, viewer) {
+            return false, false, nil
+        }
+    }
+    return false, true, nil
+}
+
+// ListFeedback returns the feedback of the candidate, hiding the others' evaluations
+// from an interviewer who has not submitted yet
+func (s *feedbackServiceImpl) ListFeedback(candidateID int, viewer string) (*domain.FeedbackList, error) {
+    submitted, visible, err := s.canSee(candidateID, viewer)
+    if err != nil {
+        return nil, err
+    }
+    all, err := s.feedback.ListByCandidate(candidateID)
+    if err != nil {
+        return nil, err
+    }
+    if visible {
+        return &domain.FeedbackList{Submitted: submitted, Feedback: all}, nil
+    }
+    return &domain.FeedbackList{Hidden: len(all), Feedback: []domain.Feedback{}}, nil
+}
+
+// Summary aggregates the feedback of the candidate. It returns nil when there is no
+// feedback or the viewer cannot see it yet.
+func (s *feedbackServiceImpl) Summary(candidateID int, viewer string) (*domain.ScorecardSummary, error) {
+    _, visible, err := s.canSee(candidateID, viewer)
+    if err != nil || !visible {
+        return nil, err
+    }
+    all, err := s.feedback.ListByCandidate(candidateID)
+    if err != nil || len(all) == 0 {
+        return nil, err
+    }
+
+    templates := map[int]*domain.ScorecardTemplate{}
+    summary := &domain.ScorecardSummary{Count: len(all), Recommendations: map[string]int{}}
+    type acc struct {
+        total float64
+        count int
+    }
+    byName := map[string]*acc{}
+    var order []string
+    var total float64
+    for _, fb := range all {
+        total += fb.Score
+        summary.Recommendations[fb.Recommendation]++
+
+        t, ok := templates[fb.TemplateID]
+        if !ok {
+            if t, err = s.templates.GetTemplate(fb.TemplateID); err != nil {
+                return nil, err
+            }
+            templates[fb.TemplateID] = t
+        }
+        if t == nil {
+            continue
+        }
+        names := map[int]string{}
+        for _, c := range t.Competencies {
+            names[c.ID] = c.Name
+        }
+        for _, r := range fb.Ratings {
+            name, ok := names[r.CompetencyID]
+            if !ok {
+                continue
+            }
+            a := byName[name]
+            if a == nil {
+                a = &acc{}
+                byName[name] = a
+                order = append(order, name)
+            }
+            a.total += normalize(*t, r.Score)
+            a.count++
+        }
+    }
+    summary.Score = round2(total / float64(len(all)))
+    summary.Competencies = []domain.CompetencyScore{}
+    for _, name := range order {
+        a := byName[name]
+        summary.Competencies = append(summary.Competencies, domain.CompetencyScore{Name: name, Score: round2(a.total / float64(a.count)), Count: a.count})
+    }
+    return summary, nil
+}
+
+func containsString(list []string, s string) bool {
+    for _, item := range list {
+        if item == s {
+            return true
+        }
+    }
+    return false
+}
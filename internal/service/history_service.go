@@ -0,0 +1,22 @@
+package service
+
+import (
+    "github.com/torvictorvic/seek-v2/internal/domain"
+    "github.com/torvictorvic/seek-v2/internal/repository"
+)
+
+type HistoryService interface {
+    GetCandidateHistory(candidateID int) ([]domain.CandidateHistoryEntry, error)
+}
+
+type historyServiceImpl struct {
+    repo repository.CandidateHistoryRepository
+}
+
+func NewHistoryService(repo repository.CandidateHistoryRepository) HistoryService {
+    return &historyServiceImpl{repo: repo}
+}
+
+func (s *historyServiceImpl) GetCandidateHistory(candidateID int) ([]domain.CandidateHistoryEntry, error) {
+    return s.repo.ListByCandidate(candidateID)
+}
@@ -0,0 +1,310 @@
+package service
+
+import (
+    "crypto/rand"
+    "crypto/sha256"
+    "encoding/base64"
+    "encoding/hex"
+    "errors"
+    "fmt"
+    "strings"
+    "time"
+    _ "time/tzdata" // time zones are validated even where the system has no zoneinfo
+
+    "github.com/torvictorvic/seek-v2/internal/domain"
+    "github.com/torvictorvic/seek-v2/internal/ical"
+    "github.com/torvictorvic/seek-v2/internal/repository"
+)
+
+type InterviewService interface {
+    ScheduleInterview(iv domain.Interview, actor string) (*domain.Interview, error)
+    GetInterview(id int) (*domain.Interview, error)
+    ListByCandidate(candidateID int) ([]domain.Interview, error)
+    RescheduleInterview(iv domain.Interview) (*domain.Interview, error)
+    CancelInterview(id int) error
+    Invite(id int) (*ical.Calendar, error)
+    IssueFeedToken(user string) (string, error)
+    RevokeFeedToken(user string) error
+    Feed(token string) (*ical.Calendar, error)
+}
+
+var (
+    ErrInterviewNotFound = errors.New("Interview not found")
+    ErrInvalidInterview  = errors.New("Invalid interview")
+    ErrInterviewConflict = errors.New("The interview overlaps another interview")
+    ErrInvalidFeedToken  = errors.New("Invalid calendar feed token")
+)
+
+// InterviewConflictError lists the interviews that overlap the one being scheduled
+type InterviewConflictError struct {
+    Conflicts []domain.InterviewConflict
+}
+
+func (e *InterviewConflictError) Error() string {
+    return fmt.Sprintf("%s (%d conflicts)", ErrInterviewConflict.Error(), len(e.Conflicts))
+}
+
+func (e *InterviewConflictError) Unwrap() error {
+    return ErrInterviewConflict
+}
+
+// FeedPastDays is how far back the personal feed goes
+const FeedPastDays = 30
+
+type interviewServiceImpl struct {
+    interviews repository.InterviewRepository
+    feeds      repository.CalendarFeedRepository
+    candidates repository.CandidateRepository
+    apps       repository.ApplicationRepository
+    now        func() time.Time
+}
+
+func NewInterviewService(interviews repository.InterviewRepository, feeds repository.CalendarFeedRepository,
+    candidates repository.CandidateRepository, apps repository.ApplicationRepository) InterviewService {
+    return &interviewServiceImpl{interviews: interviews, feeds: feeds, candidates: candidates, apps: apps, now: time.Now}
+}
+
+// validateInterview normalizes the interviewers and checks times, type and place
+func validateInterview(iv *domain.Interview) error {
+    seen := map[string]bool{}
+    interviewers := []string{}
+    for _, name := range iv.Interviewers {
+        if name = strings.TrimSpace(name); name != "" && !seen[name] {
+            seen[name] = true
+            interviewers = append(interviewers, name)
+        }
+    }
+    if len(interviewers) == 0 {
+        return fmt.Errorf("%w: at least one interviewer is required", ErrInvalidInterview)
+    }
+    iv.Interviewers = interviewers
+
+    if iv.StartsAt.IsZero() || !iv.EndsAt.After(iv.StartsAt) {
+        return fmt.Errorf("%w: 'ends_at' must be after 'starts_at'", ErrInvalidInterview)
+    }
+    if iv.TimeZone == "" {
+        iv.TimeZone = "UTC"
+    }
+    if _, err := time.LoadLocation(iv.TimeZone); err != nil {
+        return fmt.Errorf("%w: unknown time zone '%s'", ErrInvalidInterview, iv.TimeZone)
+    }
+
+    switch iv.Type {
+    case domain.InterviewPhone, domain.InterviewTechnical:
+    case domain.InterviewVideo:
+        if iv.VideoLink == "" {
+            return fmt.Errorf("%w: a video interview needs 'video_link'", ErrInvalidInterview)
+        }
+    case domain.InterviewOnsite:
+        if iv.Location == "" {
+            return fmt.Errorf("%w: an onsite interview needs 'location'", ErrInvalidInterview)
+        }
+    default:
+        return fmt.Errorf("%w: the type must be 'phone', 'video', 'onsite' or 'technical'", ErrInvalidInterview)
+    }
+    return nil
+}
+
+// check validates the interview, its candidate and application, and looks for overlaps
+func (s *interviewServiceImpl) check(iv *domain.Interview) error {
+    if err := validateInterview(iv); err != nil {
+        return err
+    }
+    candidate, err := s.candidates.GetByID(iv.CandidateID)
+    if err != nil {
+        return err
+    }
+    if candidate == nil {
+        return ErrCandidateNotFound
+    }
+    if iv.ApplicationID != nil {
+        app, err := s.apps.GetByID(*iv.ApplicationID)
+        if err != nil {
+            return err
+        }
+        if app == nil || app.CandidateID != iv.CandidateID {
+            return fmt.Errorf("%w: the application does not belong to the candidate", ErrInvalidInterview)
+        }
+    }
+
+    overlapping, err := s.interviews.FindOverlapping(*iv)
+    if err != nil {
+        return err
+    }
+    var conflicts []domain.InterviewConflict
+    for _, other := range overlapping {
+        if other.ID == iv.ID || !other.Overlaps(*iv) {
+            continue
+        }
+        if other.CandidateID == iv.CandidateID {
+            conflicts = append(conflicts, domain.InterviewConflict{InterviewID: other.ID, Reason: "candidate"})
+        }
+        for _, name := range other.Interviewers {
+            for _, mine := range iv.Interviewers {
+                if name == mine {
+                    conflicts = append(conflicts, domain.InterviewConflict{InterviewID: other.ID, Reason: "interviewer", Interviewer: name})
+                }
+            }
+        }
+    }
+    if len(conflicts) > 0 {
+        return &InterviewConflictError{Conflicts: conflicts}
+    }
+    return nil
+}
+
+// ScheduleInterview creates the interview if neither the candidate nor any interviewer is busy
+func (s *interviewServiceImpl) ScheduleInterview(iv domain.Interview, actor string) (*domain.Interview, error) {
+    iv.ID = 0
+    iv.CreatedBy = actor
+    if err := s.check(&iv); err != nil {
+        return nil, err
+    }
+    id, err := s.interviews.Create(iv)
+    if err != nil {
+        return nil, err
+    }
+    return s.GetInterview(id)
+}
+
+func (s *interviewServiceImpl) GetInterview(id int) (*domain.Interview, error) {
+    iv, err := s.interviews.GetByID(id)
+    if err != nil {
+        return nil, err
+    }
+    if iv == nil {
+        return nil, ErrInterviewNotFound
+    }
+    return iv, nil
+}
+
+func (s *interviewServiceImpl) ListByCandidate(candidateID int) ([]domain.Interview, error) {
+    return s.interviews.ListByCandidate(candidateID)
+}
+
+// RescheduleInterview changes time, place or interviewers. The candidate cannot change.
+func (s *interviewServiceImpl) RescheduleInterview(iv domain.Interview) (*domain.Interview, error) {
+    current, err := s.GetInterview(iv.ID)
+    if err != nil {
+        return nil, err
+    }
+    iv.CandidateID = current.CandidateID
+    if err := s.check(&iv); err != nil {
+        return nil, err
+    }
+    if err := s.interviews.Update(iv); err != nil {
+        return nil, err
+    }
+    return s.GetInterview(iv.ID)
+}
+
+func (s *interviewServiceImpl) CancelInterview(id int) error {
+    if _, err := s.GetInterview(id); err != nil {
+        return err
+    }
+    return s.interviews.Delete(id)
+}
+
+// Invite returns the iCalendar invite of the interview
+func (s *interviewServiceImpl) Invite(id int) (*ical.Calendar, error) {
+    iv, err := s.GetInterview(id)
+    if err != nil {
+        return nil, err
+    }
+    candidate, err := s.candidates.GetByID(iv.CandidateID)
+    if err != nil {
+        return nil, err
+    }
+    return &ical.Calendar{
+        Method: ical.MethodRequest,
+        Events: []ical.Event{s.event(*iv, candidate)},
+    }, nil
+}
+
+func hashFeedToken(token string) string {
+    sum := sha256.Sum256([]byte(token))
+    return hex.EncodeToString(sum[:])
+}
+
+// IssueFeedToken creates a new feed token for the user. The previous one stops working.
+func (s *interviewServiceImpl) IssueFeedToken(user string) (string, error) {
+    if user == "" {
+        return "", ErrInvalidFeedToken
+    }
+    b := make([]byte, 32)
+    if _, err := rand.Read(b); err != nil {
+        return "", err
+    }
+    token := base64.RawURLEncoding.EncodeToString(b)
+    if err := s.feeds.Save(user, hashFeedToken(token)); err != nil {
+        return "", err
+    }
+    return token, nil
+}
+
+func (s *interviewServiceImpl) RevokeFeedToken(user string) error {
+    return s.feeds.Delete(user)
+}
+
+// Feed returns the calendar with the recent and upcoming interviews of the token owner
+func (s *interviewServiceImpl) Feed(token string) (*ical.Calendar, error) {
+    if token == "" {
+        return nil, ErrInvalidFeedToken
+    }
+    user, err := s.feeds.GetUser(hashFeedToken(token))
+    if err != nil {
+        return nil, err
+    }
+    if user == "" {
+        return nil, ErrInvalidFeedToken
+    }
+
+    interviews, err := s.interviews.ListByInterviewer(user, s.now().AddDate(0, 0, -FeedPastDays))
+    if err != nil {
+        return nil, err
+    }
+    cal := &ical.Calendar{Name: "Interviews - " + user, Method: ical.MethodPublish}
+    candidates := map[int]*domain.Candidate{}
+    for _, iv := range interviews {
+        candidate, ok := candidates[iv.CandidateID]
+        if !ok {
+            if candidate, err = s.candidates.GetByID(iv.CandidateID); err != nil {
+                return nil, err
+            }
+            candidates[iv.CandidateID] = candidate
+        }
+        cal.Events = append(cal.Events, s.event(iv, candidate))
+    }
+    return cal, nil
+}
+
+func (s *interviewServiceImpl) event(iv domain.Interview, candidate *domain.Candidate) ical.Event {
+    name := fmt.Sprintf("candidate #%d", iv.CandidateID)
+    var attendees []ical.Attendee
+    if candidate != nil {
+        name = candidate.Name
+        attendees = append(attendees, ical.Attendee{Name: candidate.Name, Email: candidate.Email})
+    }
+    for _, interviewer := range iv.Interviewers {
+        if strings.Contains(interviewer, "@") {
+            attendees = append(attendees, ical.Attendee{Email: interviewer})
+        }
+    }
+
+    description := "Interviewers: " + strings.Join(iv.Interviewers, ", ") + "\nTime zone: " + iv.TimeZone
+    if iv.VideoLink != "" {
+        description += "\nVideo: " + iv.VideoLink
+    }
+    return ical.Event{
+        UID:         fmt.Sprintf("interview-%d@seek-v2", iv.ID),
+        Sequence:    iv.Sequence,
+        Start:       iv.StartsAt,
+        End:         iv.EndsAt,
+        Stamp:       iv.UpdatedAt,
+        Summary:     fmt.Sprintf("Interview (%s): %s", iv.Type, name),
+        Description: description,
+        Location:    iv.Location,
+        URL:         iv.VideoLink,
+        Attendees:   attendees,
+    }
+}
@@ -0,0 +1,72 @@
+package service
+
+import (
+    "errors"
+    "fmt"
+
+    "github.com/torvictorvic/seek-v2/internal/domain"
+    "github.com/torvictorvic/seek-v2/internal/repository"
+)
+
+type JobService interface {
+    CreateJob(job domain.Job) (int, error)
+    GetJobByID(id int) (*domain.Job, error)
+    GetAllJobs(filter domain.JobFilter) ([]domain.Job, error)
+    UpdateJob(job domain.Job) error
+    DeleteJob(id int) error
+}
+
+// ErrInvalidJob is wrapped by the validation errors of the jobs
+var ErrInvalidJob = errors.New("Invalid job")
+
+type jobServiceImpl struct {
+    repo repository.JobRepository
+}
+
+func NewJobService(repo repository.JobRepository) JobService {
+    return &jobServiceImpl{repo: repo}
+}
+
+// validateJob checks the required fields and defaults the status to open
+func validateJob(job *domain.Job) error {
+    if job.Title == "" {
+        return fmt.Errorf("%w: the field 'title' is required", ErrInvalidJob)
+    }
+    switch job.Status {
+    case "":
+        job.Status = domain.JobOpen
+    case domain.JobOpen, domain.JobOnHold, domain.JobClosed:
+    default:
+        return fmt.Errorf("%w: the status must be 'open', 'on_hold' or 'closed'", ErrInvalidJob)
+    }
+    if job.SalaryMin < 0 || job.SalaryMax < 0 || (job.SalaryMax > 0 && job.SalaryMin > job.SalaryMax) {
+        return fmt.Errorf("%w: the salary band is not valid", ErrInvalidJob)
+    }
+    return nil
+}
+
+func (s *jobServiceImpl) CreateJob(job domain.Job) (int, error) {
+    if err := validateJob(&job); err != nil {
+        return 0, err
+    }
+    return s.repo.Create(job)
+}
+
+func (s *jobServiceImpl) GetJobByID(id int) (*domain.Job, error) {
+    return s.repo.GetByID(id)
+}
+
+func (s *jobServiceImpl) GetAllJobs(filter domain.JobFilter) ([]domain.Job, error) {
+    return s.repo.GetAll(filter)
+}
+
+func (s *jobServiceImpl) UpdateJob(job domain.Job) error {
+    if err := validateJob(&job); err != nil {
+        return err
+    }
+    return s.repo.Update(job)
+}
+
+func (s *jobServiceImpl) DeleteJob(id int) error {
+    return s.repo.Delete(id)
+}
@@ -0,0 +1,185 @@
+package service
+
+import (
+    "errors"
+    "fmt"
+    "strings"
+
+    "github.com/torvictorvic/seek-v2/internal/domain"
+    "github.com/torvictorvic/seek-v2/internal/repository"
+)
+
+type NoteService interface {
+    AddNote(candidateID int, req domain.NoteRequest, author string) (*domain.Note, error)
+    ListNotes(candidateID int, viewer string) ([]domain.Note, error)
+    GetNote(candidateID, noteID int, viewer string) (*domain.Note, error)
+    EditNote(candidateID, noteID int, req domain.NoteRequest, editor string) (*domain.Note, error)
+    PinNote(candidateID, noteID int, pinned bool, user string) (*domain.Note, error)
+    DeleteNote(candidateID, noteID int, user string) error
+    ListRevisions(candidateID, noteID int, viewer string) ([]domain.NoteRevision, error)
+}
+
+var (
+    ErrNoteNotFound  = errors.New("Note not found")
+    ErrInvalidNote   = errors.New("Invalid note")
+    ErrNotNoteAuthor = errors.New("Only the author can change the note")
+)
+
+// MaxNoteLength is the maximum size of a note body in bytes
+const MaxNoteLength = 10000
+
+type noteServiceImpl struct {
+    notes      repository.NoteRepository
+    candidates repository.CandidateRepository
+}
+
+func NewNoteService(notes repository.NoteRepository, candidates repository.CandidateRepository) NoteService {
+    return &noteServiceImpl{notes: notes, candidates: candidates}
+}
+
+func validateNote(req *domain.NoteRequest) error {
+    if strings.TrimSpace(req.Body) == "" {
+        return fmt.Errorf("%w: the field 'body' is required", ErrInvalidNote)
+    }
+    if len(req.Body) > MaxNoteLength {
+        return fmt.Errorf("%w: the body exceeds %d bytes", ErrInvalidNote, MaxNoteLength)
+    }
+    switch req.Visibility {
+    case "":
+        req.Visibility = domain.NoteTeam
+    case domain.NoteTeam, domain.NotePrivate:
+    default:
+        return fmt.Errorf("%w: the visibility must be 'private' or 'team'", ErrInvalidNote)
+    }
+    return nil
+}
+
+// mentionNotifications notifies the mentioned users, except the actor and the ones
+// already notified. Private notes notify nobody since nobody else can read them.
+func mentionNotifications(note domain.Note, actor string, alreadyNotified []string) []domain.Notification {
+    if note.Visibility == domain.NotePrivate {
+        return nil
+    }
+    var notifications []domain.Notification
+    for _, user := range note.Mentions {
+        if user == actor || containsString(alreadyNotified, user) {
+            continue
+        }
+        notifications = append(notifications, domain.Notification{
+            User:        user,
+            Type:        domain.NotificationMention,
+            CandidateID: note.CandidateID,
+            Actor:       actor,
+            Message:     fmt.Sprintf("%s mentioned you in a note on candidate #%d", actor, note.CandidateID),
+        })
+    }
+    return notifications
+}
+
+func (s *noteServiceImpl) AddNote(candidateID int, req domain.NoteRequest, author string) (*domain.Note, error) {
+    if author == "" {
+        return nil, ErrIdentityRequired
+    }
+    if err := validateNote(&req); err != nil {
+        return nil, err
+    }
+    candidate, err := s.candidates.GetByID(candidateID)
+    if err != nil {
+        return nil, err
+    }
+    if candidate == nil {
+        return nil, ErrCandidateNotFound
+    }
+
+    note := domain.Note{
+        CandidateID: candidateID,
+        Author:      author,
+        Body:        req.Body,
+        Visibility:  req.Visibility,
+        Mentions:    domain.ParseMentions(req.Body),
+    }
+    id, err := s.notes.Create(note, mentionNotifications(note, author, nil))
+    if err != nil {
+        return nil, err
+    }
+    return s.notes.GetByID(id)
+}
+
+func (s *noteServiceImpl) ListNotes(candidateID int, viewer string) ([]domain.Note, error) {
+    return s.notes.ListByCandidate(candidateID, viewer)
+}
+
+// GetNote returns the note if it belongs to the candidate and the viewer can see it.
+// A private note of another user is reported as not found.
+func (s *noteServiceImpl) GetNote(candidateID, noteID int, viewer string) (*domain.Note, error) {
+    note, err := s.notes.GetByID(noteID)
+    if err != nil {
+        return nil, err
+    }
+    if note == nil || note.CandidateID != candidateID || (note.Visibility == domain.NotePrivate && note.Author != viewer) {
+        return nil, ErrNoteNotFound
+    }
+    return note, nil
+}
+
+// EditNote replaces body and visibility, keeping the previous version. Only users
+// mentioned for the first time are notified.
+func (s *noteServiceImpl) EditNote(candidateID, noteID int, req domain.NoteRequest, editor string) (*domain.Note, error) {
+    if err := validateNote(&req); err != nil {
+        return nil, err
+    }
+    note, err := s.GetNote(candidateID, noteID, editor)
+    if err != nil {
+        return nil, err
+    }
+    if note.Author != editor {
+        return nil, ErrNotNoteAuthor
+    }
+
+    previous := domain.NoteRevision{NoteID: note.ID, Body: note.Body, Visibility: note.Visibility, Editor: editor}
+    // A private note that becomes visible notifies all its mentions
+    var notified []string
+    if note.Visibility == domain.NoteTeam {
+        notified = note.Mentions
+    }
+    note.Body = req.Body
+    note.Visibility = req.Visibility
+    note.Mentions = domain.ParseMentions(req.Body)
+    if err := s.notes.Update(*note, previous, mentionNotifications(*note, editor, notified)); err != nil {
+        return nil, err
+    }
+    return s.notes.GetByID(note.ID)
+}
+
+func (s *noteServiceImpl) PinNote(candidateID, noteID int, pinned bool, user string) (*domain.Note, error) {
+    note, err := s.GetNote(candidateID, noteID, user)
+    if err != nil {
+        return nil, err
+    }
+    if note.Pinned == pinned {
+        return note, nil
+    }
+    note.Pinned = pinned
+    if err := s.notes.SetPinned(*note, user); err != nil {
+        return nil, err
+    }
+    return note, nil
+}
+
+func (s *noteServiceImpl) DeleteNote(candidateID, noteID int, user string) error {
+    note, err := s.GetNote(candidateID, noteID, user)
+    if err != nil {
+        return err
+    }
+    if note.Author != user {
+        return ErrNotNoteAuthor
+    }
+    return s.notes.Delete(*note, user)
+}
+
+func (s *noteServiceImpl) ListRevisions(candidateID, noteID int, viewer string) ([]domain.NoteRevision, error) {
+    if _, err := s.GetNote(candidateID, noteID, viewer); err != nil {
+        return nil, err
+    }
+    return s.notes.ListRevisions(noteID)
+}
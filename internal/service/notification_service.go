@@ -0,0 +1,42 @@
+package service
+
+import (
+    "errors"
+
+    "github.com/torvictorvic/seek-v2/internal/domain"
+    "github.com/torvictorvic/seek-v2/internal/repository"
+)
+
+type NotificationService interface {
+    ListNotifications(user string, unreadOnly bool) ([]domain.Notification, error)
+    MarkRead(id int, user string) error
+}
+
+var ErrNotificationNotFound = errors.New("Notification not found")
+
+type notificationServiceImpl struct {
+    repo repository.NotificationRepository
+}
+
+func NewNotificationService(repo repository.NotificationRepository) NotificationService {
+    return &notificationServiceImpl{repo: repo}
+}
+
+func (s *notificationServiceImpl) ListNotifications(user string, unreadOnly bool) ([]domain.Notification, error) {
+    if user == "" {
+        return nil, ErrIdentityRequired
+    }
+    return s.repo.ListByUser(user, unreadOnly)
+}
+
+// MarkRead marks a notification of the user as read. Other users' notifications are not found.
+func (s *notificationServiceImpl) MarkRead(id int, user string) error {
+    found, err := s.repo.MarkRead(id, user)
+    if err != nil {
+        return err
+    }
+    if !found {
+        return ErrNotificationNotFound
+    }
+    return nil
+}
@@ -0,0 +1,85 @@
+package storage
+
+import (
+    "context"
+    "errors"
+    "io"
+    "os"
+    "path/filepath"
+)
+
+// Local keeps the objects as files under a root directory
+type Local struct {
+    root string
+}
+
+// NewLocal creates the root directory if needed
+func NewLocal(root string) (*Local, error) {
+    if err := os.MkdirAll(root, 0o750); err != nil {
+        return nil, err
+    }
+    return &Local{root: root}, nil
+}
+
+func (l *Local) path(key string) (string, error) {
+    if err := CheckKey(key); err != nil {
+        return "", err
+    }
+    return filepath.Join(l.root, filepath.FromSlash(key)), nil
+}
+
+// Put writes to a temporary file and renames it, so readers never see a partial object
+func (l *Local) Put(ctx context.Context, key string, content io.Reader, size int64, contentType string) error {
+    path, err := l.path(key)
+    if err != nil {
+        return err
+    }
+    if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+        return err
+    }
+    tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+    if err != nil {
+        return err
+    }
+    defer os.Remove(tmp.Name())
+
+    if _, err := io.Copy(tmp, content); err != nil {
+        tmp.Close()
+        return err
+    }
+    if err := tmp.Close(); err != nil {
+        return err
+    }
+    return os.Rename(tmp.Name(), path)
+}
+
+func (l *Local) Get(ctx context.Context, key string, offset int64) (io.ReadCloser, error) {
+    path, err := l.path(key)
+    if err != nil {
+        return nil, err
+    }
+    f, err := os.Open(path)
+    if errors.Is(err, os.ErrNotExist) {
+        return nil, ErrNotFound
+    } else if err != nil {
+        return nil, err
+    }
+    if offset > 0 {
+        if _, err := f.Seek(offset, io.SeekStart); err != nil {
+            f.Close()
+            return nil, err
+        }
+    }
+    return f, nil
+}
+
+func (l *Local) Delete(ctx context.Context, key string) error {
+    path, err := l.path(key)
+    if err != nil {
+        return err
+    }
+    if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+        return err
+    }
+    return nil
+}
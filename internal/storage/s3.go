@@ -0,0 +1,244 @@
+package storage
+
+import (
+    "context"
+    "crypto/hmac"
+    "crypto/sha256"
+    "encoding/hex"
+    "fmt"
+    "io"
+    "net/http"
+    "net/url"
+    "sort"
+    "strconv"
+    "strings"
+    "time"
+)
+
+// S3Config locates an S3-compatible bucket (AWS S3, MinIO, Ceph, R2...)
+type S3Config struct {
+    Endpoint  string // e.g. https://s3.us-east-1.amazonaws.com or http://localhost:9000
+    Bucket    string
+    Region    string
+    AccessKey string
+    SecretKey string
+    // PathStyle puts the bucket in the path (endpoint/bucket/key) instead of the host,
+    // as most self-hosted implementations expect
+    PathStyle bool
+}
+
+// S3 keeps the objects in a bucket, talking to the REST API with Signature Version 4
+type S3 struct {
+    cfg      S3Config
+    endpoint *url.URL
+    client   *http.Client
+}
+
+func NewS3(cfg S3Config, client *http.Client) (*S3, error) {
+    endpoint, err := url.Parse(cfg.Endpoint)
+    if err != nil || endpoint.Host == "" {
+        return nil, fmt.Errorf("Invalid S3 endpoint '%s'", cfg.Endpoint)
+    }
+    if cfg.Bucket == "" {
+        return nil, fmt.Errorf("The S3 bucket is required")
+    }
+    if cfg.Region == "" {
+        cfg.Region = "us-east-1"
+    }
+    if client == nil {
+        client = &http.Client{Timeout: 5 * time.Minute}
+    }
+    return &S3{cfg: cfg, endpoint: endpoint, client: client}, nil
+}
+
+func (s *S3) objectURL(key string) string {
+    u := *s.endpoint
+    path := "/" + uriEncode(key, false)
+    if s.cfg.PathStyle {
+        path = "/" + uriEncode(s.cfg.Bucket, true) + path
+    } else {
+        u.Host = s.cfg.Bucket + "." + u.Host
+    }
+    u.Path = strings.TrimSuffix(u.Path, "/") + path
+    u.RawPath = u.Path
+    return u.String()
+}
+
+func (s *S3) do(ctx context.Context, method, key string, body io.Reader, header http.Header) (*http.Response, error) {
+    if err := CheckKey(key); err != nil {
+        return nil, err
+    }
+    req, err := http.NewRequestWithContext(ctx, method, s.objectURL(key), body)
+    if err != nil {
+        return nil, err
+    }
+    for name, values := range header {
+        req.Header[name] = values
+    }
+    // The payload is sent over TLS in production, so it is not hashed
+    SignV4(req, s.cfg.AccessKey, s.cfg.SecretKey, s.cfg.Region, "s3", "UNSIGNED-PAYLOAD", time.Now())
+    return s.client.Do(req)
+}
+
+func (s *S3) Put(ctx context.Context, key string, content io.Reader, size int64, contentType string) error {
+    header := http.Header{}
+    if contentType != "" {
+        header.Set("Content-Type", contentType)
+    }
+    resp, err := s.doPut(ctx, key, content, size, header)
+    if err != nil {
+        return err
+    }
+    defer resp.Body.Close()
+    if resp.StatusCode != http.StatusOK {
+        return s3Error("put", resp)
+    }
+    return nil
+}
+
+func (s *S3) doPut(ctx context.Context, key string, content io.Reader, size int64, header http.Header) (*http.Response, error) {
+    if err := CheckKey(key); err != nil {
+        return nil, err
+    }
+    req, err := http.NewRequestWithContext(ctx, http.MethodPut, s.objectURL(key), content)
+    if err != nil {
+        return nil, err
+    }
+    // S3 needs the length up front, chunked uploads are not accepted
+    req.ContentLength = size
+    for name, values := range header {
+        req.Header[name] = values
+    }
+    SignV4(req, s.cfg.AccessKey, s.cfg.SecretKey, s.cfg.Region, "s3", "UNSIGNED-PAYLOAD", time.Now())
+    return s.client.Do(req)
+}
+
+func (s *S3) Get(ctx context.Context, key string, offset int64) (io.ReadCloser, error) {
+    header := http.Header{}
+    if offset > 0 {
+        header.Set("Range", "bytes="+strconv.FormatInt(offset, 10)+"-")
+    }
+    resp, err := s.do(ctx, http.MethodGet, key, nil, header)
+    if err != nil {
+        return nil, err
+    }
+    switch resp.StatusCode {
+    case http.StatusOK, http.StatusPartialContent:
+        return resp.Body, nil
+    case http.StatusNotFound:
+        resp.Body.Close()
+        return nil, ErrNotFound
+    default:
+        defer resp.Body.Close()
+        return nil, s3Error("get", resp)
+    }
+}
+
+func (s *S3) Delete(ctx context.Context, key string) error {
+    resp, err := s.do(ctx, http.MethodDelete, key, nil, nil)
+    if err != nil {
+        return err
+    }
+    defer resp.Body.Close()
+    if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNotFound {
+        return s3Error("delete", resp)
+    }
+    return nil
+}
+
+func s3Error(op string, resp *http.Response) error {
+    body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+    return fmt.Errorf("S3 %s failed with status %d: %s", op, resp.StatusCode, strings.TrimSpace(string(body)))
+}
+
+// SignV4 adds the AWS Signature Version 4 headers to the request. It signs the host,
+// the content type, the range and every x-amz-* header.
+func SignV4(req *http.Request, accessKey, secretKey, region, service, payloadHash string, now time.Time) {
+    now = now.UTC()
+    amzDate := now.Format("20060102T150405Z")
+    date := now.Format("20060102")
+    req.Header.Set("X-Amz-Date", amzDate)
+    req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+    headers := map[string]string{"host": req.URL.Host}
+    for name, values := range req.Header {
+        lower := strings.ToLower(name)
+        if lower == "content-type" || lower == "range" || strings.HasPrefix(lower, "x-amz-") {
+            headers[lower] = strings.TrimSpace(strings.Join(values, ","))
+        }
+    }
+    names := make([]string, 0, len(headers))
+    for name := range headers {
+        names = append(names, name)
+    }
+    sort.Strings(names)
+    var canonicalHeaders strings.Builder
+    for _, name := range names {
+        canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+    }
+    signedHeaders := strings.Join(names, ";")
+
+    canonicalRequest := strings.Join([]string{
+        req.Method,
+        uriEncode(req.URL.EscapedPath(), false),
+        canonicalQuery(req.URL.Query()),
+        canonicalHeaders.String(),
+        signedHeaders,
+        payloadHash,
+    }, "\n")
+    scope := date + "/" + region + "/" + service + "/aws4_request"
+    hash := sha256.Sum256([]byte(canonicalRequest))
+    stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(hash[:])
+
+    key := hmacSHA256([]byte("AWS4"+secretKey), date)
+    for _, part := range []string{region, service, "aws4_request"} {
+        key = hmacSHA256(key, part)
+    }
+    signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+    req.Header.Set("Authorization", "AWS4-HMAC-SHA256 Credential="+accessKey+"/"+scope+
+        ", SignedHeaders="+signedHeaders+", Signature="+signature)
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+    mac := hmac.New(sha256.New, key)
+    mac.Write([]byte(data))
+    return mac.Sum(nil)
+}
+
+func canonicalQuery(values url.Values) string {
+    keys := make([]string, 0, len(values))
+    for k := range values {
+        keys = append(keys, k)
+    }
+    sort.Strings(keys)
+    var parts []string
+    for _, k := range keys {
+        vs := append([]string{}, values[k]...)
+        sort.Strings(vs)
+        for _, v := range vs {
+            parts = append(parts, uriEncode(k, true)+"="+uriEncode(v, true))
+        }
+    }
+    return strings.Join(parts, "&")
+}
+
+// uriEncode escapes everything but the unreserved characters of RFC 3986. Already
+// escaped sequences are decoded first so paths are not escaped twice.
+func uriEncode(s string, encodeSlash bool) string {
+    if unescaped, err := url.PathUnescape(s); err == nil {
+        s = unescaped
+    }
+    var b strings.Builder
+    for i := 0; i < len(s); i++ {
+        c := s[i]
+        switch {
+        case c >= 'A' && c <= 'Z', c >= 'a' && c <= 'z', c >= '0' && c <= '9', c == '-', c == '_', c == '.', c == '~':
+            b.WriteByte(c)
+        case c == '/' && !encodeSlash:
+            b.WriteByte(c)
+        default:
+            fmt.Fprintf(&b, "%%%02X", c)
+        }
+    }
+    return b.String()
+}
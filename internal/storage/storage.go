@@ -0,0 +1,101 @@
+// Package storage keeps the uploaded files. The API only depends on the Storage
+// interface, so files can live on the local disk or in an S3-compatible bucket.
+package storage
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "io"
+    "strings"
+)
+
+// ErrNotFound is returned when the object does not exist
+var ErrNotFound = errors.New("Object not found")
+
+// Storage saves and reads objects by key
+type Storage interface {
+    // Put saves the content under the key, replacing any previous object
+    Put(ctx context.Context, key string, content io.Reader, size int64, contentType string) error
+    // Get reads the object from the offset to its end
+    Get(ctx context.Context, key string, offset int64) (io.ReadCloser, error)
+    // Delete removes the object. Deleting a missing object is not an error.
+    Delete(ctx context.Context, key string) error
+}
+
+// CheckKey rejects the keys that could escape the storage root
+func CheckKey(key string) error {
+    if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") {
+        return fmt.Errorf("Invalid storage key '%s'", key)
+    }
+    for _, part := range strings.Split(key, "/") {
+        if part == "" || part == "." || part == ".." {
+            return fmt.Errorf("Invalid storage key '%s'", key)
+        }
+    }
+    return nil
+}
+
+// ReadSeeker reads an object of known size as an io.ReadSeeker, opening it again at
+// the new offset after each seek. It lets http.ServeContent answer range requests
+// without downloading the whole object.
+type ReadSeeker struct {
+    ctx    context.Context
+    store  Storage
+    key    string
+    size   int64
+    offset int64
+    body   io.ReadCloser
+}
+
+func NewReadSeeker(ctx context.Context, store Storage, key string, size int64) *ReadSeeker {
+    return &ReadSeeker{ctx: ctx, store: store, key: key, size: size}
+}
+
+func (rs *ReadSeeker) Read(p []byte) (int, error) {
+    if rs.offset >= rs.size {
+        return 0, io.EOF
+    }
+    if rs.body == nil {
+        body, err := rs.store.Get(rs.ctx, rs.key, rs.offset)
+        if err != nil {
+            return 0, err
+        }
+        rs.body = body
+    }
+    n, err := rs.body.Read(p)
+    rs.offset += int64(n)
+    return n, err
+}
+
+func (rs *ReadSeeker) Seek(offset int64, whence int) (int64, error) {
+    var abs int64
+    switch whence {
+    case io.SeekStart:
+        abs = offset
+    case io.SeekCurrent:
+        abs = rs.offset + offset
+    case io.SeekEnd:
+        abs = rs.size + offset
+    default:
+        return 0, errors.New("invalid whence")
+    }
+    if abs < 0 {
+        return 0, errors.New("negative position")
+    }
+    if abs != rs.offset {
+        rs.Close()
+        rs.offset = abs
+    }
+    return abs, nil
+}
+
+// Close releases the open object, if any
+func (rs *ReadSeeker) Close() error {
+    if rs.body == nil {
+        return nil
+    }
+    err := rs.body.Close()
+    rs.body = nil
+    return err
+}
@@ -0,0 +1,8 @@
+// Package migrations embeds the SQL migrations of every supported database, one
+// directory per dialect. The MySQL ones are also applied by Flyway.
+package migrations
+
+import "embed"
+
+//go:embed mysql/*.sql postgres/*.sql sqlite/*.sql
+var FS embed.FS
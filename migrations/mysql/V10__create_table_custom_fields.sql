@@ -0,0 +1,29 @@
+CREATE TABLE IF NOT EXISTS custom_fields (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    field_key VARCHAR(64) NOT NULL UNIQUE,
+    label VARCHAR(100) NOT NULL,
+    type VARCHAR(20) NOT NULL,
+    options TEXT NOT NULL, -- JSON array, empty for text, number and date
+    required BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- One row per value; multi_select fields have one row per selected option
+CREATE TABLE IF NOT EXISTS candidate_field_values (
+    candidate_id INT NOT NULL,
+    field_key VARCHAR(64) NOT NULL,
+    value_text VARCHAR(255) NOT NULL,
+    value_number DOUBLE NULL,
+    value_date DATE NULL,
+    PRIMARY KEY (candidate_id, field_key, value_text),
+    INDEX idx_field_values_text (field_key, value_text),
+    INDEX idx_field_values_number (field_key, value_number),
+    INDEX idx_field_values_date (field_key, value_date)
+);
+
+CREATE TABLE IF NOT EXISTS candidate_tags (
+    candidate_id INT NOT NULL,
+    tag VARCHAR(64) NOT NULL,
+    PRIMARY KEY (candidate_id, tag),
+    INDEX idx_candidate_tags_tag (tag)
+);
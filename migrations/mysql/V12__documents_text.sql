@@ -0,0 +1,2 @@
+ALTER TABLE documents ADD COLUMN text_content MEDIUMTEXT NULL;
+ALTER TABLE documents ADD FULLTEXT INDEX ft_documents_text (text_content);
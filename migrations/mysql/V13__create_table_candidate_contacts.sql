@@ -0,0 +1,26 @@
+CREATE TABLE IF NOT EXISTS candidate_contacts (
+    candidate_id INT PRIMARY KEY,
+    address_line1 VARCHAR(255) NOT NULL DEFAULT '',
+    address_line2 VARCHAR(255) NOT NULL DEFAULT '',
+    city VARCHAR(255) NOT NULL DEFAULT '',
+    region VARCHAR(255) NOT NULL DEFAULT '',
+    postal_code VARCHAR(255) NOT NULL DEFAULT '',
+    country CHAR(2) NOT NULL DEFAULT '', -- ISO 3166-1 alpha-2
+    time_zone VARCHAR(64) NOT NULL DEFAULT '', -- IANA
+    linkedin_url VARCHAR(255) NOT NULL DEFAULT '',
+    github_url VARCHAR(255) NOT NULL DEFAULT '',
+    portfolio_url VARCHAR(255) NOT NULL DEFAULT '',
+    preferred_channel VARCHAR(20) NOT NULL DEFAULT '',
+    INDEX idx_candidate_contacts_country (country)
+);
+
+-- Numbers in E.164
+CREATE TABLE IF NOT EXISTS candidate_phones (
+    candidate_id INT NOT NULL,
+    number VARCHAR(16) NOT NULL,
+    type VARCHAR(10) NOT NULL DEFAULT '',
+    is_primary BOOLEAN NOT NULL DEFAULT FALSE,
+    position INT NOT NULL,
+    PRIMARY KEY (candidate_id, number),
+    INDEX idx_candidate_phones_number (number)
+);
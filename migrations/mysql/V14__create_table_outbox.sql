@@ -0,0 +1,13 @@
+-- Events written in the same transaction as the candidate changes and published by the relay
+CREATE TABLE IF NOT EXISTS outbox (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    event_type VARCHAR(64) NOT NULL,
+    aggregate_id INT NOT NULL,
+    idempotency_key CHAR(32) NOT NULL UNIQUE,
+    payload JSON NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    published_at TIMESTAMP NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    last_error VARCHAR(255) NULL,
+    INDEX idx_outbox_pending (published_at, id)
+);
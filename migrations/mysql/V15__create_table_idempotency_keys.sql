@@ -0,0 +1,11 @@
+-- Responses stored by Idempotency-Key, replayed to the retries of the same request
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    idempotency_key VARCHAR(320) NOT NULL PRIMARY KEY,
+    fingerprint CHAR(64) NOT NULL,
+    status_code INT NOT NULL DEFAULT 0,
+    content_type VARCHAR(128) NOT NULL DEFAULT '',
+    response_body MEDIUMBLOB NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMP NOT NULL,
+    INDEX idx_idempotency_keys_expires (expires_at)
+);
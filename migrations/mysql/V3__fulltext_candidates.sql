@@ -0,0 +1 @@
+ALTER TABLE candidates ADD FULLTEXT INDEX ft_candidates_name_email (name, email);
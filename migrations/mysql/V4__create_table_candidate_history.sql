@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS candidate_history (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    candidate_id INT NOT NULL,
+    action VARCHAR(50) NOT NULL,
+    details JSON,
+    actor VARCHAR(100),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_candidate_history_candidate (candidate_id)
+);
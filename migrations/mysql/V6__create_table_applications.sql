@@ -0,0 +1,35 @@
+CREATE TABLE IF NOT EXISTS applications (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    candidate_id INT NOT NULL,
+    job_id INT NOT NULL,
+    stage VARCHAR(30) NOT NULL,
+    rejection_reason VARCHAR(50) NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    INDEX idx_applications_candidate (candidate_id),
+    INDEX idx_applications_job (job_id)
+);
+
+CREATE TABLE IF NOT EXISTS application_transitions (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    application_id INT NOT NULL,
+    from_stage VARCHAR(30) NOT NULL DEFAULT '',
+    to_stage VARCHAR(30) NOT NULL,
+    reason VARCHAR(500) NOT NULL DEFAULT '',
+    actor VARCHAR(100) NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_application_transitions_application (application_id)
+);
+
+CREATE TABLE IF NOT EXISTS rejection_reasons (
+    code VARCHAR(50) PRIMARY KEY,
+    label VARCHAR(150) NOT NULL,
+    active BOOLEAN NOT NULL DEFAULT TRUE
+);
+
+INSERT INTO rejection_reasons (code, label) VALUES
+('skills_mismatch', 'Skills do not match the position'),
+('salary_expectations', 'Salary expectations above the band'),
+('position_filled', 'Position filled by another candidate'),
+('withdrew', 'Candidate withdrew'),
+('no_show', 'Candidate did not attend the interview');
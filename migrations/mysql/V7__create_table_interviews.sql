@@ -0,0 +1,31 @@
+CREATE TABLE IF NOT EXISTS interviews (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    candidate_id INT NOT NULL,
+    application_id INT NULL,
+    starts_at DATETIME NOT NULL,
+    ends_at DATETIME NOT NULL,
+    time_zone VARCHAR(64) NOT NULL DEFAULT 'UTC',
+    location VARCHAR(255) NOT NULL DEFAULT '',
+    video_link VARCHAR(500) NOT NULL DEFAULT '',
+    type VARCHAR(20) NOT NULL,
+    sequence INT NOT NULL DEFAULT 0,
+    created_by VARCHAR(100) NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    INDEX idx_interviews_candidate (candidate_id, starts_at),
+    INDEX idx_interviews_application (application_id)
+);
+
+CREATE TABLE IF NOT EXISTS interview_interviewers (
+    interview_id INT NOT NULL,
+    interviewer VARCHAR(150) NOT NULL,
+    PRIMARY KEY (interview_id, interviewer),
+    INDEX idx_interview_interviewers_interviewer (interviewer)
+);
+
+-- Only the SHA-256 of the feed token is stored
+CREATE TABLE IF NOT EXISTS calendar_feeds (
+    user VARCHAR(100) PRIMARY KEY,
+    token_hash CHAR(64) NOT NULL UNIQUE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
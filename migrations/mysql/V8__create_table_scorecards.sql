@@ -0,0 +1,37 @@
+CREATE TABLE IF NOT EXISTS scorecard_templates (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    name VARCHAR(150) NOT NULL,
+    scale_min INT NOT NULL DEFAULT 1,
+    scale_max INT NOT NULL DEFAULT 5,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS scorecard_competencies (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    template_id INT NOT NULL,
+    name VARCHAR(150) NOT NULL,
+    description VARCHAR(500) NOT NULL DEFAULT '',
+    weight DECIMAL(6,2) NOT NULL DEFAULT 1,
+    INDEX idx_scorecard_competencies_template (template_id)
+);
+
+CREATE TABLE IF NOT EXISTS feedback (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    candidate_id INT NOT NULL,
+    interview_id INT NULL,
+    template_id INT NOT NULL,
+    interviewer VARCHAR(100) NOT NULL,
+    recommendation VARCHAR(20) NOT NULL,
+    notes TEXT,
+    score DECIMAL(5,2) NOT NULL,
+    submitted_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_feedback_candidate (candidate_id, interviewer)
+);
+
+CREATE TABLE IF NOT EXISTS feedback_ratings (
+    feedback_id INT NOT NULL,
+    competency_id INT NOT NULL,
+    score INT NOT NULL,
+    comment VARCHAR(1000) NOT NULL DEFAULT '',
+    PRIMARY KEY (feedback_id, competency_id)
+);
@@ -0,0 +1,37 @@
+CREATE TABLE IF NOT EXISTS notes (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    candidate_id INT NOT NULL,
+    author VARCHAR(100) NOT NULL,
+    body TEXT NOT NULL,
+    visibility VARCHAR(10) NOT NULL DEFAULT 'team',
+    pinned BOOLEAN NOT NULL DEFAULT FALSE,
+    mentions VARCHAR(1000) NOT NULL DEFAULT '',
+    edited BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL,
+    INDEX idx_notes_candidate (candidate_id, deleted_at)
+);
+
+CREATE TABLE IF NOT EXISTS note_revisions (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    note_id INT NOT NULL,
+    body TEXT NOT NULL,
+    visibility VARCHAR(10) NOT NULL,
+    editor VARCHAR(100) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_note_revisions_note (note_id)
+);
+
+CREATE TABLE IF NOT EXISTS notifications (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    user VARCHAR(100) NOT NULL,
+    type VARCHAR(30) NOT NULL,
+    candidate_id INT NOT NULL,
+    note_id INT NULL,
+    actor VARCHAR(100) NOT NULL DEFAULT '',
+    message VARCHAR(500) NOT NULL DEFAULT '',
+    read_at TIMESTAMP NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_notifications_user (user, read_at)
+);
@@ -0,0 +1,14 @@
+INSERT INTO candidates (name, email, gender, salary_expected)
+VALUES
+  ('Roy Smith', 'roy.smith@example.com', 'male', 30000.00),
+  ('Charles Adams', 'charles.adams@example.com', 'male', 35000.00),
+  ('Louis Johnson', 'louis.johnson@example.com', 'male', 40000.00),
+  ('Anna Walker', 'anna.walker@example.com', 'female', 32000.00),
+  ('Tania Roberts', 'tania.roberts@example.com', 'female', 36000.00);
+
+INSERT INTO rejection_reasons (code, label) VALUES
+('skills_mismatch', 'Skills do not match the position'),
+('salary_expectations', 'Salary expectations above the band'),
+('position_filled', 'Position filled by another candidate'),
+('withdrew', 'Candidate withdrew'),
+('no_show', 'Candidate did not attend the interview');
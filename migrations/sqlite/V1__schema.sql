@@ -0,0 +1,294 @@
+-- Schema of the MySQL migrations V1 to V15 for SQLite. The full-text indexes are
+-- MySQL only, so the search falls back to the list filters. The triggers stand in for
+-- ON UPDATE CURRENT_TIMESTAMP.
+
+CREATE TABLE IF NOT EXISTS candidates (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name VARCHAR(100) NOT NULL,
+    email VARCHAR(150) NOT NULL UNIQUE,
+    gender VARCHAR(20),
+    salary_expected DECIMAL(10,2),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+CREATE TRIGGER IF NOT EXISTS candidates_updated_at AFTER UPDATE ON candidates FOR EACH ROW WHEN NEW.updated_at = OLD.updated_at
+BEGIN
+    UPDATE candidates SET updated_at = CURRENT_TIMESTAMP WHERE id = NEW.id;
+END;
+
+CREATE TABLE IF NOT EXISTS candidate_history (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    candidate_id INT NOT NULL,
+    action VARCHAR(50) NOT NULL,
+    details TEXT,
+    actor VARCHAR(100),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_candidate_history_candidate ON candidate_history (candidate_id);
+
+CREATE TABLE IF NOT EXISTS jobs (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    title VARCHAR(150) NOT NULL,
+    department VARCHAR(100) NOT NULL DEFAULT '',
+    location VARCHAR(100) NOT NULL DEFAULT '',
+    salary_min DECIMAL(10,2) NOT NULL DEFAULT 0,
+    salary_max DECIMAL(10,2) NOT NULL DEFAULT 0,
+    status VARCHAR(20) NOT NULL DEFAULT 'open',
+    hiring_manager VARCHAR(100) NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_jobs_status ON jobs (status);
+CREATE TRIGGER IF NOT EXISTS jobs_updated_at AFTER UPDATE ON jobs FOR EACH ROW WHEN NEW.updated_at = OLD.updated_at
+BEGIN
+    UPDATE jobs SET updated_at = CURRENT_TIMESTAMP WHERE id = NEW.id;
+END;
+
+CREATE TABLE IF NOT EXISTS applications (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    candidate_id INT NOT NULL,
+    job_id INT NOT NULL,
+    stage VARCHAR(30) NOT NULL,
+    rejection_reason VARCHAR(50) NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_applications_candidate ON applications (candidate_id);
+CREATE INDEX IF NOT EXISTS idx_applications_job ON applications (job_id);
+CREATE TRIGGER IF NOT EXISTS applications_updated_at AFTER UPDATE ON applications FOR EACH ROW WHEN NEW.updated_at = OLD.updated_at
+BEGIN
+    UPDATE applications SET updated_at = CURRENT_TIMESTAMP WHERE id = NEW.id;
+END;
+
+CREATE TABLE IF NOT EXISTS application_transitions (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    application_id INT NOT NULL,
+    from_stage VARCHAR(30) NOT NULL DEFAULT '',
+    to_stage VARCHAR(30) NOT NULL,
+    reason VARCHAR(500) NOT NULL DEFAULT '',
+    actor VARCHAR(100) NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_application_transitions_application ON application_transitions (application_id);
+
+CREATE TABLE IF NOT EXISTS rejection_reasons (
+    code VARCHAR(50) PRIMARY KEY,
+    label VARCHAR(150) NOT NULL,
+    active BOOLEAN NOT NULL DEFAULT TRUE
+);
+
+CREATE TABLE IF NOT EXISTS interviews (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    candidate_id INT NOT NULL,
+    application_id INT NULL,
+    starts_at TIMESTAMP NOT NULL,
+    ends_at TIMESTAMP NOT NULL,
+    time_zone VARCHAR(64) NOT NULL DEFAULT 'UTC',
+    location VARCHAR(255) NOT NULL DEFAULT '',
+    video_link VARCHAR(500) NOT NULL DEFAULT '',
+    type VARCHAR(20) NOT NULL,
+    sequence INT NOT NULL DEFAULT 0,
+    created_by VARCHAR(100) NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_interviews_candidate ON interviews (candidate_id, starts_at);
+CREATE INDEX IF NOT EXISTS idx_interviews_application ON interviews (application_id);
+CREATE TRIGGER IF NOT EXISTS interviews_updated_at AFTER UPDATE ON interviews FOR EACH ROW WHEN NEW.updated_at = OLD.updated_at
+BEGIN
+    UPDATE interviews SET updated_at = CURRENT_TIMESTAMP WHERE id = NEW.id;
+END;
+
+CREATE TABLE IF NOT EXISTS interview_interviewers (
+    interview_id INT NOT NULL,
+    interviewer VARCHAR(150) NOT NULL,
+    PRIMARY KEY (interview_id, interviewer)
+);
+CREATE INDEX IF NOT EXISTS idx_interview_interviewers_interviewer ON interview_interviewers (interviewer);
+
+-- Only the SHA-256 of the feed token is stored.
+CREATE TABLE IF NOT EXISTS calendar_feeds (
+    "user" VARCHAR(100) PRIMARY KEY,
+    token_hash CHAR(64) NOT NULL UNIQUE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS scorecard_templates (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name VARCHAR(150) NOT NULL,
+    scale_min INT NOT NULL DEFAULT 1,
+    scale_max INT NOT NULL DEFAULT 5,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS scorecard_competencies (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    template_id INT NOT NULL,
+    name VARCHAR(150) NOT NULL,
+    description VARCHAR(500) NOT NULL DEFAULT '',
+    weight DECIMAL(6,2) NOT NULL DEFAULT 1
+);
+CREATE INDEX IF NOT EXISTS idx_scorecard_competencies_template ON scorecard_competencies (template_id);
+
+CREATE TABLE IF NOT EXISTS feedback (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    candidate_id INT NOT NULL,
+    interview_id INT NULL,
+    template_id INT NOT NULL,
+    interviewer VARCHAR(100) NOT NULL,
+    recommendation VARCHAR(20) NOT NULL,
+    notes TEXT,
+    score DECIMAL(5,2) NOT NULL,
+    submitted_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_feedback_candidate ON feedback (candidate_id, interviewer);
+
+CREATE TABLE IF NOT EXISTS feedback_ratings (
+    feedback_id INT NOT NULL,
+    competency_id INT NOT NULL,
+    score INT NOT NULL,
+    comment VARCHAR(1000) NOT NULL DEFAULT '',
+    PRIMARY KEY (feedback_id, competency_id)
+);
+
+CREATE TABLE IF NOT EXISTS notes (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    candidate_id INT NOT NULL,
+    author VARCHAR(100) NOT NULL,
+    body TEXT NOT NULL,
+    visibility VARCHAR(10) NOT NULL DEFAULT 'team',
+    pinned BOOLEAN NOT NULL DEFAULT FALSE,
+    mentions VARCHAR(1000) NOT NULL DEFAULT '',
+    edited BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL
+);
+CREATE INDEX IF NOT EXISTS idx_notes_candidate ON notes (candidate_id, deleted_at);
+CREATE TRIGGER IF NOT EXISTS notes_updated_at AFTER UPDATE ON notes FOR EACH ROW WHEN NEW.updated_at = OLD.updated_at
+BEGIN
+    UPDATE notes SET updated_at = CURRENT_TIMESTAMP WHERE id = NEW.id;
+END;
+
+CREATE TABLE IF NOT EXISTS note_revisions (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    note_id INT NOT NULL,
+    body TEXT NOT NULL,
+    visibility VARCHAR(10) NOT NULL,
+    editor VARCHAR(100) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_note_revisions_note ON note_revisions (note_id);
+
+CREATE TABLE IF NOT EXISTS notifications (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    "user" VARCHAR(100) NOT NULL,
+    type VARCHAR(30) NOT NULL,
+    candidate_id INT NOT NULL,
+    note_id INT NULL,
+    actor VARCHAR(100) NOT NULL DEFAULT '',
+    message VARCHAR(500) NOT NULL DEFAULT '',
+    read_at TIMESTAMP NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_notifications_user ON notifications ("user", read_at);
+
+CREATE TABLE IF NOT EXISTS custom_fields (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    field_key VARCHAR(64) NOT NULL UNIQUE,
+    label VARCHAR(100) NOT NULL,
+    type VARCHAR(20) NOT NULL,
+    options TEXT NOT NULL, -- JSON array, empty for text, number and date
+    required BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- One row per value; multi_select fields have one row per selected option
+CREATE TABLE IF NOT EXISTS candidate_field_values (
+    candidate_id INT NOT NULL,
+    field_key VARCHAR(64) NOT NULL,
+    value_text VARCHAR(255) NOT NULL,
+    value_number REAL NULL,
+    value_date DATE NULL,
+    PRIMARY KEY (candidate_id, field_key, value_text)
+);
+CREATE INDEX IF NOT EXISTS idx_field_values_text ON candidate_field_values (field_key, value_text);
+CREATE INDEX IF NOT EXISTS idx_field_values_number ON candidate_field_values (field_key, value_number);
+CREATE INDEX IF NOT EXISTS idx_field_values_date ON candidate_field_values (field_key, value_date);
+
+CREATE TABLE IF NOT EXISTS candidate_tags (
+    candidate_id INT NOT NULL,
+    tag VARCHAR(64) NOT NULL,
+    PRIMARY KEY (candidate_id, tag)
+);
+CREATE INDEX IF NOT EXISTS idx_candidate_tags_tag ON candidate_tags (tag);
+
+CREATE TABLE IF NOT EXISTS documents (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    candidate_id INT NOT NULL,
+    file_name VARCHAR(255) NOT NULL,
+    content_type VARCHAR(100) NOT NULL,
+    kind VARCHAR(10) NOT NULL,
+    size BIGINT NOT NULL,
+    sha256 CHAR(64) NOT NULL,
+    storage_key VARCHAR(255) NOT NULL,
+    uploaded_by VARCHAR(100) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL,
+    text_content TEXT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_documents_candidate ON documents (candidate_id, deleted_at);
+CREATE INDEX IF NOT EXISTS idx_documents_deleted ON documents (deleted_at);
+
+CREATE TABLE IF NOT EXISTS candidate_contacts (
+    candidate_id INT PRIMARY KEY,
+    address_line1 VARCHAR(255) NOT NULL DEFAULT '',
+    address_line2 VARCHAR(255) NOT NULL DEFAULT '',
+    city VARCHAR(255) NOT NULL DEFAULT '',
+    region VARCHAR(255) NOT NULL DEFAULT '',
+    postal_code VARCHAR(255) NOT NULL DEFAULT '',
+    country CHAR(2) NOT NULL DEFAULT '', -- ISO 3166-1 alpha-2
+    time_zone VARCHAR(64) NOT NULL DEFAULT '', -- IANA
+    linkedin_url VARCHAR(255) NOT NULL DEFAULT '',
+    github_url VARCHAR(255) NOT NULL DEFAULT '',
+    portfolio_url VARCHAR(255) NOT NULL DEFAULT '',
+    preferred_channel VARCHAR(20) NOT NULL DEFAULT ''
+);
+CREATE INDEX IF NOT EXISTS idx_candidate_contacts_country ON candidate_contacts (country);
+
+-- Numbers in E.164
+CREATE TABLE IF NOT EXISTS candidate_phones (
+    candidate_id INT NOT NULL,
+    number VARCHAR(16) NOT NULL,
+    type VARCHAR(10) NOT NULL DEFAULT '',
+    is_primary BOOLEAN NOT NULL DEFAULT FALSE,
+    position INT NOT NULL,
+    PRIMARY KEY (candidate_id, number)
+);
+CREATE INDEX IF NOT EXISTS idx_candidate_phones_number ON candidate_phones (number);
+
+-- Events written in the same transaction as the candidate changes and published by the relay
+CREATE TABLE IF NOT EXISTS outbox (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    event_type VARCHAR(64) NOT NULL,
+    aggregate_id INT NOT NULL,
+    idempotency_key CHAR(32) NOT NULL UNIQUE,
+    payload TEXT NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    published_at TIMESTAMP NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    last_error VARCHAR(255) NULL
+);
+CREATE INDEX IF NOT EXISTS idx_outbox_pending ON outbox (published_at, id);
+
+-- Responses stored by Idempotency-Key, replayed to the retries of the same request
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    idempotency_key VARCHAR(320) NOT NULL PRIMARY KEY,
+    fingerprint CHAR(64) NOT NULL,
+    status_code INT NOT NULL DEFAULT 0,
+    content_type VARCHAR(128) NOT NULL DEFAULT '',
+    response_body BLOB NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMP NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_idempotency_keys_expires ON idempotency_keys (expires_at);
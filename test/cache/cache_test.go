@@ -0,0 +1,240 @@
+package cache_test
+
+import (
+    "bufio"
+    "context"
+    "fmt"
+    "io"
+    "net"
+    "strconv"
+    "strings"
+    "sync"
+    "testing"
+    "time"
+
+    "github.com/stretchr/testify/assert"
+    "github.com/stretchr/testify/require"
+
+    "github.com/torvictorvic/seek-v2/internal/cache"
+)
+
+func TestLRU_TTLAndEviction(t *testing.T) {
+    ctx := context.Background()
+    now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+    lru := cache.NewLRU(2, cache.WithLRUClock(func() time.Time { return now }))
+
+    require.NoError(t, lru.Set(ctx, "a", []byte("1"), time.Minute))
+    require.NoError(t, lru.Set(ctx, "b", []byte("2"), time.Second))
+    // Leer "a" la deja como la más reciente, así que "b" es la que sale al llenarse
+    _, ok, _ := lru.Get(ctx, "a")
+    assert.True(t, ok)
+    require.NoError(t, lru.Set(ctx, "c", []byte("3"), time.Minute))
+    _, ok, _ = lru.Get(ctx, "b")
+    assert.False(t, ok)
+    assert.Equal(t, 2, lru.Len())
+
+    // Los valores caducados no se devuelven
+    now = now.Add(time.Minute)
+    _, ok, _ = lru.Get(ctx, "a")
+    assert.False(t, ok)
+    value, ok, _ := lru.Get(ctx, "c")
+    assert.False(t, ok)
+    assert.Nil(t, value)
+
+    require.NoError(t, lru.Set(ctx, "d", []byte("4"), time.Minute))
+    require.NoError(t, lru.Delete(ctx, "d", "missing"))
+    _, ok, _ = lru.Get(ctx, "d")
+    assert.False(t, ok)
+}
+
+func TestLRU_CopiesValues(t *testing.T) {
+    ctx := context.Background()
+    lru := cache.NewLRU(10)
+    value := []byte("jane")
+    require.NoError(t, lru.Set(ctx, "k", value, time.Minute))
+    value[0] = 'J'
+
+    got, ok, _ := lru.Get(ctx, "k")
+    require.True(t, ok)
+    got[1] = 'A'
+    again, _, _ := lru.Get(ctx, "k")
+    assert.Equal(t, "jane", string(again))
+}
+
+// fakeRedis es un servidor RESP mínimo en memoria con GET, SET PX, DEL, AUTH, SELECT y PING
+type fakeRedis struct {
+    mu       sync.Mutex
+    values   map[string]string
+    expires  map[string]time.Time
+    password string
+    commands []string
+    listener net.Listener
+}
+
+func newFakeRedis(t *testing.T, password string) *fakeRedis {
+    listener, err := net.Listen("tcp", "127.0.0.1:0")
+    require.NoError(t, err)
+    f := &fakeRedis{values: map[string]string{}, expires: map[string]time.Time{}, password: password, listener: listener}
+    t.Cleanup(func() { listener.Close() })
+    go func() {
+        for {
+            conn, err := listener.Accept()
+            if err != nil {
+                return
+            }
+            go f.serve(conn)
+        }
+    }()
+    return f
+}
+
+func (f *fakeRedis) serve(conn net.Conn) {
+    defer conn.Close()
+    r := bufio.NewReader(conn)
+    authenticated := f.password == ""
+    for {
+        args, err := readCommand(r)
+        if err != nil {
+            return
+        }
+        f.mu.Lock()
+        f.commands = append(f.commands, strings.Join(args, " "))
+        reply := f.run(args, &authenticated)
+        f.mu.Unlock()
+        if _, err := io.WriteString(conn, reply); err != nil {
+            return
+        }
+    }
+}
+
+func (f *fakeRedis) run(args []string, authenticated *bool) string {
+    cmd := strings.ToUpper(args[0])
+    if cmd == "AUTH" {
+        if args[1] != f.password {
+            return "-WRONGPASS invalid password\r\n"
+        }
+        *authenticated = true
+        return "+OK\r\n"
+    }
+    if !*authenticated {
+        return "-NOAUTH Authentication required.\r\n"
+    }
+    switch cmd {
+    case "PING":
+        return "+PONG\r\n"
+    case "SELECT":
+        return "+OK\r\n"
+    case "GET":
+        value, ok := f.values[args[1]]
+        if !ok || !time.Now().Before(f.expires[args[1]]) {
+            return "$-1\r\n"
+        }
+        return fmt.Sprintf("$%d\r\n%s\r\n", len(value), value)
+    case "SET":
+        ms, _ := strconv.Atoi(args[4])
+        f.values[args[1]] = args[2]
+        f.expires[args[1]] = time.Now().Add(time.Duration(ms) * time.Millisecond)
+        return "+OK\r\n"
+    case "DEL":
+        n := 0
+        for _, key := range args[1:] {
+            if _, ok := f.values[key]; ok {
+                delete(f.values, key)
+                n++
+            }
+        }
+        return fmt.Sprintf(":%d\r\n", n)
+    }
+    return "-ERR unknown command '" + args[0] + "'\r\n"
+}
+
+func readCommand(r *bufio.Reader) ([]string, error) {
+    line, err := r.ReadString('\n')
+    if err != nil {
+        return nil, err
+    }
+    n, err := strconv.Atoi(strings.TrimSpace(line[1:]))
+    if err != nil || line[0] != '*' {
+        return nil, fmt.Errorf("invalid command %q", line)
+    }
+    args := make([]string, n)
+    for i := range args {
+        line, err := r.ReadString('\n')
+        if err != nil {
+            return nil, err
+        }
+        size, _ := strconv.Atoi(strings.TrimSpace(line[1:]))
+        buf := make([]byte, size+2)
+        if _, err := io.ReadFull(r, buf); err != nil {
+            return nil, err
+        }
+        args[i] = string(buf[:size])
+    }
+    return args, nil
+}
+
+func (f *fakeRedis) log() []string {
+    f.mu.Lock()
+    defer f.mu.Unlock()
+    return append([]string(nil), f.commands...)
+}
+
+func TestRedis(t *testing.T) {
+    ctx := context.Background()
+    fake := newFakeRedis(t, "secret")
+    store, err := cache.NewRedis(cache.RedisConfig{Addr: fake.listener.Addr().String(), Password: "secret", DB: 2, Prefix: "seek:"})
+    require.NoError(t, err)
+    defer store.Close()
+
+    _, ok, err := store.Get(ctx, "candidate:1")
+    require.NoError(t, err)
+    assert.False(t, ok)
+
+    // Los valores binarios y con saltos de línea llegan intactos
+    value := []byte("{\"name\":\"Jane\"}\r\n\x00")
+    require.NoError(t, store.Set(ctx, "candidate:1", value, 1500*time.Millisecond))
+    got, ok, err := store.Get(ctx, "candidate:1")
+    require.NoError(t, err)
+    assert.True(t, ok)
+    assert.Equal(t, value, got)
+
+    require.NoError(t, store.Delete(ctx, "candidate:1", "candidate:2"))
+    _, ok, err = store.Get(ctx, "candidate:1")
+    require.NoError(t, err)
+    assert.False(t, ok)
+    require.NoError(t, store.Ping(ctx))
+
+    // Una sola conexión, autenticada y en la base de datos configurada, con las claves con prefijo
+    assert.Equal(t, []string{
+        "AUTH secret",
+        "SELECT 2",
+        "GET seek:candidate:1",
+        "SET seek:candidate:1 " + string(value) + " PX 1500",
+        "GET seek:candidate:1",
+        "DEL seek:candidate:1 seek:candidate:2",
+        "GET seek:candidate:1",
+        "PING",
+    }, fake.log())
+}
+
+func TestRedis_Errors(t *testing.T) {
+    ctx := context.Background()
+    fake := newFakeRedis(t, "secret")
+
+    store, err := cache.NewRedis(cache.RedisConfig{Addr: fake.listener.Addr().String(), Password: "wrong"})
+    require.NoError(t, err)
+    _, _, err = store.Get(ctx, "k")
+    assert.ErrorContains(t, err, "WRONGPASS")
+
+    // Un servidor caído es un error, no un fallo de caché
+    addr := fake.listener.Addr().String()
+    fake.listener.Close()
+    store, err = cache.NewRedis(cache.RedisConfig{Addr: addr, Timeout: 100 * time.Millisecond})
+    require.NoError(t, err)
+    _, ok, err := store.Get(ctx, "k")
+    assert.Error(t, err)
+    assert.False(t, ok)
+
+    _, err = cache.NewRedis(cache.RedisConfig{})
+    assert.Error(t, err)
+}
@@ -0,0 +1,72 @@
+package config_test
+
+import (
+    "testing"
+
+    "github.com/stretchr/testify/assert"
+
+    "github.com/torvictorvic/seek-v2/internal/config"
+)
+
+func TestLoadEventsConfig_Heartbeat(t *testing.T) {
+    t.Setenv("SSE_HEARTBEAT", "30")
+    assert.Equal(t, 30, config.LoadEventsConfig().Heartbeat)
+
+    // Un valor no positivo haría fallar el ticker del stream, se usa el de por defecto
+    for _, v := range []string{"0", "-5"} {
+        t.Setenv("SSE_HEARTBEAT", v)
+        assert.Equal(t, 15, config.LoadEventsConfig().Heartbeat)
+    }
+}
+
+func TestLoadDatabaseConfig_ReplicaCheckInterval(t *testing.T) {
+    t.Setenv("DB_REPLICA_CHECK_INTERVAL", "30")
+    assert.Equal(t, 30, config.LoadDatabaseConfig().ReplicaCheckInterval)
+
+    // Con 0 el chequeo de las réplicas haría fallar el ticker, se usa el de por defecto
+    for _, v := range []string{"0", "-1"} {
+        t.Setenv("DB_REPLICA_CHECK_INTERVAL", v)
+        assert.Equal(t, 10, config.LoadDatabaseConfig().ReplicaCheckInterval)
+    }
+}
+
+func TestLoadOutboxConfig(t *testing.T) {
+    cfg := config.LoadOutboxConfig()
+    assert.Equal(t, 1, cfg.Interval)
+    assert.True(t, cfg.RelayEnabled)
+
+    // Un intervalo o un lote de 0 harían fallar el ticker y el relay, se usan los de por defecto
+    t.Setenv("OUTBOX_INTERVAL", "0")
+    t.Setenv("OUTBOX_BATCH_SIZE", "-5")
+    t.Setenv("OUTBOX_RELAY_ENABLED", "false")
+    cfg = config.LoadOutboxConfig()
+    assert.Equal(t, 1, cfg.Interval)
+    assert.Equal(t, 100, cfg.BatchSize)
+    assert.False(t, cfg.RelayEnabled)
+}
+
+func TestLoadConfig_Intervals(t *testing.T) {
+    // 0 desactiva la tarea y un valor negativo usa el de por defecto
+    t.Setenv("SEARCH_REINDEX_INTERVAL", "0")
+    t.Setenv("DOCUMENT_PURGE_INTERVAL", "0")
+    t.Setenv("CACHE_TTL_GET_BY_ID", "0")
+    assert.Equal(t, 0, config.LoadSearchConfig().ReindexInterval)
+    assert.Equal(t, 0, config.LoadStorageConfig().PurgeInterval)
+    assert.Equal(t, 0, config.LoadCacheConfig().TTLGetByID)
+
+    t.Setenv("SEARCH_REINDEX_INTERVAL", "-1")
+    t.Setenv("DOCUMENT_PURGE_INTERVAL", "-1")
+    t.Setenv("CACHE_TTL_GET_BY_ID", "-1")
+    t.Setenv("CACHE_REDIS_TIMEOUT_MS", "0")
+    assert.Equal(t, 300, config.LoadSearchConfig().ReindexInterval)
+    assert.Equal(t, 3600, config.LoadStorageConfig().PurgeInterval)
+    cacheCfg := config.LoadCacheConfig()
+    assert.Equal(t, 60, cacheCfg.TTLGetByID)
+    assert.Equal(t, 500, cacheCfg.RedisTimeout)
+}
+
+func TestLoadAuthConfig(t *testing.T) {
+    // Las entradas sin contraseña se ignoran
+    t.Setenv("AUTH_USERS", "maria:clave, luis:otra:clave,ana")
+    assert.Equal(t, map[string]string{"maria": "clave", "luis": "otra:clave"}, config.LoadAuthConfig().Users)
+}
@@ -0,0 +1,44 @@
+package dedupe_test
+
+import (
+    "testing"
+
+    "github.com/stretchr/testify/assert"
+
+    "github.com/torvictorvic/seek-v2/internal/dedupe"
+    "github.com/torvictorvic/seek-v2/internal/domain"
+)
+
+func TestNormalize(t *testing.T) {
+    assert.Equal(t, "anna walker", dedupe.NormalizeName("Walker, Anna"))
+    assert.Equal(t, "jose perez", dedupe.NormalizeName("  José   PÉREZ "))
+    assert.Equal(t, "annawalker", dedupe.NormalizeEmailLocal("Anna.Walker+jobs@gmail.com"))
+}
+
+func TestScore_WorkAndPersonalEmail(t *testing.T) {
+    personal := domain.Candidate{Name: "Anna Walker", Email: "anna.walker@gmail.com"}
+    work := domain.Candidate{Name: "Ana Walker", Email: "awalker@acme.com"}
+
+    score, reasons := dedupe.Score(personal, work)
+    assert.GreaterOrEqual(t, score, dedupe.DefaultThreshold)
+    assert.Contains(t, reasons, "similar name")
+    assert.Contains(t, reasons, "email matches name")
+}
+
+func TestScore_SameEmailLocalPart(t *testing.T) {
+    a := domain.Candidate{Name: "Tania Roberts", Email: "tania.roberts@example.com"}
+    b := domain.Candidate{Name: "Tania R.", Email: "taniaroberts@gmail.com"}
+
+    score, reasons := dedupe.Score(a, b)
+    assert.GreaterOrEqual(t, score, dedupe.DefaultThreshold)
+    assert.Contains(t, reasons, "same email local part")
+}
+
+func TestScore_DifferentPeople(t *testing.T) {
+    a := domain.Candidate{Name: "Roy Smith", Email: "roy.smith@example.com"}
+    b := domain.Candidate{Name: "Charles Adams", Email: "charles.adams@example.com"}
+
+    score, reasons := dedupe.Score(a, b)
+    assert.Less(t, score, 0.5)
+    assert.Empty(t, reasons)
+}
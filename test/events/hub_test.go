@@ -0,0 +1,137 @@
+package events_test
+
+import (
+    "context"
+    "encoding/json"
+    "sync"
+    "testing"
+
+    "github.com/stretchr/testify/assert"
+
+    "github.com/torvictorvic/seek-v2/internal/domain"
+    "github.com/torvictorvic/seek-v2/internal/events"
+)
+
+func event(id int64, eventType string) domain.OutboxEvent {
+    return domain.OutboxEvent{ID: id, Type: eventType, AggregateID: int(id), Payload: json.RawMessage(`{"id":1}`)}
+}
+
+func ids(events []domain.OutboxEvent) []int64 {
+    out := []int64{}
+    for _, e := range events {
+        out = append(out, e.ID)
+    }
+    return out
+}
+
+func TestHub_FanOutAndFilter(t *testing.T) {
+    hub := events.NewHub(10, 10)
+    all, _, _ := hub.Subscribe(0, nil)
+    deleted, _, _ := hub.Subscribe(0, []string{domain.EventCandidateDeleted})
+
+    hub.Publish(context.Background(), event(1, domain.EventCandidateCreated))
+    hub.Publish(context.Background(), event(2, domain.EventCandidateDeleted))
+    // El relay puede repetir eventos, se ignoran
+    hub.Publish(context.Background(), event(2, domain.EventCandidateDeleted))
+
+    assert.Equal(t, int64(1), (<-all.C).ID)
+    assert.Equal(t, int64(2), (<-all.C).ID)
+    assert.Equal(t, int64(2), (<-deleted.C).ID)
+    assert.Len(t, all.C, 0)
+    assert.Len(t, deleted.C, 0)
+
+    all.Close()
+    all.Close()
+    _, ok := <-all.C
+    assert.False(t, ok)
+    assert.Equal(t, 1, hub.Subscribers())
+}
+
+func TestHub_ResumeFromLastEventID(t *testing.T) {
+    hub := events.NewHub(3, 10)
+
+    // Un cliente nuevo solo recibe los eventos siguientes
+    hub.Publish(context.Background(), event(1, domain.EventCandidateCreated))
+    _, replay, complete := hub.Subscribe(0, nil)
+    assert.True(t, complete)
+    assert.Empty(t, replay)
+
+    hub = events.NewHub(3, 10)
+
+    // Tras un reinicio el hub no sabe qué eventos se perdieron
+    _, _, complete = hub.Subscribe(5, nil)
+    assert.False(t, complete)
+
+    for id := int64(1); id <= 5; id++ {
+        hub.Publish(context.Background(), event(id, domain.EventCandidateUpdated))
+    }
+
+    // Solo se conservan los eventos 3, 4 y 5
+    _, replay, complete = hub.Subscribe(3, nil)
+    assert.True(t, complete)
+    assert.Equal(t, []int64{4, 5}, ids(replay))
+
+    _, replay, complete = hub.Subscribe(5, nil)
+    assert.True(t, complete)
+    assert.Empty(t, replay)
+
+    // El 1 ya no se conserva: se envía lo que hay y el cliente debe recargar
+    _, replay, complete = hub.Subscribe(1, nil)
+    assert.False(t, complete)
+    assert.Equal(t, []int64{3, 4, 5}, ids(replay))
+
+    _, replay, complete = hub.Subscribe(3, []string{domain.EventCandidateCreated})
+    assert.True(t, complete)
+    assert.Empty(t, replay)
+}
+
+func TestHub_DropsSlowSubscribers(t *testing.T) {
+    hub := events.NewHub(10, 2)
+    slow, _, _ := hub.Subscribe(0, nil)
+
+    for id := int64(1); id <= 3; id++ {
+        hub.Publish(context.Background(), event(id, domain.EventCandidateCreated))
+    }
+    // Recibe lo que alcanzó a encolar y el canal se cierra para que reconecte
+    assert.Equal(t, int64(1), (<-slow.C).ID)
+    assert.Equal(t, int64(2), (<-slow.C).ID)
+    _, ok := <-slow.C
+    assert.False(t, ok)
+    assert.Equal(t, 0, hub.Subscribers())
+}
+
+func TestHub_ConcurrentSubscribers(t *testing.T) {
+    hub := events.NewHub(100, 100)
+    const subscribers, published = 50, 100
+
+    var wg sync.WaitGroup
+    received := make([]int, subscribers)
+    ready := make(chan struct{}, subscribers)
+    for i := 0; i < subscribers; i++ {
+        wg.Add(1)
+        go func(i int) {
+            defer wg.Done()
+            sub, _, _ := hub.Subscribe(0, nil)
+            defer sub.Close()
+            ready <- struct{}{}
+            for range sub.C {
+                received[i]++
+                if received[i] == published {
+                    return
+                }
+            }
+        }(i)
+    }
+    for i := 0; i < subscribers; i++ {
+        <-ready
+    }
+    for id := int64(1); id <= published; id++ {
+        hub.Publish(context.Background(), event(id, domain.EventCandidateUpdated))
+    }
+    wg.Wait()
+
+    for _, n := range received {
+        assert.Equal(t, published, n)
+    }
+    assert.Equal(t, 0, hub.Subscribers())
+}
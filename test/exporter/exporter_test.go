@@ -0,0 +1,127 @@
+package exporter_test
+
+import (
+    "bytes"
+    "io"
+    "strings"
+    "testing"
+    "time"
+
+    "github.com/stretchr/testify/assert"
+
+    "github.com/torvictorvic/seek-v2/internal/domain"
+    "github.com/torvictorvic/seek-v2/internal/exporter"
+    "github.com/torvictorvic/seek-v2/internal/importer"
+)
+
+var candidates = []domain.Candidate{
+    {ID: 1, Name: "Roy Smith", Email: "roy.smith@example.com", Gender: "male", SalaryExpected: 30000, CreatedAt: time.Date(2024, 12, 27, 8, 0, 0, 0, time.UTC)},
+    {ID: 2, Name: "Tania \"Tani\" Roberts", Email: "tania.roberts@example.com", Gender: "female", SalaryExpected: 36000.5},
+}
+
+func export(t *testing.T, format string, columns []exporter.Column) []byte {
+    var buf bytes.Buffer
+    w, err := exporter.NewWriter(format, &buf, columns, "Candidatos")
+    assert.NoError(t, err)
+    for _, c := range candidates {
+        assert.NoError(t, w.WriteRow(c))
+    }
+    assert.NoError(t, w.Close())
+    return buf.Bytes()
+}
+
+func TestExportCSV_SelectedColumns(t *testing.T) {
+    columns, err := exporter.Columns([]string{"name", "salary_expected"}, "es")
+    assert.NoError(t, err)
+
+    out := export(t, exporter.FormatCSV, columns)
+    assert.Equal(t, "Nombre,Salario esperado\nRoy Smith,30000.00\n\"Tania \"\"Tani\"\" Roberts\",36000.50\n", string(out))
+}
+
+func TestExportNDJSON(t *testing.T) {
+    columns, err := exporter.Columns([]string{"id", "email", "created_at"}, "en")
+    assert.NoError(t, err)
+
+    lines := strings.Split(strings.TrimSpace(string(export(t, exporter.FormatNDJSON, columns))), "\n")
+    assert.Len(t, lines, 2)
+    // Se respeta el orden de las columnas
+    assert.Equal(t, `{"id":1,"email":"roy.smith@example.com","created_at":"2024-12-27T08:00:00Z"}`, lines[0])
+}
+
+func TestExportXLSX_ReadBack(t *testing.T) {
+    columns, err := exporter.Columns(nil, "en")
+    assert.NoError(t, err)
+
+    out := export(t, exporter.FormatXLSX, columns)
+
+    // El archivo generado se puede leer con el importador
+    reader, err := importer.NewXLSXReader(bytes.NewReader(out), int64(len(out)), "")
+    assert.NoError(t, err)
+
+    header, err := reader.Read()
+    assert.NoError(t, err)
+    assert.Equal(t, []string{"ID", "Name", "Email", "Gender", "Expected salary", "Tags", "Created at", "Updated at"}, header)
+
+    row, err := reader.Read()
+    assert.NoError(t, err)
+    assert.Equal(t, "1", row[0])
+    assert.Equal(t, "Roy Smith", row[1])
+    assert.Equal(t, "30000.00", row[4])
+
+    _, err = reader.Read()
+    assert.NoError(t, err)
+    _, err = reader.Read()
+    assert.Equal(t, io.EOF, err)
+}
+
+func TestExport_TagsAndCustomFields(t *testing.T) {
+    custom := []domain.CustomField{
+        {Key: "years_experience", Label: "Años de experiencia", Type: domain.FieldNumber},
+        {Key: "stack", Label: "Stack", Type: domain.FieldMultiSelect, Options: []string{"go", "java"}},
+    }
+    rows := []domain.Candidate{
+        {ID: 1, Tags: []string{"backend", "referido"}, CustomFields: map[string]interface{}{"years_experience": 5.0, "stack": []string{"go", "java"}}},
+        {ID: 2},
+    }
+    columns, err := exporter.Columns([]string{"id", "tags", "cf.years_experience", "cf.stack"}, "es", custom...)
+    assert.NoError(t, err)
+
+    var buf bytes.Buffer
+    w, err := exporter.NewWriter(exporter.FormatCSV, &buf, columns, "")
+    assert.NoError(t, err)
+    for _, c := range rows {
+        assert.NoError(t, w.WriteRow(c))
+    }
+    assert.NoError(t, w.Close())
+    // Los campos vacíos se exportan en blanco
+    assert.Equal(t, "ID,Etiquetas,Años de experiencia,Stack\n1,\"backend, referido\",5,\"go, java\"\n2,,,\n", buf.String())
+
+    // Sin columnas se exportan todas, con los campos personalizados al final
+    all, err := exporter.Columns(nil, "en", custom...)
+    assert.NoError(t, err)
+    assert.Equal(t, "cf.stack", all[len(all)-1].Key)
+}
+
+func TestExportPDF(t *testing.T) {
+    columns, err := exporter.Columns([]string{"name", "email"}, "es")
+    assert.NoError(t, err)
+
+    out := export(t, exporter.FormatPDF, columns)
+    assert.True(t, bytes.HasPrefix(out, []byte("%PDF-1.4")))
+    assert.True(t, bytes.HasSuffix(out, []byte("%%EOF\n")))
+    assert.Contains(t, string(out), "/Count 1")
+}
+
+func TestColumns_Unknown(t *testing.T) {
+    _, err := exporter.Columns([]string{"name", "password"}, "es")
+    assert.Error(t, err)
+}
+
+func TestNegotiate(t *testing.T) {
+    assert.Equal(t, exporter.FormatCSV, exporter.Negotiate("", ""))
+    assert.Equal(t, exporter.FormatXLSX, exporter.Negotiate("XLSX", "application/pdf"))
+    assert.Equal(t, exporter.FormatPDF, exporter.Negotiate("", "application/pdf"))
+    assert.Equal(t, exporter.FormatNDJSON, exporter.Negotiate("", "text/html, application/x-ndjson"))
+    assert.Equal(t, "", exporter.Negotiate("", "text/html"))
+    assert.Equal(t, "", exporter.Negotiate("docx", ""))
+}
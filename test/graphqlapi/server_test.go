@@ -0,0 +1,475 @@
+package graphqlapi_test
+
+import (
+    "context"
+    "database/sql"
+    "encoding/base64"
+    "encoding/json"
+    "errors"
+    "net/http"
+    "net/http/httptest"
+    "net/url"
+    "sort"
+    "strings"
+    "testing"
+    "time"
+
+    "github.com/DATA-DOG/go-sqlmock"
+    "github.com/gin-gonic/gin"
+    "github.com/golang-jwt/jwt/v4"
+    "github.com/graphql-go/graphql"
+    "github.com/stretchr/testify/assert"
+    "github.com/stretchr/testify/require"
+
+    "github.com/torvictorvic/seek-v2/internal/domain"
+    "github.com/torvictorvic/seek-v2/internal/graphqlapi"
+    "github.com/torvictorvic/seek-v2/internal/handler"
+    "github.com/torvictorvic/seek-v2/internal/repository"
+    "github.com/torvictorvic/seek-v2/internal/security"
+    "github.com/torvictorvic/seek-v2/internal/service"
+)
+
+// fakeCandidateService guarda los candidatos en memoria y cuenta las lecturas en lote
+type fakeCandidateService struct {
+    service.CandidateService
+    candidates map[int]domain.Candidate
+    batches    [][]int
+    filters    []domain.CandidateFilter
+    updated    []domain.Candidate
+    deleted    []int
+}
+
+func (s *fakeCandidateService) CreateCandidate(c domain.Candidate) (int, error) {
+    if c.Name == "" || c.Email == "" {
+        return 0, service.ErrInvalidCandidate
+    }
+    c.ID = len(s.candidates) + 1
+    s.candidates[c.ID] = c
+    return c.ID, nil
+}
+func (s *fakeCandidateService) GetCandidateByID(id int) (*domain.Candidate, error) {
+    c, ok := s.candidates[id]
+    if !ok {
+        return nil, nil
+    }
+    return &c, nil
+}
+func (s *fakeCandidateService) GetCandidatesByIDs(ids []int) ([]domain.Candidate, error) {
+    s.batches = append(s.batches, ids)
+    var out []domain.Candidate
+    for _, id := range ids {
+        if c, ok := s.candidates[id]; ok {
+            out = append(out, c)
+        }
+    }
+    return out, nil
+}
+func (s *fakeCandidateService) GetAllCandidates(filter domain.CandidateFilter) ([]domain.Candidate, error) {
+    s.filters = append(s.filters, filter)
+    out := []domain.Candidate{}
+    for _, c := range s.candidates {
+        if filter.Matches(c) {
+            out = append(out, c)
+        }
+    }
+    sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+    return out, nil
+}
+func (s *fakeCandidateService) ListCandidatesPage(filter domain.CandidateFilter, afterID, limit int) ([]domain.Candidate, error) {
+    all, _ := s.GetAllCandidates(filter)
+    out := []domain.Candidate{}
+    for _, c := range all {
+        if c.ID > afterID && len(out) < limit {
+            out = append(out, c)
+        }
+    }
+    return out, nil
+}
+func (s *fakeCandidateService) CountCandidates(filter domain.CandidateFilter) (int, error) {
+    all, _ := s.GetAllCandidates(filter)
+    return len(all), nil
+}
+func (s *fakeCandidateService) ParseFieldFilters(raw map[string]string) ([]domain.FieldCondition, error) {
+    if len(raw) > 0 {
+        return nil, service.ErrUnknownCustomField
+    }
+    return nil, nil
+}
+func (s *fakeCandidateService) UpdateCandidate(c domain.Candidate) error {
+    s.updated = append(s.updated, c)
+    current := s.candidates[c.ID]
+    if c.Tags == nil {
+        c.Tags = current.Tags
+    }
+    s.candidates[c.ID] = c
+    return nil
+}
+func (s *fakeCandidateService) DeleteCandidate(id int) error {
+    s.deleted = append(s.deleted, id)
+    delete(s.candidates, id)
+    return nil
+}
+
+type fakeApplicationService struct {
+    service.ApplicationService
+    apps []domain.Application
+}
+
+func (s *fakeApplicationService) ListByCandidate(candidateID int) ([]domain.Application, error) {
+    var out []domain.Application
+    for _, a := range s.apps {
+        if a.CandidateID == candidateID {
+            out = append(out, a)
+        }
+    }
+    return out, nil
+}
+func (s *fakeApplicationService) ListByJob(jobID int) ([]domain.Application, error) {
+    var out []domain.Application
+    for _, a := range s.apps {
+        if a.JobID == jobID {
+            out = append(out, a)
+        }
+    }
+    return out, nil
+}
+
+type fakeJobService struct {
+    service.JobService
+    reads int
+}
+
+func (s *fakeJobService) GetJobByID(id int) (*domain.Job, error) {
+    s.reads++
+    return &domain.Job{ID: id, Title: "Backend Engineer"}, nil
+}
+
+type fakeNoteService struct {
+    service.NoteService
+    viewers []string
+}
+
+func (s *fakeNoteService) ListNotes(candidateID int, viewer string) ([]domain.Note, error) {
+    s.viewers = append(s.viewers, viewer)
+    return []domain.Note{{ID: 1, CandidateID: candidateID, Author: viewer, Body: "Buen perfil"}}, nil
+}
+
+type fixture struct {
+    candidates *fakeCandidateService
+    jobs       *fakeJobService
+    notes      *fakeNoteService
+    server     *graphqlapi.Server
+}
+
+func newFixture(t *testing.T, limits graphqlapi.Limits) *fixture {
+    f := &fixture{
+        candidates: &fakeCandidateService{candidates: map[int]domain.Candidate{
+            1: {ID: 1, Name: "Jane Doe", Email: "jane@example.com", SalaryExpected: 3000, Tags: []string{"backend"}},
+            2: {ID: 2, Name: "John Roe", Email: "john@example.com", SalaryExpected: 4500},
+            3: {ID: 3, Name: "Ana Díaz", Email: "ana@example.com", SalaryExpected: 5000, Tags: []string{"backend"}},
+        }},
+        jobs:  &fakeJobService{},
+        notes: &fakeNoteService{},
+    }
+    apps := &fakeApplicationService{apps: []domain.Application{
+        {ID: 10, CandidateID: 1, JobID: 7, Stage: "applied"},
+        {ID: 11, CandidateID: 2, JobID: 7, Stage: "screening"},
+        {ID: 12, CandidateID: 1, JobID: 8, Stage: "offer"},
+        {ID: 13, CandidateID: 3, JobID: 7, Stage: "applied"},
+    }}
+    server, err := graphqlapi.NewServer(graphqlapi.Services{
+        Candidates:   f.candidates,
+        Applications: apps,
+        Jobs:         f.jobs,
+        Notes:        f.notes,
+    }, limits)
+    require.NoError(t, err)
+    f.server = server
+    return f
+}
+
+// run ejecuta la consulta y devuelve los datos como JSON genérico
+func (f *fixture) run(t *testing.T, query string, variables map[string]interface{}) (map[string]interface{}, *graphql.Result) {
+    result := f.server.Execute(context.Background(), "ana", graphqlapi.Request{Query: query, Variables: variables})
+    raw, err := json.Marshal(result.Data)
+    require.NoError(t, err)
+    var data map[string]interface{}
+    require.NoError(t, json.Unmarshal(raw, &data))
+    return data, result
+}
+
+func TestGraphQL_CandidateWithRelatedData(t *testing.T) {
+    f := newFixture(t, graphqlapi.Limits{})
+
+    data, result := f.run(t, `{
+        candidate(id: 1) {
+            name tags salaryExpected
+            applications { stage job { title } }
+            notes { author body }
+        }
+        missing: candidate(id: 99) { name }
+    }`, nil)
+    require.Empty(t, result.Errors)
+
+    c := data["candidate"].(map[string]interface{})
+    assert.Equal(t, "Jane Doe", c["name"])
+    assert.Equal(t, []interface{}{"backend"}, c["tags"])
+    assert.Equal(t, 3000.0, c["salaryExpected"])
+    assert.Len(t, c["applications"], 2)
+    assert.Equal(t, "Backend Engineer", c["applications"].([]interface{})[0].(map[string]interface{})["job"].(map[string]interface{})["title"])
+    // Las notas se leen con el usuario del token
+    assert.Equal(t, []string{"ana"}, f.notes.viewers)
+    assert.Nil(t, data["missing"])
+}
+
+func TestGraphQL_BatchesCandidatesOfApplications(t *testing.T) {
+    f := newFixture(t, graphqlapi.Limits{})
+
+    data, result := f.run(t, `{ applications(jobId: 7) { id candidate { name } job { id } } }`, nil)
+    require.Empty(t, result.Errors)
+
+    apps := data["applications"].([]interface{})
+    require.Len(t, apps, 3)
+    assert.Equal(t, "Jane Doe", apps[0].(map[string]interface{})["candidate"].(map[string]interface{})["name"])
+    assert.Equal(t, "Ana Díaz", apps[2].(map[string]interface{})["candidate"].(map[string]interface{})["name"])
+
+    // Los tres candidatos se leen en una sola llamada y el puesto repetido una sola vez
+    require.Len(t, f.candidates.batches, 1)
+    assert.ElementsMatch(t, []int{1, 2, 3}, f.candidates.batches[0])
+    assert.Equal(t, 1, f.jobs.reads)
+}
+
+func TestGraphQL_CursorPagination(t *testing.T) {
+    f := newFixture(t, graphqlapi.Limits{})
+    query := `query($after: String) {
+        candidates(first: 2, after: $after, filter: {salaryMin: 3500}) {
+            totalCount
+            edges { cursor node { id } }
+            pageInfo { hasNextPage endCursor }
+        }
+    }`
+
+    data, result := f.run(t, query, nil)
+    require.Empty(t, result.Errors)
+    page := data["candidates"].(map[string]interface{})
+    assert.Equal(t, 2.0, page["totalCount"])
+    edges := page["edges"].([]interface{})
+    require.Len(t, edges, 2)
+    assert.Equal(t, 2.0, edges[0].(map[string]interface{})["node"].(map[string]interface{})["id"])
+    info := page["pageInfo"].(map[string]interface{})
+    assert.Equal(t, false, info["hasNextPage"])
+    require.NotNil(t, f.candidates.filters[0].SalaryMin)
+    assert.Equal(t, 3500.0, *f.candidates.filters[0].SalaryMin)
+
+    // Tras el último cursor la página está vacía
+    data, result = f.run(t, query, map[string]interface{}{"after": info["endCursor"]})
+    require.Empty(t, result.Errors)
+    page = data["candidates"].(map[string]interface{})
+    assert.Empty(t, page["edges"])
+    assert.Nil(t, page["pageInfo"].(map[string]interface{})["endCursor"])
+
+    // Con first: 1 hay página siguiente
+    data, _ = f.run(t, `{ candidates(first: 1) { pageInfo { hasNextPage } } }`, nil)
+    assert.Equal(t, true, data["candidates"].(map[string]interface{})["pageInfo"].(map[string]interface{})["hasNextPage"])
+
+    // El cursor es el ID del último candidato: la página siguiente no cambia aunque se
+    // creen o borren candidatos anteriores
+    data, result = f.run(t, `{ candidates(first: 1) { edges { cursor node { id } } } }`, nil)
+    require.Empty(t, result.Errors)
+    cursor := data["candidates"].(map[string]interface{})["edges"].([]interface{})[0].(map[string]interface{})["cursor"]
+    delete(f.candidates.candidates, 1)
+    data, result = f.run(t, query, map[string]interface{}{"after": cursor})
+    require.Empty(t, result.Errors)
+    edges = data["candidates"].(map[string]interface{})["edges"].([]interface{})
+    require.NotEmpty(t, edges)
+    assert.Equal(t, 2.0, edges[0].(map[string]interface{})["node"].(map[string]interface{})["id"])
+
+    // Los cursores que no son un ID válido se rechazan
+    for _, after := range []string{"%%%", base64.RawURLEncoding.EncodeToString([]byte("9223372036854775807")),
+        base64.RawURLEncoding.EncodeToString([]byte("-1"))} {
+        _, result = f.run(t, `query($after: String) { candidates(after: $after) { totalCount } }`, map[string]interface{}{"after": after})
+        require.NotEmpty(t, result.Errors)
+        assert.Contains(t, result.Errors[0].Message, graphqlapi.ErrInvalidCursor.Error())
+    }
+
+    _, result = f.run(t, `{ candidates(first: 500) { totalCount } }`, nil)
+    require.NotEmpty(t, result.Errors)
+    assert.Contains(t, result.Errors[0].Message, graphqlapi.ErrInvalidPageSize.Error())
+
+    _, result = f.run(t, `{ candidates(filter: {fields: [{key: "years", value: "3"}]}) { totalCount } }`, nil)
+    require.NotEmpty(t, result.Errors)
+    assert.Contains(t, result.Errors[0].Message, service.ErrUnknownCustomField.Error())
+}
+
+func TestGraphQL_Mutations(t *testing.T) {
+    f := newFixture(t, graphqlapi.Limits{})
+
+    data, result := f.run(t, `mutation { createCandidate(input: {name: "Eva", email: "eva@example.com", salaryExpected: 2800}) { id name } }`, nil)
+    require.Empty(t, result.Errors)
+    assert.Equal(t, 4.0, data["createCandidate"].(map[string]interface{})["id"])
+
+    _, result = f.run(t, `mutation { createCandidate(input: {name: "Sin correo"}) { id } }`, nil)
+    require.NotEmpty(t, result.Errors)
+    assert.Contains(t, result.Errors[0].Message, service.ErrInvalidCandidate.Error())
+
+    // Los campos omitidos no cambian, y las etiquetas no se envían al servicio
+    data, result = f.run(t, `mutation($id: Int!) { updateCandidate(id: $id, input: {salaryExpected: 3200}) { name salaryExpected tags } }`,
+        map[string]interface{}{"id": 1})
+    require.Empty(t, result.Errors)
+    updated := data["updateCandidate"].(map[string]interface{})
+    assert.Equal(t, "Jane Doe", updated["name"])
+    assert.Equal(t, 3200.0, updated["salaryExpected"])
+    assert.Equal(t, []interface{}{"backend"}, updated["tags"])
+    require.Len(t, f.candidates.updated, 1)
+    assert.Nil(t, f.candidates.updated[0].Tags)
+
+    _, result = f.run(t, `mutation { updateCandidate(id: 99, input: {name: "X"}) { id } }`, nil)
+    require.NotEmpty(t, result.Errors)
+    assert.Contains(t, result.Errors[0].Message, service.ErrCandidateNotFound.Error())
+
+    data, result = f.run(t, `mutation { deleteCandidate(id: 2) }`, nil)
+    require.Empty(t, result.Errors)
+    assert.Equal(t, true, data["deleteCandidate"])
+    assert.Equal(t, []int{2}, f.candidates.deleted)
+}
+
+func TestGraphQL_Limits(t *testing.T) {
+    f := newFixture(t, graphqlapi.Limits{MaxDepth: 5, MaxComplexity: 200})
+
+    // candidate > applications > candidate > applications > job > id son 6 niveles
+    _, result := f.run(t, `{ candidate(id: 1) { applications { candidate { applications { job { id } } } } } }`, nil)
+    require.NotEmpty(t, result.Errors)
+    assert.Contains(t, result.Errors[0].Message, graphqlapi.ErrQueryTooDeep.Error())
+
+    // Los fragmentos cuentan igual que los campos
+    _, result = f.run(t, `
+        fragment deep on Candidate { applications { candidate { applications { job { id } } } } }
+        { candidate(id: 1) { ...deep } }`, nil)
+    require.NotEmpty(t, result.Errors)
+    assert.Contains(t, result.Errors[0].Message, graphqlapi.ErrQueryTooDeep.Error())
+
+    // 100 candidatos con sus postulaciones superan la complejidad
+    _, result = f.run(t, `query($n: Int) { candidates(first: $n) { edges { node { applications { stage } } } } }`,
+        map[string]interface{}{"n": 100})
+    require.NotEmpty(t, result.Errors)
+    assert.Contains(t, result.Errors[0].Message, graphqlapi.ErrQueryTooComplex.Error())
+
+    _, result = f.run(t, `{ candidates(first: 5) { edges { node { applications { stage } } } } }`, nil)
+    assert.Empty(t, result.Errors)
+
+    // La introspección no cuenta para los límites
+    _, result = f.run(t, `{ __schema { types { name fields { name type { name ofType { name ofType { name } } } } } } }`, nil)
+    assert.Empty(t, result.Errors)
+}
+
+func TestGraphQL_Loader(t *testing.T) {
+    calls := 0
+    loader := graphqlapi.NewLoader(func(keys []int) (map[int]string, error) {
+        calls++
+        if keys[0] == 0 {
+            return nil, errors.New("boom")
+        }
+        out := map[int]string{}
+        for _, k := range keys {
+            out[k] = strings.Repeat("x", k)
+        }
+        return out, nil
+    })
+
+    a, b, again := loader.Load(1), loader.Load(2), loader.Load(1)
+    v, err := b()
+    assert.NoError(t, err)
+    assert.Equal(t, "xx", v)
+    v, _ = a()
+    assert.Equal(t, "x", v)
+    v, _ = again()
+    assert.Equal(t, "x", v)
+    assert.Equal(t, 1, calls)
+
+    // Las claves ya leídas salen de la caché
+    v, _ = loader.Load(2)()
+    assert.Equal(t, "xx", v)
+    assert.Equal(t, 1, calls)
+
+    _, err = loader.Load(0)()
+    assert.EqualError(t, err, "boom")
+}
+
+func TestGraphQL_HTTPHandler(t *testing.T) {
+    gin.SetMode(gin.TestMode)
+    t.Setenv("JWT_SECRET", "test-secret")
+    f := newFixture(t, graphqlapi.Limits{})
+    h := handler.NewGraphQLHandler(f.server)
+    r := gin.New()
+    r.POST("/graphql", security.AuthMiddleware(), h.Serve)
+    r.GET("/graphql", security.AuthMiddleware(), h.Serve)
+
+    token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"user": "ana"}).SignedString([]byte("test-secret"))
+    require.NoError(t, err)
+    do := func(req *http.Request, withToken bool) *httptest.ResponseRecorder {
+        if withToken {
+            req.Header.Set("Authorization", "Bearer "+token)
+        }
+        w := httptest.NewRecorder()
+        r.ServeHTTP(w, req)
+        return w
+    }
+
+    // Sin token no se ejecuta la consulta
+    w := do(httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(`{"query": "{ candidate(id: 1) { name } }"}`)), false)
+    assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+    w = do(httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(`{"query": "query($id: Int!) { candidate(id: $id) { name notes { author } } }", "variables": {"id": 1}}`)), true)
+    assert.Equal(t, http.StatusOK, w.Code)
+    assert.JSONEq(t, `{"data": {"candidate": {"name": "Jane Doe", "notes": [{"author": "ana"}]}}}`, w.Body.String())
+
+    w = do(httptest.NewRequest(http.MethodGet, `/graphql?query=%7Bcandidate(id:2)%7Bname%7D%7D`, nil), true)
+    assert.Equal(t, http.StatusOK, w.Code)
+    assert.JSONEq(t, `{"data": {"candidate": {"name": "John Roe"}}}`, w.Body.String())
+
+    // Los errores de la consulta van en "errors" con estado 200
+    w = do(httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(`{"query": "{ nope }"}`)), true)
+    assert.Equal(t, http.StatusOK, w.Code)
+    assert.Contains(t, w.Body.String(), `"errors"`)
+
+    w = do(httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(`{`)), true)
+    assert.Equal(t, http.StatusBadRequest, w.Code)
+
+    // Por GET solo se ejecutan consultas: las mutaciones necesitan POST
+    both := url.QueryEscape(`query Get { candidate(id: 2) { name } } mutation Delete { deleteCandidate(id: 2) }`)
+    for _, path := range []string{
+        "/graphql?query=" + url.QueryEscape(`mutation { deleteCandidate(id: 2) }`),
+        "/graphql?operationName=Delete&query=" + both,
+    } {
+        w = do(httptest.NewRequest(http.MethodGet, path, nil), true)
+        assert.Equal(t, http.StatusMethodNotAllowed, w.Code, path)
+        assert.Equal(t, http.MethodPost, w.Header().Get("Allow"))
+    }
+    assert.Empty(t, f.candidates.deleted)
+    w = do(httptest.NewRequest(http.MethodGet, "/graphql?operationName=Get&query="+both, nil), true)
+    assert.Equal(t, http.StatusOK, w.Code)
+    assert.JSONEq(t, `{"data": {"candidate": {"name": "John Roe"}}}`, w.Body.String())
+}
+
+func TestGraphQL_ReadsFromReplicas(t *testing.T) {
+    primary, primaryMock, err := sqlmock.New()
+    require.NoError(t, err)
+    defer primary.Close()
+    replica, replicaMock, err := sqlmock.New()
+    require.NoError(t, err)
+    defer replica.Close()
+    router := repository.NewReplicaRouter(primary, []*sql.DB{replica})
+    repo := repository.NewCandidateRepositoryFor(primary, repository.MySQL, repository.WithReplicas(router))
+    server, err := graphqlapi.NewServer(graphqlapi.Services{Candidates: service.NewCandidateService(repo)}, graphqlapi.Limits{})
+    require.NoError(t, err)
+
+    // Las lecturas de la consulta van a la réplica, como las de la API REST
+    replicaMock.ExpectQuery("SELECT id, name, email").WillReturnRows(
+        sqlmock.NewRows([]string{"id", "name", "email", "gender", "salary_expected", "created_at", "updated_at"}).
+            AddRow(1, "Jane Doe", "jane@example.com", "F", "3000", time.Now(), time.Now()))
+    result := server.Execute(context.Background(), "ana", graphqlapi.Request{Query: `{ candidate(id: 1) { name } }`})
+    require.Empty(t, result.Errors)
+    assert.Equal(t, map[string]interface{}{"candidate": map[string]interface{}{"name": "Jane Doe"}}, result.Data)
+    assert.NoError(t, replicaMock.ExpectationsWereMet())
+    assert.NoError(t, primaryMock.ExpectationsWereMet())
+}
@@ -0,0 +1,294 @@
+package grpcapi_test
+
+import (
+    "context"
+    "database/sql"
+    "encoding/base64"
+    "encoding/json"
+    "net"
+    "sort"
+    "testing"
+    "time"
+
+    "github.com/DATA-DOG/go-sqlmock"
+    "github.com/golang-jwt/jwt/v4"
+    "github.com/stretchr/testify/assert"
+    "github.com/stretchr/testify/require"
+    "google.golang.org/grpc"
+    "google.golang.org/grpc/codes"
+    "google.golang.org/grpc/credentials/insecure"
+    healthpb "google.golang.org/grpc/health/grpc_health_v1"
+    "google.golang.org/grpc/metadata"
+    "google.golang.org/grpc/status"
+    "google.golang.org/grpc/test/bufconn"
+    "google.golang.org/protobuf/types/known/fieldmaskpb"
+
+    "github.com/torvictorvic/seek-v2/internal/domain"
+    "github.com/torvictorvic/seek-v2/internal/events"
+    "github.com/torvictorvic/seek-v2/internal/grpcapi"
+    "github.com/torvictorvic/seek-v2/internal/grpcapi/seekpb"
+    "github.com/torvictorvic/seek-v2/internal/repository"
+    "github.com/torvictorvic/seek-v2/internal/service"
+)
+
+// fakeCandidateService guarda los candidatos en memoria; los métodos que no usa el
+// servidor gRPC quedan sin implementar
+type fakeCandidateService struct {
+    service.CandidateService
+    candidates map[int]domain.Candidate
+    updated    []domain.Candidate
+}
+
+func (s *fakeCandidateService) CreateCandidate(c domain.Candidate) (int, error) {
+    if c.Name == "" || c.Email == "" {
+        return 0, service.ErrInvalidCandidate
+    }
+    c.ID = len(s.candidates) + 1
+    s.candidates[c.ID] = c
+    return c.ID, nil
+}
+func (s *fakeCandidateService) GetCandidateByID(id int) (*domain.Candidate, error) {
+    c, ok := s.candidates[id]
+    if !ok {
+        return nil, nil
+    }
+    return &c, nil
+}
+func (s *fakeCandidateService) GetAllCandidates(filter domain.CandidateFilter) ([]domain.Candidate, error) {
+    out := []domain.Candidate{}
+    for _, c := range s.candidates {
+        if filter.Matches(c) {
+            out = append(out, c)
+        }
+    }
+    sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+    return out, nil
+}
+func (s *fakeCandidateService) ListCandidatesPage(filter domain.CandidateFilter, afterID, limit int) ([]domain.Candidate, error) {
+    all, _ := s.GetAllCandidates(filter)
+    out := []domain.Candidate{}
+    for _, c := range all {
+        if c.ID > afterID && len(out) < limit {
+            out = append(out, c)
+        }
+    }
+    return out, nil
+}
+func (s *fakeCandidateService) ParseFieldFilters(raw map[string]string) ([]domain.FieldCondition, error) {
+    if len(raw) > 0 {
+        return nil, service.ErrUnknownCustomField
+    }
+    return nil, nil
+}
+func (s *fakeCandidateService) UpdateCandidate(c domain.Candidate) error {
+    s.updated = append(s.updated, c)
+    current := s.candidates[c.ID]
+    if c.Tags == nil {
+        c.Tags = current.Tags
+    }
+    s.candidates[c.ID] = c
+    return nil
+}
+
+const secret = "test-secret"
+
+func startServer(t *testing.T, svc service.CandidateService, hub *events.Hub) *grpc.ClientConn {
+    t.Setenv("JWT_SECRET", secret)
+    lis := bufconn.Listen(1 << 20)
+    server := grpcapi.NewServer(svc, hub, true)
+    go server.Serve(lis)
+    t.Cleanup(server.Stop)
+
+    conn, err := grpc.NewClient("passthrough:///bufnet",
+        grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+        grpc.WithTransportCredentials(insecure.NewCredentials()))
+    require.NoError(t, err)
+    t.Cleanup(func() { conn.Close() })
+    return conn
+}
+
+func authContext(t *testing.T) context.Context {
+    token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"user": "maria"}).SignedString([]byte(secret))
+    require.NoError(t, err)
+    ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+    t.Cleanup(cancel)
+    return metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+token)
+}
+
+func TestGRPC_Authentication(t *testing.T) {
+    svc := &fakeCandidateService{candidates: map[int]domain.Candidate{}}
+    conn := startServer(t, svc, events.NewHub(10, 10))
+    client := seekpb.NewCandidateServiceClient(conn)
+
+    _, err := client.GetCandidate(context.Background(), &seekpb.GetCandidateRequest{Id: 1})
+    assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+    ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer invalid")
+    _, err = client.GetCandidate(ctx, &seekpb.GetCandidateRequest{Id: 1})
+    assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+    // El health check no necesita token
+    resp, err := healthpb.NewHealthClient(conn).Check(context.Background(), &healthpb.HealthCheckRequest{Service: "seek.v1.CandidateService"})
+    assert.NoError(t, err)
+    assert.Equal(t, healthpb.HealthCheckResponse_SERVING, resp.Status)
+}
+
+func TestGRPC_CreateGetAndErrors(t *testing.T) {
+    svc := &fakeCandidateService{candidates: map[int]domain.Candidate{}}
+    client := seekpb.NewCandidateServiceClient(startServer(t, svc, events.NewHub(10, 10)))
+    ctx := authContext(t)
+
+    created, err := client.CreateCandidate(ctx, &seekpb.CreateCandidateRequest{Candidate: &seekpb.Candidate{
+        Name: "Jane Doe", Email: "jane@example.com", Tags: []string{"backend"},
+        Contact: &seekpb.ContactDetails{Country: "PE"},
+    }})
+    require.NoError(t, err)
+    assert.Equal(t, int64(1), created.Id)
+    assert.Equal(t, []string{"backend"}, created.Tags)
+    assert.Equal(t, "PE", created.Contact.Country)
+
+    // Los códigos equivalen a los estados HTTP de la API REST
+    _, err = client.CreateCandidate(ctx, &seekpb.CreateCandidateRequest{Candidate: &seekpb.Candidate{Name: "Jane"}})
+    assert.Equal(t, codes.InvalidArgument, status.Code(err))
+    _, err = client.GetCandidate(ctx, &seekpb.GetCandidateRequest{Id: 99})
+    assert.Equal(t, codes.NotFound, status.Code(err))
+    _, err = client.ListCandidates(ctx, &seekpb.ListCandidatesRequest{CustomFields: map[string]string{"shoe_size": "42"}})
+    assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestGRPC_ListPagination(t *testing.T) {
+    svc := &fakeCandidateService{candidates: map[int]domain.Candidate{}}
+    for _, name := range []string{"Ana", "Bruno", "Carla"} {
+        svc.CreateCandidate(domain.Candidate{Name: name, Email: name + "@example.com", Gender: "female"})
+    }
+    client := seekpb.NewCandidateServiceClient(startServer(t, svc, events.NewHub(10, 10)))
+    ctx := authContext(t)
+
+    page, err := client.ListCandidates(ctx, &seekpb.ListCandidatesRequest{PageSize: 2})
+    require.NoError(t, err)
+    assert.Len(t, page.Candidates, 2)
+    assert.NotEmpty(t, page.NextPageToken)
+
+    // Borrar un candidato de la primera página no hace que la siguiente se salte otro
+    delete(svc.candidates, 1)
+    page, err = client.ListCandidates(ctx, &seekpb.ListCandidatesRequest{PageSize: 2, PageToken: page.NextPageToken})
+    require.NoError(t, err)
+    assert.Len(t, page.Candidates, 1)
+    assert.Equal(t, "Carla", page.Candidates[0].Name)
+    assert.Empty(t, page.NextPageToken)
+
+    for _, token := range []string{"%%%", base64.RawURLEncoding.EncodeToString([]byte("9223372036854775807"))} {
+        _, err = client.ListCandidates(ctx, &seekpb.ListCandidatesRequest{PageToken: token})
+        assert.Equal(t, codes.InvalidArgument, status.Code(err))
+    }
+}
+
+func TestGRPC_UpdateWithMask(t *testing.T) {
+    svc := &fakeCandidateService{candidates: map[int]domain.Candidate{
+        1: {ID: 1, Name: "Jane", Email: "jane@example.com", Gender: "female", Tags: []string{"backend"}},
+    }}
+    client := seekpb.NewCandidateServiceClient(startServer(t, svc, events.NewHub(10, 10)))
+    ctx := authContext(t)
+
+    // Solo cambia el nombre: el resto de campos se conserva y las etiquetas no se tocan
+    updated, err := client.UpdateCandidate(ctx, &seekpb.UpdateCandidateRequest{
+        Candidate:  &seekpb.Candidate{Id: 1, Name: "Jane Doe"},
+        UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"name"}},
+    })
+    require.NoError(t, err)
+    assert.Equal(t, "Jane Doe", updated.Name)
+    assert.Equal(t, "jane@example.com", updated.Email)
+    assert.Equal(t, []string{"backend"}, updated.Tags)
+    assert.Nil(t, svc.updated[0].Tags)
+
+    // Con la máscara se pueden vaciar las etiquetas
+    _, err = client.UpdateCandidate(ctx, &seekpb.UpdateCandidateRequest{
+        Candidate:  &seekpb.Candidate{Id: 1},
+        UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"tags"}},
+    })
+    require.NoError(t, err)
+    assert.Equal(t, []string{}, svc.updated[1].Tags)
+
+    _, err = client.UpdateCandidate(ctx, &seekpb.UpdateCandidateRequest{
+        Candidate:  &seekpb.Candidate{Id: 1},
+        UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"created_at"}},
+    })
+    assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+    _, err = client.UpdateCandidate(ctx, &seekpb.UpdateCandidateRequest{Candidate: &seekpb.Candidate{Id: 9, Name: "X", Email: "x@example.com"}})
+    assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestGRPC_UpdateWithoutMaskValidates(t *testing.T) {
+    svc := service.NewCandidateService(repository.NewMemoryCandidateRepository())
+    id, err := svc.CreateCandidate(domain.Candidate{Name: "Jane", Email: "jane@example.com"})
+    require.NoError(t, err)
+    client := seekpb.NewCandidateServiceClient(startServer(t, svc, events.NewHub(10, 10)))
+
+    // Sin máscara se reemplaza el candidato entero, y sin nombre ni email se rechaza
+    _, err = client.UpdateCandidate(authContext(t), &seekpb.UpdateCandidateRequest{Candidate: &seekpb.Candidate{Id: int64(id)}})
+    assert.Equal(t, codes.InvalidArgument, status.Code(err))
+    current, err := svc.GetCandidateByID(id)
+    require.NoError(t, err)
+    assert.Equal(t, "Jane", current.Name)
+    assert.Equal(t, "jane@example.com", current.Email)
+}
+
+func TestGRPC_Watch(t *testing.T) {
+    hub := events.NewHub(10, 10)
+    payload, _ := json.Marshal(domain.CandidateEventPayload{ID: 4, Name: "Jane"})
+    hub.Publish(context.Background(), domain.OutboxEvent{ID: 1, Type: domain.EventCandidateCreated, AggregateID: 4, Payload: payload})
+
+    svc := &fakeCandidateService{candidates: map[int]domain.Candidate{}}
+    client := seekpb.NewCandidateServiceClient(startServer(t, svc, hub))
+    ctx := authContext(t)
+
+    // Reanuda desde el evento 0 y recibe los nuevos del tipo pedido
+    stream, err := client.WatchCandidates(ctx, &seekpb.WatchCandidatesRequest{Types: []string{domain.EventCandidateCreated}})
+    require.NoError(t, err)
+    // Las cabeceras llegan cuando la suscripción está activa
+    _, err = stream.Header()
+    require.NoError(t, err)
+    hub.Publish(context.Background(), domain.OutboxEvent{ID: 2, Type: domain.EventCandidateDeleted, AggregateID: 4, Payload: json.RawMessage(`{"id":4}`)})
+    hub.Publish(context.Background(), domain.OutboxEvent{ID: 3, Type: domain.EventCandidateCreated, AggregateID: 5, Payload: json.RawMessage(`{"id":5,"name":"Ana"}`)})
+
+    event, err := stream.Recv()
+    require.NoError(t, err)
+    assert.Equal(t, int64(3), event.Id)
+    assert.Equal(t, "Ana", event.Candidate.Name)
+
+    // Un cliente que reconecta con after_id recibe lo que se perdió
+    stream, err = client.WatchCandidates(ctx, &seekpb.WatchCandidatesRequest{AfterId: 1})
+    require.NoError(t, err)
+    event, err = stream.Recv()
+    require.NoError(t, err)
+    assert.Equal(t, domain.EventCandidateDeleted, event.Type)
+    assert.Equal(t, int64(4), event.CandidateId)
+
+    stream, err = client.WatchCandidates(ctx, &seekpb.WatchCandidatesRequest{Types: []string{"candidate.viewed"}})
+    require.NoError(t, err)
+    _, err = stream.Recv()
+    assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestGRPC_ReadsFromReplicas(t *testing.T) {
+    primary, primaryMock, err := sqlmock.New()
+    require.NoError(t, err)
+    defer primary.Close()
+    replica, replicaMock, err := sqlmock.New()
+    require.NoError(t, err)
+    defer replica.Close()
+    router := repository.NewReplicaRouter(primary, []*sql.DB{replica})
+    repo := repository.NewCandidateRepositoryFor(primary, repository.MySQL, repository.WithReplicas(router))
+    client := seekpb.NewCandidateServiceClient(startServer(t, service.NewCandidateService(repo), events.NewHub(10, 10)))
+
+    // Las lecturas de la llamada van a la réplica, como las de la API REST
+    replicaMock.ExpectQuery("SELECT id, name, email").WillReturnRows(
+        sqlmock.NewRows([]string{"id", "name", "email", "gender", "salary_expected", "created_at", "updated_at"}).
+            AddRow(1, "Jane Doe", "jane@example.com", "F", "3000", time.Now(), time.Now()))
+    got, err := client.GetCandidate(authContext(t), &seekpb.GetCandidateRequest{Id: 1})
+    require.NoError(t, err)
+    assert.Equal(t, "Jane Doe", got.Name)
+    assert.NoError(t, replicaMock.ExpectationsWereMet())
+    assert.NoError(t, primaryMock.ExpectationsWereMet())
+}
@@ -0,0 +1,64 @@
+package ical_test
+
+import (
+    "bytes"
+    "strings"
+    "testing"
+    "time"
+
+    "github.com/stretchr/testify/assert"
+
+    "github.com/torvictorvic/seek-v2/internal/ical"
+)
+
+func TestWrite(t *testing.T) {
+    lima := time.FixedZone("PET", -5*3600)
+    cal := ical.Calendar{
+        Method: ical.MethodRequest,
+        Events: []ical.Event{{
+            UID:         "interview-7@seek-v2",
+            Sequence:    2,
+            Start:       time.Date(2026, 10, 19, 10, 0, 0, 0, lima),
+            End:         time.Date(2026, 10, 19, 11, 0, 0, 0, lima),
+            Stamp:       time.Date(2026, 10, 1, 8, 0, 0, 0, time.UTC),
+            Summary:     "Interview (video): Walker, Anna; round 2",
+            Description: "Interviewers: maria\nTime zone: America/Lima",
+            Attendees:   []ical.Attendee{{Name: "Anna Walker", Email: "anna@example.com"}, {Name: "sin email"}},
+        }},
+    }
+
+    var buf bytes.Buffer
+    assert.NoError(t, ical.Write(&buf, cal))
+    out := buf.String()
+
+    // Líneas terminadas en CRLF y horas en UTC
+    assert.True(t, strings.HasPrefix(out, "BEGIN:VCALENDAR\r\nVERSION:2.0\r\n"))
+    assert.True(t, strings.HasSuffix(out, "END:VCALENDAR\r\n"))
+    assert.Contains(t, out, "METHOD:REQUEST\r\n")
+    assert.Contains(t, out, "DTSTART:20261019T150000Z\r\n")
+    assert.Contains(t, out, "DTEND:20261019T160000Z\r\n")
+    assert.Contains(t, out, "SEQUENCE:2\r\n")
+    // Texto escapado
+    assert.Contains(t, out, `SUMMARY:Interview (video): Walker\, Anna\; round 2`)
+    assert.Contains(t, out, `DESCRIPTION:Interviewers: maria\nTime zone: America/Lima`)
+    // Solo los asistentes con email
+    assert.Equal(t, 1, strings.Count(out, "ATTENDEE"))
+    assert.Contains(t, strings.ReplaceAll(out, "\r\n ", ""), "CN=Anna Walker:mailto:anna@example.com")
+}
+
+func TestWrite_FoldsLongLines(t *testing.T) {
+    cal := ical.Calendar{Events: []ical.Event{{
+        UID:     "x",
+        Summary: strings.Repeat("entrevista técnica ", 20),
+    }}}
+
+    var buf bytes.Buffer
+    assert.NoError(t, ical.Write(&buf, cal))
+
+    for _, line := range strings.Split(strings.TrimSuffix(buf.String(), "\r\n"), "\r\n") {
+        assert.LessOrEqual(t, len(line), 75)
+    }
+    // Al desplegar las líneas se recupera el texto original
+    unfolded := strings.ReplaceAll(buf.String(), "\r\n ", "")
+    assert.Contains(t, unfolded, "SUMMARY:"+strings.Repeat("entrevista técnica ", 20))
+}
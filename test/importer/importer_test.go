@@ -0,0 +1,109 @@
+package importer_test
+
+import (
+    "archive/zip"
+    "bytes"
+    "io"
+    "regexp"
+    "strings"
+    "testing"
+    "time"
+
+    "github.com/DATA-DOG/go-sqlmock"
+    "github.com/stretchr/testify/assert"
+
+    "github.com/torvictorvic/seek-v2/internal/importer"
+    "github.com/torvictorvic/seek-v2/internal/repository"
+    "github.com/torvictorvic/seek-v2/internal/service"
+)
+
+var selectByEmail = regexp.QuoteMeta("SELECT id, name, email, gender, salary_expected, created_at, updated_at FROM candidates WHERE email = ?")
+
+var candidateColumns = []string{"id", "name", "email", "gender", "salary_expected", "created_at", "updated_at"}
+
+func TestImportCSV_DryRun(t *testing.T) {
+    db, mock, err := sqlmock.New()
+    assert.NoError(t, err)
+    defer db.Close()
+
+    imp := importer.NewImporter(service.NewCandidateService(repository.NewCandidateRepository(db)))
+
+    csv := "Nombre;Correo;Salario\n" +
+        "Ana Walker;ana@example.com;32000\n" +
+        ";sin.nombre@example.com;1000\n" +
+        "Roy Smith;roy.smith@example.com;abc\n" +
+        "\n" +
+        "Roy Smith;roy.smith@example.com;30000\n"
+
+    // Solo se consulta por email, en dry-run nunca se escribe
+    mock.ExpectQuery(selectByEmail).WithArgs("ana@example.com").WillReturnRows(sqlmock.NewRows(candidateColumns))
+    mock.ExpectQuery(selectByEmail).WithArgs("roy.smith@example.com").
+        WillReturnRows(sqlmock.NewRows(candidateColumns).AddRow(1, "Roy Smith", "roy.smith@example.com", "male", 30000.0, time.Now(), time.Now()))
+
+    report, err := imp.Import(importer.NewCSVReader(strings.NewReader(csv), ';'), importer.Options{
+        DryRun:  true,
+        Mapping: importer.Mapping{"name": "nombre", "email": "correo", "salary_expected": "salario"},
+    })
+    assert.NoError(t, err)
+    assert.Equal(t, 4, report.Rows)
+    assert.Equal(t, 1, report.Created)
+    assert.Equal(t, 3, report.Failed)
+
+    // Los números de fila corresponden a la hoja (la cabecera es la fila 1)
+    assert.Equal(t, 3, report.Errors[0].Row)
+    assert.Contains(t, report.Errors[0].Error, "required")
+    assert.Equal(t, 4, report.Errors[1].Row)
+    assert.Contains(t, report.Errors[1].Error, "salary_expected")
+    assert.Equal(t, 6, report.Errors[2].Row)
+    assert.Contains(t, report.Errors[2].Error, "already exists")
+
+    assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestImportCSV_UnknownColumn(t *testing.T) {
+    imp := importer.NewImporter(service.NewCandidateService(nil))
+
+    _, err := imp.Import(importer.NewCSVReader(strings.NewReader("name,mail\n"), 0), importer.Options{})
+    assert.ErrorIs(t, err, importer.ErrUnknownColumn)
+}
+
+func TestXLSXReader(t *testing.T) {
+    data := buildXLSX(t, map[string]string{
+        "xl/workbook.xml": `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+            <sheets><sheet name="Candidatos" sheetId="1" r:id="rId1"/></sheets></workbook>`,
+        "xl/_rels/workbook.xml.rels": `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+            <Relationship Id="rId1" Type="worksheet" Target="worksheets/sheet1.xml"/></Relationships>`,
+        "xl/sharedStrings.xml": `<sst><si><t>name</t></si><si><t>email</t></si><si><r><t>Anna </t></r><r><t>Walker</t></r></si></sst>`,
+        "xl/worksheets/sheet1.xml": `<worksheet><sheetData>
+            <row r="1"><c r="A1" t="s"><v>0</v></c><c r="C1" t="s"><v>1</v></c></row>
+            <row r="2"><c r="A2" t="s"><v>2</v></c><c r="C2" t="inlineStr"><is><t>anna@example.com</t></is></c><c r="D2"><v>32000</v></c></row>
+        </sheetData></worksheet>`,
+    })
+
+    reader, err := importer.NewXLSXReader(bytes.NewReader(data), int64(len(data)), "candidatos")
+    assert.NoError(t, err)
+
+    header, err := reader.Read()
+    assert.NoError(t, err)
+    assert.Equal(t, []string{"name", "", "email"}, header)
+
+    row, err := reader.Read()
+    assert.NoError(t, err)
+    assert.Equal(t, []string{"Anna Walker", "", "anna@example.com", "32000"}, row)
+
+    _, err = reader.Read()
+    assert.Equal(t, io.EOF, err)
+}
+
+func buildXLSX(t *testing.T, files map[string]string) []byte {
+    var buf bytes.Buffer
+    zw := zip.NewWriter(&buf)
+    for name, content := range files {
+        w, err := zw.Create(name)
+        assert.NoError(t, err)
+        _, err = w.Write([]byte(content))
+        assert.NoError(t, err)
+    }
+    assert.NoError(t, zw.Close())
+    return buf.Bytes()
+}
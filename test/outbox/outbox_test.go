@@ -0,0 +1,205 @@
+package outbox_test
+
+import (
+    "context"
+    "encoding/json"
+    "errors"
+    "net/http"
+    "net/http/httptest"
+    "testing"
+    "time"
+
+    "github.com/stretchr/testify/assert"
+
+    "github.com/torvictorvic/seek-v2/internal/domain"
+    "github.com/torvictorvic/seek-v2/internal/events"
+    "github.com/torvictorvic/seek-v2/internal/outbox"
+)
+
+// fakeOutboxRepo guarda los eventos en memoria
+type fakeOutboxRepo struct {
+    events    []domain.OutboxEvent
+    published map[int64]bool
+    failures  map[int64]string
+    purged    time.Time
+}
+
+func newFakeOutboxRepo(events ...domain.OutboxEvent) *fakeOutboxRepo {
+    return &fakeOutboxRepo{events: events, published: map[int64]bool{}, failures: map[int64]string{}}
+}
+
+func (r *fakeOutboxRepo) Pending(limit int) ([]domain.OutboxEvent, error) {
+    pending := []domain.OutboxEvent{}
+    for _, e := range r.events {
+        if !r.published[e.ID] && len(pending) < limit {
+            pending = append(pending, e)
+        }
+    }
+    return pending, nil
+}
+func (r *fakeOutboxRepo) MarkPublished(ids []int64) error {
+    for _, id := range ids {
+        r.published[id] = true
+    }
+    return nil
+}
+func (r *fakeOutboxRepo) MarkFailed(id int64, reason string) error {
+    r.failures[id] = reason
+    return nil
+}
+func (r *fakeOutboxRepo) Lag() (int, *time.Time, error) {
+    pending, _ := r.Pending(len(r.events))
+    if len(pending) == 0 {
+        return 0, nil, nil
+    }
+    return len(pending), &pending[0].CreatedAt, nil
+}
+func (r *fakeOutboxRepo) PurgePublished(before time.Time) (int64, error) {
+    r.purged = before
+    return 0, nil
+}
+
+func (r *fakeOutboxRepo) After(id int64, limit int) ([]domain.OutboxEvent, error) {
+    after := []domain.OutboxEvent{}
+    for _, e := range r.events {
+        if e.ID > id && len(after) < limit {
+            after = append(after, e)
+        }
+    }
+    return after, nil
+}
+func (r *fakeOutboxRepo) LastID() (int64, error) {
+    if len(r.events) == 0 {
+        return 0, nil
+    }
+    return r.events[len(r.events)-1].ID, nil
+}
+
+var t0 = time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC)
+
+func event(id int64, eventType string) domain.OutboxEvent {
+    return domain.OutboxEvent{
+        ID:             id,
+        Type:           eventType,
+        AggregateID:    int(id),
+        IdempotencyKey: "key-" + string(rune('a'+id)),
+        Payload:        json.RawMessage(`{"id":1}`),
+        CreatedAt:      t0.Add(time.Duration(id) * time.Second),
+    }
+}
+
+func TestRelay_PublishesInOrder(t *testing.T) {
+    repo := newFakeOutboxRepo(event(1, domain.EventCandidateCreated), event(2, domain.EventCandidateUpdated), event(3, domain.EventCandidateDeleted))
+    broker := outbox.NewMemoryBroker()
+    relay := outbox.NewRelay(repo, outbox.NewBrokerSink(broker, "seek"), outbox.WithBatchSize(2))
+
+    // Cada tick publica un lote como máximo
+    n, err := relay.Tick(context.Background())
+    assert.NoError(t, err)
+    assert.Equal(t, 2, n)
+    n, err = relay.Tick(context.Background())
+    assert.NoError(t, err)
+    assert.Equal(t, 1, n)
+
+    messages := broker.Messages()
+    assert.Len(t, messages, 3)
+    assert.Equal(t, "seek.candidate.created", messages[0].Subject)
+    assert.Equal(t, "seek.candidate.deleted", messages[2].Subject)
+    assert.Equal(t, "key-b", messages[0].ID)
+}
+
+func TestRelay_FailureKeepsOrder(t *testing.T) {
+    repo := newFakeOutboxRepo(event(1, domain.EventCandidateCreated), event(2, domain.EventCandidateUpdated))
+    broker := outbox.NewMemoryBroker()
+    now := t0.Add(time.Minute)
+    relay := outbox.NewRelay(repo, outbox.NewBrokerSink(broker, ""), outbox.WithClock(func() time.Time { return now }))
+
+    broker.SetError(errors.New("broker down"))
+    n, err := relay.Tick(context.Background())
+    assert.Error(t, err)
+    assert.Equal(t, 0, n)
+    // El primero queda pendiente con el error y el segundo no se intenta
+    assert.Equal(t, "broker down", repo.failures[1])
+    assert.NotContains(t, repo.failures, int64(2))
+
+    stats, err := relay.Stats()
+    assert.NoError(t, err)
+    assert.Equal(t, 2, stats.Pending)
+    assert.Equal(t, 59.0, stats.LagSeconds)
+    assert.Equal(t, int64(1), stats.Failures)
+    assert.Equal(t, "broker down", stats.LastError)
+
+    broker.SetError(nil)
+    n, err = relay.Tick(context.Background())
+    assert.NoError(t, err)
+    assert.Equal(t, 2, n)
+
+    stats, _ = relay.Stats()
+    assert.Equal(t, 0, stats.Pending)
+    assert.Equal(t, 0.0, stats.LagSeconds)
+    assert.Equal(t, int64(2), stats.Published)
+    assert.Empty(t, stats.LastError)
+}
+
+func TestTail_EveryInstanceReceivesTheEvents(t *testing.T) {
+    repo := newFakeOutboxRepo(event(1, domain.EventCandidateCreated))
+    // Dos instancias, cada una con su hub; el relay de cualquiera de ellas marca los eventos publicados
+    hubs := []*events.Hub{events.NewHub(10, 10), events.NewHub(10, 10)}
+    tails := []*outbox.Tail{outbox.NewTail(repo, hubs[0], 10), outbox.NewTail(repo, hubs[1], 10)}
+    for _, tail := range tails {
+        // El primer tick se sitúa en el último evento y no reenvía los anteriores
+        n, err := tail.Tick(context.Background())
+        assert.NoError(t, err)
+        assert.Zero(t, n)
+    }
+
+    repo.events = append(repo.events, event(2, domain.EventCandidateUpdated), event(3, domain.EventCandidateDeleted))
+    repo.published[2] = true
+    for i, tail := range tails {
+        n, err := tail.Tick(context.Background())
+        assert.NoError(t, err)
+        assert.Equal(t, 2, n)
+
+        sub, replay, _ := hubs[i].Subscribe(1, nil)
+        assert.Len(t, replay, 2)
+        sub.Close()
+    }
+    n, err := tails[0].Tick(context.Background())
+    assert.NoError(t, err)
+    assert.Zero(t, n)
+}
+
+func TestMemoryBroker_DiscardsDuplicates(t *testing.T) {
+    broker := outbox.NewMemoryBroker()
+    sink := outbox.NewBrokerSink(broker, "")
+
+    // Un evento publicado dos veces (por ejemplo tras una caída del relay) se recibe una sola vez
+    e := event(1, domain.EventCandidateCreated)
+    assert.NoError(t, sink.Publish(context.Background(), e))
+    assert.NoError(t, sink.Publish(context.Background(), e))
+    assert.Len(t, broker.Messages(), 1)
+}
+
+func TestHTTPSink(t *testing.T) {
+    var got *http.Request
+    var body domain.OutboxEvent
+    status := http.StatusAccepted
+    server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        got = r
+        json.NewDecoder(r.Body).Decode(&body)
+        w.WriteHeader(status)
+    }))
+    defer server.Close()
+
+    sink := outbox.NewHTTPSink(server.URL, server.Client())
+    e := event(4, domain.EventCandidateMerged)
+    assert.NoError(t, sink.Publish(context.Background(), e))
+    assert.Equal(t, http.MethodPost, got.Method)
+    assert.Equal(t, e.IdempotencyKey, got.Header.Get("Idempotency-Key"))
+    assert.Equal(t, domain.EventCandidateMerged, got.Header.Get("X-Event-Type"))
+    assert.Equal(t, int64(4), body.ID)
+
+    // Una respuesta que no es 2xx hace que se reintente
+    status = http.StatusServiceUnavailable
+    assert.Error(t, sink.Publish(context.Background(), e))
+}
@@ -0,0 +1,30 @@
+package repository_test
+
+import (
+    "regexp"
+    "testing"
+
+    "github.com/DATA-DOG/go-sqlmock"
+    "github.com/stretchr/testify/assert"
+
+    "github.com/torvictorvic/seek-v2/internal/domain"
+    "github.com/torvictorvic/seek-v2/internal/repository"
+)
+
+func TestMoveApplicationStage_Changed(t *testing.T) {
+    db, mock, err := sqlmock.New()
+    assert.NoError(t, err)
+    defer db.Close()
+
+    repo := repository.NewApplicationRepository(db)
+
+    // Otra petición movió la postulación antes: no se actualiza ninguna fila
+    mock.ExpectBegin()
+    mock.ExpectExec(regexp.QuoteMeta("UPDATE applications SET stage = ?, rejection_reason = ? WHERE id = ? AND stage = ?")).
+        WithArgs("screening", "", 10, "applied").WillReturnResult(sqlmock.NewResult(0, 0))
+    mock.ExpectRollback()
+
+    err = repo.MoveStage(domain.Application{ID: 10, Stage: "screening"}, domain.StageTransition{FromStage: "applied", ToStage: "screening"})
+    assert.ErrorIs(t, err, repository.ErrStageChanged)
+    assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,32 @@
+package repository_test
+
+import (
+    "regexp"
+    "testing"
+
+    "github.com/DATA-DOG/go-sqlmock"
+    "github.com/stretchr/testify/assert"
+
+    "github.com/torvictorvic/seek-v2/internal/repository"
+)
+
+func TestCalendarFeedSave_Postgres(t *testing.T) {
+    db, mock, err := sqlmock.New()
+    assert.NoError(t, err)
+    defer db.Close()
+
+    // user es una palabra reservada en PostgreSQL
+    repo := repository.NewCalendarFeedRepositoryFor(db, repository.Postgres)
+    mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO calendar_feeds ("user", token_hash) VALUES ($1, $2) ON CONFLICT ("user") DO UPDATE SET token_hash = excluded.token_hash`)).
+        WithArgs("ana", "hash").
+        WillReturnResult(sqlmock.NewResult(0, 1))
+    mock.ExpectQuery(regexp.QuoteMeta(`SELECT "user" FROM calendar_feeds WHERE token_hash = $1`)).
+        WithArgs("hash").
+        WillReturnRows(sqlmock.NewRows([]string{"user"}).AddRow("ana"))
+
+    assert.NoError(t, repo.Save("ana", "hash"))
+    user, err := repo.GetUser("hash")
+    assert.NoError(t, err)
+    assert.Equal(t, "ana", user)
+    assert.NoError(t, mock.ExpectationsWereMet())
+}
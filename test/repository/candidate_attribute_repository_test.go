@@ -0,0 +1,68 @@
+package repository_test
+
+import (
+    "regexp"
+    "testing"
+
+    "github.com/DATA-DOG/go-sqlmock"
+    "github.com/stretchr/testify/assert"
+
+    "github.com/torvictorvic/seek-v2/internal/domain"
+    "github.com/torvictorvic/seek-v2/internal/repository"
+)
+
+func TestLoadCandidateAttributes(t *testing.T) {
+    db, mock, err := sqlmock.New()
+    assert.NoError(t, err)
+    defer db.Close()
+
+    repo := repository.NewCandidateAttributeRepository(db)
+
+    mock.ExpectQuery(regexp.QuoteMeta("SELECT candidate_id, tag FROM candidate_tags WHERE candidate_id IN (?, ?) ORDER BY candidate_id, tag")).
+        WithArgs(1, 2).
+        WillReturnRows(sqlmock.NewRows([]string{"candidate_id", "tag"}).AddRow(1, "backend").AddRow(1, "referido"))
+
+    // El tipo del campo indica cómo leer el valor
+    mock.ExpectQuery(regexp.QuoteMeta("SELECT v.candidate_id, v.field_key, f.type, v.value_text FROM candidate_field_values v")).
+        WithArgs(1, 2).
+        WillReturnRows(sqlmock.NewRows([]string{"candidate_id", "field_key", "type", "value_text"}).
+            AddRow(1, "stack", domain.FieldMultiSelect, "go").
+            AddRow(1, "stack", domain.FieldMultiSelect, "java").
+            AddRow(1, "years_experience", domain.FieldNumber, "5.5").
+            AddRow(2, "available_from", domain.FieldDate, "2026-11-01"))
+
+    attrs, err := repo.Load([]int{1, 2})
+    assert.NoError(t, err)
+    assert.Equal(t, []string{"backend", "referido"}, attrs[1].Tags)
+    assert.Equal(t, []string{"go", "java"}, attrs[1].CustomFields["stack"])
+    assert.Equal(t, 5.5, attrs[1].CustomFields["years_experience"])
+    assert.Nil(t, attrs[2].Tags)
+    assert.Equal(t, "2026-11-01", attrs[2].CustomFields["available_from"])
+
+    assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSaveFieldValues(t *testing.T) {
+    db, mock, err := sqlmock.New()
+    assert.NoError(t, err)
+    defer db.Close()
+
+    repo := repository.NewCandidateAttributeRepository(db)
+    years := domain.CustomField{Key: "years_experience", Type: domain.FieldNumber}
+    stack := domain.CustomField{Key: "stack", Type: domain.FieldMultiSelect}
+    values := append(years.Values(5.0), stack.Values([]string{"go", "java"})...)
+
+    // Se reemplazan todos los valores del candidato en una transacción
+    mock.ExpectBegin()
+    mock.ExpectExec(regexp.QuoteMeta("DELETE FROM candidate_field_values WHERE candidate_id = ?")).
+        WithArgs(7).
+        WillReturnResult(sqlmock.NewResult(0, 2))
+    mock.ExpectExec(regexp.QuoteMeta("INSERT INTO candidate_field_values (candidate_id, field_key, value_text, value_number, value_date) VALUES (?, ?, ?, ?, ?), (?, ?, ?, ?, ?), (?, ?, ?, ?, ?)")).
+        WithArgs(7, "years_experience", "5", 5.0, nil, 7, "stack", "go", nil, nil, 7, "stack", "java", nil, nil).
+        WillReturnResult(sqlmock.NewResult(0, 3))
+    mock.ExpectCommit()
+
+    err = repo.SaveFieldValues(7, values)
+    assert.NoError(t, err)
+    assert.NoError(t, mock.ExpectationsWereMet())
+}
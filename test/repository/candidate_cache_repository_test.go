@@ -0,0 +1,275 @@
+package repository_test
+
+import (
+    "context"
+    "database/sql"
+    "encoding/json"
+    "errors"
+    "path/filepath"
+    "regexp"
+    "sync"
+    "sync/atomic"
+    "testing"
+    "time"
+
+    "github.com/DATA-DOG/go-sqlmock"
+    "github.com/stretchr/testify/assert"
+    "github.com/stretchr/testify/require"
+
+    "github.com/torvictorvic/seek-v2/internal/cache"
+    "github.com/torvictorvic/seek-v2/internal/domain"
+    "github.com/torvictorvic/seek-v2/internal/repository"
+)
+
+// El repositorio con caché cumple el mismo contrato que el que envuelve
+func TestCandidateRepositoryContract_CachedMemory(t *testing.T) {
+    runCandidateRepositoryContract(t, func(t *testing.T) repository.CandidateRepository {
+        return repository.NewCachedCandidateRepository(repository.NewMemoryCandidateRepository(), cache.NewLRU(100))
+    })
+}
+
+func TestCandidateRepositoryContract_CachedSQLite(t *testing.T) {
+    runCandidateRepositoryContract(t, func(t *testing.T) repository.CandidateRepository {
+        db, err := sql.Open("sqlite", "file:"+filepath.Join(t.TempDir(), "seek.db")+"?_pragma=busy_timeout(5000)")
+        require.NoError(t, err)
+        t.Cleanup(func() { db.Close() })
+        return repository.NewCachedCandidateRepository(migratedCandidateRepo(t, db, repository.SQLite), cache.NewLRU(100))
+    })
+}
+
+// countingRepo cuenta las lecturas que llegan al repositorio envuelto
+type countingRepo struct {
+    repository.CandidateRepository
+    gets    atomic.Int64
+    release chan struct{} // si no es nil, GetByID espera a que se cierre
+}
+
+func (r *countingRepo) GetByID(id int) (*domain.Candidate, error) {
+    r.gets.Add(1)
+    if r.release != nil {
+        <-r.release
+    }
+    return r.CandidateRepository.GetByID(id)
+}
+
+func newCachedRepo(t *testing.T, opts ...repository.CacheOption) (*repository.CachedCandidateRepository, *countingRepo, int) {
+    inner := &countingRepo{CandidateRepository: repository.NewMemoryCandidateRepository()}
+    id, err := inner.Create(domain.Candidate{Name: "Jane", Email: "jane@example.com"})
+    require.NoError(t, err)
+    return repository.NewCachedCandidateRepository(inner, cache.NewLRU(100), opts...), inner, id
+}
+
+func TestCachedCandidateRepository_ReadThrough(t *testing.T) {
+    repo, inner, id := newCachedRepo(t)
+
+    for i := 0; i < 3; i++ {
+        c, err := repo.GetByID(id)
+        require.NoError(t, err)
+        assert.Equal(t, "Jane", c.Name)
+        // Cambiar el resultado no cambia lo guardado en la caché
+        c.Name = "changed"
+    }
+    assert.EqualValues(t, 1, inner.gets.Load())
+
+    // Los candidatos que no existen no se guardan
+    for i := 0; i < 2; i++ {
+        c, err := repo.GetByID(id + 100)
+        require.NoError(t, err)
+        assert.Nil(t, c)
+    }
+    assert.EqualValues(t, 3, inner.gets.Load())
+
+    stats := repo.Stats().Operations[repository.CacheGetByID]
+    assert.EqualValues(t, 2, stats.Hits)
+    assert.EqualValues(t, 3, stats.Misses)
+    assert.InDelta(t, 0.4, stats.HitRatio, 0.001)
+    assert.Equal(t, 60.0, stats.TTLSeconds)
+}
+
+func TestCachedCandidateRepository_Invalidation(t *testing.T) {
+    repo, inner, id := newCachedRepo(t)
+
+    _, err := repo.GetByEmail("JANE@example.com")
+    require.NoError(t, err)
+    require.NoError(t, repo.Update(domain.Candidate{ID: id, Name: "Jane Roe", Email: "roe@example.com"}))
+
+    // La actualización borra el candidato y la búsqueda por el email anterior ya no lo encuentra
+    c, err := repo.GetByID(id)
+    require.NoError(t, err)
+    assert.Equal(t, "Jane Roe", c.Name)
+    c, err = repo.GetByEmail("jane@example.com")
+    require.NoError(t, err)
+    assert.Nil(t, c)
+    c, err = repo.GetByEmail("roe@example.com")
+    require.NoError(t, err)
+    assert.Equal(t, id, c.ID)
+    c, err = repo.GetByEmail("roe@example.com")
+    require.NoError(t, err)
+    assert.Equal(t, id, c.ID)
+    assert.EqualValues(t, 1, repo.Stats().Operations[repository.CacheGetByEmail].Hits)
+
+    require.NoError(t, repo.Delete(id))
+    c, err = repo.GetByID(id)
+    require.NoError(t, err)
+    assert.Nil(t, c)
+    c, err = repo.GetByEmail("roe@example.com")
+    require.NoError(t, err)
+    assert.Nil(t, c)
+    assert.EqualValues(t, 2, repo.Stats().Invalidations)
+    assert.EqualValues(t, 2, inner.gets.Load())
+}
+
+func TestCachedCandidateRepository_GetByIDs(t *testing.T) {
+    repo, _, id := newCachedRepo(t)
+    other, err := repo.Create(domain.Candidate{Name: "John", Email: "john@example.com"})
+    require.NoError(t, err)
+
+    _, err = repo.GetByID(id)
+    require.NoError(t, err)
+    // Solo los IDs que faltan se leen del repositorio, y el resultado sale en orden de ID
+    candidates, err := repo.GetByIDs([]int{other, id, other, 999})
+    require.NoError(t, err)
+    require.Len(t, candidates, 2)
+    assert.Equal(t, []int{id, other}, []int{candidates[0].ID, candidates[1].ID})
+
+    stats := repo.Stats().Operations[repository.CacheGetByIDs]
+    assert.EqualValues(t, 1, stats.Hits)
+    assert.EqualValues(t, 2, stats.Misses)
+}
+
+func TestCachedCandidateRepository_PerOperationTTL(t *testing.T) {
+    repo, inner, id := newCachedRepo(t, repository.WithCacheTTL(repository.CacheGetByID, 0))
+
+    // Con TTL 0 la operación no pasa por la caché
+    for i := 0; i < 2; i++ {
+        _, err := repo.GetByID(id)
+        require.NoError(t, err)
+    }
+    assert.EqualValues(t, 2, inner.gets.Load())
+    assert.Zero(t, repo.Stats().Operations[repository.CacheGetByID].Misses)
+    assert.Zero(t, repo.Stats().Operations[repository.CacheGetByID].TTLSeconds)
+}
+
+func TestCachedCandidateRepository_Singleflight(t *testing.T) {
+    repo, inner, id := newCachedRepo(t)
+    inner.release = make(chan struct{})
+
+    // Las lecturas concurrentes de un candidato que no está en caché hacen una sola consulta
+    var wg sync.WaitGroup
+    for i := 0; i < 10; i++ {
+        wg.Add(1)
+        go func() {
+            defer wg.Done()
+            c, err := repo.GetByID(id)
+            assert.NoError(t, err)
+            assert.Equal(t, "Jane", c.Name)
+        }()
+    }
+    require.Eventually(t, func() bool { return inner.gets.Load() == 1 }, time.Second, time.Millisecond)
+    require.Eventually(t, func() bool {
+        stats := repo.Stats().Operations[repository.CacheGetByID]
+        return stats.Misses == 10
+    }, time.Second, time.Millisecond)
+    time.Sleep(10 * time.Millisecond)
+    close(inner.release)
+    wg.Wait()
+
+    assert.EqualValues(t, 1, inner.gets.Load())
+    assert.EqualValues(t, 9, repo.Stats().Operations[repository.CacheGetByID].Shared)
+}
+
+func TestCachedCandidateRepository_WriteDuringLoad(t *testing.T) {
+    repo, inner, id := newCachedRepo(t)
+    inner.release = make(chan struct{})
+
+    // Lo leído antes de una escritura no se guarda en la caché
+    done := make(chan struct{})
+    go func() {
+        defer close(done)
+        _, _ = repo.GetByID(id)
+    }()
+    require.Eventually(t, func() bool { return inner.gets.Load() == 1 }, time.Second, time.Millisecond)
+    require.NoError(t, repo.Update(domain.Candidate{ID: id, Name: "Jane Roe", Email: "jane@example.com"}))
+    close(inner.release)
+    <-done
+
+    c, err := repo.GetByID(id)
+    require.NoError(t, err)
+    assert.Equal(t, "Jane Roe", c.Name)
+    assert.EqualValues(t, 2, inner.gets.Load())
+}
+
+func TestCachedCandidateRepository_UnitOfWork(t *testing.T) {
+    db, mock, err := sqlmock.New()
+    require.NoError(t, err)
+    defer db.Close()
+
+    store := cache.NewLRU(100)
+    repo := repository.NewCachedCandidateRepository(repository.NewCandidateRepository(db), store)
+    uow := repository.NewUnitOfWork(db)
+    cached, err := json.Marshal(domain.Candidate{ID: 7, Name: "Jane", Email: "jane@example.com"})
+    require.NoError(t, err)
+    require.NoError(t, store.Set(context.Background(), "candidate:7", cached, time.Minute))
+
+    // Dentro de la transacción se lee de la base de datos y la escritura borra el candidato
+    // también después del commit
+    rows := sqlmock.NewRows([]string{"id", "name", "email", "gender", "salary_expected", "created_at", "updated_at"}).
+        AddRow(7, "Jane Roe", "jane@example.com", "", 0, time.Now(), time.Now())
+    mock.ExpectBegin()
+    mock.ExpectQuery(regexp.QuoteMeta("SELECT id, name, email, gender, salary_expected, created_at, updated_at FROM candidates WHERE id = ?")).
+        WillReturnRows(rows)
+    mock.ExpectExec(regexp.QuoteMeta("SAVEPOINT sp_1")).WillReturnResult(sqlmock.NewResult(0, 0))
+    mock.ExpectQuery(regexp.QuoteMeta("SELECT 1 FROM candidates WHERE id = ?")).WillReturnRows(sqlmock.NewRows([]string{"1"}).AddRow(1))
+    mock.ExpectExec(regexp.QuoteMeta("UPDATE candidates SET")).WillReturnResult(sqlmock.NewResult(0, 1))
+    mock.ExpectExec(insertOutbox).WillReturnResult(sqlmock.NewResult(1, 1))
+    mock.ExpectExec(regexp.QuoteMeta("RELEASE SAVEPOINT sp_1")).WillReturnResult(sqlmock.NewResult(0, 0))
+    mock.ExpectCommit()
+
+    err = uow.Do(context.Background(), func(ctx context.Context) error {
+        bound := repository.Bind(ctx, repository.CandidateRepository(repo))
+        c, err := bound.GetByID(7)
+        require.NoError(t, err)
+        assert.Equal(t, "Jane Roe", c.Name)
+        if err := bound.Update(*c); err != nil {
+            return err
+        }
+        // Otra lectura antes del commit vuelve a llenar la caché con el dato anterior
+        return store.Set(context.Background(), "candidate:7", cached, time.Minute)
+    })
+    require.NoError(t, err)
+    _, ok, _ := store.Get(context.Background(), "candidate:7")
+    assert.False(t, ok)
+    assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// failingStore simula una caché caída
+type failingStore struct{}
+
+func (failingStore) Get(context.Context, string) ([]byte, bool, error) {
+    return nil, false, errors.New("connection refused")
+}
+
+func (failingStore) Set(context.Context, string, []byte, time.Duration) error {
+    return errors.New("connection refused")
+}
+
+func (failingStore) Delete(context.Context, ...string) error {
+    return errors.New("connection refused")
+}
+
+func TestCachedCandidateRepository_StoreDown(t *testing.T) {
+    inner := repository.NewMemoryCandidateRepository()
+    id, err := inner.Create(domain.Candidate{Name: "Jane", Email: "jane@example.com"})
+    require.NoError(t, err)
+    repo := repository.NewCachedCandidateRepository(inner, failingStore{})
+
+    // Sin caché se sigue leyendo y escribiendo en el repositorio, y los errores se cuentan
+    c, err := repo.GetByID(id)
+    require.NoError(t, err)
+    assert.Equal(t, "Jane", c.Name)
+    require.NoError(t, repo.Delete(id))
+
+    stats := repo.Stats()
+    assert.EqualValues(t, 2, stats.Operations[repository.CacheGetByID].Errors)
+    assert.EqualValues(t, 1, stats.InvalidationErrors)
+}
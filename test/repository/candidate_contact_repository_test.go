@@ -0,0 +1,68 @@
+package repository_test
+
+import (
+    "regexp"
+    "testing"
+
+    "github.com/DATA-DOG/go-sqlmock"
+    "github.com/stretchr/testify/assert"
+
+    "github.com/torvictorvic/seek-v2/internal/domain"
+    "github.com/torvictorvic/seek-v2/internal/repository"
+)
+
+func TestSaveContactDetails(t *testing.T) {
+    db, mock, err := sqlmock.New()
+    assert.NoError(t, err)
+    defer db.Close()
+
+    repo := repository.NewCandidateContactRepository(db)
+    contact := domain.ContactDetails{
+        Phones:  []domain.Phone{{Number: "+51987654321", Type: domain.PhoneMobile, Primary: true}, {Number: "+5114123456"}},
+        Address: &domain.Address{City: "Lima"},
+        Country: "PE",
+    }
+
+    // Los teléfonos se reemplazan y conservan su orden
+    mock.ExpectBegin()
+    mock.ExpectExec(regexp.QuoteMeta("DELETE FROM candidate_phones WHERE candidate_id = ?")).
+        WithArgs(4).WillReturnResult(sqlmock.NewResult(0, 1))
+    mock.ExpectExec(regexp.QuoteMeta("DELETE FROM candidate_contacts WHERE candidate_id = ?")).
+        WithArgs(4).WillReturnResult(sqlmock.NewResult(0, 1))
+    mock.ExpectExec(regexp.QuoteMeta("INSERT INTO candidate_contacts")).
+        WithArgs(4, "", "", "Lima", "", "", "PE", "", "", "", "", "").
+        WillReturnResult(sqlmock.NewResult(0, 1))
+    mock.ExpectExec(regexp.QuoteMeta("INSERT INTO candidate_phones (candidate_id, number, type, is_primary, position) VALUES (?, ?, ?, ?, ?), (?, ?, ?, ?, ?)")).
+        WithArgs(4, "+51987654321", "mobile", true, 0, 4, "+5114123456", "", false, 1).
+        WillReturnResult(sqlmock.NewResult(0, 2))
+    mock.ExpectCommit()
+
+    assert.NoError(t, repo.Save(4, contact))
+    assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestLoadContactDetails(t *testing.T) {
+    db, mock, err := sqlmock.New()
+    assert.NoError(t, err)
+    defer db.Close()
+
+    repo := repository.NewCandidateContactRepository(db)
+
+    mock.ExpectQuery(regexp.QuoteMeta("FROM candidate_contacts WHERE candidate_id IN (?, ?)")).
+        WithArgs(4, 5).
+        WillReturnRows(sqlmock.NewRows([]string{"candidate_id", "address_line1", "address_line2", "city", "region", "postal_code",
+            "country", "time_zone", "linkedin_url", "github_url", "portfolio_url", "preferred_channel"}).
+            AddRow(4, "", "", "", "", "", "PE", "America/Lima", "", "", "", "phone"))
+    mock.ExpectQuery(regexp.QuoteMeta("SELECT candidate_id, number, type, is_primary FROM candidate_phones WHERE candidate_id IN (?, ?) ORDER BY candidate_id, position")).
+        WithArgs(4, 5).
+        WillReturnRows(sqlmock.NewRows([]string{"candidate_id", "number", "type", "is_primary"}).
+            AddRow(4, "+51987654321", "mobile", true))
+
+    contacts, err := repo.Load([]int{4, 5})
+    assert.NoError(t, err)
+    assert.Len(t, contacts, 1)
+    // Sin datos de dirección no hay dirección
+    assert.Nil(t, contacts[4].Address)
+    assert.Equal(t, "+51987654321", contacts[4].PrimaryPhone())
+    assert.NoError(t, mock.ExpectationsWereMet())
+}
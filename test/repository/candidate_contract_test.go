@@ -0,0 +1,324 @@
+package repository_test
+
+import (
+    "database/sql"
+    "errors"
+    "os"
+    "path/filepath"
+    "sort"
+    "testing"
+
+    "github.com/stretchr/testify/assert"
+    "github.com/stretchr/testify/require"
+
+    _ "github.com/go-sql-driver/mysql"
+    _ "github.com/lib/pq"
+    _ "modernc.org/sqlite"
+
+    "github.com/torvictorvic/seek-v2/internal/domain"
+    "github.com/torvictorvic/seek-v2/internal/repository"
+    "github.com/torvictorvic/seek-v2/migrations"
+)
+
+// newCandidateRepoFunc devuelve un repositorio vacío para cada caso del contrato
+type newCandidateRepoFunc func(t *testing.T) repository.CandidateRepository
+
+// runCandidateRepositoryContract comprueba el comportamiento que todas las
+// implementaciones de CandidateRepository deben compartir
+func runCandidateRepositoryContract(t *testing.T, newRepo newCandidateRepoFunc) {
+    t.Run("CreateAndGet", func(t *testing.T) {
+        repo := newRepo(t)
+        id, err := repo.Create(domain.Candidate{Name: "Jane Doe", Email: "jane@example.com", Gender: "female", SalaryExpected: 50000})
+        require.NoError(t, err)
+        assert.NotZero(t, id)
+
+        c, err := repo.GetByID(id)
+        require.NoError(t, err)
+        require.NotNil(t, c)
+        assert.Equal(t, "Jane Doe", c.Name)
+        assert.Equal(t, "jane@example.com", c.Email)
+        assert.Equal(t, "female", c.Gender)
+        assert.Equal(t, 50000.0, c.SalaryExpected)
+        assert.False(t, c.CreatedAt.IsZero())
+
+        byEmail, err := repo.GetByEmail("jane@example.com")
+        require.NoError(t, err)
+        require.NotNil(t, byEmail)
+        assert.Equal(t, id, byEmail.ID)
+
+        // Los que no existen devuelven nil sin error
+        missing, err := repo.GetByID(id + 1000)
+        assert.NoError(t, err)
+        assert.Nil(t, missing)
+        missing, err = repo.GetByEmail("nobody@example.com")
+        assert.NoError(t, err)
+        assert.Nil(t, missing)
+
+        // El email es único
+        _, err = repo.Create(domain.Candidate{Name: "Other", Email: "jane@example.com"})
+        assert.Error(t, err)
+    })
+
+    t.Run("GetByIDs", func(t *testing.T) {
+        repo := newRepo(t)
+        ids, err := repo.CreateBatch([]domain.Candidate{
+            {Name: "A", Email: "a@example.com"},
+            {Name: "B", Email: "b@example.com"},
+            {Name: "C", Email: "c@example.com"},
+        })
+        require.NoError(t, err)
+
+        candidates, err := repo.GetByIDs([]int{ids[0], ids[2], ids[2] + 1000})
+        require.NoError(t, err)
+        assert.ElementsMatch(t, []string{"a@example.com", "c@example.com"}, emails(candidates))
+
+        candidates, err = repo.GetByIDs(nil)
+        assert.NoError(t, err)
+        assert.Empty(t, candidates)
+    })
+
+    t.Run("UpdateAndDelete", func(t *testing.T) {
+        repo := newRepo(t)
+        id, err := repo.Create(domain.Candidate{Name: "Jane", Email: "jane@example.com", Gender: "female", SalaryExpected: 1000})
+        require.NoError(t, err)
+
+        require.NoError(t, repo.Update(domain.Candidate{ID: id, Name: "Jane Roe", Email: "jane.roe@example.com", Gender: "female", SalaryExpected: 2000}))
+        c, err := repo.GetByID(id)
+        require.NoError(t, err)
+        assert.Equal(t, "Jane Roe", c.Name)
+        assert.Equal(t, "jane.roe@example.com", c.Email)
+        assert.Equal(t, 2000.0, c.SalaryExpected)
+
+        require.NoError(t, repo.Delete(id))
+        c, err = repo.GetByID(id)
+        assert.NoError(t, err)
+        assert.Nil(t, c)
+    })
+
+    t.Run("Filter", func(t *testing.T) {
+        repo := newRepo(t)
+        _, err := repo.CreateBatch([]domain.Candidate{
+            {Name: "Ana Torres", Email: "ana@example.com", Gender: "female", SalaryExpected: 30000},
+            {Name: "Luis Mora", Email: "luis@example.com", Gender: "male", SalaryExpected: 45000},
+            {Name: "Mariana Ruiz", Email: "mariana@corp.com", Gender: "female", SalaryExpected: 60000},
+        })
+        require.NoError(t, err)
+
+        all, err := repo.GetAll(domain.CandidateFilter{})
+        require.NoError(t, err)
+        assert.Len(t, all, 3)
+
+        // Las coincidencias parciales no distinguen mayúsculas
+        byName, err := repo.GetAll(domain.CandidateFilter{Name: "ANA"})
+        require.NoError(t, err)
+        assert.ElementsMatch(t, []string{"ana@example.com", "mariana@corp.com"}, emails(byName))
+
+        byEmail, err := repo.GetAll(domain.CandidateFilter{Email: "corp"})
+        require.NoError(t, err)
+        assert.Equal(t, []string{"mariana@corp.com"}, emails(byEmail))
+
+        min, max := 40000.0, 60000.0
+        bySalary, err := repo.GetAll(domain.CandidateFilter{Gender: "female", SalaryMin: &min, SalaryMax: &max})
+        require.NoError(t, err)
+        assert.Equal(t, []string{"mariana@corp.com"}, emails(bySalary))
+
+        none, err := repo.GetAll(domain.CandidateFilter{Name: "nadie"})
+        assert.NoError(t, err)
+        assert.Empty(t, none)
+    })
+
+    t.Run("Page", func(t *testing.T) {
+        repo := newRepo(t)
+        ids, err := repo.CreateBatch([]domain.Candidate{
+            {Name: "A", Email: "a@example.com", Gender: "female"},
+            {Name: "B", Email: "b@example.com", Gender: "male"},
+            {Name: "C", Email: "c@example.com", Gender: "female"},
+            {Name: "D", Email: "d@example.com", Gender: "female"},
+        })
+        require.NoError(t, err)
+        female := domain.CandidateFilter{Gender: "female"}
+
+        // Las páginas siguen el orden de ID a partir del último devuelto
+        page, err := repo.Page(female, 0, 2)
+        require.NoError(t, err)
+        assert.Equal(t, []string{"a@example.com", "c@example.com"}, emails(page))
+        page, err = repo.Page(female, page[1].ID, 2)
+        require.NoError(t, err)
+        assert.Equal(t, []string{"d@example.com"}, emails(page))
+
+        // Borrar un candidato ya devuelto no mueve la página siguiente
+        require.NoError(t, repo.Delete(ids[0]))
+        page, err = repo.Page(female, ids[2], 2)
+        require.NoError(t, err)
+        assert.Equal(t, []string{"d@example.com"}, emails(page))
+
+        page, err = repo.Page(female, ids[3], 2)
+        assert.NoError(t, err)
+        assert.Empty(t, page)
+
+        n, err := repo.Count(female)
+        require.NoError(t, err)
+        assert.Equal(t, 2, n)
+        n, err = repo.Count(domain.CandidateFilter{})
+        require.NoError(t, err)
+        assert.Equal(t, 3, n)
+    })
+
+    t.Run("StreamStopsOnError", func(t *testing.T) {
+        repo := newRepo(t)
+        _, err := repo.CreateBatch([]domain.Candidate{{Name: "A", Email: "a@example.com"}, {Name: "B", Email: "b@example.com"}})
+        require.NoError(t, err)
+
+        stop := errors.New("stop")
+        calls := 0
+        err = repo.Stream(domain.CandidateFilter{}, func(domain.Candidate) error {
+            calls++
+            return stop
+        })
+        assert.ErrorIs(t, err, stop)
+        assert.Equal(t, 1, calls)
+    })
+
+    t.Run("Upsert", func(t *testing.T) {
+        repo := newRepo(t)
+        id, created, err := repo.Upsert(domain.Candidate{Name: "Jane", Email: "jane@example.com", SalaryExpected: 1000})
+        require.NoError(t, err)
+        assert.True(t, created)
+
+        // El mismo email actualiza el candidato existente
+        again, created, err := repo.Upsert(domain.Candidate{Name: "Jane Doe", Email: "jane@example.com", SalaryExpected: 2000})
+        require.NoError(t, err)
+        assert.False(t, created)
+        assert.Equal(t, id, again)
+
+        c, err := repo.GetByID(id)
+        require.NoError(t, err)
+        assert.Equal(t, "Jane Doe", c.Name)
+        assert.Equal(t, 2000.0, c.SalaryExpected)
+    })
+
+    t.Run("Merge", func(t *testing.T) {
+        repo := newRepo(t)
+        ids, err := repo.CreateBatch([]domain.Candidate{
+            {Name: "Jane", Email: "jane@example.com"},
+            {Name: "Jane Doe", Email: "jane.doe@example.com"},
+        })
+        require.NoError(t, err)
+
+        // El destino se queda con el email del origen, que se elimina
+        target := domain.Candidate{ID: ids[0], Name: "Jane Doe", Email: "jane.doe@example.com"}
+        entry := domain.CandidateHistoryEntry{CandidateID: ids[0], Action: domain.HistoryMerged, Actor: "ana"}
+        require.NoError(t, repo.Merge(target, ids[1], entry))
+
+        source, err := repo.GetByID(ids[1])
+        assert.NoError(t, err)
+        assert.Nil(t, source)
+        merged, err := repo.GetByID(ids[0])
+        require.NoError(t, err)
+        assert.Equal(t, "jane.doe@example.com", merged.Email)
+    })
+
+    t.Run("Batch", func(t *testing.T) {
+        repo := newRepo(t)
+        ids, err := repo.CreateBatch([]domain.Candidate{
+            {Name: "A", Email: "a@example.com"},
+            {Name: "B", Email: "b@example.com"},
+            {Name: "C", Email: "c@example.com"},
+        })
+        require.NoError(t, err)
+        require.Len(t, ids, 3)
+        assert.True(t, sort.IntsAreSorted(ids))
+
+        // Los IDs siguen el orden de los candidatos
+        for i, email := range []string{"a@example.com", "b@example.com", "c@example.com"} {
+            c, err := repo.GetByID(ids[i])
+            require.NoError(t, err)
+            assert.Equal(t, email, c.Email)
+        }
+
+        require.NoError(t, repo.UpdateBatch([]domain.Candidate{
+            {ID: ids[0], Name: "A2", Email: "a@example.com"},
+            {ID: ids[1], Name: "B2", Email: "b@example.com"},
+        }))
+        c, err := repo.GetByID(ids[1])
+        require.NoError(t, err)
+        assert.Equal(t, "B2", c.Name)
+
+        require.NoError(t, repo.DeleteBatch(ids[:2]))
+        all, err := repo.GetAll(domain.CandidateFilter{})
+        require.NoError(t, err)
+        assert.Equal(t, []string{"c@example.com"}, emails(all))
+
+        // Un lote vacío no hace nada
+        empty, err := repo.CreateBatch(nil)
+        assert.NoError(t, err)
+        assert.Empty(t, empty)
+    })
+}
+
+func emails(candidates []domain.Candidate) []string {
+    out := make([]string, len(candidates))
+    for i, c := range candidates {
+        out[i] = c.Email
+    }
+    return out
+}
+
+func TestCandidateRepositoryContract_Memory(t *testing.T) {
+    runCandidateRepositoryContract(t, func(t *testing.T) repository.CandidateRepository {
+        return repository.NewMemoryCandidateRepository()
+    })
+}
+
+func TestCandidateRepositoryContract_SQLite(t *testing.T) {
+    runCandidateRepositoryContract(t, func(t *testing.T) repository.CandidateRepository {
+        db, err := sql.Open("sqlite", "file:"+filepath.Join(t.TempDir(), "seek.db")+"?_pragma=busy_timeout(5000)")
+        require.NoError(t, err)
+        t.Cleanup(func() { db.Close() })
+        return migratedCandidateRepo(t, db, repository.SQLite)
+    })
+}
+
+// Necesita una base de datos de pruebas, que se vacía: POSTGRES_TEST_DSN=postgres://...?sslmode=disable
+func TestCandidateRepositoryContract_Postgres(t *testing.T) {
+    runExternalContract(t, "POSTGRES_TEST_DSN", repository.Postgres)
+}
+
+// Necesita una base de datos de pruebas, que se vacía: MYSQL_TEST_DSN=user:pass@tcp(host)/db?parseTime=true&multiStatements=true
+func TestCandidateRepositoryContract_MySQL(t *testing.T) {
+    runExternalContract(t, "MYSQL_TEST_DSN", repository.MySQL)
+}
+
+func runExternalContract(t *testing.T, env string, dialect repository.Dialect) {
+    dsn := os.Getenv(env)
+    if dsn == "" {
+        t.Skipf("%s is not set", env)
+    }
+    db, err := sql.Open(dialect.Name, dsn)
+    require.NoError(t, err)
+    t.Cleanup(func() { db.Close() })
+
+    runCandidateRepositoryContract(t, func(t *testing.T) repository.CandidateRepository {
+        return migratedCandidateRepo(t, db, dialect)
+    })
+}
+
+// migratedCandidateRepo aplica las migraciones y borra los datos iniciales
+func migratedCandidateRepo(t *testing.T, db *sql.DB, dialect repository.Dialect) repository.CandidateRepository {
+    require.NoError(t, repository.Migrate(db, dialect, migrations.FS))
+    for _, table := range []string{"candidates", "candidate_history", "outbox"} {
+        _, err := db.Exec("DELETE FROM " + table)
+        require.NoError(t, err)
+    }
+    return repository.NewCandidateRepositoryFor(db, dialect)
+}
+
+func TestDialectRebind(t *testing.T) {
+    query := `SELECT id FROM candidates WHERE name = ? AND email <> '?' AND id IN (?, ?)`
+    assert.Equal(t, query, repository.MySQL.Rebind(query))
+    assert.Equal(t, query, repository.SQLite.Rebind(query))
+    assert.Equal(t, `SELECT id FROM candidates WHERE name = $1 AND email <> '?' AND id IN ($2, $3)`, repository.Postgres.Rebind(query))
+
+    _, err := repository.DialectFor("oracle")
+    assert.Error(t, err)
+}
@@ -0,0 +1,79 @@
+package repository_test
+
+import (
+    "fmt"
+    "sync"
+    "testing"
+
+    "github.com/stretchr/testify/assert"
+    "github.com/stretchr/testify/require"
+
+    "github.com/torvictorvic/seek-v2/internal/domain"
+    "github.com/torvictorvic/seek-v2/internal/repository"
+)
+
+func TestMemoryCandidateRepository_MySQLSemantics(t *testing.T) {
+    repo := repository.NewMemoryCandidateRepository()
+
+    id, err := repo.Create(domain.Candidate{Name: "Jane", Email: "Jane@Example.com", Tags: []string{"go"}})
+    require.NoError(t, err)
+    assert.Equal(t, 1, id)
+
+    // Como con la collation de MySQL, el email no distingue mayúsculas
+    _, err = repo.Create(domain.Candidate{Name: "Other", Email: "jane@example.com"})
+    assert.ErrorIs(t, err, repository.ErrDuplicateEmail)
+    c, err := repo.GetByEmail("JANE@example.com")
+    require.NoError(t, err)
+    require.NotNil(t, c)
+    assert.Equal(t, id, c.ID)
+
+    // Solo se guardan las columnas de la tabla, con precisión de segundos
+    assert.Nil(t, c.Tags)
+    assert.Zero(t, c.CreatedAt.Nanosecond())
+    assert.Equal(t, c.CreatedAt, c.UpdatedAt)
+
+    // Los IDs no se reutilizan tras un borrado
+    require.NoError(t, repo.Delete(id))
+    next, err := repo.Create(domain.Candidate{Name: "Jane", Email: "jane@example.com"})
+    require.NoError(t, err)
+    assert.Equal(t, 2, next)
+
+    // Un lote con un email repetido no crea ninguno
+    _, err = repo.CreateBatch([]domain.Candidate{{Email: "a@example.com"}, {Email: "A@example.com"}})
+    assert.ErrorIs(t, err, repository.ErrDuplicateEmail)
+    all, _ := repo.GetAll(domain.CandidateFilter{})
+    assert.Len(t, all, 1)
+
+    // Ni un lote de actualizaciones que choca con otro email
+    ids, err := repo.CreateBatch([]domain.Candidate{{Name: "A", Email: "a@example.com"}, {Name: "B", Email: "b@example.com"}})
+    require.NoError(t, err)
+    err = repo.UpdateBatch([]domain.Candidate{{ID: ids[0], Name: "A2", Email: "a@example.com"}, {ID: ids[1], Name: "B2", Email: "jane@example.com"}})
+    assert.ErrorIs(t, err, repository.ErrDuplicateEmail)
+    a, _ := repo.GetByID(ids[0])
+    assert.Equal(t, "A", a.Name)
+}
+
+func TestMemoryCandidateRepository_Concurrent(t *testing.T) {
+    repo := repository.NewMemoryCandidateRepository()
+
+    var wg sync.WaitGroup
+    for i := 0; i < 50; i++ {
+        wg.Add(1)
+        go func(i int) {
+            defer wg.Done()
+            id, err := repo.Create(domain.Candidate{Name: "C", Email: fmt.Sprintf("c%d@example.com", i)})
+            assert.NoError(t, err)
+            assert.NoError(t, repo.Update(domain.Candidate{ID: id, Name: "C2", Email: fmt.Sprintf("c%d@example.com", i)}))
+            _, _ = repo.GetAll(domain.CandidateFilter{Name: "c"})
+        }(i)
+    }
+    wg.Wait()
+
+    all, err := repo.GetAll(domain.CandidateFilter{Name: "C2"})
+    require.NoError(t, err)
+    require.Len(t, all, 50)
+    // Los IDs son únicos y consecutivos
+    for i, c := range all {
+        assert.Equal(t, i+1, c.ID)
+    }
+}
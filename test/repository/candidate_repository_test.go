@@ -1,6 +1,7 @@
 package repository_test
 
 import (
+    "errors"
     "regexp"
     "testing"
     "time"
@@ -29,12 +30,18 @@ func TestCreateCandidate(t *testing.T) {
         SalaryExpected: 35000.0,
     }
 
-    // 4) Esperamos que se ejecute un INSERT con estos campos
+    // 4) Esperamos que se ejecute un INSERT con estos campos y el evento en la misma transacción
     insertQuery := regexp.QuoteMeta("INSERT INTO candidates (name, email, gender, salary_expected) VALUES (?, ?, ?, ?)")
 
+    mock.ExpectBegin()
     mock.ExpectExec(insertQuery).
         WithArgs(candidate.Name, candidate.Email, candidate.Gender, candidate.SalaryExpected).
         WillReturnResult(sqlmock.NewResult(1, 1)) // Devuelve ID=1, 1 fila afectada
+    mock.ExpectExec(regexp.QuoteMeta("INSERT INTO outbox (event_type, aggregate_id, idempotency_key, payload) VALUES (?, ?, ?, ?)")).
+        WithArgs(domain.EventCandidateCreated, 1, sqlmock.AnyArg(),
+            `{"id":1,"name":"Test User","email":"test.user@example.com","gender":"female","salary_expected":35000}`).
+        WillReturnResult(sqlmock.NewResult(1, 1))
+    mock.ExpectCommit()
 
     // 5) Llamamos al método
     id, err := repo.Create(candidate)
@@ -117,6 +124,38 @@ func TestGetByIDCandidate_NotFound(t *testing.T) {
     assert.NoError(t, err)
 }
 
+func TestGetByIDsCandidate(t *testing.T) {
+    db, mock, err := sqlmock.New()
+    assert.NoError(t, err)
+    defer db.Close()
+
+    repo := repository.NewCandidateRepository(db)
+
+    // Sin IDs no se consulta la base de datos
+    candidates, err := repo.GetByIDs(nil)
+    assert.NoError(t, err)
+    assert.Empty(t, candidates)
+
+    // Una sola consulta para todos los IDs; los que no existen se omiten
+    now := time.Now()
+    rows := sqlmock.NewRows([]string{
+        "id", "name", "email", "gender", "salary_expected", "created_at", "updated_at",
+    }).
+        AddRow(3, "Ana", "ana@example.com", "female", 30000.0, now, now).
+        AddRow(1, "Luis", "luis@example.com", "male", 35000.0, now, now)
+    mock.ExpectQuery(regexp.QuoteMeta("SELECT id, name, email, gender, salary_expected, created_at, updated_at FROM candidates WHERE id IN (?,?,?)")).
+        WithArgs(1, 3, 7).
+        WillReturnRows(rows)
+
+    candidates, err = repo.GetByIDs([]int{1, 3, 7})
+    assert.NoError(t, err)
+    assert.Len(t, candidates, 2)
+    assert.Equal(t, 3, candidates[0].ID)
+    assert.Equal(t, "Luis", candidates[1].Name)
+
+    assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestUpdateCandidate(t *testing.T) {
     db, mock, err := sqlmock.New()
     assert.NoError(t, err)
@@ -135,9 +174,17 @@ func TestUpdateCandidate(t *testing.T) {
     }
 
     // Simulamos 1 fila afectada
+    mock.ExpectBegin()
+    mock.ExpectQuery(regexp.QuoteMeta("SELECT 1 FROM candidates WHERE id = ?")).
+        WithArgs(1).
+        WillReturnRows(sqlmock.NewRows([]string{"1"}).AddRow(1))
     mock.ExpectExec(updateQuery).
         WithArgs(candidate.Name, candidate.Email, candidate.Gender, candidate.SalaryExpected, candidate.ID).
         WillReturnResult(sqlmock.NewResult(0, 1))
+    mock.ExpectExec(regexp.QuoteMeta("INSERT INTO outbox")).
+        WithArgs(domain.EventCandidateUpdated, 1, sqlmock.AnyArg(), sqlmock.AnyArg()).
+        WillReturnResult(sqlmock.NewResult(2, 1))
+    mock.ExpectCommit()
 
     err = repo.Update(candidate)
     assert.NoError(t, err)
@@ -146,6 +193,49 @@ func TestUpdateCandidate(t *testing.T) {
     assert.NoError(t, err)
 }
 
+func TestUpdateCandidate_NotFound(t *testing.T) {
+    db, mock, err := sqlmock.New()
+    assert.NoError(t, err)
+    defer db.Close()
+
+    repo := repository.NewCandidateRepository(db)
+
+    // Sin candidato no se actualiza nada ni se encola el evento
+    mock.ExpectBegin()
+    mock.ExpectQuery(regexp.QuoteMeta("SELECT 1 FROM candidates WHERE id = ?")).
+        WithArgs(9999).
+        WillReturnRows(sqlmock.NewRows([]string{"1"}))
+    mock.ExpectRollback()
+
+    err = repo.Update(domain.Candidate{ID: 9999, Name: "Jane Doe", Email: "jane@example.com"})
+    assert.ErrorIs(t, err, repository.ErrCandidateNotFound)
+    assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteCandidate_NotFound(t *testing.T) {
+    db, mock, err := sqlmock.New()
+    assert.NoError(t, err)
+    defer db.Close()
+
+    repo := repository.NewCandidateRepository(db)
+
+    // Si el DELETE no borra ninguna fila se deshace la transacción sin evento candidate.deleted
+    mock.ExpectBegin()
+    mock.ExpectExec("UPDATE notes").WillReturnResult(sqlmock.NewResult(0, 0))
+    mock.ExpectExec("UPDATE documents").WillReturnResult(sqlmock.NewResult(0, 0))
+    for _, table := range []string{"candidate_tags", "candidate_field_values", "candidate_contacts", "candidate_phones"} {
+        mock.ExpectExec("DELETE FROM " + table).WillReturnResult(sqlmock.NewResult(0, 0))
+    }
+    mock.ExpectExec(regexp.QuoteMeta("DELETE FROM candidates WHERE id = ?")).
+        WithArgs(9999).
+        WillReturnResult(sqlmock.NewResult(0, 0))
+    mock.ExpectRollback()
+
+    err = repo.Delete(9999)
+    assert.ErrorIs(t, err, repository.ErrCandidateNotFound)
+    assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestDeleteCandidate(t *testing.T) {
     db, mock, err := sqlmock.New()
     assert.NoError(t, err)
@@ -155,9 +245,33 @@ func TestDeleteCandidate(t *testing.T) {
 
     deleteQuery := regexp.QuoteMeta("DELETE FROM candidates WHERE id = ?")
 
+    // Las notas y documentos del candidato se marcan como borrados y sus etiquetas y campos se borran en la misma transacción
+    mock.ExpectBegin()
+    mock.ExpectExec(regexp.QuoteMeta("UPDATE notes SET deleted_at = CURRENT_TIMESTAMP WHERE deleted_at IS NULL AND candidate_id = ?")).
+        WithArgs(10).
+        WillReturnResult(sqlmock.NewResult(0, 2))
+    mock.ExpectExec(regexp.QuoteMeta("UPDATE documents SET deleted_at = CURRENT_TIMESTAMP WHERE deleted_at IS NULL AND candidate_id = ?")).
+        WithArgs(10).
+        WillReturnResult(sqlmock.NewResult(0, 1))
+    mock.ExpectExec(regexp.QuoteMeta("DELETE FROM candidate_tags WHERE candidate_id = ?")).
+        WithArgs(10).
+        WillReturnResult(sqlmock.NewResult(0, 3))
+    mock.ExpectExec(regexp.QuoteMeta("DELETE FROM candidate_field_values WHERE candidate_id = ?")).
+        WithArgs(10).
+        WillReturnResult(sqlmock.NewResult(0, 1))
+    mock.ExpectExec(regexp.QuoteMeta("DELETE FROM candidate_contacts WHERE candidate_id = ?")).
+        WithArgs(10).
+        WillReturnResult(sqlmock.NewResult(0, 1))
+    mock.ExpectExec(regexp.QuoteMeta("DELETE FROM candidate_phones WHERE candidate_id = ?")).
+        WithArgs(10).
+        WillReturnResult(sqlmock.NewResult(0, 2))
     mock.ExpectExec(deleteQuery).
         WithArgs(10).
         WillReturnResult(sqlmock.NewResult(0, 1))
+    mock.ExpectExec(regexp.QuoteMeta("INSERT INTO outbox")).
+        WithArgs(domain.EventCandidateDeleted, 10, sqlmock.AnyArg(), `{"id":10}`).
+        WillReturnResult(sqlmock.NewResult(3, 1))
+    mock.ExpectCommit()
 
     err = repo.Delete(10)
     assert.NoError(t, err)
@@ -165,3 +279,186 @@ func TestDeleteCandidate(t *testing.T) {
     err = mock.ExpectationsWereMet()
     assert.NoError(t, err)
 }
+
+func TestCreateBatchCandidates(t *testing.T) {
+    db, mock, err := sqlmock.New()
+    assert.NoError(t, err)
+    defer db.Close()
+
+    repo := repository.NewCandidateRepository(db)
+
+    candidates := []domain.Candidate{
+        {Name: "User One", Email: "one@example.com", Gender: "female", SalaryExpected: 30000.0},
+        {Name: "User Two", Email: "two@example.com", Gender: "male", SalaryExpected: 32000.0},
+    }
+
+    // Un solo INSERT multi-fila dentro de una transacción
+    insertQuery := regexp.QuoteMeta("INSERT INTO candidates (name, email, gender, salary_expected) VALUES (?, ?, ?, ?), (?, ?, ?, ?)")
+
+    mock.ExpectBegin()
+    mock.ExpectExec(insertQuery).
+        WithArgs("User One", "one@example.com", "female", 30000.0, "User Two", "two@example.com", "male", 32000.0).
+        WillReturnResult(sqlmock.NewResult(5, 2))
+    // Los IDs se leen por email: no tienen por qué ser consecutivos ni venir en orden
+    mock.ExpectQuery(regexp.QuoteMeta("SELECT id, email FROM candidates WHERE email IN (?,?)")).
+        WithArgs("one@example.com", "two@example.com").
+        WillReturnRows(sqlmock.NewRows([]string{"id", "email"}).AddRow(9, "two@example.com").AddRow(5, "one@example.com"))
+    // Un evento por candidato con el ID asignado
+    mock.ExpectExec(regexp.QuoteMeta("INSERT INTO outbox (event_type, aggregate_id, idempotency_key, payload) VALUES (?, ?, ?, ?), (?, ?, ?, ?)")).
+        WithArgs(domain.EventCandidateCreated, 5, sqlmock.AnyArg(), sqlmock.AnyArg(),
+            domain.EventCandidateCreated, 9, sqlmock.AnyArg(), sqlmock.AnyArg()).
+        WillReturnResult(sqlmock.NewResult(4, 2))
+    mock.ExpectCommit()
+
+    ids, err := repo.CreateBatch(candidates)
+    assert.NoError(t, err)
+    assert.Equal(t, []int{5, 9}, ids)
+
+    err = mock.ExpectationsWereMet()
+    assert.NoError(t, err)
+}
+
+func TestUpdateBatchCandidates_Rollback(t *testing.T) {
+    db, mock, err := sqlmock.New()
+    assert.NoError(t, err)
+    defer db.Close()
+
+    repo := repository.NewCandidateRepository(db)
+
+    updateQuery := regexp.QuoteMeta("UPDATE candidates SET name = ?, email = ?, gender = ?, salary_expected = ? WHERE id = ?")
+
+    candidates := []domain.Candidate{
+        {ID: 1, Name: "User One", Email: "one@example.com"},
+        {ID: 2, Name: "User Two", Email: "two@example.com"},
+    }
+
+    // Si falla el segundo UPDATE se hace rollback de todo
+    mock.ExpectBegin()
+    prep := mock.ExpectPrepare(updateQuery)
+    prep.ExpectExec().WithArgs("User One", "one@example.com", "", 0.0, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+    prep.ExpectExec().WithArgs("User Two", "two@example.com", "", 0.0, 2).WillReturnError(errors.New("duplicate entry"))
+    mock.ExpectRollback()
+
+    err = repo.UpdateBatch(candidates)
+    assert.Error(t, err)
+
+    err = mock.ExpectationsWereMet()
+    assert.NoError(t, err)
+}
+
+func TestGetAllCandidates_Filter(t *testing.T) {
+    db, mock, err := sqlmock.New()
+    assert.NoError(t, err)
+    defer db.Close()
+
+    repo := repository.NewCandidateRepository(db)
+
+    selectQuery := regexp.QuoteMeta("SELECT id, name, email, gender, salary_expected, created_at, updated_at FROM candidates WHERE name LIKE ? AND gender = ? AND salary_expected >= ?")
+
+    now := time.Now()
+    rows := sqlmock.NewRows([]string{
+        "id", "name", "email", "gender", "salary_expected", "created_at", "updated_at",
+    }).AddRow(4, "Anna Walker", "anna.walker@example.com", "female", 32000.0, now, now)
+
+    mock.ExpectQuery(selectQuery).
+        WithArgs("%anna%", "female", 30000.0).
+        WillReturnRows(rows)
+
+    salaryMin := 30000.0
+    candidates, err := repo.GetAll(domain.CandidateFilter{Name: "anna", Gender: "female", SalaryMin: &salaryMin})
+    assert.NoError(t, err)
+    assert.Len(t, candidates, 1)
+    assert.Equal(t, "Anna Walker", candidates[0].Name)
+
+    err = mock.ExpectationsWereMet()
+    assert.NoError(t, err)
+}
+
+func TestGetAllCandidatesByTagsAndCustomFields(t *testing.T) {
+    db, mock, err := sqlmock.New()
+    assert.NoError(t, err)
+    defer db.Close()
+
+    repo := repository.NewCandidateRepository(db)
+
+    // Cada etiqueta, el país y cada campo personalizado agregan una subconsulta EXISTS
+    selectQuery := regexp.QuoteMeta("SELECT id, name, email, gender, salary_expected, created_at, updated_at FROM candidates WHERE " +
+        "EXISTS (SELECT 1 FROM candidate_tags t WHERE t.candidate_id = candidates.id AND t.tag = ?) AND " +
+        "EXISTS (SELECT 1 FROM candidate_contacts ct WHERE ct.candidate_id = candidates.id AND ct.country = ?) AND " +
+        "EXISTS (SELECT 1 FROM candidate_field_values v WHERE v.candidate_id = candidates.id AND v.field_key = ? AND v.value_number IS NOT NULL AND v.value_number >= ?) AND " +
+        "EXISTS (SELECT 1 FROM candidate_field_values v WHERE v.candidate_id = candidates.id AND v.field_key = ? AND v.value_text = ?)")
+
+    now := time.Now()
+    rows := sqlmock.NewRows([]string{
+        "id", "name", "email", "gender", "salary_expected", "created_at", "updated_at",
+    }).AddRow(4, "Anna Walker", "anna.walker@example.com", "female", 32000.0, now, now)
+
+    mock.ExpectQuery(selectQuery).
+        WithArgs("backend", "PE", "years_experience", 3.0, "stack", "go").
+        WillReturnRows(rows)
+
+    minYears := 3.0
+    candidates, err := repo.GetAll(domain.CandidateFilter{
+        Tags:    []string{"Backend"},
+        Country: "pe",
+        Fields: []domain.FieldCondition{
+            {Key: "years_experience", Type: domain.FieldNumber, Min: &minYears},
+            {Key: "stack", Type: domain.FieldMultiSelect, Value: "go"},
+        },
+    })
+    assert.NoError(t, err)
+    assert.Len(t, candidates, 1)
+
+    err = mock.ExpectationsWereMet()
+    assert.NoError(t, err)
+}
+
+func TestMergeCandidates(t *testing.T) {
+    db, mock, err := sqlmock.New()
+    assert.NoError(t, err)
+    defer db.Close()
+
+    repo := repository.NewCandidateRepository(db)
+
+    target := domain.Candidate{ID: 4, Name: "Anna Walker", Email: "anna.walker@gmail.com", Gender: "female", SalaryExpected: 35000.0}
+    entry := domain.CandidateHistoryEntry{CandidateID: 4, Action: domain.HistoryMerged, Details: []byte(`{"source_id":9}`), Actor: "recruiter"}
+
+    // Todo dentro de una transacción: mover datos relacionados, borrar origen, actualizar destino, historial
+    mock.ExpectBegin()
+    mock.ExpectExec(regexp.QuoteMeta("UPDATE candidate_history SET candidate_id = ? WHERE candidate_id = ?")).
+        WithArgs(4, 9).WillReturnResult(sqlmock.NewResult(0, 2))
+    mock.ExpectExec(regexp.QuoteMeta("UPDATE applications SET candidate_id = ? WHERE candidate_id = ?")).
+        WithArgs(4, 9).WillReturnResult(sqlmock.NewResult(0, 1))
+    mock.ExpectExec(regexp.QuoteMeta("UPDATE interviews SET candidate_id = ? WHERE candidate_id = ?")).
+        WithArgs(4, 9).WillReturnResult(sqlmock.NewResult(0, 0))
+    mock.ExpectExec(regexp.QuoteMeta("UPDATE feedback SET candidate_id = ? WHERE candidate_id = ?")).
+        WithArgs(4, 9).WillReturnResult(sqlmock.NewResult(0, 0))
+    mock.ExpectExec(regexp.QuoteMeta("UPDATE notes SET candidate_id = ? WHERE candidate_id = ?")).
+        WithArgs(4, 9).WillReturnResult(sqlmock.NewResult(0, 0))
+    mock.ExpectExec(regexp.QuoteMeta("UPDATE documents SET candidate_id = ? WHERE candidate_id = ?")).
+        WithArgs(4, 9).WillReturnResult(sqlmock.NewResult(0, 0))
+    mock.ExpectExec(regexp.QuoteMeta("DELETE FROM candidate_tags WHERE candidate_id = ?")).
+        WithArgs(9).WillReturnResult(sqlmock.NewResult(0, 0))
+    mock.ExpectExec(regexp.QuoteMeta("DELETE FROM candidate_field_values WHERE candidate_id = ?")).
+        WithArgs(9).WillReturnResult(sqlmock.NewResult(0, 0))
+    mock.ExpectExec(regexp.QuoteMeta("DELETE FROM candidate_contacts WHERE candidate_id = ?")).
+        WithArgs(9).WillReturnResult(sqlmock.NewResult(0, 0))
+    mock.ExpectExec(regexp.QuoteMeta("DELETE FROM candidate_phones WHERE candidate_id = ?")).
+        WithArgs(9).WillReturnResult(sqlmock.NewResult(0, 0))
+    mock.ExpectExec(regexp.QuoteMeta("DELETE FROM candidates WHERE id = ?")).
+        WithArgs(9).WillReturnResult(sqlmock.NewResult(0, 1))
+    mock.ExpectExec(regexp.QuoteMeta("UPDATE candidates SET name = ?, email = ?, gender = ?, salary_expected = ? WHERE id = ?")).
+        WithArgs("Anna Walker", "anna.walker@gmail.com", "female", 35000.0, 4).WillReturnResult(sqlmock.NewResult(0, 1))
+    mock.ExpectExec(regexp.QuoteMeta("INSERT INTO candidate_history (candidate_id, action, details, actor) VALUES (?, ?, ?, ?)")).
+        WithArgs(4, "merged", `{"source_id":9}`, "recruiter").WillReturnResult(sqlmock.NewResult(1, 1))
+    mock.ExpectExec(regexp.QuoteMeta("INSERT INTO outbox")).
+        WithArgs(domain.EventCandidateMerged, 4, sqlmock.AnyArg(), sqlmock.AnyArg()).
+        WillReturnResult(sqlmock.NewResult(5, 1))
+    mock.ExpectCommit()
+
+    err = repo.Merge(target, 9, entry)
+    assert.NoError(t, err)
+
+    err = mock.ExpectationsWereMet()
+    assert.NoError(t, err)
+}
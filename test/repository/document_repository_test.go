@@ -0,0 +1,52 @@
+package repository_test
+
+import (
+    "regexp"
+    "testing"
+
+    "github.com/DATA-DOG/go-sqlmock"
+    "github.com/stretchr/testify/assert"
+
+    "github.com/torvictorvic/seek-v2/internal/domain"
+    "github.com/torvictorvic/seek-v2/internal/repository"
+)
+
+func TestCreateDocument(t *testing.T) {
+    db, mock, err := sqlmock.New()
+    assert.NoError(t, err)
+    defer db.Close()
+
+    repo := repository.NewDocumentRepository(db)
+    doc := domain.Document{CandidateID: 4, FileName: "cv.pdf", ContentType: "application/pdf", Kind: domain.DocumentPDF,
+        Size: 120, SHA256: "abc", StorageKey: "candidates/4/k", UploadedBy: "ana", Text: "Jane Doe"}
+
+    // El documento y su entrada de historial se guardan en la misma transacción
+    mock.ExpectBegin()
+    mock.ExpectExec(regexp.QuoteMeta("INSERT INTO documents (candidate_id, file_name, content_type, kind, size, sha256, storage_key, uploaded_by, text_content)")).
+        WithArgs(4, "cv.pdf", "application/pdf", "pdf", int64(120), "abc", "candidates/4/k", "ana", "Jane Doe").
+        WillReturnResult(sqlmock.NewResult(9, 1))
+    mock.ExpectExec(regexp.QuoteMeta("INSERT INTO candidate_history (candidate_id, action, details, actor)")).
+        WithArgs(4, domain.HistoryDocumentAdded, `{"document_id":9,"file_name":"cv.pdf"}`, "ana").
+        WillReturnResult(sqlmock.NewResult(1, 1))
+    mock.ExpectCommit()
+
+    id, err := repo.Create(doc)
+    assert.NoError(t, err)
+    assert.Equal(t, 9, id)
+    assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPurgeDocument_OnlyDeleted(t *testing.T) {
+    db, mock, err := sqlmock.New()
+    assert.NoError(t, err)
+    defer db.Close()
+
+    repo := repository.NewDocumentRepository(db)
+
+    mock.ExpectExec(regexp.QuoteMeta("DELETE FROM documents WHERE id = ? AND deleted_at IS NOT NULL")).
+        WithArgs(9).
+        WillReturnResult(sqlmock.NewResult(0, 1))
+
+    assert.NoError(t, repo.Purge(9))
+    assert.NoError(t, mock.ExpectationsWereMet())
+}
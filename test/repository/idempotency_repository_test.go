@@ -0,0 +1,127 @@
+package repository_test
+
+import (
+    "regexp"
+    "testing"
+    "time"
+
+    "github.com/DATA-DOG/go-sqlmock"
+    "github.com/stretchr/testify/assert"
+
+    "github.com/torvictorvic/seek-v2/internal/domain"
+    "github.com/torvictorvic/seek-v2/internal/repository"
+)
+
+func TestIdempotencyBegin(t *testing.T) {
+    db, mock, err := sqlmock.New()
+    assert.NoError(t, err)
+    defer db.Close()
+
+    repo := repository.NewIdempotencyRepository(db)
+    now := time.Now()
+    record := domain.IdempotencyRecord{Key: "ana|k1", Fingerprint: "abc", ExpiresAt: now.Add(time.Minute)}
+
+    // La primera petición inserta la clave en curso
+    mock.ExpectExec(regexp.QuoteMeta("DELETE FROM idempotency_keys WHERE idempotency_key = ? AND expires_at < ?")).
+        WithArgs("ana|k1", now).
+        WillReturnResult(sqlmock.NewResult(0, 0))
+    mock.ExpectExec(regexp.QuoteMeta("INSERT IGNORE INTO idempotency_keys (idempotency_key, fingerprint, expires_at) VALUES (?, ?, ?)")).
+        WithArgs("ana|k1", "abc", record.ExpiresAt).
+        WillReturnResult(sqlmock.NewResult(0, 1))
+
+    saved, created, err := repo.Begin(record, now)
+    assert.NoError(t, err)
+    assert.True(t, created)
+    assert.True(t, saved.InFlight())
+
+    // La siguiente lee la respuesta guardada
+    mock.ExpectExec(regexp.QuoteMeta("DELETE FROM idempotency_keys")).WillReturnResult(sqlmock.NewResult(0, 0))
+    mock.ExpectExec(regexp.QuoteMeta("INSERT IGNORE INTO idempotency_keys")).WillReturnResult(sqlmock.NewResult(0, 0))
+    mock.ExpectQuery(regexp.QuoteMeta("SELECT idempotency_key, fingerprint, status_code, content_type, response_body, expires_at FROM idempotency_keys WHERE idempotency_key = ?")).
+        WithArgs("ana|k1").
+        WillReturnRows(sqlmock.NewRows([]string{"idempotency_key", "fingerprint", "status_code", "content_type", "response_body", "expires_at"}).
+            AddRow("ana|k1", "abc", 201, "application/json", []byte(`{"id":1}`), now.Add(time.Hour)))
+
+    existing, created, err := repo.Begin(record, now)
+    assert.NoError(t, err)
+    assert.False(t, created)
+    assert.Equal(t, 201, existing.StatusCode)
+    assert.Equal(t, `{"id":1}`, string(existing.Body))
+    assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestIdempotencyCompleteAndRelease(t *testing.T) {
+    db, mock, err := sqlmock.New()
+    assert.NoError(t, err)
+    defer db.Close()
+
+    repo := repository.NewIdempotencyRepository(db)
+    expires := time.Now().Add(time.Hour)
+
+    mock.ExpectExec(regexp.QuoteMeta("UPDATE idempotency_keys SET status_code = ?, content_type = ?, response_body = ?, expires_at = ? WHERE idempotency_key = ?")).
+        WithArgs(201, "application/json", []byte(`{"id":1}`), expires, "ana|k1").
+        WillReturnResult(sqlmock.NewResult(0, 1))
+    // Solo se libera una clave que sigue en curso
+    mock.ExpectExec(regexp.QuoteMeta("DELETE FROM idempotency_keys WHERE idempotency_key = ? AND status_code = 0")).
+        WithArgs("ana|k2").
+        WillReturnResult(sqlmock.NewResult(0, 1))
+    mock.ExpectExec(regexp.QuoteMeta("DELETE FROM idempotency_keys WHERE expires_at < ?")).
+        WillReturnResult(sqlmock.NewResult(0, 3))
+
+    assert.NoError(t, repo.Complete("ana|k1", 201, "application/json", []byte(`{"id":1}`), expires))
+    assert.NoError(t, repo.Release("ana|k2"))
+    n, err := repo.PurgeExpired(time.Now())
+    assert.NoError(t, err)
+    assert.Equal(t, int64(3), n)
+    assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMemoryIdempotencyExpiry(t *testing.T) {
+    repo := repository.NewMemoryIdempotencyRepository()
+    now := time.Now()
+    record := domain.IdempotencyRecord{Key: "ana|k1", Fingerprint: "abc", ExpiresAt: now.Add(time.Minute)}
+
+    _, created, _ := repo.Begin(record, now)
+    assert.True(t, created)
+    assert.NoError(t, repo.Complete("ana|k1", 201, "application/json", []byte(`{"id":1}`), now.Add(time.Hour)))
+
+    existing, created, _ := repo.Begin(record, now.Add(30*time.Minute))
+    assert.False(t, created)
+    assert.Equal(t, 201, existing.StatusCode)
+
+    // Una respuesta completa no se libera
+    assert.NoError(t, repo.Release("ana|k1"))
+    _, created, _ = repo.Begin(record, now.Add(30*time.Minute))
+    assert.False(t, created)
+
+    // Pasado el TTL la clave se puede volver a usar
+    _, created, _ = repo.Begin(record, now.Add(2*time.Hour))
+    assert.True(t, created)
+
+    // Una petición en curso abandonada caduca con el bloqueo
+    n, _ := repo.PurgeExpired(now.Add(3 * time.Hour))
+    assert.Equal(t, int64(1), n)
+}
+
+// Fuera de MySQL las consultas usan los marcadores del dialecto y ON CONFLICT
+func TestIdempotencyBegin_Postgres(t *testing.T) {
+    db, mock, err := sqlmock.New()
+    assert.NoError(t, err)
+    defer db.Close()
+
+    repo := repository.NewIdempotencyRepositoryFor(db, repository.Postgres)
+    now := time.Now()
+    record := domain.IdempotencyRecord{Key: "ana|k1", Fingerprint: "abc", ExpiresAt: now.Add(time.Minute)}
+
+    mock.ExpectExec(regexp.QuoteMeta("DELETE FROM idempotency_keys WHERE idempotency_key = $1 AND expires_at < $2")).
+        WithArgs("ana|k1", now).
+        WillReturnResult(sqlmock.NewResult(0, 0))
+    mock.ExpectExec(regexp.QuoteMeta("INSERT INTO idempotency_keys (idempotency_key, fingerprint, expires_at) VALUES ($1, $2, $3) ON CONFLICT (idempotency_key) DO NOTHING")).
+        WithArgs("ana|k1", "abc", record.ExpiresAt).
+        WillReturnResult(sqlmock.NewResult(0, 1))
+
+    _, created, err := repo.Begin(record, now)
+    assert.NoError(t, err)
+    assert.True(t, created)
+    assert.NoError(t, mock.ExpectationsWereMet())
+}
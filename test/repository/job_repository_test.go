@@ -0,0 +1,96 @@
+package repository_test
+
+import (
+    "regexp"
+    "testing"
+    "time"
+
+    "github.com/DATA-DOG/go-sqlmock"
+    "github.com/stretchr/testify/assert"
+
+    "github.com/torvictorvic/seek-v2/internal/domain"
+    "github.com/torvictorvic/seek-v2/internal/repository"
+)
+
+func TestCreateJob(t *testing.T) {
+    db, mock, err := sqlmock.New()
+    assert.NoError(t, err)
+    defer db.Close()
+
+    repo := repository.NewJobRepository(db)
+
+    job := domain.Job{
+        Title:         "Backend Engineer",
+        Department:    "Engineering",
+        Location:      "Lima",
+        SalaryMin:     3000,
+        SalaryMax:     4500,
+        Status:        domain.JobOpen,
+        HiringManager: "maria",
+    }
+
+    insertQuery := regexp.QuoteMeta("INSERT INTO jobs (title, department, location, salary_min, salary_max, status, hiring_manager) VALUES (?, ?, ?, ?, ?, ?, ?)")
+    mock.ExpectExec(insertQuery).
+        WithArgs(job.Title, job.Department, job.Location, job.SalaryMin, job.SalaryMax, job.Status, job.HiringManager).
+        WillReturnResult(sqlmock.NewResult(7, 1))
+
+    id, err := repo.Create(job)
+    assert.NoError(t, err)
+    assert.Equal(t, 7, id)
+    assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetByIDJob_NotFound(t *testing.T) {
+    db, mock, err := sqlmock.New()
+    assert.NoError(t, err)
+    defer db.Close()
+
+    repo := repository.NewJobRepository(db)
+
+    selectQuery := regexp.QuoteMeta("FROM jobs WHERE id = ?")
+    mock.ExpectQuery(selectQuery).WithArgs(99).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+    job, err := repo.GetByID(99)
+    assert.NoError(t, err)
+    assert.Nil(t, job)
+    assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetAllJobs_Filter(t *testing.T) {
+    db, mock, err := sqlmock.New()
+    assert.NoError(t, err)
+    defer db.Close()
+
+    repo := repository.NewJobRepository(db)
+
+    // El título se busca por coincidencia parcial, el resto por igualdad
+    selectQuery := regexp.QuoteMeta("FROM jobs WHERE title LIKE ? AND status = ?")
+    now := time.Now()
+    rows := sqlmock.NewRows([]string{"id", "title", "department", "location", "salary_min", "salary_max", "status", "hiring_manager", "created_at", "updated_at"}).
+        AddRow(1, "Backend Engineer", "Engineering", "Lima", 3000.0, 4500.0, "open", "maria", now, now)
+    mock.ExpectQuery(selectQuery).WithArgs("%Backend%", "open").WillReturnRows(rows)
+
+    jobs, err := repo.GetAll(domain.JobFilter{Title: "Backend", Status: "open"})
+    assert.NoError(t, err)
+    assert.Len(t, jobs, 1)
+    assert.Equal(t, "Backend Engineer", jobs[0].Title)
+    assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreateJob_Postgres(t *testing.T) {
+    db, mock, err := sqlmock.New()
+    assert.NoError(t, err)
+    defer db.Close()
+
+    // PostgreSQL usa $n y devuelve el ID con RETURNING
+    repo := repository.NewJobRepositoryFor(db, repository.Postgres)
+    job := domain.Job{Title: "Backend Engineer", Status: domain.JobOpen, HiringManager: "maria"}
+    mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO jobs (title, department, location, salary_min, salary_max, status, hiring_manager) VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id")).
+        WithArgs(job.Title, job.Department, job.Location, job.SalaryMin, job.SalaryMax, job.Status, job.HiringManager).
+        WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7))
+
+    id, err := repo.Create(job)
+    assert.NoError(t, err)
+    assert.Equal(t, 7, id)
+    assert.NoError(t, mock.ExpectationsWereMet())
+}
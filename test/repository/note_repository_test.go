@@ -0,0 +1,83 @@
+package repository_test
+
+import (
+    "errors"
+    "regexp"
+    "testing"
+
+    "github.com/DATA-DOG/go-sqlmock"
+    "github.com/stretchr/testify/assert"
+
+    "github.com/torvictorvic/seek-v2/internal/domain"
+    "github.com/torvictorvic/seek-v2/internal/repository"
+)
+
+func TestDeleteNote(t *testing.T) {
+    db, mock, err := sqlmock.New()
+    assert.NoError(t, err)
+    defer db.Close()
+
+    repo := repository.NewNoteRepository(db)
+    note := domain.Note{ID: 7, CandidateID: 4, Author: "ana", Visibility: domain.NoteTeam}
+
+    // El borrado es lógico y queda en el historial dentro de la misma transacción
+    mock.ExpectBegin()
+    mock.ExpectExec(regexp.QuoteMeta("UPDATE notes SET deleted_at = CURRENT_TIMESTAMP WHERE id = ?")).
+        WithArgs(7).
+        WillReturnResult(sqlmock.NewResult(0, 1))
+    mock.ExpectExec(regexp.QuoteMeta("INSERT INTO candidate_history (candidate_id, action, details, actor)")).
+        WithArgs(4, domain.HistoryNoteDeleted, sqlmock.AnyArg(), "ana").
+        WillReturnResult(sqlmock.NewResult(1, 1))
+    mock.ExpectCommit()
+
+    err = repo.Delete(note, "ana")
+    assert.NoError(t, err)
+    assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteNote_HistoryErrorRollsBack(t *testing.T) {
+    db, mock, err := sqlmock.New()
+    assert.NoError(t, err)
+    defer db.Close()
+
+    repo := repository.NewNoteRepository(db)
+
+    mock.ExpectBegin()
+    mock.ExpectExec(regexp.QuoteMeta("UPDATE notes SET deleted_at")).
+        WillReturnResult(sqlmock.NewResult(0, 1))
+    mock.ExpectExec(regexp.QuoteMeta("INSERT INTO candidate_history")).
+        WillReturnError(errors.New("db error"))
+    mock.ExpectRollback()
+
+    err = repo.Delete(domain.Note{ID: 7, CandidateID: 4}, "ana")
+    assert.Error(t, err)
+    assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreateNote_Postgres(t *testing.T) {
+    db, mock, err := sqlmock.New()
+    assert.NoError(t, err)
+    defer db.Close()
+
+    repo := repository.NewNoteRepositoryFor(db, repository.Postgres)
+    note := domain.Note{CandidateID: 4, Author: "ana", Body: "Hola @luis", Visibility: domain.NoteTeam, Mentions: []string{"luis"}}
+    notifications := []domain.Notification{{User: "luis", Type: domain.NotificationMention, CandidateID: 4, Actor: "ana", Message: "ana te mencionó"}}
+
+    // La nota, su historial y las notificaciones usan $n; user es una palabra reservada en PostgreSQL
+    mock.ExpectBegin()
+    mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO notes (candidate_id, author, body, visibility, mentions) VALUES ($1, $2, $3, $4, $5) RETURNING id")).
+        WithArgs(4, "ana", "Hola @luis", domain.NoteTeam, "luis").
+        WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(9))
+    mock.ExpectExec(regexp.QuoteMeta("INSERT INTO candidate_history (candidate_id, action, details, actor) VALUES ($1, $2, $3, $4)")).
+        WithArgs(4, domain.HistoryNoteAdded, sqlmock.AnyArg(), "ana").
+        WillReturnResult(sqlmock.NewResult(0, 1))
+    mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO notifications ("user", type, candidate_id, note_id, actor, message) VALUES ($1, $2, $3, $4, $5, $6)`)).
+        WithArgs("luis", domain.NotificationMention, 4, 9, "ana", "ana te mencionó").
+        WillReturnResult(sqlmock.NewResult(0, 1))
+    mock.ExpectCommit()
+
+    id, err := repo.Create(note, notifications)
+    assert.NoError(t, err)
+    assert.Equal(t, 9, id)
+    assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,75 @@
+package repository_test
+
+import (
+    "regexp"
+    "testing"
+    "time"
+
+    "github.com/DATA-DOG/go-sqlmock"
+    "github.com/stretchr/testify/assert"
+
+    "github.com/torvictorvic/seek-v2/internal/repository"
+)
+
+func TestOutboxPending(t *testing.T) {
+    db, mock, err := sqlmock.New()
+    assert.NoError(t, err)
+    defer db.Close()
+
+    repo := repository.NewOutboxRepository(db)
+    now := time.Now()
+
+    // Los pendientes se leen en el orden en que se escribieron
+    mock.ExpectQuery(regexp.QuoteMeta("FROM outbox WHERE published_at IS NULL ORDER BY id LIMIT ?")).
+        WithArgs(50).
+        WillReturnRows(sqlmock.NewRows([]string{"id", "event_type", "aggregate_id", "idempotency_key", "payload", "created_at", "attempts"}).
+            AddRow(7, "candidate.created", 4, "0123456789abcdef0123456789abcdef", `{"id":4}`, now, 0))
+
+    events, err := repo.Pending(50)
+    assert.NoError(t, err)
+    assert.Len(t, events, 1)
+    assert.Equal(t, int64(7), events[0].ID)
+    assert.JSONEq(t, `{"id":4}`, string(events[0].Payload))
+    assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestOutboxMarkPublishedAndLag(t *testing.T) {
+    db, mock, err := sqlmock.New()
+    assert.NoError(t, err)
+    defer db.Close()
+
+    repo := repository.NewOutboxRepository(db)
+    oldest := time.Now().Add(-time.Minute)
+
+    mock.ExpectExec(regexp.QuoteMeta("UPDATE outbox SET published_at = CURRENT_TIMESTAMP, attempts = attempts + 1, last_error = NULL\n        WHERE id IN (?, ?)")).
+        WithArgs(7, 8).
+        WillReturnResult(sqlmock.NewResult(0, 2))
+    mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*), MIN(created_at) FROM outbox WHERE published_at IS NULL")).
+        WillReturnRows(sqlmock.NewRows([]string{"count", "min"}).AddRow(3, oldest))
+
+    assert.NoError(t, repo.MarkPublished([]int64{7, 8}))
+    pending, since, err := repo.Lag()
+    assert.NoError(t, err)
+    assert.Equal(t, 3, pending)
+    assert.WithinDuration(t, oldest, *since, time.Second)
+    assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestOutboxPending_Postgres(t *testing.T) {
+    db, mock, err := sqlmock.New()
+    assert.NoError(t, err)
+    defer db.Close()
+
+    repo := repository.NewOutboxRepositoryFor(db, repository.Postgres)
+    mock.ExpectQuery(regexp.QuoteMeta("FROM outbox WHERE published_at IS NULL ORDER BY id LIMIT $1")).
+        WithArgs(50).
+        WillReturnRows(sqlmock.NewRows([]string{"id", "event_type", "aggregate_id", "idempotency_key", "payload", "created_at", "attempts"}))
+    mock.ExpectExec(regexp.QuoteMeta("WHERE id IN ($1, $2)")).
+        WithArgs(int64(7), int64(8)).
+        WillReturnResult(sqlmock.NewResult(0, 2))
+
+    _, err = repo.Pending(50)
+    assert.NoError(t, err)
+    assert.NoError(t, repo.MarkPublished([]int64{7, 8}))
+    assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,181 @@
+package repository_test
+
+import (
+    "context"
+    "database/sql"
+    "errors"
+    "regexp"
+    "testing"
+    "time"
+
+    "github.com/DATA-DOG/go-sqlmock"
+    "github.com/go-sql-driver/mysql"
+    "github.com/stretchr/testify/assert"
+    "github.com/stretchr/testify/require"
+
+    "github.com/torvictorvic/seek-v2/internal/domain"
+    "github.com/torvictorvic/seek-v2/internal/repository"
+    "github.com/torvictorvic/seek-v2/internal/service"
+)
+
+var selectCandidates = regexp.QuoteMeta("SELECT id, name, email, gender, salary_expected, created_at, updated_at FROM candidates")
+
+type mockDB struct {
+    db   *sql.DB
+    mock sqlmock.Sqlmock
+}
+
+func newMockDBs(t *testing.T, n int) []mockDB {
+    dbs := make([]mockDB, n)
+    for i := range dbs {
+        db, mock, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
+        require.NoError(t, err)
+        t.Cleanup(func() { db.Close() })
+        dbs[i] = mockDB{db: db, mock: mock}
+    }
+    return dbs
+}
+
+func expectList(m mockDB, name string) {
+    m.mock.ExpectQuery(selectCandidates).WillReturnRows(
+        sqlmock.NewRows([]string{"id", "name", "email", "gender", "salary_expected", "created_at", "updated_at"}).
+            AddRow(1, name, "jane@example.com", "F", "5000", time.Now(), time.Now()))
+}
+
+func expectationsMet(t *testing.T, dbs []mockDB) {
+    for _, m := range dbs {
+        assert.NoError(t, m.mock.ExpectationsWereMet())
+    }
+}
+
+// newRoutedRepo devuelve el repositorio sobre dbs[0] con las réplicas dbs[1:], ligado a la petición de ana
+func newRoutedRepo(dbs []mockDB, opts ...repository.ReplicaOption) (repository.CandidateRepository, *repository.ReplicaRouter) {
+    var replicas []*sql.DB
+    for _, m := range dbs[1:] {
+        replicas = append(replicas, m.db)
+    }
+    router := repository.NewReplicaRouter(dbs[0].db, replicas, opts...)
+    repo := repository.NewCandidateRepositoryFor(dbs[0].db, repository.MySQL, repository.WithReplicas(router))
+    return repository.Bind(repository.ReadAs(context.Background(), "ana"), repo), router
+}
+
+func TestReplicaRouter_RoundRobin(t *testing.T) {
+    dbs := newMockDBs(t, 3)
+    repo, _ := newRoutedRepo(dbs)
+
+    // Las lecturas se reparten entre las réplicas por turnos
+    expectList(dbs[1], "first")
+    expectList(dbs[2], "second")
+    expectList(dbs[1], "third")
+    for _, name := range []string{"first", "second", "third"} {
+        candidates, err := repo.GetAll(domain.CandidateFilter{})
+        require.NoError(t, err)
+        assert.Equal(t, name, candidates[0].Name)
+    }
+    expectationsMet(t, dbs)
+}
+
+func TestReplicaRouter_UnboundReadsFromPrimary(t *testing.T) {
+    dbs := newMockDBs(t, 2)
+    router := repository.NewReplicaRouter(dbs[0].db, []*sql.DB{dbs[1].db})
+    repo := repository.NewCandidateRepositoryFor(dbs[0].db, repository.MySQL, repository.WithReplicas(router))
+
+    // Fuera de una petición (importador, procesos internos) se lee del primario
+    expectList(dbs[0], "primary")
+    _, err := repo.GetAll(domain.CandidateFilter{})
+    require.NoError(t, err)
+    expectationsMet(t, dbs)
+}
+
+func TestReplicaRouter_ReadYourWrites(t *testing.T) {
+    dbs := newMockDBs(t, 2)
+    now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+    repo, router := newRoutedRepo(dbs,
+        repository.WithReadYourWritesWindow(5*time.Second),
+        repository.WithReplicaClock(func() time.Time { return now }))
+
+    dbs[0].mock.ExpectBegin()
+    dbs[0].mock.ExpectExec(insertCandidate).WillReturnResult(sqlmock.NewResult(7, 1))
+    dbs[0].mock.ExpectExec(insertOutbox).WillReturnResult(sqlmock.NewResult(1, 1))
+    dbs[0].mock.ExpectCommit()
+    _, err := repo.Create(domain.Candidate{Name: "Jane", Email: "jane@example.com"})
+    require.NoError(t, err)
+
+    // Tras escribir, ana lee del primario durante la ventana; los demás usuarios siguen en la réplica
+    expectList(dbs[0], "primary")
+    _, err = repo.GetAll(domain.CandidateFilter{})
+    require.NoError(t, err)
+    assert.Same(t, dbs[1].db, router.Reader("bob"))
+
+    now = now.Add(5 * time.Second)
+    expectList(dbs[1], "replica")
+    _, err = repo.GetAll(domain.CandidateFilter{})
+    require.NoError(t, err)
+    expectationsMet(t, dbs)
+}
+
+func TestReplicaRouter_FailedReplica(t *testing.T) {
+    dbs := newMockDBs(t, 3)
+    repo, router := newRoutedRepo(dbs)
+
+    // La réplica que no responde queda fuera y la consulta se repite en la siguiente
+    dbs[1].mock.ExpectQuery(selectCandidates).WillReturnError(errors.New("connection refused"))
+    expectList(dbs[2], "second")
+    candidates, err := repo.GetAll(domain.CandidateFilter{})
+    require.NoError(t, err)
+    assert.Equal(t, "second", candidates[0].Name)
+    assert.Same(t, dbs[2].db, router.Reader("bob"))
+    assert.Same(t, dbs[2].db, router.Reader("bob"))
+
+    // Si fallan todas se lee del primario
+    dbs[2].mock.ExpectQuery(selectCandidates).WillReturnError(errors.New("connection refused"))
+    expectList(dbs[0], "primary")
+    candidates, err = repo.GetAll(domain.CandidateFilter{})
+    require.NoError(t, err)
+    assert.Equal(t, "primary", candidates[0].Name)
+
+    // El health check vuelve a incluir las réplicas que responden
+    dbs[1].mock.ExpectPing()
+    dbs[2].mock.ExpectPing().WillReturnError(errors.New("connection refused"))
+    router.CheckReplicas(context.Background())
+    assert.Same(t, dbs[1].db, router.Reader("bob"))
+    assert.Same(t, dbs[1].db, router.Reader("bob"))
+    expectationsMet(t, dbs)
+}
+
+func TestReplicaRouter_ServerErrorNotRetried(t *testing.T) {
+    dbs := newMockDBs(t, 3)
+    repo, router := newRoutedRepo(dbs)
+
+    // Un error de la propia consulta también fallaría en el primario: se devuelve sin marcar la réplica
+    dbs[1].mock.ExpectQuery(selectCandidates).WillReturnError(&mysql.MySQLError{Number: 1054, Message: "Unknown column"})
+    _, err := repo.GetAll(domain.CandidateFilter{})
+    assert.Error(t, err)
+    assert.Same(t, dbs[2].db, router.Reader("bob"))
+    assert.Same(t, dbs[1].db, router.Reader("bob"))
+    expectationsMet(t, dbs)
+}
+
+func TestReplicaRouter_DuplicatesReadFromReplicas(t *testing.T) {
+    dbs := newMockDBs(t, 2)
+    router := repository.NewReplicaRouter(dbs[0].db, []*sql.DB{dbs[1].db})
+    repo := repository.NewCandidateRepositoryFor(dbs[0].db, repository.MySQL, repository.WithReplicas(router))
+    svc := service.NewDuplicateService(repo)
+
+    // La búsqueda de duplicados de una petición lee el candidato y el resto de la réplica
+    expectList(dbs[1], "Jane")
+    expectList(dbs[1], "Jane")
+    _, err := service.DuplicatesForUser(svc, "ana").FindDuplicates(1, 0, 0)
+    require.NoError(t, err)
+    expectationsMet(t, dbs)
+}
+
+func TestReplicaRouter_RunWithoutInterval(t *testing.T) {
+    dbs := newMockDBs(t, 2)
+    router := repository.NewReplicaRouter(dbs[0].db, []*sql.DB{dbs[1].db})
+
+    // Un intervalo no positivo usa el de por defecto en vez de hacer fallar el ticker
+    ctx, cancel := context.WithCancel(context.Background())
+    cancel()
+    assert.NotPanics(t, func() { router.Run(ctx, 0) })
+}
@@ -0,0 +1,228 @@
+package repository_test
+
+import (
+    "context"
+    "errors"
+    "regexp"
+    "testing"
+
+    "github.com/DATA-DOG/go-sqlmock"
+    "github.com/go-sql-driver/mysql"
+    "github.com/stretchr/testify/assert"
+    "github.com/stretchr/testify/require"
+
+    "github.com/torvictorvic/seek-v2/internal/domain"
+    "github.com/torvictorvic/seek-v2/internal/repository"
+)
+
+var (
+    insertCandidate = regexp.QuoteMeta("INSERT INTO candidates (name, email, gender, salary_expected) VALUES (?, ?, ?, ?)")
+    insertOutbox    = regexp.QuoteMeta("INSERT INTO outbox")
+    insertHistory   = regexp.QuoteMeta("INSERT INTO candidate_history (candidate_id, action, details, actor) VALUES (?, ?, ?, ?)")
+)
+
+func TestUnitOfWork_Commit(t *testing.T) {
+    db, mock, err := sqlmock.New()
+    require.NoError(t, err)
+    defer db.Close()
+
+    uow := repository.NewUnitOfWork(db)
+    candidates := repository.NewCandidateRepository(db)
+    history := repository.NewCandidateHistoryRepository(db)
+
+    // El candidato y su entrada del historial se guardan en la misma transacción;
+    // la transacción propia del repositorio pasa a ser un savepoint
+    mock.ExpectBegin()
+    mock.ExpectExec(regexp.QuoteMeta("SAVEPOINT sp_1")).WillReturnResult(sqlmock.NewResult(0, 0))
+    mock.ExpectExec(insertCandidate).WillReturnResult(sqlmock.NewResult(7, 1))
+    mock.ExpectExec(insertOutbox).WillReturnResult(sqlmock.NewResult(1, 1))
+    mock.ExpectExec(regexp.QuoteMeta("RELEASE SAVEPOINT sp_1")).WillReturnResult(sqlmock.NewResult(0, 0))
+    mock.ExpectExec(insertHistory).WithArgs(7, "created", nil, "ana").WillReturnResult(sqlmock.NewResult(1, 1))
+    mock.ExpectCommit()
+
+    err = uow.Do(context.Background(), func(ctx context.Context) error {
+        id, err := repository.Bind(ctx, candidates).Create(domain.Candidate{Name: "Jane", Email: "jane@example.com"})
+        if err != nil {
+            return err
+        }
+        _, err = repository.Bind(ctx, history).Add(domain.CandidateHistoryEntry{CandidateID: id, Action: "created", Actor: "ana"})
+        return err
+    })
+    assert.NoError(t, err)
+    assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUnitOfWork_RollbackOnError(t *testing.T) {
+    db, mock, err := sqlmock.New()
+    require.NoError(t, err)
+    defer db.Close()
+
+    uow := repository.NewUnitOfWork(db)
+    candidates := repository.NewCandidateRepository(db)
+    history := repository.NewCandidateHistoryRepository(db)
+
+    // Si falla el historial, el candidato creado también se deshace
+    mock.ExpectBegin()
+    mock.ExpectExec(regexp.QuoteMeta("SAVEPOINT sp_1")).WillReturnResult(sqlmock.NewResult(0, 0))
+    mock.ExpectExec(insertCandidate).WillReturnResult(sqlmock.NewResult(7, 1))
+    mock.ExpectExec(insertOutbox).WillReturnResult(sqlmock.NewResult(1, 1))
+    mock.ExpectExec(regexp.QuoteMeta("RELEASE SAVEPOINT sp_1")).WillReturnResult(sqlmock.NewResult(0, 0))
+    mock.ExpectExec(insertHistory).WillReturnError(errors.New("disk full"))
+    mock.ExpectRollback()
+
+    err = uow.Do(context.Background(), func(ctx context.Context) error {
+        id, err := repository.Bind(ctx, candidates).Create(domain.Candidate{Name: "Jane", Email: "jane@example.com"})
+        if err != nil {
+            return err
+        }
+        _, err = repository.Bind(ctx, history).Add(domain.CandidateHistoryEntry{CandidateID: id, Action: "created"})
+        return err
+    })
+    assert.ErrorContains(t, err, "disk full")
+    assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUnitOfWork_RollbackOnPanic(t *testing.T) {
+    db, mock, err := sqlmock.New()
+    require.NoError(t, err)
+    defer db.Close()
+
+    uow := repository.NewUnitOfWork(db)
+    history := repository.NewCandidateHistoryRepository(db)
+
+    mock.ExpectBegin()
+    mock.ExpectExec(insertHistory).WillReturnResult(sqlmock.NewResult(1, 1))
+    mock.ExpectRollback()
+
+    // El panic sigue su curso después de deshacer la transacción
+    assert.PanicsWithValue(t, "boom", func() {
+        _ = uow.Do(context.Background(), func(ctx context.Context) error {
+            _, _ = repository.Bind(ctx, history).Add(domain.CandidateHistoryEntry{CandidateID: 1, Action: "created"})
+            panic("boom")
+        })
+    })
+    assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUnitOfWork_NestedSavepoint(t *testing.T) {
+    db, mock, err := sqlmock.New()
+    require.NoError(t, err)
+    defer db.Close()
+
+    uow := repository.NewUnitOfWork(db)
+    history := repository.NewCandidateHistoryRepository(db)
+
+    // El error del bloque anidado solo deshace su savepoint y la transacción se confirma
+    mock.ExpectBegin()
+    mock.ExpectExec(insertHistory).WithArgs(1, "first", nil, "").WillReturnResult(sqlmock.NewResult(1, 1))
+    mock.ExpectExec(regexp.QuoteMeta("SAVEPOINT sp_1")).WillReturnResult(sqlmock.NewResult(0, 0))
+    mock.ExpectExec(insertHistory).WithArgs(1, "second", nil, "").WillReturnResult(sqlmock.NewResult(2, 1))
+    mock.ExpectExec(regexp.QuoteMeta("ROLLBACK TO SAVEPOINT sp_1")).WillReturnResult(sqlmock.NewResult(0, 0))
+    mock.ExpectExec(regexp.QuoteMeta("SAVEPOINT sp_2")).WillReturnResult(sqlmock.NewResult(0, 0))
+    mock.ExpectExec(insertHistory).WithArgs(1, "third", nil, "").WillReturnResult(sqlmock.NewResult(3, 1))
+    mock.ExpectExec(regexp.QuoteMeta("RELEASE SAVEPOINT sp_2")).WillReturnResult(sqlmock.NewResult(0, 0))
+    mock.ExpectCommit()
+
+    add := func(ctx context.Context, action string) error {
+        _, err := repository.Bind(ctx, history).Add(domain.CandidateHistoryEntry{CandidateID: 1, Action: action})
+        return err
+    }
+    failed := errors.New("skip")
+    err = uow.Do(context.Background(), func(ctx context.Context) error {
+        if err := add(ctx, "first"); err != nil {
+            return err
+        }
+        err := uow.Do(ctx, func(ctx context.Context) error {
+            _ = add(ctx, "second")
+            return failed
+        })
+        assert.ErrorIs(t, err, failed)
+        return uow.Do(ctx, func(ctx context.Context) error {
+            return add(ctx, "third")
+        })
+    })
+    assert.NoError(t, err)
+    assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUnitOfWork_RetriesDeadlock(t *testing.T) {
+    db, mock, err := sqlmock.New()
+    require.NoError(t, err)
+    defer db.Close()
+
+    uow := repository.NewUnitOfWork(db, repository.WithTxRetries(2, 0))
+    history := repository.NewCandidateHistoryRepository(db)
+
+    // El primer intento muere por un deadlock y se repite entero
+    mock.ExpectBegin()
+    mock.ExpectExec(insertHistory).WillReturnError(&mysql.MySQLError{Number: 1213, Message: "Deadlock found when trying to get lock"})
+    mock.ExpectRollback()
+    mock.ExpectBegin()
+    mock.ExpectExec(insertHistory).WillReturnResult(sqlmock.NewResult(1, 1))
+    mock.ExpectCommit()
+
+    attempts := 0
+    err = uow.Do(context.Background(), func(ctx context.Context) error {
+        attempts++
+        _, err := repository.Bind(ctx, history).Add(domain.CandidateHistoryEntry{CandidateID: 1, Action: "created"})
+        return err
+    })
+    assert.NoError(t, err)
+    assert.Equal(t, 2, attempts)
+    assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUnitOfWork_RetryLimit(t *testing.T) {
+    db, mock, err := sqlmock.New()
+    require.NoError(t, err)
+    defer db.Close()
+
+    uow := repository.NewUnitOfWork(db, repository.WithTxRetries(1, 0))
+    deadlock := &mysql.MySQLError{Number: 1213, Message: "Deadlock found when trying to get lock"}
+
+    for i := 0; i < 2; i++ {
+        mock.ExpectBegin()
+        mock.ExpectRollback()
+    }
+    attempts := 0
+    err = uow.Do(context.Background(), func(ctx context.Context) error {
+        attempts++
+        return deadlock
+    })
+    assert.ErrorIs(t, err, deadlock)
+    assert.Equal(t, 2, attempts)
+
+    // Los demás errores no se reintentan
+    mock.ExpectBegin()
+    mock.ExpectRollback()
+    attempts = 0
+    err = uow.Do(context.Background(), func(ctx context.Context) error {
+        attempts++
+        return &mysql.MySQLError{Number: 1062, Message: "Duplicate entry"}
+    })
+    assert.Error(t, err)
+    assert.Equal(t, 1, attempts)
+    assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestBind_WithoutTransaction(t *testing.T) {
+    db, mock, err := sqlmock.New()
+    require.NoError(t, err)
+    defer db.Close()
+
+    // Sin transacción, o si el repositorio no puede unirse a ella, se devuelve el mismo
+    repo := repository.NewCandidateRepository(db)
+    assert.Same(t, repo, repository.Bind(context.Background(), repo))
+
+    memory := repository.NewMemoryCandidateRepository()
+    uow := repository.NewUnitOfWork(db)
+    mock.ExpectBegin()
+    mock.ExpectCommit()
+    err = uow.Do(context.Background(), func(ctx context.Context) error {
+        assert.Same(t, memory, repository.Bind(ctx, memory))
+        assert.NotSame(t, repo, repository.Bind(ctx, repo))
+        return nil
+    })
+    assert.NoError(t, err)
+    assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,117 @@
+package resume_test
+
+import (
+    "archive/zip"
+    "bytes"
+    "compress/zlib"
+    "fmt"
+    "strings"
+    "testing"
+
+    "github.com/stretchr/testify/assert"
+
+    "github.com/torvictorvic/seek-v2/internal/domain"
+    "github.com/torvictorvic/seek-v2/internal/exporter"
+    "github.com/torvictorvic/seek-v2/internal/resume"
+)
+
+// pdfWith construye un PDF de una página con el contenido dado, comprimido o no
+func pdfWith(content string, compress bool) []byte {
+    var buf bytes.Buffer
+    buf.WriteString("%PDF-1.4\n1 0 obj\n<< /Type /Catalog /Pages 2 0 R >>\nendobj\n")
+    buf.WriteString("2 0 obj\n<< /Type /Pages /Kids [3 0 R] /Count 1 >>\nendobj\n")
+    buf.WriteString("3 0 obj\n<< /Type /Page /Parent 2 0 R /Contents 4 0 R >>\nendobj\n")
+    data, filter := []byte(content), ""
+    if compress {
+        var z bytes.Buffer
+        zw := zlib.NewWriter(&z)
+        zw.Write(data)
+        zw.Close()
+        data, filter = z.Bytes(), " /Filter /FlateDecode"
+    }
+    fmt.Fprintf(&buf, "4 0 obj\n<< /Length %d%s >>\nstream\n", len(data), filter)
+    buf.Write(data)
+    buf.WriteString("\nendstream\nendobj\ntrailer\n<< /Root 1 0 R >>\n%%EOF\n")
+    return buf.Bytes()
+}
+
+func TestPDFText_Operators(t *testing.T) {
+    content := `BT /F1 18 Tf 72 760 Td (JANE DOE) Tj ET
+BT /F1 10 Tf 72 740 Td (jane.doe@example.com) Tj 200 0 Td (+51 987 654 321) Tj ET
+BT 72 720 Td [(Desa) 20 (rrollo en) -300 (Go)] TJ 0 -14 Td <4B756265726E65746573> Tj
+T* (Caf\351 \(Lima\)) Tj 0 -14 Td <FEFF00C9006C00E8007600650020> Tj ET`
+
+    for _, compress := range []bool{false, true} {
+        text := resume.PDFText(pdfWith(content, compress))
+        assert.Equal(t, "JANE DOE\njane.doe@example.com +51 987 654 321\nDesarrollo en Go\nKubernetes\nCafé (Lima)\nÉlève", text)
+    }
+}
+
+func TestPDFText_ExporterReport(t *testing.T) {
+    // Un PDF real: el reporte del exportador, con flujos FlateDecode
+    var buf bytes.Buffer
+    columns, err := exporter.Columns([]string{"name", "email"}, "es")
+    assert.NoError(t, err)
+    w, err := exporter.NewWriter(exporter.FormatPDF, &buf, columns, "Candidatos")
+    assert.NoError(t, err)
+    assert.NoError(t, w.WriteRow(domain.Candidate{Name: "José Pérez", Email: "jose@example.com"}))
+    assert.NoError(t, w.Close())
+
+    text := resume.PDFText(buf.Bytes())
+    assert.Contains(t, text, "Candidatos")
+    assert.Contains(t, text, "José Pérez jose@example.com")
+}
+
+func TestDOCXText(t *testing.T) {
+    var buf bytes.Buffer
+    zw := zip.NewWriter(&buf)
+    w, _ := zw.Create("word/document.xml")
+    w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?>
+<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"><w:body>
+<w:p><w:r><w:t>Jane</w:t></w:r><w:r><w:t xml:space="preserve"> Doe</w:t></w:r></w:p>
+<w:p><w:r><w:t>Email:</w:t><w:tab/><w:t>jane@example.com</w:t><w:br/><w:t>Lima</w:t></w:r></w:p>
+</w:body></w:document>`))
+    zw.Close()
+
+    text, err := resume.DOCXText(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+    assert.NoError(t, err)
+    assert.Equal(t, "Jane Doe\nEmail: jane@example.com\nLima", text)
+
+    _, err = resume.DOCXText(strings.NewReader("no es un zip"), 12)
+    assert.Error(t, err)
+}
+
+const sampleResume = `CURRICULUM VITAE
+MARÍA JOSÉ QUISPE
+Backend Developer | Lima, Perú
+maria.quispe@Example.com · +51 (987) 654-321 · 01 234 5678
+linkedin.com/in/mariaquispe | https://github.com/mquispe | www.mquispe.dev.
+Experiencia
+2019 - 2023 Desarrolladora en ACME: APIs en Go y Node.js sobre Kubernetes y AWS.
+Tecnologías: PostgreSQL, Redis, JavaScript, C++ y análisis de datos.`
+
+func TestParse(t *testing.T) {
+    s := resume.NewParser().Parse(sampleResume)
+
+    assert.Equal(t, "María José Quispe", s.Candidate.Name)
+    assert.Equal(t, "maria.quispe@example.com", s.Candidate.Email)
+    // Los años "2019 - 2023" no son un teléfono y un fijo de 9 dígitos sí
+    assert.Equal(t, []string{"+51987654321", "012345678"}, s.Phones)
+    assert.Equal(t, []domain.ResumeLink{
+        {Kind: domain.LinkLinkedIn, URL: "https://linkedin.com/in/mariaquispe"},
+        {Kind: domain.LinkGitHub, URL: "https://github.com/mquispe"},
+        {Kind: domain.LinkPortfolio, URL: "https://www.mquispe.dev"},
+    }, s.Links)
+    // "javascript" no implica "java"
+    assert.Equal(t, []string{"aws", "c++", "data analysis", "go", "javascript", "kubernetes", "node.js", "postgresql", "redis"}, s.Tags)
+}
+
+func TestParse_KnownTags(t *testing.T) {
+    // Las etiquetas en uso también se sugieren, las muy cortas se ignoran
+    p := resume.NewParser(resume.TagSkills([]string{"fintech", "go", "ux"})...)
+    s := p.Parse("Jane Doe\nExperiencia en FinTech y UX")
+    assert.Equal(t, []string{"fintech"}, s.Tags)
+    assert.Equal(t, "Jane Doe", s.Candidate.Name)
+    assert.Empty(t, s.Phones)
+    assert.Empty(t, s.Links)
+}
@@ -0,0 +1,150 @@
+package search_test
+
+import (
+    "regexp"
+    "strings"
+    "testing"
+    "time"
+
+    "github.com/DATA-DOG/go-sqlmock"
+    "github.com/stretchr/testify/assert"
+
+    "github.com/torvictorvic/seek-v2/internal/domain"
+    "github.com/torvictorvic/seek-v2/internal/repository"
+    "github.com/torvictorvic/seek-v2/internal/search"
+)
+
+// Mismos datos que la migración V2
+var seed = []domain.Candidate{
+    {ID: 1, Name: "Roy Smith", Email: "roy.smith@example.com", Gender: "male", SalaryExpected: 30000},
+    {ID: 2, Name: "Charles Adams", Email: "charles.adams@example.com", Gender: "male", SalaryExpected: 35000},
+    {ID: 3, Name: "Louis Johnson", Email: "louis.johnson@example.com", Gender: "male", SalaryExpected: 40000},
+    {ID: 4, Name: "Anna Walker", Email: "anna.walker@example.com", Gender: "female", SalaryExpected: 32000},
+    {ID: 5, Name: "Tania Roberts", Email: "tania.roberts@example.com", Gender: "female", SalaryExpected: 36000},
+}
+
+func newIndex() *search.MemoryIndex {
+    idx := search.NewMemoryIndex()
+    for _, c := range seed {
+        idx.Index(c)
+    }
+    return idx
+}
+
+func TestMemoryIndex_Typos(t *testing.T) {
+    hits, err := newIndex().Search("ana walkr", domain.CandidateFilter{}, 10)
+    assert.NoError(t, err)
+    assert.Len(t, hits, 1)
+    assert.Equal(t, 4, hits[0].Candidate.ID)
+    assert.Equal(t, "<mark>Anna</mark> <mark>Walker</mark>", hits[0].Highlights["name"])
+}
+
+func TestMemoryIndex_RequestExample(t *testing.T) {
+    idx := search.NewMemoryIndex()
+    idx.Index(domain.Candidate{ID: 1, Name: "Ana Walker", Email: "awalker@example.com"})
+
+    hits, err := idx.Search("anna walkr", domain.CandidateFilter{}, 10)
+    assert.NoError(t, err)
+    assert.Len(t, hits, 1)
+}
+
+func TestMemoryIndex_PrefixAndRanking(t *testing.T) {
+    hits, err := newIndex().Search("ro", domain.CandidateFilter{}, 10)
+    assert.NoError(t, err)
+    // "Roy" y "Roberts"; el prefijo más largo respecto a la palabra puntúa más
+    assert.Len(t, hits, 2)
+    assert.Equal(t, 1, hits[0].Candidate.ID)
+    assert.Equal(t, 5, hits[1].Candidate.ID)
+}
+
+func TestMemoryIndex_AccentsAndFilters(t *testing.T) {
+    idx := newIndex()
+    idx.Index(domain.Candidate{ID: 6, Name: "José Pérez", Email: "jose@example.com", Gender: "male", SalaryExpected: 50000})
+
+    hits, err := idx.Search("perez", domain.CandidateFilter{}, 10)
+    assert.NoError(t, err)
+    assert.Len(t, hits, 1)
+    assert.Equal(t, "José <mark>Pérez</mark>", hits[0].Highlights["name"])
+
+    // Se combina con los filtros del listado
+    max := 40000.0
+    hits, err = idx.Search("perez", domain.CandidateFilter{SalaryMax: &max}, 10)
+    assert.NoError(t, err)
+    assert.Empty(t, hits)
+
+    idx.Remove(6)
+    hits, err = idx.Search("jose", domain.CandidateFilter{}, 10)
+    assert.NoError(t, err)
+    assert.Empty(t, hits)
+}
+
+func TestMemoryIndex_ResumeSnippet(t *testing.T) {
+    idx := newIndex()
+    text := strings.Repeat("Experiencia previa en varias empresas de retail. ", 4) + "Lideró la migración a Kubernetes en 2021. " +
+        strings.Repeat("Cursos de liderazgo y comunicación efectiva. ", 4)
+    idx.IndexResume(4, text)
+    // Reindexar el candidato conserva el texto del CV
+    idx.Index(seed[3])
+
+    hits, err := idx.Search("kubernetes", domain.CandidateFilter{}, 10)
+    assert.NoError(t, err)
+    assert.Len(t, hits, 1)
+    snippet := hits[0].Highlights["resume"]
+    assert.True(t, strings.HasPrefix(snippet, "…") && strings.HasSuffix(snippet, "…"), snippet)
+    assert.Contains(t, snippet, "migración a <mark>Kubernetes</mark> en 2021")
+    assert.Less(t, len(snippet), len(text))
+}
+
+func TestMemoryIndex_Rebuild(t *testing.T) {
+    idx := newIndex()
+    idx.IndexResume(4, "Lideró la migración a Kubernetes")
+
+    // Lo escrito por otros procesos aparece al reconstruir, lo borrado desaparece y el CV se conserva
+    idx.Rebuild([]domain.Candidate{seed[3], {ID: 6, Name: "Laura Walker", Email: "laura@example.com"}})
+    hits, err := idx.Search("walker", domain.CandidateFilter{}, 10)
+    assert.NoError(t, err)
+    assert.Len(t, hits, 2)
+    hits, err = idx.Search("roy", domain.CandidateFilter{}, 10)
+    assert.NoError(t, err)
+    assert.Empty(t, hits)
+    hits, err = idx.Search("kubernetes", domain.CandidateFilter{}, 10)
+    assert.NoError(t, err)
+    assert.Len(t, hits, 1)
+}
+
+func TestMySQLSearcher(t *testing.T) {
+    db, mock, err := sqlmock.New()
+    assert.NoError(t, err)
+    defer db.Close()
+
+    searcher := repository.NewCandidateSearcher(db)
+
+    query := regexp.QuoteMeta("SELECT id, name, email, gender, salary_expected, created_at, updated_at FROM candidates WHERE " +
+        "(MATCH(name, email) AGAINST (? IN BOOLEAN MODE) OR id IN (SELECT candidate_id FROM documents WHERE deleted_at IS NULL AND MATCH(text_content) AGAINST (? IN BOOLEAN MODE))) " +
+        "AND gender = ? LIMIT 500")
+
+    // FULLTEXT devuelve candidatos por prefijo corto, el ranking descarta los que no coinciden
+    now := time.Now()
+    rows := sqlmock.NewRows([]string{"id", "name", "email", "gender", "salary_expected", "created_at", "updated_at"}).
+        AddRow(4, "Anna Walker", "anna.walker@example.com", "female", 32000.0, now, now).
+        AddRow(7, "Annabel Walsh", "annabel@example.com", "female", 31000.0, now, now).
+        AddRow(9, "Jane Doe", "jane@example.com", "female", 30000.0, now, now)
+
+    mock.ExpectQuery(query).
+        WithArgs("+(anna* ann*) +(walkr* wal*)", "+(anna* ann*) +(walkr* wal*)", "female").
+        WillReturnRows(rows)
+    // Jane coincide por el texto de su CV
+    mock.ExpectQuery(regexp.QuoteMeta("SELECT candidate_id, text_content FROM documents WHERE candidate_id IN (?, ?, ?) AND deleted_at IS NULL AND text_content IS NOT NULL")).
+        WithArgs(4, 7, 9).
+        WillReturnRows(sqlmock.NewRows([]string{"candidate_id", "text_content"}).
+            AddRow(9, "Referencias: Anna Walker, gerente de ACME"))
+
+    hits, err := searcher.Search("Anna walkr", domain.CandidateFilter{Gender: "female"}, 10)
+    assert.NoError(t, err)
+    assert.Len(t, hits, 2)
+    assert.Equal(t, 4, hits[0].Candidate.ID)
+    assert.Equal(t, 9, hits[1].Candidate.ID)
+    assert.Equal(t, "Referencias: <mark>Anna</mark> <mark>Walker</mark>, gerente de ACME", hits[1].Highlights["resume"])
+
+    assert.NoError(t, mock.ExpectationsWereMet())
+}
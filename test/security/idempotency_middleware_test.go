@@ -0,0 +1,146 @@
+package security_test
+
+import (
+    "io"
+    "net/http"
+    "net/http/httptest"
+    "strings"
+    "sync/atomic"
+    "testing"
+
+    "github.com/gin-gonic/gin"
+    "github.com/stretchr/testify/assert"
+
+    "github.com/torvictorvic/seek-v2/internal/config"
+    "github.com/torvictorvic/seek-v2/internal/repository"
+    "github.com/torvictorvic/seek-v2/internal/security"
+)
+
+var idempotencyCfg = config.IdempotencyConfig{TTL: 3600, LockTimeout: 60, MaxBodyBytes: 1024}
+
+type idempotencyRouter struct {
+    *gin.Engine
+    calls   atomic.Int32
+    release chan struct{} // si no es nil, el handler espera a que se cierre
+    started chan struct{}
+}
+
+func newIdempotencyRouter(store repository.IdempotencyRepository) *idempotencyRouter {
+    gin.SetMode(gin.TestMode)
+    r := &idempotencyRouter{Engine: gin.New()}
+    r.Use(gin.Recovery())
+    r.Use(func(c *gin.Context) {
+        c.Set(security.UserKey, c.GetHeader("X-User"))
+    })
+    r.Use(security.IdempotencyMiddleware(store, idempotencyCfg))
+    r.POST("/api/candidates", func(c *gin.Context) {
+        n := r.calls.Add(1)
+        if r.release != nil {
+            close(r.started)
+            <-r.release
+        }
+        body, _ := io.ReadAll(c.Request.Body)
+        switch string(body) {
+        case "fail":
+            c.JSON(http.StatusInternalServerError, gin.H{"error": "boom"})
+        case "panic":
+            panic("boom")
+        case "invalid":
+            c.JSON(http.StatusBadRequest, gin.H{"error": "JSON no valid"})
+        default:
+            c.JSON(http.StatusCreated, gin.H{"id": n})
+        }
+    })
+    r.GET("/api/candidates", func(c *gin.Context) {
+        r.calls.Add(1)
+        c.JSON(http.StatusOK, gin.H{})
+    })
+    return r
+}
+
+func (r *idempotencyRouter) post(key, user, body string) *httptest.ResponseRecorder {
+    req := httptest.NewRequest(http.MethodPost, "/api/candidates", strings.NewReader(body))
+    if key != "" {
+        req.Header.Set("Idempotency-Key", key)
+    }
+    req.Header.Set("X-User", user)
+    w := httptest.NewRecorder()
+    r.ServeHTTP(w, req)
+    return w
+}
+
+func TestIdempotency_ReplaysFirstResponse(t *testing.T) {
+    r := newIdempotencyRouter(repository.NewMemoryIdempotencyRepository())
+
+    first := r.post("k1", "ana", `{"name":"Jane"}`)
+    assert.Equal(t, http.StatusCreated, first.Code)
+    assert.Empty(t, first.Header().Get("Idempotent-Replayed"))
+
+    // El reintento devuelve la misma respuesta sin volver a ejecutar el handler
+    replay := r.post("k1", "ana", `{"name":"Jane"}`)
+    assert.Equal(t, http.StatusCreated, replay.Code)
+    assert.Equal(t, first.Body.String(), replay.Body.String())
+    assert.Equal(t, "true", replay.Header().Get("Idempotent-Replayed"))
+    assert.Equal(t, first.Header().Get("Content-Type"), replay.Header().Get("Content-Type"))
+    assert.Equal(t, int32(1), r.calls.Load())
+
+    // Los errores del cliente también se guardan
+    r.post("k2", "ana", "invalid")
+    assert.Equal(t, http.StatusBadRequest, r.post("k2", "ana", "invalid").Code)
+    assert.Equal(t, int32(2), r.calls.Load())
+
+    // Sin clave, con otro usuario o en un GET no interviene
+    r.post("", "ana", `{"name":"Jane"}`)
+    assert.Equal(t, http.StatusCreated, r.post("k1", "luis", `{"name":"Jane"}`).Code)
+    get := httptest.NewRequest(http.MethodGet, "/api/candidates", nil)
+    get.Header.Set("Idempotency-Key", "k1")
+    r.ServeHTTP(httptest.NewRecorder(), get)
+    assert.Equal(t, int32(5), r.calls.Load())
+}
+
+func TestIdempotency_DifferentPayload(t *testing.T) {
+    r := newIdempotencyRouter(repository.NewMemoryIdempotencyRepository())
+
+    r.post("k1", "ana", `{"name":"Jane"}`)
+    w := r.post("k1", "ana", `{"name":"John"}`)
+    assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+    assert.Equal(t, int32(1), r.calls.Load())
+
+    w = r.post(strings.Repeat("k", security.MaxIdempotencyKeyLength+1), "ana", `{}`)
+    assert.Equal(t, http.StatusBadRequest, w.Code)
+
+    w = r.post("k3", "ana", strings.Repeat("x", idempotencyCfg.MaxBodyBytes+1))
+    assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+    assert.Equal(t, int32(1), r.calls.Load())
+}
+
+func TestIdempotency_ConcurrentDuplicate(t *testing.T) {
+    r := newIdempotencyRouter(repository.NewMemoryIdempotencyRepository())
+    r.release, r.started = make(chan struct{}), make(chan struct{})
+
+    done := make(chan *httptest.ResponseRecorder)
+    go func() { done <- r.post("k1", "ana", `{"name":"Jane"}`) }()
+    <-r.started
+
+    // Mientras la primera petición sigue en curso la segunda recibe 409
+    assert.Equal(t, http.StatusConflict, r.post("k1", "ana", `{"name":"Jane"}`).Code)
+
+    close(r.release)
+    assert.Equal(t, http.StatusCreated, (<-done).Code)
+    r.release = nil
+    assert.Equal(t, http.StatusCreated, r.post("k1", "ana", `{"name":"Jane"}`).Code)
+    assert.Equal(t, int32(1), r.calls.Load())
+}
+
+func TestIdempotency_ServerErrorsAreNotStored(t *testing.T) {
+    r := newIdempotencyRouter(repository.NewMemoryIdempotencyRepository())
+
+    assert.Equal(t, http.StatusInternalServerError, r.post("k1", "ana", "fail").Code)
+    assert.Equal(t, http.StatusInternalServerError, r.post("k1", "ana", "fail").Code)
+    assert.Equal(t, int32(2), r.calls.Load())
+
+    // Un panic también libera la clave
+    assert.Equal(t, http.StatusInternalServerError, r.post("k2", "ana", "panic").Code)
+    assert.Equal(t, http.StatusInternalServerError, r.post("k2", "ana", "panic").Code)
+    assert.Equal(t, int32(4), r.calls.Load())
+}
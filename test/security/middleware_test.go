@@ -0,0 +1,110 @@
+package security_test
+
+import (
+    "net/http"
+    "net/http/httptest"
+    "testing"
+
+    "github.com/gin-gonic/gin"
+    "github.com/stretchr/testify/assert"
+
+    "github.com/torvictorvic/seek-v2/internal/config"
+    "github.com/torvictorvic/seek-v2/internal/security"
+)
+
+func newRouter(cors config.CORSConfig, headers config.SecurityHeadersConfig) *gin.Engine {
+    gin.SetMode(gin.TestMode)
+    r := gin.New()
+    r.Use(security.SecurityHeadersMiddleware(headers, []string{"10.0.0.0/8"}))
+    r.Use(security.CORSMiddleware(cors))
+    r.GET("/api/candidates", func(c *gin.Context) { c.JSON(http.StatusOK, gin.H{}) })
+    r.GET("/swagger/index.html", func(c *gin.Context) { c.String(http.StatusOK, "ui") })
+    return r
+}
+
+var corsCfg = config.CORSConfig{
+    AllowedOrigins:   []string{"https://recruiter.example.com"},
+    AllowedMethods:   []string{"GET", "POST"},
+    AllowedHeaders:   []string{"Authorization", "Content-Type"},
+    AllowCredentials: true,
+    MaxAge:           300,
+}
+
+var headersCfg = config.SecurityHeadersConfig{
+    HSTSMaxAge:   3600,
+    FrameOptions: "DENY",
+    CSP:          "default-src 'none'",
+    SwaggerCSP:   "default-src 'self'",
+}
+
+func TestCORS_Preflight(t *testing.T) {
+    r := newRouter(corsCfg, headersCfg)
+
+    // La ruta OPTIONS no existe, el middleware debe responder igualmente
+    req := httptest.NewRequest(http.MethodOptions, "/api/candidates", nil)
+    req.Header.Set("Origin", "https://recruiter.example.com")
+    req.Header.Set("Access-Control-Request-Method", "POST")
+    w := httptest.NewRecorder()
+    r.ServeHTTP(w, req)
+
+    assert.Equal(t, http.StatusNoContent, w.Code)
+    assert.Equal(t, "https://recruiter.example.com", w.Header().Get("Access-Control-Allow-Origin"))
+    assert.Equal(t, "true", w.Header().Get("Access-Control-Allow-Credentials"))
+    assert.Equal(t, "GET, POST", w.Header().Get("Access-Control-Allow-Methods"))
+    assert.Equal(t, "300", w.Header().Get("Access-Control-Max-Age"))
+}
+
+func TestCORS_OriginNotAllowed(t *testing.T) {
+    r := newRouter(corsCfg, headersCfg)
+
+    req := httptest.NewRequest(http.MethodGet, "/api/candidates", nil)
+    req.Header.Set("Origin", "https://evil.example.com")
+    w := httptest.NewRecorder()
+    r.ServeHTTP(w, req)
+
+    assert.Equal(t, http.StatusOK, w.Code)
+    assert.Empty(t, w.Header().Get("Access-Control-Allow-Origin"))
+}
+
+func TestSecurityHeaders(t *testing.T) {
+    r := newRouter(corsCfg, headersCfg)
+
+    req := httptest.NewRequest(http.MethodGet, "/api/candidates", nil)
+    w := httptest.NewRecorder()
+    r.ServeHTTP(w, req)
+
+    assert.Equal(t, "nosniff", w.Header().Get("X-Content-Type-Options"))
+    assert.Equal(t, "DENY", w.Header().Get("X-Frame-Options"))
+    assert.Equal(t, "default-src 'none'", w.Header().Get("Content-Security-Policy"))
+    // Sin HTTPS no se envía HSTS
+    assert.Empty(t, w.Header().Get("Strict-Transport-Security"))
+
+    // Swagger UI usa su propia CSP
+    req = httptest.NewRequest(http.MethodGet, "/swagger/index.html", nil)
+    req.RemoteAddr = "10.1.2.3:4000"
+    req.Header.Set("X-Forwarded-Proto", "https")
+    w = httptest.NewRecorder()
+    r.ServeHTTP(w, req)
+
+    assert.Equal(t, "default-src 'self'", w.Header().Get("Content-Security-Policy"))
+    assert.Equal(t, "max-age=3600; includeSubDomains", w.Header().Get("Strict-Transport-Security"))
+}
+
+func TestSecurityHeaders_HSTSOnlyFromTrustedProxies(t *testing.T) {
+    r := newRouter(corsCfg, headersCfg)
+
+    // Un cliente que no pasa por el proxy no puede pedir HSTS con la cabecera
+    req := httptest.NewRequest(http.MethodGet, "/api/candidates", nil)
+    req.RemoteAddr = "203.0.113.7:4000"
+    req.Header.Set("X-Forwarded-Proto", "https")
+    w := httptest.NewRecorder()
+    r.ServeHTTP(w, req)
+    assert.Empty(t, w.Header().Get("Strict-Transport-Security"))
+
+    // Con TLS directo sí se envía
+    req = httptest.NewRequest(http.MethodGet, "https://seek.example.com/api/candidates", nil)
+    req.RemoteAddr = "203.0.113.7:4000"
+    w = httptest.NewRecorder()
+    r.ServeHTTP(w, req)
+    assert.Equal(t, "max-age=3600; includeSubDomains", w.Header().Get("Strict-Transport-Security"))
+}
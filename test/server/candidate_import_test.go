@@ -0,0 +1,59 @@
+package server_test
+
+import (
+    "bytes"
+    "database/sql"
+    "mime/multipart"
+    "net/http"
+    "net/http/httptest"
+    "testing"
+
+    "github.com/DATA-DOG/go-sqlmock"
+    "github.com/gin-gonic/gin"
+    "github.com/stretchr/testify/assert"
+    "github.com/stretchr/testify/require"
+
+    "github.com/torvictorvic/seek-v2/internal/handler"
+    "github.com/torvictorvic/seek-v2/internal/repository"
+    "github.com/torvictorvic/seek-v2/internal/security"
+    "github.com/torvictorvic/seek-v2/internal/service"
+)
+
+func TestImport_ReadsFromReplicas(t *testing.T) {
+    primary, primaryMock, err := sqlmock.New()
+    require.NoError(t, err)
+    defer primary.Close()
+    replica, replicaMock, err := sqlmock.New()
+    require.NoError(t, err)
+    defer replica.Close()
+    router := repository.NewReplicaRouter(primary, []*sql.DB{replica})
+    repo := repository.NewCandidateRepositoryFor(primary, repository.MySQL, repository.WithReplicas(router))
+    candidateHandler := handler.NewCandidateHandler(service.NewCandidateService(repo))
+
+    gin.SetMode(gin.TestMode)
+    r := gin.New()
+    r.POST("/api/candidates/import", func(c *gin.Context) {
+        c.Set(security.UserKey, "recruiter")
+    }, candidateHandler.ImportCandidates)
+
+    var body bytes.Buffer
+    form := multipart.NewWriter(&body)
+    file, err := form.CreateFormFile("file", "candidatos.csv")
+    require.NoError(t, err)
+    _, err = file.Write([]byte("name,email,gender,salary_expected\nJane Doe,jane@example.com,F,3000\n"))
+    require.NoError(t, err)
+    require.NoError(t, form.Close())
+
+    // La simulación busca el email en la réplica, como las demás lecturas del usuario
+    replicaMock.ExpectQuery("SELECT id, name, email").WithArgs("jane@example.com").
+        WillReturnRows(sqlmock.NewRows([]string{"id", "name", "email", "gender", "salary_expected", "created_at", "updated_at"}))
+    req := httptest.NewRequest(http.MethodPost, "/api/candidates/import?dry_run=true", &body)
+    req.Header.Set("Content-Type", form.FormDataContentType())
+    w := httptest.NewRecorder()
+    r.ServeHTTP(w, req)
+
+    require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+    assert.Contains(t, w.Body.String(), `"created":1`)
+    assert.NoError(t, replicaMock.ExpectationsWereMet())
+    assert.NoError(t, primaryMock.ExpectationsWereMet())
+}
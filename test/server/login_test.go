@@ -0,0 +1,41 @@
+package server_test
+
+import (
+    "encoding/json"
+    "net/http"
+    "testing"
+
+    "github.com/gin-gonic/gin"
+    "github.com/stretchr/testify/assert"
+    "github.com/stretchr/testify/require"
+
+    "github.com/torvictorvic/seek-v2/internal/handler"
+    "github.com/torvictorvic/seek-v2/internal/security"
+)
+
+func TestLogin(t *testing.T) {
+    t.Setenv("JWT_SECRET", "secreto")
+    gin.SetMode(gin.TestMode)
+    r := gin.New()
+    r.POST("/login", handler.NewAuthHandler(map[string]string{"maria": "clave"}).GenerateToken)
+
+    // El token lleva el usuario que inició sesión
+    w := do(r, http.MethodPost, "/login", `{"user": "maria", "password": "clave"}`)
+    require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+    var body struct{ Token string }
+    require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+    user, err := security.ValidateToken(body.Token)
+    require.NoError(t, err)
+    assert.Equal(t, "maria", user)
+
+    // Una contraseña incorrecta o un usuario que no existe no reciben token
+    for _, credentials := range []string{
+        `{"user": "maria", "password": "otra"}`,
+        `{"user": "luis", "password": "clave"}`,
+    } {
+        w = do(r, http.MethodPost, "/login", credentials)
+        assert.Equal(t, http.StatusUnauthorized, w.Code, credentials)
+        assert.NotContains(t, w.Body.String(), "token")
+    }
+    assert.Equal(t, http.StatusBadRequest, do(r, http.MethodPost, "/login", `{"user": "maria"}`).Code)
+}
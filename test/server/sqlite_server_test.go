@@ -0,0 +1,336 @@
+package server_test
+
+import (
+    "database/sql"
+    "encoding/json"
+    "net/http"
+    "net/http/httptest"
+    "path/filepath"
+    "strconv"
+    "strings"
+    "testing"
+    "time"
+
+    "github.com/gin-gonic/gin"
+    "github.com/stretchr/testify/assert"
+    "github.com/stretchr/testify/require"
+    _ "modernc.org/sqlite"
+
+    "github.com/torvictorvic/seek-v2/internal/config"
+    "github.com/torvictorvic/seek-v2/internal/domain"
+    "github.com/torvictorvic/seek-v2/internal/handler"
+    "github.com/torvictorvic/seek-v2/internal/repository"
+    "github.com/torvictorvic/seek-v2/internal/security"
+    "github.com/torvictorvic/seek-v2/internal/service"
+    "github.com/torvictorvic/seek-v2/migrations"
+)
+
+// newSQLiteServer monta las rutas de candidatos como cmd/main.go con DB_DRIVER=sqlite,
+// con el usuario de la cabecera X-User en lugar del token
+func newSQLiteServer(t *testing.T) (*gin.Engine, *sql.DB) {
+    db, err := sql.Open("sqlite", "file:"+filepath.Join(t.TempDir(), "seek.db")+"?_pragma=busy_timeout(5000)")
+    require.NoError(t, err)
+    t.Cleanup(func() { db.Close() })
+    dialect := repository.SQLite
+    require.NoError(t, repository.Migrate(db, dialect, migrations.FS))
+
+    customFieldRepo := repository.NewCustomFieldRepositoryFor(db, dialect)
+    attributeRepo := repository.NewCandidateAttributeRepositoryFor(db, dialect)
+    candidateService := service.NewCandidateService(repository.NewCandidateRepositoryFor(db, dialect),
+        service.WithUnitOfWork(repository.NewUnitOfWork(db)),
+        service.WithCustomFields(customFieldRepo, attributeRepo),
+        service.WithContactDetails(repository.NewCandidateContactRepositoryFor(db, dialect)),
+    )
+    candidateHandler := handler.NewCandidateHandler(candidateService)
+    customFieldHandler := handler.NewCustomFieldHandler(service.NewCustomFieldService(customFieldRepo, attributeRepo))
+    historyHandler := handler.NewHistoryHandler(service.NewHistoryService(repository.NewCandidateHistoryRepositoryFor(db, dialect)))
+    idempotencyCfg := config.IdempotencyConfig{TTL: 3600, LockTimeout: 60, MaxBodyBytes: 1 << 20}
+
+    gin.SetMode(gin.TestMode)
+    r := gin.New()
+    auth := r.Group("/api", func(c *gin.Context) {
+        c.Set(security.UserKey, c.GetHeader("X-User"))
+    }, security.IdempotencyMiddleware(repository.NewIdempotencyRepositoryFor(db, dialect), idempotencyCfg))
+    auth.POST("/candidates", candidateHandler.CreateCandidate)
+    auth.GET("/candidates/:id", candidateHandler.GetCandidateByID)
+    auth.GET("/candidates", candidateHandler.GetAllCandidates)
+    auth.PUT("/candidates/:id", candidateHandler.UpdateCandidate)
+    auth.DELETE("/candidates/:id", candidateHandler.DeleteCandidate)
+    auth.GET("/candidates/:id/history", historyHandler.GetCandidateHistory)
+    handler.CustomMethods(auth, "/candidates", map[string]gin.HandlerFunc{
+        "batch":       candidateHandler.CreateCandidates,
+        "batchUpdate": candidateHandler.UpdateCandidates,
+        "batchDelete": candidateHandler.DeleteCandidates,
+    })
+    auth.POST("/custom-fields", customFieldHandler.CreateField)
+    auth.GET("/tags", customFieldHandler.ListTags)
+    return r, db
+}
+
+func do(r *gin.Engine, method, path, body string, headers ...string) *httptest.ResponseRecorder {
+    req := httptest.NewRequest(method, path, strings.NewReader(body))
+    req.Header.Set("Content-Type", "application/json")
+    req.Header.Set("X-User", "recruiter")
+    for i := 0; i+1 < len(headers); i += 2 {
+        req.Header.Set(headers[i], headers[i+1])
+    }
+    w := httptest.NewRecorder()
+    r.ServeHTTP(w, req)
+    return w
+}
+
+func TestSQLiteServer_Candidates(t *testing.T) {
+    r, _ := newSQLiteServer(t)
+
+    w := do(r, http.MethodPost, "/api/custom-fields", `{"key": "years", "label": "Años", "type": "number"}`)
+    require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+    // El alta con Idempotency-Key se guarda una vez y el reintento repite la respuesta
+    body := `{"name": "Jane Doe", "email": "jane.doe@example.com", "tags": ["backend"], "custom_fields": {"years": 5},
+        "contact": {"country": "PE", "phones": [{"number": "+51987654321", "primary": true}], "address": {"city": "Lima"}}}`
+    w = do(r, http.MethodPost, "/api/candidates", body, "Idempotency-Key", "create-jane")
+    require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+    var created struct{ ID int }
+    require.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+    replay := do(r, http.MethodPost, "/api/candidates", body, "Idempotency-Key", "create-jane")
+    assert.Equal(t, http.StatusOK, replay.Code)
+    assert.Equal(t, "true", replay.Header().Get("Idempotent-Replayed"))
+    assert.JSONEq(t, w.Body.String(), replay.Body.String())
+
+    // El detalle y el listado llevan las etiquetas, los campos y el contacto
+    w = do(r, http.MethodGet, "/api/candidates/"+strconv.Itoa(created.ID), "")
+    require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+    var detail struct {
+        Tags         []string               `json:"tags"`
+        CustomFields map[string]interface{} `json:"custom_fields"`
+        Contact      struct {
+            Country string `json:"country"`
+            Phones  []struct {
+                Number string `json:"number"`
+            } `json:"phones"`
+        } `json:"contact"`
+    }
+    require.NoError(t, json.Unmarshal(w.Body.Bytes(), &detail))
+    assert.Equal(t, []string{"backend"}, detail.Tags)
+    assert.Equal(t, 5.0, detail.CustomFields["years"])
+    assert.Equal(t, "PE", detail.Contact.Country)
+    require.Len(t, detail.Contact.Phones, 1)
+
+    w = do(r, http.MethodGet, "/api/candidates?tag=backend&country=PE&cf[years]=3..", "")
+    require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+    var list []struct {
+        Email string   `json:"email"`
+        Tags  []string `json:"tags"`
+    }
+    require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+    require.Len(t, list, 1)
+    assert.Equal(t, "jane.doe@example.com", list[0].Email)
+    assert.Equal(t, []string{"backend"}, list[0].Tags)
+
+    // Cambiar el contacto lo reemplaza
+    w = do(r, http.MethodPut, "/api/candidates/"+strconv.Itoa(created.ID),
+        `{"name": "Jane Doe", "email": "jane.doe@example.com", "contact": {"country": "CL"}}`)
+    require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+    w = do(r, http.MethodGet, "/api/candidates?country=CL", "")
+    require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+    assert.Len(t, list, 1)
+
+    w = do(r, http.MethodGet, "/api/candidates/"+strconv.Itoa(created.ID)+"/history", "")
+    assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+    w = do(r, http.MethodGet, "/api/tags", "")
+    assert.Equal(t, http.StatusOK, w.Code)
+    assert.Contains(t, w.Body.String(), `"backend"`)
+}
+
+func TestSQLiteServer_MissingCandidate(t *testing.T) {
+    r, db := newSQLiteServer(t)
+
+    // Modificar o borrar un candidato que no existe responde 404 sin escribir nada
+    w := do(r, http.MethodPut, "/api/candidates/9999", `{"name": "Jane Doe", "email": "jane@example.com", "tags": ["backend"]}`)
+    assert.Equal(t, http.StatusNotFound, w.Code, w.Body.String())
+    w = do(r, http.MethodDelete, "/api/candidates/9999", "")
+    assert.Equal(t, http.StatusNotFound, w.Code, w.Body.String())
+
+    for _, query := range []string{
+        "SELECT COUNT(*) FROM candidate_tags WHERE candidate_id = 9999",
+        "SELECT COUNT(*) FROM outbox WHERE aggregate_id = 9999",
+    } {
+        var n int
+        require.NoError(t, db.QueryRow(query).Scan(&n))
+        assert.Zero(t, n, query)
+    }
+}
+
+func TestSQLiteServer_UpdateValidates(t *testing.T) {
+    r, _ := newSQLiteServer(t)
+    w := do(r, http.MethodPost, "/api/candidates", `{"name": "Jane Doe", "email": "jane.doe@example.com"}`)
+    require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+    var created struct{ ID int }
+    require.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+
+    // Un PUT vacío no borra el nombre ni el email
+    w = do(r, http.MethodPut, "/api/candidates/"+strconv.Itoa(created.ID), `{}`)
+    assert.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+    w = do(r, http.MethodGet, "/api/candidates/"+strconv.Itoa(created.ID), "")
+    require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+    assert.Contains(t, w.Body.String(), `"email":"jane.doe@example.com"`)
+}
+
+func TestSQLiteServer_Batch(t *testing.T) {
+    r, _ := newSQLiteServer(t)
+
+    // Cada elemento recibe el ID de su fila, leído por email
+    w := do(r, http.MethodPost, "/api/candidates:batch", `{"mode": "all_or_nothing", "items": [
+        {"name": "Ana Díaz", "email": "ana.diaz@example.com"}, {"name": "Luis Pérez", "email": "luis.perez@example.com"}]}`)
+    require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+    var result struct {
+        Items []struct {
+            ID     int    `json:"id"`
+            Status string `json:"status"`
+            Error  string `json:"error"`
+        } `json:"items"`
+    }
+    require.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+    require.Len(t, result.Items, 2)
+    for i, email := range []string{"ana.diaz@example.com", "luis.perez@example.com"} {
+        w = do(r, http.MethodGet, "/api/candidates/"+strconv.Itoa(result.Items[i].ID), "")
+        require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+        assert.Contains(t, w.Body.String(), email)
+    }
+
+    // Un ID que no existe falla en lugar de darse por borrado
+    w = do(r, http.MethodPost, "/api/candidates:batchDelete",
+        `{"mode": "partial", "ids": [`+strconv.Itoa(result.Items[0].ID)+`, 999999]}`)
+    require.Equal(t, http.StatusMultiStatus, w.Code, w.Body.String())
+    require.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+    assert.Equal(t, "deleted", result.Items[0].Status)
+    assert.Equal(t, "failed", result.Items[1].Status)
+    assert.Equal(t, "Candidate not found", result.Items[1].Error)
+
+    // Solo existen los métodos registrados
+    for _, path := range []string{"/api/candidates:batchPurge", "/api/candidatesbatch"} {
+        assert.Equal(t, http.StatusNotFound, do(r, http.MethodPost, path, `{}`).Code, path)
+    }
+}
+
+func TestSQLiteServer_CalendarFeeds(t *testing.T) {
+    _, db := newSQLiteServer(t)
+    feeds := repository.NewCalendarFeedRepositoryFor(db, repository.SQLite)
+
+    // Un segundo token del mismo usuario reemplaza al anterior
+    require.NoError(t, feeds.Save("recruiter", "hash-1"))
+    require.NoError(t, feeds.Save("recruiter", "hash-2"))
+    user, err := feeds.GetUser("hash-1")
+    require.NoError(t, err)
+    assert.Empty(t, user)
+    user, err = feeds.GetUser("hash-2")
+    require.NoError(t, err)
+    assert.Equal(t, "recruiter", user)
+
+    require.NoError(t, feeds.Delete("recruiter"))
+    user, err = feeds.GetUser("hash-2")
+    require.NoError(t, err)
+    assert.Empty(t, user)
+}
+
+func TestSQLiteServer_Repositories(t *testing.T) {
+    r, db := newSQLiteServer(t)
+    dialect := repository.SQLite
+    w := do(r, http.MethodPost, "/api/candidates", `{"name": "Jane Doe", "email": "jane.doe@example.com"}`)
+    require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+    var candidate struct{ ID int }
+    require.NoError(t, json.Unmarshal(w.Body.Bytes(), &candidate))
+
+    // Vacantes y postulaciones, con su transición inicial
+    jobs := repository.NewJobRepositoryFor(db, dialect)
+    jobID, err := jobs.Create(domain.Job{Title: "Backend Engineer", Status: domain.JobOpen, HiringManager: "maria"})
+    require.NoError(t, err)
+    list, err := jobs.GetAll(domain.JobFilter{Title: "backend"})
+    require.NoError(t, err)
+    require.Len(t, list, 1)
+    assert.Equal(t, jobID, list[0].ID)
+
+    applications := repository.NewApplicationRepositoryFor(db, dialect)
+    appID, err := applications.Create(domain.Application{CandidateID: candidate.ID, JobID: jobID, Stage: domain.StageApplied},
+        domain.StageTransition{ToStage: domain.StageApplied, Actor: "maria"})
+    require.NoError(t, err)
+    transitions, err := applications.ListTransitions(appID)
+    require.NoError(t, err)
+    require.Len(t, transitions, 1)
+
+    reasons := repository.NewRejectionReasonRepositoryFor(db, dialect)
+    require.NoError(t, reasons.Save(domain.RejectionReason{Code: "salary", Label: "Salario"}))
+    require.NoError(t, reasons.Save(domain.RejectionReason{Code: "salary", Label: "Expectativa salarial"}))
+    reason, err := reasons.GetByCode("salary")
+    require.NoError(t, err)
+    assert.Equal(t, "Expectativa salarial", reason.Label)
+
+    // Entrevistas, plantillas y feedback
+    interviews := repository.NewInterviewRepositoryFor(db, dialect)
+    start := time.Now().UTC().Add(time.Hour).Truncate(time.Second)
+    ivID, err := interviews.Create(domain.Interview{CandidateID: candidate.ID, Interviewers: []string{"luis"}, StartsAt: start,
+        EndsAt: start.Add(time.Hour), TimeZone: "America/Lima", Type: domain.InterviewVideo, CreatedBy: "maria"})
+    require.NoError(t, err)
+    upcoming, err := interviews.ListByInterviewer("luis", time.Now())
+    require.NoError(t, err)
+    require.Len(t, upcoming, 1)
+    assert.Equal(t, ivID, upcoming[0].ID)
+
+    scorecards := repository.NewScorecardRepositoryFor(db, dialect)
+    templateID, err := scorecards.CreateTemplate(domain.ScorecardTemplate{Name: "Backend", ScaleMin: 1, ScaleMax: 5,
+        Competencies: []domain.Competency{{Name: "Go", Weight: 1}}})
+    require.NoError(t, err)
+    template, err := scorecards.GetTemplate(templateID)
+    require.NoError(t, err)
+    require.Len(t, template.Competencies, 1)
+    feedback := repository.NewFeedbackRepositoryFor(db, dialect)
+    _, err = feedback.Create(domain.Feedback{CandidateID: candidate.ID, InterviewID: &ivID, TemplateID: templateID, Interviewer: "luis",
+        Ratings: []domain.Rating{{CompetencyID: template.Competencies[0].ID, Score: 4}}, Recommendation: domain.RecommendYes, Score: 4})
+    require.NoError(t, err)
+    submitted, err := feedback.HasSubmitted(candidate.ID, "luis")
+    require.NoError(t, err)
+    assert.True(t, submitted)
+
+    // Las notas notifican a los mencionados
+    notes := repository.NewNoteRepositoryFor(db, dialect)
+    noteID, err := notes.Create(domain.Note{CandidateID: candidate.ID, Author: "ana", Body: "Hola @luis", Visibility: domain.NoteTeam, Mentions: []string{"luis"}},
+        []domain.Notification{{User: "luis", Type: domain.NotificationMention, CandidateID: candidate.ID, Actor: "ana", Message: "ana te mencionó"}})
+    require.NoError(t, err)
+    notifications := repository.NewNotificationRepositoryFor(db, dialect)
+    unread, err := notifications.ListByUser("luis", true)
+    require.NoError(t, err)
+    require.Len(t, unread, 1)
+    assert.Equal(t, noteID, unread[0].NoteID)
+    found, err := notifications.MarkRead(unread[0].ID, "luis")
+    require.NoError(t, err)
+    assert.True(t, found)
+
+    documents := repository.NewDocumentRepositoryFor(db, dialect)
+    docID, err := documents.Create(domain.Document{CandidateID: candidate.ID, FileName: "cv.pdf", ContentType: "application/pdf",
+        Kind: domain.DocumentPDF, Size: 3, SHA256: "abc", StorageKey: "k", Text: "Go", UploadedBy: "ana"})
+    require.NoError(t, err)
+    text, err := documents.GetText(docID)
+    require.NoError(t, err)
+    assert.Equal(t, "Go", text)
+    texts, err := documents.ResumeTexts([]int{candidate.ID})
+    require.NoError(t, err)
+    assert.Equal(t, "Go", texts[candidate.ID])
+
+    // El relay lee y marca los eventos del outbox
+    outbox := repository.NewOutboxRepositoryFor(db, dialect)
+    events, err := outbox.Pending(50)
+    require.NoError(t, err)
+    require.NotEmpty(t, events)
+    require.NoError(t, outbox.MarkPublished([]int64{events[0].ID}))
+    pending, _, err := outbox.Lag()
+    require.NoError(t, err)
+    assert.Equal(t, len(events)-1, pending)
+
+    // La cola del outbox lee también los publicados, desde su cursor
+    last, err := outbox.LastID()
+    require.NoError(t, err)
+    assert.Equal(t, events[len(events)-1].ID, last)
+    after, err := outbox.After(events[0].ID-1, 50)
+    require.NoError(t, err)
+    assert.Len(t, after, len(events))
+}
@@ -0,0 +1,174 @@
+package service_test
+
+import (
+    "errors"
+    "testing"
+
+    "github.com/stretchr/testify/assert"
+    "github.com/stretchr/testify/mock"
+
+    "github.com/torvictorvic/seek-v2/internal/domain"
+    "github.com/torvictorvic/seek-v2/internal/service"
+)
+
+// mockApplicationRepo implementa ApplicationRepository usando testify/mock
+type mockApplicationRepo struct {
+    mock.Mock
+}
+
+func (m *mockApplicationRepo) Create(app domain.Application, transition domain.StageTransition) (int, error) {
+    args := m.Called(app, transition)
+    return args.Int(0), args.Error(1)
+}
+func (m *mockApplicationRepo) GetByID(id int) (*domain.Application, error) {
+    args := m.Called(id)
+    if args.Get(0) == nil {
+        return nil, args.Error(1)
+    }
+    // Copia para que el servicio no modifique el valor configurado en el mock
+    app := *args.Get(0).(*domain.Application)
+    return &app, args.Error(1)
+}
+func (m *mockApplicationRepo) GetByCandidateAndJob(candidateID, jobID int) (*domain.Application, error) {
+    args := m.Called(candidateID, jobID)
+    if args.Get(0) == nil {
+        return nil, args.Error(1)
+    }
+    return args.Get(0).(*domain.Application), args.Error(1)
+}
+func (m *mockApplicationRepo) ListByCandidate(candidateID int) ([]domain.Application, error) {
+    args := m.Called(candidateID)
+    return args.Get(0).([]domain.Application), args.Error(1)
+}
+func (m *mockApplicationRepo) ListByJob(jobID int) ([]domain.Application, error) {
+    args := m.Called(jobID)
+    return args.Get(0).([]domain.Application), args.Error(1)
+}
+func (m *mockApplicationRepo) MoveStage(app domain.Application, transition domain.StageTransition) error {
+    args := m.Called(app, transition)
+    return args.Error(0)
+}
+func (m *mockApplicationRepo) ListTransitions(applicationID int) ([]domain.StageTransition, error) {
+    args := m.Called(applicationID)
+    return args.Get(0).([]domain.StageTransition), args.Error(1)
+}
+
+// mockRejectionReasonRepo implementa RejectionReasonRepository usando testify/mock
+type mockRejectionReasonRepo struct {
+    mock.Mock
+}
+
+func (m *mockRejectionReasonRepo) List() ([]domain.RejectionReason, error) {
+    args := m.Called()
+    return args.Get(0).([]domain.RejectionReason), args.Error(1)
+}
+func (m *mockRejectionReasonRepo) GetByCode(code string) (*domain.RejectionReason, error) {
+    args := m.Called(code)
+    if args.Get(0) == nil {
+        return nil, args.Error(1)
+    }
+    return args.Get(0).(*domain.RejectionReason), args.Error(1)
+}
+func (m *mockRejectionReasonRepo) Save(reason domain.RejectionReason) error {
+    return m.Called(reason).Error(0)
+}
+func (m *mockRejectionReasonRepo) Deactivate(code string) error {
+    return m.Called(code).Error(0)
+}
+
+func newApplicationService() (service.ApplicationService, *mockApplicationRepo, *mockRejectionReasonRepo, *mockCandidateRepo, *mockJobRepo) {
+    apps := new(mockApplicationRepo)
+    reasons := new(mockRejectionReasonRepo)
+    candidates := new(mockCandidateRepo)
+    jobs := new(mockJobRepo)
+    svc := service.NewApplicationService(apps, reasons, candidates, jobs, domain.DefaultPipeline())
+    return svc, apps, reasons, candidates, jobs
+}
+
+func TestApply(t *testing.T) {
+    svc, apps, _, candidates, jobs := newApplicationService()
+
+    candidates.On("GetByID", 4).Return(&domain.Candidate{ID: 4}, nil)
+    jobs.On("GetByID", 2).Return(&domain.Job{ID: 2, Status: domain.JobOpen}, nil)
+    apps.On("GetByCandidateAndJob", 4, 2).Return(nil, nil)
+    apps.On("Create", domain.Application{CandidateID: 4, JobID: 2, Stage: domain.StageApplied},
+        domain.StageTransition{ToStage: domain.StageApplied, Actor: "recruiter"}).Return(10, nil)
+    apps.On("GetByID", 10).Return(&domain.Application{ID: 10, CandidateID: 4, JobID: 2, Stage: domain.StageApplied}, nil)
+    apps.On("ListTransitions", 10).Return([]domain.StageTransition{{ToStage: domain.StageApplied}}, nil)
+
+    app, err := svc.Apply(domain.ApplyRequest{CandidateID: 4, JobID: 2}, "recruiter")
+    assert.NoError(t, err)
+    assert.Equal(t, domain.StageApplied, app.Stage)
+    assert.Len(t, app.Transitions, 1)
+    apps.AssertExpectations(t)
+}
+
+func TestApply_JobClosed(t *testing.T) {
+    svc, _, _, candidates, jobs := newApplicationService()
+
+    candidates.On("GetByID", 4).Return(&domain.Candidate{ID: 4}, nil)
+    jobs.On("GetByID", 2).Return(&domain.Job{ID: 2, Status: domain.JobClosed}, nil)
+
+    _, err := svc.Apply(domain.ApplyRequest{CandidateID: 4, JobID: 2}, "recruiter")
+    assert.True(t, errors.Is(err, service.ErrJobNotOpen))
+}
+
+func TestMoveStage(t *testing.T) {
+    svc, apps, _, _, _ := newApplicationService()
+
+    apps.On("GetByID", 10).Return(&domain.Application{ID: 10, Stage: domain.StageApplied}, nil)
+    apps.On("MoveStage", domain.Application{ID: 10, Stage: domain.StageScreening},
+        domain.StageTransition{FromStage: domain.StageApplied, ToStage: domain.StageScreening, Reason: "CV ok", Actor: "recruiter"}).Return(nil)
+    apps.On("ListTransitions", 10).Return([]domain.StageTransition{}, nil)
+
+    _, err := svc.MoveStage(10, domain.StageChangeRequest{Stage: domain.StageScreening, Reason: "CV ok"}, "recruiter")
+    assert.NoError(t, err)
+    apps.AssertExpectations(t)
+}
+
+func TestMoveStage_InvalidTransition(t *testing.T) {
+    svc, apps, _, _, _ := newApplicationService()
+
+    // De "applied" no se puede saltar directamente a "offer"
+    apps.On("GetByID", 10).Return(&domain.Application{ID: 10, Stage: domain.StageApplied}, nil)
+
+    _, err := svc.MoveStage(10, domain.StageChangeRequest{Stage: domain.StageOffer}, "recruiter")
+    assert.True(t, errors.Is(err, service.ErrInvalidTransition))
+
+    _, err = svc.MoveStage(10, domain.StageChangeRequest{Stage: "archived"}, "recruiter")
+    assert.True(t, errors.Is(err, service.ErrInvalidStage))
+    apps.AssertNotCalled(t, "MoveStage", mock.Anything, mock.Anything)
+}
+
+func TestMoveStage_RejectRequiresReason(t *testing.T) {
+    svc, apps, reasons, _, _ := newApplicationService()
+
+    apps.On("GetByID", 10).Return(&domain.Application{ID: 10, Stage: domain.StageInterview}, nil)
+    reasons.On("GetByCode", "withdrew").Return(&domain.RejectionReason{Code: "withdrew", Active: true}, nil)
+    reasons.On("GetByCode", "old_reason").Return(&domain.RejectionReason{Code: "old_reason", Active: false}, nil)
+    apps.On("MoveStage", domain.Application{ID: 10, Stage: domain.StageRejected, RejectionReason: "withdrew"}, mock.Anything).Return(nil)
+    apps.On("ListTransitions", 10).Return([]domain.StageTransition{}, nil)
+
+    _, err := svc.MoveStage(10, domain.StageChangeRequest{Stage: domain.StageRejected}, "recruiter")
+    assert.True(t, errors.Is(err, service.ErrRejectionReasonRequired))
+
+    // Un motivo desactivado no se puede usar
+    _, err = svc.MoveStage(10, domain.StageChangeRequest{Stage: domain.StageRejected, RejectionReason: "old_reason"}, "recruiter")
+    assert.True(t, errors.Is(err, service.ErrUnknownRejectionReason))
+
+    _, err = svc.MoveStage(10, domain.StageChangeRequest{Stage: domain.StageRejected, RejectionReason: "withdrew"}, "recruiter")
+    assert.NoError(t, err)
+    apps.AssertExpectations(t)
+}
+
+func TestParsePipeline(t *testing.T) {
+    p, err := domain.ParsePipeline("applied:phone_screen|rejected, phone_screen:hired|rejected")
+    assert.NoError(t, err)
+    assert.Equal(t, []string{"applied", "phone_screen", "rejected", "hired"}, p.Stages)
+    assert.True(t, p.CanMove("phone_screen", "hired"))
+    assert.False(t, p.CanMove("applied", "hired"))
+
+    // Sin la etapa "rejected" no se podría rechazar
+    _, err = domain.ParsePipeline("applied:hired")
+    assert.Error(t, err)
+}
@@ -0,0 +1,143 @@
+package service_test
+
+import (
+    "errors"
+    "testing"
+
+    "github.com/stretchr/testify/assert"
+    "github.com/stretchr/testify/mock"
+
+    "github.com/torvictorvic/seek-v2/internal/domain"
+    "github.com/torvictorvic/seek-v2/internal/service"
+)
+
+func TestCreateCandidates_AllOrNothing_InvalidItem(t *testing.T) {
+    mockRepo := new(mockCandidateRepo)
+    svc := service.NewCandidateService(mockRepo)
+
+    items := []domain.Candidate{
+        {Name: "Jane Doe", Email: "jane@example.com"},
+        {Name: "", Email: "noname@example.com"},
+    }
+
+    result, err := svc.CreateCandidates(items, domain.BatchAllOrNothing)
+    assert.NoError(t, err)
+    assert.Equal(t, 1, result.Failed)
+    assert.Equal(t, domain.BatchStatusSkipped, result.Items[0].Status)
+    assert.Equal(t, domain.BatchStatusFailed, result.Items[1].Status)
+
+    // Nada se escribe si algún elemento es inválido
+    mockRepo.AssertNotCalled(t, "CreateBatch", mock.Anything)
+}
+
+func TestCreateCandidates_Partial(t *testing.T) {
+    mockRepo := new(mockCandidateRepo)
+    svc := service.NewCandidateService(mockRepo)
+
+    items := []domain.Candidate{
+        {Name: "Jane Doe", Email: "jane@example.com"},
+        {Name: "", Email: "noname@example.com"},
+        {Name: "John Doe", Email: "john@example.com"},
+    }
+
+    mockRepo.On("CreateBatch", []domain.Candidate{items[0], items[2]}).Return([]int{7, 8}, nil)
+
+    result, err := svc.CreateCandidates(items, domain.BatchPartial)
+    assert.NoError(t, err)
+    assert.Equal(t, 2, result.Succeeded)
+    assert.Equal(t, 1, result.Failed)
+    assert.Equal(t, 7, result.Items[0].ID)
+    assert.Equal(t, domain.BatchStatusFailed, result.Items[1].Status)
+    assert.Equal(t, 8, result.Items[2].ID)
+
+    mockRepo.AssertExpectations(t)
+}
+
+func TestCreateCandidates_Partial_FallbackPerItem(t *testing.T) {
+    mockRepo := new(mockCandidateRepo)
+    svc := service.NewCandidateService(mockRepo)
+
+    items := []domain.Candidate{
+        {Name: "Jane Doe", Email: "jane@example.com"},
+        {Name: "John Doe", Email: "duplicated@example.com"},
+    }
+
+    // El insert multi-fila falla y se reintenta elemento por elemento
+    mockRepo.On("CreateBatch", items).Return(nil, errors.New("duplicate entry"))
+    mockRepo.On("Create", items[0]).Return(1, nil)
+    mockRepo.On("Create", items[1]).Return(0, errors.New("duplicate entry"))
+
+    result, err := svc.CreateCandidates(items, domain.BatchPartial)
+    assert.NoError(t, err)
+    assert.Equal(t, domain.BatchStatusCreated, result.Items[0].Status)
+    assert.Equal(t, domain.BatchStatusFailed, result.Items[1].Status)
+    assert.Equal(t, "duplicate entry", result.Items[1].Error)
+
+    mockRepo.AssertExpectations(t)
+}
+
+func TestCreateCandidates_TooLarge(t *testing.T) {
+    mockRepo := new(mockCandidateRepo)
+    svc := service.NewCandidateService(mockRepo, service.WithBatchMaxItems(1))
+
+    items := []domain.Candidate{
+        {Name: "Jane Doe", Email: "jane@example.com"},
+        {Name: "John Doe", Email: "john@example.com"},
+    }
+
+    result, err := svc.CreateCandidates(items, domain.BatchPartial)
+    assert.Nil(t, result)
+    assert.ErrorIs(t, err, service.ErrBatchTooLarge)
+}
+
+func TestDeleteCandidates_AllOrNothing_RepoError(t *testing.T) {
+    mockRepo := new(mockCandidateRepo)
+    svc := service.NewCandidateService(mockRepo)
+
+    mockRepo.On("GetByIDs", []int{1, 2}).Return([]domain.Candidate{{ID: 1}, {ID: 2}}, nil)
+    mockRepo.On("DeleteBatch", []int{1, 2}).Return(errors.New("db error"))
+
+    result, err := svc.DeleteCandidates([]int{1, 2}, "")
+    assert.NoError(t, err)
+    assert.Equal(t, domain.BatchAllOrNothing, result.Mode)
+    assert.Equal(t, 2, result.Failed)
+
+    mockRepo.AssertExpectations(t)
+}
+
+func TestDeleteCandidates_NotFound(t *testing.T) {
+    mockRepo := new(mockCandidateRepo)
+    svc := service.NewCandidateService(mockRepo)
+
+    // El ID 3 no existe: falla el elemento en lugar de darse por borrado
+    mockRepo.On("GetByIDs", []int{1, 3}).Return([]domain.Candidate{{ID: 1}}, nil)
+    mockRepo.On("DeleteBatch", []int{1}).Return(nil)
+
+    result, err := svc.DeleteCandidates([]int{1, 3}, domain.BatchPartial)
+    assert.NoError(t, err)
+    assert.Equal(t, 1, result.Succeeded)
+    assert.Equal(t, domain.BatchStatusDeleted, result.Items[0].Status)
+    assert.Equal(t, domain.BatchStatusFailed, result.Items[1].Status)
+    assert.Equal(t, service.ErrCandidateNotFound.Error(), result.Items[1].Error)
+
+    // En all_or_nothing no se borra nada
+    result, err = svc.DeleteCandidates([]int{1, 3}, domain.BatchAllOrNothing)
+    assert.NoError(t, err)
+    assert.Equal(t, domain.BatchStatusSkipped, result.Items[0].Status)
+    assert.Equal(t, domain.BatchStatusFailed, result.Items[1].Status)
+    mockRepo.AssertNumberOfCalls(t, "DeleteBatch", 1)
+}
+
+func TestUpdateCandidates_NotFound(t *testing.T) {
+    mockRepo := new(mockCandidateRepo)
+    svc := service.NewCandidateService(mockRepo)
+
+    items := []domain.Candidate{{ID: 4, Name: "Jane Doe", Email: "jane@example.com"}}
+    mockRepo.On("GetByIDs", []int{4}).Return([]domain.Candidate{}, nil)
+    mockRepo.On("UpdateBatch", []domain.Candidate{}).Return(nil)
+
+    result, err := svc.UpdateCandidates(items, domain.BatchPartial)
+    assert.NoError(t, err)
+    assert.Equal(t, 1, result.Failed)
+    assert.Equal(t, service.ErrCandidateNotFound.Error(), result.Items[0].Error)
+}
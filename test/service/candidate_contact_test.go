@@ -0,0 +1,133 @@
+package service_test
+
+import (
+    "testing"
+
+    "github.com/stretchr/testify/assert"
+    "github.com/stretchr/testify/mock"
+
+    "github.com/torvictorvic/seek-v2/internal/domain"
+    "github.com/torvictorvic/seek-v2/internal/service"
+)
+
+// mockContactRepo implementa CandidateContactRepository usando testify/mock
+type mockContactRepo struct {
+    mock.Mock
+}
+
+func (m *mockContactRepo) Save(candidateID int, contact domain.ContactDetails) error {
+    args := m.Called(candidateID, contact)
+    return args.Error(0)
+}
+func (m *mockContactRepo) Load(candidateIDs []int) (map[int]domain.ContactDetails, error) {
+    args := m.Called(candidateIDs)
+    return args.Get(0).(map[int]domain.ContactDetails), args.Error(1)
+}
+
+func TestNormalizePhone(t *testing.T) {
+    cases := []struct {
+        raw, country, want string
+    }{
+        {"+51 987 654 321", "", "+51987654321"},
+        {"0051 987-654-321", "", "+51987654321"},
+        {"987654321", "PE", "+51987654321"},
+        {"(011) 4123-4567", "AR", "+541141234567"},
+        {"06 1234 5678", "IT", "+390612345678"}, // Italia conserva el 0
+        {"1 (415) 555-2671", "US", "+14155552671"},
+        {"020 7946 0958", "GB", "+442079460958"},
+    }
+    for _, tc := range cases {
+        got, err := domain.NormalizePhone(tc.raw, tc.country)
+        assert.NoError(t, err, tc.raw)
+        assert.Equal(t, tc.want, got, tc.raw)
+    }
+
+    // Sin prefijo ni país, con letras, muy corto o con un código inexistente
+    for _, raw := range []string{"987654321", "+51 98765 abc", "+51 123", "+999 1234 5678"} {
+        _, err := domain.NormalizePhone(raw, "")
+        assert.Error(t, err, raw)
+    }
+}
+
+func TestContactDetailsNormalize(t *testing.T) {
+    contact := domain.ContactDetails{
+        Phones:           []domain.Phone{{Number: "987 654 321", Type: domain.PhoneMobile}, {Number: "01 4123456", Type: domain.PhoneHome}},
+        Address:          &domain.Address{City: " Lima "},
+        Country:          "pe",
+        TimeZone:         "America/Lima",
+        LinkedInURL:      "linkedin.com/in/janedoe",
+        GitHubURL:        "https://github.com/janedoe",
+        PreferredChannel: domain.ChannelWhatsApp,
+    }
+    assert.NoError(t, contact.Normalize())
+    assert.Equal(t, "PE", contact.Country)
+    assert.Equal(t, "+51987654321", contact.PrimaryPhone()) // el primero queda como principal
+    assert.Equal(t, "+5114123456", contact.Phones[1].Number)
+    assert.Equal(t, "Lima", contact.Address.City)
+    assert.Equal(t, "https://linkedin.com/in/janedoe", contact.LinkedInURL)
+
+    invalid := []domain.ContactDetails{
+        {Country: "XX"},
+        {TimeZone: "America/Atlantis"},
+        {GitHubURL: "https://gitlab.com/janedoe"},
+        {PortfolioURL: "ftp://janedoe.dev"},
+        {PreferredChannel: domain.ChannelPhone},
+        {PreferredChannel: "pigeon"},
+        {Phones: []domain.Phone{{Number: "+51987654321"}, {Number: "+51 987 654 321"}}},
+        {Phones: []domain.Phone{{Number: "+51987654321", Primary: true}, {Number: "+51987654322", Primary: true}}},
+        {Phones: []domain.Phone{{Number: "+51987654321", Type: "fax"}}},
+    }
+    for _, c := range invalid {
+        assert.Error(t, c.Normalize(), "%+v", c)
+    }
+}
+
+func TestCreateCandidate_WithContactDetails(t *testing.T) {
+    repo := new(mockCandidateRepo)
+    contacts := new(mockContactRepo)
+    svc := service.NewCandidateService(repo, service.WithContactDetails(contacts))
+
+    contact := &domain.ContactDetails{Country: "pe", Phones: []domain.Phone{{Number: "987654321"}}}
+    repo.On("Create", mock.Anything).Return(7, nil)
+    contacts.On("Save", 7, mock.MatchedBy(func(c domain.ContactDetails) bool {
+        // Se guarda normalizado
+        return c.Country == "PE" && c.Phones[0].Number == "+51987654321" && c.Phones[0].Primary
+    })).Return(nil)
+
+    _, err := svc.CreateCandidate(domain.Candidate{Name: "Jane", Email: "jane@example.com", Contact: contact})
+    assert.NoError(t, err)
+    // El valor enviado no se modifica
+    assert.Equal(t, "987654321", contact.Phones[0].Number)
+
+    _, err = svc.CreateCandidate(domain.Candidate{Name: "Jane", Email: "jane@example.com",
+        Contact: &domain.ContactDetails{Phones: []domain.Phone{{Number: "987654321"}}}})
+    assert.ErrorIs(t, err, service.ErrInvalidContact)
+
+    repo.AssertNumberOfCalls(t, "Create", 1)
+    contacts.AssertExpectations(t)
+}
+
+func TestUpdateCandidate_KeepsOmittedContact(t *testing.T) {
+    repo := new(mockCandidateRepo)
+    contacts := new(mockContactRepo)
+    svc := service.NewCandidateService(repo, service.WithContactDetails(contacts))
+
+    repo.On("Update", mock.Anything).Return(nil)
+
+    err := svc.UpdateCandidate(domain.Candidate{ID: 7, Name: "Jane", Email: "jane@example.com"})
+    assert.NoError(t, err)
+    contacts.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+}
+
+func TestGetCandidate_LoadsContact(t *testing.T) {
+    repo := new(mockCandidateRepo)
+    contacts := new(mockContactRepo)
+    svc := service.NewCandidateService(repo, service.WithContactDetails(contacts))
+
+    repo.On("GetByID", 7).Return(&domain.Candidate{ID: 7, Name: "Jane"}, nil)
+    contacts.On("Load", []int{7}).Return(map[int]domain.ContactDetails{7: {Country: "PE"}}, nil)
+
+    candidate, err := svc.GetCandidateByID(7)
+    assert.NoError(t, err)
+    assert.Equal(t, "PE", candidate.Contact.Country)
+}
@@ -0,0 +1,51 @@
+package service_test
+
+import (
+    "testing"
+    "time"
+
+    "github.com/stretchr/testify/assert"
+
+    "github.com/torvictorvic/seek-v2/internal/domain"
+    "github.com/torvictorvic/seek-v2/internal/search"
+    "github.com/torvictorvic/seek-v2/internal/service"
+)
+
+func TestSearchCandidates_IndexUpdatedOnWrites(t *testing.T) {
+    mockRepo := new(mockCandidateRepo)
+    idx := search.NewMemoryIndex()
+    svc := service.NewCandidateService(mockRepo, service.WithSearcher(idx))
+
+    input := domain.Candidate{Name: "Anna Walker", Email: "anna.walker@example.com"}
+    createdAt := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+    stored := domain.Candidate{ID: 4, Name: input.Name, Email: input.Email, CreatedAt: createdAt, UpdatedAt: createdAt}
+    mockRepo.On("Create", input).Return(4, nil)
+    mockRepo.On("GetByID", 4).Return(&stored, nil)
+    mockRepo.On("Delete", 4).Return(nil)
+
+    _, err := svc.CreateCandidate(input)
+    assert.NoError(t, err)
+
+    // Se indexa el candidato guardado, con las fechas que pone la base de datos
+    hits, err := svc.SearchCandidates("walker", domain.CandidateFilter{}, 10)
+    assert.NoError(t, err)
+    assert.Len(t, hits, 1)
+    assert.Equal(t, 4, hits[0].Candidate.ID)
+    assert.Equal(t, createdAt, hits[0].Candidate.CreatedAt)
+    assert.Equal(t, createdAt, hits[0].Candidate.UpdatedAt)
+
+    // Al borrar se quita del índice
+    assert.NoError(t, svc.DeleteCandidate(4))
+    hits, err = svc.SearchCandidates("walker", domain.CandidateFilter{}, 10)
+    assert.NoError(t, err)
+    assert.Empty(t, hits)
+
+    mockRepo.AssertExpectations(t)
+}
+
+func TestSearchCandidates_EmptyQuery(t *testing.T) {
+    svc := service.NewCandidateService(new(mockCandidateRepo), service.WithSearcher(search.NewMemoryIndex()))
+
+    _, err := svc.SearchCandidates("  ", domain.CandidateFilter{}, 10)
+    assert.ErrorIs(t, err, service.ErrEmptyQuery)
+}
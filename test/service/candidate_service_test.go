@@ -27,10 +27,29 @@ func (m *mockCandidateRepo) GetByID(id int) (*domain.Candidate, error) {
     }
     return args.Get(0).(*domain.Candidate), args.Error(1)
 }
-func (m *mockCandidateRepo) GetAll() ([]domain.Candidate, error) {
-    args := m.Called()
+func (m *mockCandidateRepo) GetByEmail(email string) (*domain.Candidate, error) {
+    args := m.Called(email)
+    if args.Get(0) == nil {
+        return nil, args.Error(1)
+    }
+    return args.Get(0).(*domain.Candidate), args.Error(1)
+}
+func (m *mockCandidateRepo) GetAll(filter domain.CandidateFilter) ([]domain.Candidate, error) {
+    args := m.Called(filter)
+    return args.Get(0).([]domain.Candidate), args.Error(1)
+}
+func (m *mockCandidateRepo) Stream(filter domain.CandidateFilter, fn func(domain.Candidate) error) error {
+    args := m.Called(filter, fn)
+    return args.Error(0)
+}
+func (m *mockCandidateRepo) Page(filter domain.CandidateFilter, afterID, limit int) ([]domain.Candidate, error) {
+    args := m.Called(filter, afterID, limit)
     return args.Get(0).([]domain.Candidate), args.Error(1)
 }
+func (m *mockCandidateRepo) Count(filter domain.CandidateFilter) (int, error) {
+    args := m.Called(filter)
+    return args.Int(0), args.Error(1)
+}
 func (m *mockCandidateRepo) Update(candidate domain.Candidate) error {
     args := m.Called(candidate)
     return args.Error(0)
@@ -39,7 +58,36 @@ func (m *mockCandidateRepo) Delete(id int) error {
     args := m.Called(id)
     return args.Error(0)
 }
-
+func (m *mockCandidateRepo) Upsert(candidate domain.Candidate) (int, bool, error) {
+    args := m.Called(candidate)
+    return args.Int(0), args.Bool(1), args.Error(2)
+}
+func (m *mockCandidateRepo) Merge(target domain.Candidate, sourceID int, entry domain.CandidateHistoryEntry) error {
+    args := m.Called(target, sourceID, entry)
+    return args.Error(0)
+}
+func (m *mockCandidateRepo) CreateBatch(candidates []domain.Candidate) ([]int, error) {
+    args := m.Called(candidates)
+    if args.Get(0) == nil {
+        return nil, args.Error(1)
+    }
+    return args.Get(0).([]int), args.Error(1)
+}
+func (m *mockCandidateRepo) UpdateBatch(candidates []domain.Candidate) error {
+    args := m.Called(candidates)
+    return args.Error(0)
+}
+func (m *mockCandidateRepo) DeleteBatch(ids []int) error {
+    args := m.Called(ids)
+    return args.Error(0)
+}
+func (m *mockCandidateRepo) GetByIDs(ids []int) ([]domain.Candidate, error) {
+    args := m.Called(ids)
+    if args.Get(0) == nil {
+        return nil, args.Error(1)
+    }
+    return args.Get(0).([]domain.Candidate), args.Error(1)
+}
 
 func TestCreateCandidate_Success(t *testing.T) {
     mockRepo := new(mockCandidateRepo)
@@ -115,7 +163,6 @@ func TestGetCandidateByID_NotFound(t *testing.T) {
     mockRepo.AssertExpectations(t)
 }
 
-
 func TestUpdateCandidate_Success(t *testing.T) {
     mockRepo := new(mockCandidateRepo)
     svc := service.NewCandidateService(mockRepo)
@@ -154,6 +201,16 @@ func TestUpdateCandidate_RepoError(t *testing.T) {
     mockRepo.AssertExpectations(t)
 }
 
+func TestUpdateCandidate_Invalid(t *testing.T) {
+    mockRepo := new(mockCandidateRepo)
+    svc := service.NewCandidateService(mockRepo)
+
+    // Sin nombre o email no se escribe nada, como en el alta
+    err := svc.UpdateCandidate(domain.Candidate{ID: 5})
+    assert.ErrorIs(t, err, service.ErrInvalidCandidate)
+
+    mockRepo.AssertNotCalled(t, "Update", mock.Anything)
+}
 
 func TestDeleteCandidate_Success(t *testing.T) {
     mockRepo := new(mockCandidateRepo)
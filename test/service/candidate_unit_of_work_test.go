@@ -0,0 +1,46 @@
+package service_test
+
+import (
+    "errors"
+    "regexp"
+    "testing"
+
+    "github.com/DATA-DOG/go-sqlmock"
+    "github.com/stretchr/testify/assert"
+    "github.com/stretchr/testify/require"
+
+    "github.com/torvictorvic/seek-v2/internal/domain"
+    "github.com/torvictorvic/seek-v2/internal/repository"
+    "github.com/torvictorvic/seek-v2/internal/service"
+)
+
+func TestCreateCandidate_UnitOfWorkRollsBackAttributes(t *testing.T) {
+    db, mock, err := sqlmock.New()
+    require.NoError(t, err)
+    defer db.Close()
+
+    fields := new(mockCustomFieldRepo)
+    fields.On("List").Return([]domain.CustomField{}, nil)
+    svc := service.NewCandidateService(repository.NewCandidateRepository(db),
+        service.WithCustomFields(fields, repository.NewCandidateAttributeRepository(db)),
+        service.WithUnitOfWork(repository.NewUnitOfWork(db)),
+    )
+
+    // Si fallan las etiquetas, el candidato ya insertado se deshace con ellas
+    mock.ExpectBegin()
+    mock.ExpectExec(regexp.QuoteMeta("SAVEPOINT sp_1")).WillReturnResult(sqlmock.NewResult(0, 0))
+    mock.ExpectExec(regexp.QuoteMeta("INSERT INTO candidates")).WillReturnResult(sqlmock.NewResult(7, 1))
+    mock.ExpectExec(regexp.QuoteMeta("INSERT INTO outbox")).WillReturnResult(sqlmock.NewResult(1, 1))
+    mock.ExpectExec(regexp.QuoteMeta("RELEASE SAVEPOINT sp_1")).WillReturnResult(sqlmock.NewResult(0, 0))
+    mock.ExpectExec(regexp.QuoteMeta("SAVEPOINT sp_2")).WillReturnResult(sqlmock.NewResult(0, 0))
+    mock.ExpectExec(regexp.QuoteMeta("DELETE FROM candidate_tags WHERE candidate_id = ?")).
+        WithArgs(7).
+        WillReturnError(errors.New("lock wait"))
+    mock.ExpectExec(regexp.QuoteMeta("ROLLBACK TO SAVEPOINT sp_2")).WillReturnResult(sqlmock.NewResult(0, 0))
+    mock.ExpectRollback()
+
+    id, err := svc.CreateCandidate(domain.Candidate{Name: "Jane", Email: "jane@example.com", Tags: []string{"go"}})
+    assert.ErrorContains(t, err, "lock wait")
+    assert.Zero(t, id)
+    assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,232 @@
+package service_test
+
+import (
+    "testing"
+
+    "github.com/stretchr/testify/assert"
+    "github.com/stretchr/testify/mock"
+
+    "github.com/torvictorvic/seek-v2/internal/domain"
+    "github.com/torvictorvic/seek-v2/internal/service"
+)
+
+// mockCustomFieldRepo implementa CustomFieldRepository usando testify/mock
+type mockCustomFieldRepo struct {
+    mock.Mock
+}
+
+func (m *mockCustomFieldRepo) Create(field domain.CustomField) (int, error) {
+    args := m.Called(field)
+    return args.Int(0), args.Error(1)
+}
+func (m *mockCustomFieldRepo) GetByKey(key string) (*domain.CustomField, error) {
+    args := m.Called(key)
+    if args.Get(0) == nil {
+        return nil, args.Error(1)
+    }
+    return args.Get(0).(*domain.CustomField), args.Error(1)
+}
+func (m *mockCustomFieldRepo) List() ([]domain.CustomField, error) {
+    args := m.Called()
+    return args.Get(0).([]domain.CustomField), args.Error(1)
+}
+func (m *mockCustomFieldRepo) Update(field domain.CustomField) error {
+    args := m.Called(field)
+    return args.Error(0)
+}
+func (m *mockCustomFieldRepo) Delete(key string) error {
+    args := m.Called(key)
+    return args.Error(0)
+}
+
+// mockAttributeRepo implementa CandidateAttributeRepository usando testify/mock
+type mockAttributeRepo struct {
+    mock.Mock
+}
+
+func (m *mockAttributeRepo) SaveTags(candidateID int, tags []string) error {
+    args := m.Called(candidateID, tags)
+    return args.Error(0)
+}
+func (m *mockAttributeRepo) SaveFieldValues(candidateID int, values []domain.FieldValue) error {
+    args := m.Called(candidateID, values)
+    return args.Error(0)
+}
+func (m *mockAttributeRepo) Load(candidateIDs []int) (map[int]domain.CandidateAttributes, error) {
+    args := m.Called(candidateIDs)
+    return args.Get(0).(map[int]domain.CandidateAttributes), args.Error(1)
+}
+func (m *mockAttributeRepo) ListTags() ([]domain.TagCount, error) {
+    args := m.Called()
+    return args.Get(0).([]domain.TagCount), args.Error(1)
+}
+
+var testFields = []domain.CustomField{
+    {Key: "years_experience", Label: "Años de experiencia", Type: domain.FieldNumber, Required: true},
+    {Key: "stack", Label: "Stack", Type: domain.FieldMultiSelect, Options: []string{"go", "java", "python"}},
+    {Key: "visa", Label: "Visa", Type: domain.FieldEnum, Options: []string{"none", "sponsored"}},
+    {Key: "available_from", Label: "Disponible desde", Type: domain.FieldDate},
+}
+
+func TestCustomField_Normalize(t *testing.T) {
+    stack := testFields[1]
+    value, err := stack.Normalize([]interface{}{"go", "java", "go"})
+    assert.NoError(t, err)
+    assert.Equal(t, []string{"go", "java"}, value)
+
+    _, err = stack.Normalize([]interface{}{"rust"})
+    assert.Error(t, err)
+
+    _, err = testFields[3].Normalize("31/12/2026")
+    assert.Error(t, err)
+
+    value, err = testFields[0].Normalize("5")
+    assert.NoError(t, err)
+    assert.Equal(t, 5.0, value)
+}
+
+func TestCreateCandidate_WithTagsAndCustomFields(t *testing.T) {
+    repo := new(mockCandidateRepo)
+    fields := new(mockCustomFieldRepo)
+    attrs := new(mockAttributeRepo)
+    svc := service.NewCandidateService(repo, service.WithCustomFields(fields, attrs))
+
+    fields.On("List").Return(testFields, nil)
+    repo.On("Create", mock.MatchedBy(func(c domain.Candidate) bool {
+        // Las etiquetas y los valores se guardan normalizados
+        return assert.ObjectsAreEqual([]string{"backend", "referido"}, c.Tags) && c.CustomFields["years_experience"] == 5.0
+    })).Return(7, nil)
+    attrs.On("SaveTags", 7, []string{"backend", "referido"}).Return(nil)
+    attrs.On("SaveFieldValues", 7, mock.MatchedBy(func(values []domain.FieldValue) bool {
+        // Un valor por campo y uno por cada opción de multi_select
+        return len(values) == 3 && values[0].Key == "years_experience" && *values[0].Number == 5
+    })).Return(nil)
+
+    id, err := svc.CreateCandidate(domain.Candidate{
+        Name:         "Jane Doe",
+        Email:        "jane@example.com",
+        Tags:         []string{" Referido", "backend", "BACKEND"},
+        CustomFields: map[string]interface{}{"years_experience": 5.0, "stack": []interface{}{"go", "python"}},
+    })
+    assert.NoError(t, err)
+    assert.Equal(t, 7, id)
+
+    repo.AssertExpectations(t)
+    attrs.AssertExpectations(t)
+}
+
+func TestCreateCandidate_InvalidCustomFields(t *testing.T) {
+    repo := new(mockCandidateRepo)
+    fields := new(mockCustomFieldRepo)
+    svc := service.NewCandidateService(repo, service.WithCustomFields(fields, new(mockAttributeRepo)))
+
+    fields.On("List").Return(testFields, nil)
+
+    // Falta el campo obligatorio
+    _, err := svc.CreateCandidate(domain.Candidate{Name: "Jane", Email: "jane@example.com"})
+    assert.ErrorIs(t, err, service.ErrInvalidAttributes)
+
+    _, err = svc.CreateCandidate(domain.Candidate{Name: "Jane", Email: "jane@example.com",
+        CustomFields: map[string]interface{}{"years_experience": 2.0, "visa": "maybe"}})
+    assert.ErrorIs(t, err, service.ErrInvalidAttributes)
+
+    _, err = svc.CreateCandidate(domain.Candidate{Name: "Jane", Email: "jane@example.com",
+        CustomFields: map[string]interface{}{"years_experience": 2.0, "shoe_size": 42.0}})
+    assert.ErrorIs(t, err, service.ErrUnknownCustomField)
+
+    repo.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestUpdateCandidate_KeepsOmittedAttributes(t *testing.T) {
+    repo := new(mockCandidateRepo)
+    attrs := new(mockAttributeRepo)
+    svc := service.NewCandidateService(repo, service.WithCustomFields(new(mockCustomFieldRepo), attrs))
+
+    candidate := domain.Candidate{ID: 5, Name: "New Name", Email: "new@example.com"}
+    repo.On("Update", candidate).Return(nil)
+
+    // Sin etiquetas ni campos en el body no se tocan los guardados
+    err := svc.UpdateCandidate(candidate)
+    assert.NoError(t, err)
+    attrs.AssertNotCalled(t, "SaveTags", mock.Anything, mock.Anything)
+    attrs.AssertNotCalled(t, "SaveFieldValues", mock.Anything, mock.Anything)
+}
+
+func TestGetAllCandidates_LoadsAttributes(t *testing.T) {
+    repo := new(mockCandidateRepo)
+    attrs := new(mockAttributeRepo)
+    svc := service.NewCandidateService(repo, service.WithCustomFields(new(mockCustomFieldRepo), attrs))
+
+    repo.On("GetAll", domain.CandidateFilter{}).Return([]domain.Candidate{{ID: 1}, {ID: 2}}, nil)
+    attrs.On("Load", []int{1, 2}).Return(map[int]domain.CandidateAttributes{
+        2: {Tags: []string{"backend"}, CustomFields: map[string]interface{}{"visa": "none"}},
+    }, nil)
+
+    candidates, err := svc.GetAllCandidates(domain.CandidateFilter{})
+    assert.NoError(t, err)
+    assert.Nil(t, candidates[0].Tags)
+    assert.Equal(t, []string{"backend"}, candidates[1].Tags)
+    assert.Equal(t, "none", candidates[1].CustomFields["visa"])
+}
+
+func TestParseFieldFilters(t *testing.T) {
+    fields := new(mockCustomFieldRepo)
+    svc := service.NewCandidateService(new(mockCandidateRepo), service.WithCustomFields(fields, new(mockAttributeRepo)))
+
+    fields.On("List").Return(testFields, nil)
+
+    conds, err := svc.ParseFieldFilters(map[string]string{"years_experience": "3..", "stack": "go", "available_from": "..2026-12-31"})
+    assert.NoError(t, err)
+    assert.Len(t, conds, 3)
+    // Ordenadas por clave
+    assert.Equal(t, "available_from", conds[0].Key)
+    assert.Equal(t, "2026-12-31", conds[0].To)
+    assert.Equal(t, 3.0, *conds[2].Min)
+    assert.Nil(t, conds[2].Max)
+
+    _, err = svc.ParseFieldFilters(map[string]string{"years_experience": "many"})
+    assert.ErrorIs(t, err, service.ErrInvalidAttributes)
+
+    _, err = svc.ParseFieldFilters(map[string]string{"shoe_size": "42"})
+    assert.ErrorIs(t, err, service.ErrUnknownCustomField)
+}
+
+func TestCandidateFilter_MatchesAttributes(t *testing.T) {
+    min := 3.0
+    filter := domain.CandidateFilter{
+        Tags:   []string{"Backend"},
+        Fields: []domain.FieldCondition{{Key: "years_experience", Type: domain.FieldNumber, Min: &min}, {Key: "stack", Type: domain.FieldMultiSelect, Value: "go"}},
+    }
+    c := domain.Candidate{Tags: []string{"backend"}, CustomFields: map[string]interface{}{"years_experience": 5.0, "stack": []string{"go"}}}
+    assert.True(t, filter.Matches(c))
+
+    c.CustomFields["years_experience"] = 2.0
+    assert.False(t, filter.Matches(c))
+}
+
+func TestCreateField(t *testing.T) {
+    fields := new(mockCustomFieldRepo)
+    svc := service.NewCustomFieldService(fields, new(mockAttributeRepo))
+
+    _, err := svc.CreateField(domain.CustomField{Key: "Stack", Label: "Stack", Type: domain.FieldText})
+    assert.ErrorIs(t, err, service.ErrInvalidCustomField)
+
+    _, err = svc.CreateField(domain.CustomField{Key: "stack", Label: "Stack", Type: domain.FieldEnum})
+    assert.ErrorIs(t, err, service.ErrInvalidCustomField)
+
+    fields.On("GetByKey", "visa").Return(&testFields[2], nil)
+    _, err = svc.CreateField(domain.CustomField{Key: "visa", Label: "Visa", Type: domain.FieldText})
+    assert.ErrorIs(t, err, service.ErrCustomFieldExists)
+    fields.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestUpdateField_TypeCannotChange(t *testing.T) {
+    fields := new(mockCustomFieldRepo)
+    svc := service.NewCustomFieldService(fields, new(mockAttributeRepo))
+
+    fields.On("GetByKey", "visa").Return(&testFields[2], nil)
+
+    _, err := svc.UpdateField(domain.CustomField{Key: "visa", Label: "Visa", Type: domain.FieldText})
+    assert.ErrorIs(t, err, service.ErrInvalidCustomField)
+    fields.AssertNotCalled(t, "Update", mock.Anything)
+}
@@ -0,0 +1,245 @@
+package service_test
+
+import (
+    "archive/zip"
+    "bytes"
+    "context"
+    "crypto/sha256"
+    "encoding/hex"
+    "io"
+    "net/url"
+    "strings"
+    "testing"
+    "time"
+    "unicode/utf8"
+
+    "github.com/stretchr/testify/assert"
+    "github.com/stretchr/testify/mock"
+
+    "github.com/torvictorvic/seek-v2/internal/domain"
+    "github.com/torvictorvic/seek-v2/internal/search"
+    "github.com/torvictorvic/seek-v2/internal/service"
+    "github.com/torvictorvic/seek-v2/internal/storage"
+)
+
+// mockDocumentRepo implementa DocumentRepository usando testify/mock
+type mockDocumentRepo struct {
+    mock.Mock
+}
+
+func (m *mockDocumentRepo) Create(doc domain.Document) (int, error) {
+    args := m.Called(doc)
+    return args.Int(0), args.Error(1)
+}
+func (m *mockDocumentRepo) GetByID(id int) (*domain.Document, error) {
+    args := m.Called(id)
+    if args.Get(0) == nil {
+        return nil, args.Error(1)
+    }
+    return args.Get(0).(*domain.Document), args.Error(1)
+}
+func (m *mockDocumentRepo) ListByCandidate(candidateID int) ([]domain.Document, error) {
+    args := m.Called(candidateID)
+    return args.Get(0).([]domain.Document), args.Error(1)
+}
+func (m *mockDocumentRepo) Delete(doc domain.Document, actor string) error {
+    args := m.Called(doc, actor)
+    return args.Error(0)
+}
+func (m *mockDocumentRepo) ListDeleted(limit int) ([]domain.Document, error) {
+    args := m.Called(limit)
+    return args.Get(0).([]domain.Document), args.Error(1)
+}
+func (m *mockDocumentRepo) Purge(id int) error {
+    args := m.Called(id)
+    return args.Error(0)
+}
+func (m *mockDocumentRepo) GetText(id int) (string, error) {
+    args := m.Called(id)
+    return args.String(0), args.Error(1)
+}
+func (m *mockDocumentRepo) ResumeTexts(candidateIDs []int) (map[int]string, error) {
+    args := m.Called(candidateIDs)
+    return args.Get(0).(map[int]string), args.Error(1)
+}
+
+var testPDF = []byte("%PDF-1.7\n1 0 obj\n<< /Length 58 >>\nstream\nBT 72 720 Td (Jane Doe) Tj 0 -14 Td (Golang, Docker) Tj ET\nendstream\nendobj\n%%EOF\n")
+
+func newDocumentService(t *testing.T, settings service.DocumentSettings, opts ...service.DocumentOption) (service.DocumentService, *mockDocumentRepo, *mockCandidateRepo, storage.Storage) {
+    store, err := storage.NewLocal(t.TempDir())
+    assert.NoError(t, err)
+    docs := new(mockDocumentRepo)
+    candidates := new(mockCandidateRepo)
+    return service.NewDocumentService(docs, candidates, store, settings, opts...), docs, candidates, store
+}
+
+// docxFile construye un DOCX mínimo: un zip con las partes de Word
+func docxFile(t *testing.T) []byte {
+    var buf bytes.Buffer
+    zw := zip.NewWriter(&buf)
+    for _, name := range []string{"[Content_Types].xml", "word/document.xml"} {
+        w, err := zw.Create(name)
+        assert.NoError(t, err)
+        w.Write([]byte("<xml/>"))
+    }
+    assert.NoError(t, zw.Close())
+    return buf.Bytes()
+}
+
+func TestSniffDocument(t *testing.T) {
+    contentType, kind, err := service.SniffDocument(bytes.NewReader(testPDF), int64(len(testPDF)))
+    assert.NoError(t, err)
+    assert.Equal(t, "application/pdf", contentType)
+    assert.Equal(t, domain.DocumentPDF, kind)
+
+    docx := docxFile(t)
+    contentType, kind, err = service.SniffDocument(bytes.NewReader(docx), int64(len(docx)))
+    assert.NoError(t, err)
+    assert.Equal(t, service.DOCXContentType, contentType)
+    assert.Equal(t, domain.DocumentDOCX, kind)
+
+    png := append([]byte("\x89PNG\r\n\x1a\n"), make([]byte, 16)...)
+    _, kind, err = service.SniffDocument(bytes.NewReader(png), int64(len(png)))
+    assert.NoError(t, err)
+    assert.Equal(t, domain.DocumentImage, kind)
+
+    // Un zip que no es Word, un ejecutable o un HTML no se aceptan
+    var other bytes.Buffer
+    zw := zip.NewWriter(&other)
+    zw.Create("payload.exe")
+    zw.Close()
+    for _, content := range [][]byte{other.Bytes(), []byte("MZ\x90\x00"), []byte("<html><script>alert(1)</script>")} {
+        _, _, err = service.SniffDocument(bytes.NewReader(content), int64(len(content)))
+        assert.ErrorIs(t, err, service.ErrUnsupportedDocument)
+    }
+}
+
+func TestUpload_StoresSniffedDocument(t *testing.T) {
+    idx := search.NewMemoryIndex()
+    idx.Index(domain.Candidate{ID: 4, Name: "Jane Doe"})
+    svc, docs, candidates, store := newDocumentService(t, service.DocumentSettings{MaxBytes: 1024}, service.WithResumeIndex(idx))
+    sum := sha256.Sum256(testPDF)
+
+    candidates.On("GetByID", 4).Return(&domain.Candidate{ID: 4}, nil)
+    var saved domain.Document
+    docs.On("Create", mock.MatchedBy(func(d domain.Document) bool {
+        saved = d
+        // El tipo declarado por el cliente se ignora y el nombre pierde la ruta
+        return d.CandidateID == 4 && d.Kind == domain.DocumentPDF && d.FileName == "cv.pdf" && d.Text == "Jane Doe\nGolang, Docker" &&
+            d.SHA256 == hex.EncodeToString(sum[:]) && strings.HasPrefix(d.StorageKey, "candidates/4/")
+    })).Return(9, nil)
+    docs.On("GetByID", 9).Return(&domain.Document{ID: 9, CandidateID: 4}, nil)
+    docs.On("ResumeTexts", []int{4}).Return(map[int]string{4: "Jane Doe\nGolang, Docker"}, nil)
+
+    doc, err := svc.Upload(context.Background(), 4, "../../cv.pdf", bytes.NewReader(testPDF), int64(len(testPDF)), "ana")
+    assert.NoError(t, err)
+    assert.Equal(t, 9, doc.ID)
+
+    body, err := store.Get(context.Background(), saved.StorageKey, 0)
+    assert.NoError(t, err)
+    content, _ := io.ReadAll(body)
+    body.Close()
+    assert.Equal(t, testPDF, content)
+    docs.AssertExpectations(t)
+
+    // El texto del CV queda en el índice de búsqueda
+    hits, err := idx.Search("docker", domain.CandidateFilter{}, 10)
+    assert.NoError(t, err)
+    assert.Len(t, hits, 1)
+    assert.Equal(t, "Jane Doe\nGolang, <mark>Docker</mark>", hits[0].Highlights["resume"])
+}
+
+func TestParseResume_SuggestsWithoutSaving(t *testing.T) {
+    svc, docs, _, _ := newDocumentService(t, service.DocumentSettings{MaxBytes: 1024})
+
+    suggestion, err := svc.ParseResume(bytes.NewReader(testPDF), int64(len(testPDF)))
+    assert.NoError(t, err)
+    assert.Equal(t, "Jane Doe", suggestion.Candidate.Name)
+    assert.Equal(t, []string{"docker", "go"}, suggestion.Tags)
+    docs.AssertNotCalled(t, "Create", mock.Anything)
+
+    // Una imagen no tiene texto
+    png := append([]byte("\x89PNG\r\n\x1a\n"), make([]byte, 16)...)
+    _, err = svc.ParseResume(bytes.NewReader(png), int64(len(png)))
+    assert.ErrorIs(t, err, service.ErrNoResumeText)
+}
+
+func TestUpload_RejectsLargeAndUnsupported(t *testing.T) {
+    svc, docs, candidates, _ := newDocumentService(t, service.DocumentSettings{MaxBytes: 10})
+    candidates.On("GetByID", 4).Return(&domain.Candidate{ID: 4}, nil)
+
+    _, err := svc.Upload(context.Background(), 4, "cv.pdf", bytes.NewReader(testPDF), int64(len(testPDF)), "ana")
+    assert.ErrorIs(t, err, service.ErrDocumentTooLarge)
+
+    _, err = svc.Upload(context.Background(), 4, "cv.pdf", strings.NewReader("texto"), 5, "ana")
+    assert.ErrorIs(t, err, service.ErrUnsupportedDocument)
+
+    docs.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestSignedURL_VerifiesSignatureAndExpiry(t *testing.T) {
+    svc, docs, _, _ := newDocumentService(t, service.DocumentSettings{URLTTL: time.Minute, URLSecret: []byte("secreto")})
+    doc := domain.Document{ID: 9, CandidateID: 4}
+    docs.On("GetByID", 9).Return(&doc, nil)
+
+    signed := svc.SignURL(doc)
+    u, err := url.Parse(signed.URL)
+    assert.NoError(t, err)
+    assert.Equal(t, "/documents/9/download", u.Path)
+    q := u.Query()
+
+    got, err := svc.VerifyURL(9, q.Get("expires"), q.Get("signature"))
+    assert.NoError(t, err)
+    assert.Equal(t, 9, got.ID)
+
+    // La firma no sirve para otro documento ni con otra expiración
+    _, err = svc.VerifyURL(10, q.Get("expires"), q.Get("signature"))
+    assert.ErrorIs(t, err, service.ErrInvalidDocumentURL)
+    _, err = svc.VerifyURL(9, "9999999999", q.Get("signature"))
+    assert.ErrorIs(t, err, service.ErrInvalidDocumentURL)
+
+    // Un enlace vencido se rechaza
+    expired, _, _, _ := newDocumentService(t, service.DocumentSettings{URLTTL: -time.Minute, URLSecret: []byte("secreto")})
+    u, _ = url.Parse(expired.SignURL(doc).URL)
+    _, err = expired.VerifyURL(9, u.Query().Get("expires"), u.Query().Get("signature"))
+    assert.ErrorIs(t, err, service.ErrInvalidDocumentURL)
+}
+
+func TestPurgeDeleted_RemovesContentAndRows(t *testing.T) {
+    svc, docs, _, store := newDocumentService(t, service.DocumentSettings{})
+    ctx := context.Background()
+    // Documentos de un candidato borrado, uno ya sin contenido
+    assert.NoError(t, store.Put(ctx, "candidates/4/a", bytes.NewReader(testPDF), int64(len(testPDF)), "application/pdf"))
+    deleted := []domain.Document{{ID: 1, StorageKey: "candidates/4/a"}, {ID: 2, StorageKey: "candidates/4/b"}}
+
+    docs.On("ListDeleted", 100).Return(deleted, nil)
+    docs.On("Purge", 1).Return(nil)
+    docs.On("Purge", 2).Return(nil)
+
+    n, err := svc.PurgeDeleted(ctx)
+    assert.NoError(t, err)
+    assert.Equal(t, 2, n)
+    _, err = store.Get(ctx, "candidates/4/a", 0)
+    assert.ErrorIs(t, err, storage.ErrNotFound)
+    docs.AssertExpectations(t)
+}
+
+func TestUpload_TruncatesLongFileName(t *testing.T) {
+    svc, docs, candidates, _ := newDocumentService(t, service.DocumentSettings{MaxBytes: 1024})
+    // 2 bytes por "ó": el corte a 255 bytes caería en mitad de un carácter
+    name := strings.Repeat("ó", 200) + ".pdf"
+
+    candidates.On("GetByID", 4).Return(&domain.Candidate{ID: 4}, nil)
+    var saved domain.Document
+    docs.On("Create", mock.MatchedBy(func(d domain.Document) bool {
+        saved = d
+        return true
+    })).Return(9, nil)
+    docs.On("GetByID", 9).Return(&domain.Document{ID: 9, CandidateID: 4}, nil)
+
+    _, err := svc.Upload(context.Background(), 4, name, bytes.NewReader(testPDF), int64(len(testPDF)), "ana")
+    assert.NoError(t, err)
+    assert.True(t, utf8.ValidString(saved.FileName))
+    assert.LessOrEqual(t, len(saved.FileName), 255)
+    assert.Equal(t, strings.Repeat("ó", 125)+".pdf", saved.FileName)
+}
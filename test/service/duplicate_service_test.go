@@ -0,0 +1,88 @@
+package service_test
+
+import (
+    "testing"
+
+    "github.com/stretchr/testify/assert"
+    "github.com/stretchr/testify/mock"
+
+    "github.com/torvictorvic/seek-v2/internal/domain"
+    "github.com/torvictorvic/seek-v2/internal/service"
+)
+
+func TestFindDuplicates(t *testing.T) {
+    mockRepo := new(mockCandidateRepo)
+    svc := service.NewDuplicateService(mockRepo)
+
+    target := &domain.Candidate{ID: 4, Name: "Anna Walker", Email: "anna.walker@example.com"}
+    others := []domain.Candidate{
+        *target,
+        {ID: 1, Name: "Roy Smith", Email: "roy.smith@example.com"},
+        {ID: 9, Name: "Ana Walker", Email: "anna.walker@gmail.com"},
+    }
+
+    mockRepo.On("GetByID", 4).Return(target, nil)
+    mockRepo.On("Stream", domain.CandidateFilter{}, mock.Anything).
+        Run(func(args mock.Arguments) {
+            fn := args.Get(1).(func(domain.Candidate) error)
+            for _, c := range others {
+                fn(c)
+            }
+        }).
+        Return(nil)
+
+    duplicates, err := svc.FindDuplicates(4, 0, 0)
+    assert.NoError(t, err)
+    assert.Len(t, duplicates, 1)
+    assert.Equal(t, 9, duplicates[0].Candidate.ID)
+
+    mockRepo.AssertExpectations(t)
+}
+
+func TestFindDuplicates_NotFound(t *testing.T) {
+    mockRepo := new(mockCandidateRepo)
+    svc := service.NewDuplicateService(mockRepo)
+
+    mockRepo.On("GetByID", 99).Return(nil, nil)
+
+    _, err := svc.FindDuplicates(99, 0, 0)
+    assert.ErrorIs(t, err, service.ErrCandidateNotFound)
+}
+
+func TestMergeCandidates(t *testing.T) {
+    mockRepo := new(mockCandidateRepo)
+    svc := service.NewCandidateService(mockRepo)
+
+    target := &domain.Candidate{ID: 4, Name: "Anna Walker", Email: "awalker@acme.com", SalaryExpected: 32000}
+    source := &domain.Candidate{ID: 9, Name: "Ana Walker", Email: "anna.walker@gmail.com", SalaryExpected: 35000}
+
+    mockRepo.On("GetByID", 4).Return(target, nil)
+    mockRepo.On("GetByID", 9).Return(source, nil)
+    mockRepo.On("Merge", mock.MatchedBy(func(c domain.Candidate) bool {
+        // Se conserva el nombre del destino y el email y salario del origen
+        return c.ID == 4 && c.Name == "Anna Walker" && c.Email == "anna.walker@gmail.com" && c.SalaryExpected == 35000
+    }), 9, mock.MatchedBy(func(e domain.CandidateHistoryEntry) bool {
+        return e.CandidateID == 4 && e.Action == domain.HistoryMerged && e.Actor == "recruiter"
+    })).Return(nil)
+
+    merged, err := svc.MergeCandidates(4, domain.MergeRequest{
+        SourceID: 9,
+        Fields:   map[string]string{"email": "source", "salary_expected": "source"},
+    }, "recruiter")
+    assert.NoError(t, err)
+    assert.Equal(t, "anna.walker@gmail.com", merged.Email)
+
+    mockRepo.AssertExpectations(t)
+}
+
+func TestMergeCandidates_InvalidField(t *testing.T) {
+    mockRepo := new(mockCandidateRepo)
+    svc := service.NewCandidateService(mockRepo)
+
+    mockRepo.On("GetByID", 4).Return(&domain.Candidate{ID: 4, Name: "A", Email: "a@example.com"}, nil)
+    mockRepo.On("GetByID", 9).Return(&domain.Candidate{ID: 9, Name: "B", Email: "b@example.com"}, nil)
+
+    _, err := svc.MergeCandidates(4, domain.MergeRequest{SourceID: 9, Fields: map[string]string{"password": "source"}}, "")
+    assert.ErrorIs(t, err, service.ErrInvalidMergeField)
+    mockRepo.AssertNotCalled(t, "Merge", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,153 @@
+package service_test
+
+import (
+    "errors"
+    "testing"
+
+    "github.com/stretchr/testify/assert"
+    "github.com/stretchr/testify/mock"
+
+    "github.com/torvictorvic/seek-v2/internal/domain"
+    "github.com/torvictorvic/seek-v2/internal/service"
+)
+
+// mockFeedbackRepo implementa FeedbackRepository usando testify/mock
+type mockFeedbackRepo struct {
+    mock.Mock
+}
+
+func (m *mockFeedbackRepo) Create(fb domain.Feedback) (int, error) {
+    args := m.Called(fb)
+    return args.Int(0), args.Error(1)
+}
+func (m *mockFeedbackRepo) HasSubmitted(candidateID int, interviewer string) (bool, error) {
+    args := m.Called(candidateID, interviewer)
+    return args.Bool(0), args.Error(1)
+}
+func (m *mockFeedbackRepo) ListByCandidate(candidateID int) ([]domain.Feedback, error) {
+    args := m.Called(candidateID)
+    return args.Get(0).([]domain.Feedback), args.Error(1)
+}
+
+// mockScorecardRepo implementa ScorecardRepository usando testify/mock
+type mockScorecardRepo struct {
+    mock.Mock
+}
+
+func (m *mockScorecardRepo) CreateTemplate(t domain.ScorecardTemplate) (int, error) {
+    args := m.Called(t)
+    return args.Int(0), args.Error(1)
+}
+func (m *mockScorecardRepo) GetTemplate(id int) (*domain.ScorecardTemplate, error) {
+    args := m.Called(id)
+    if args.Get(0) == nil {
+        return nil, args.Error(1)
+    }
+    return args.Get(0).(*domain.ScorecardTemplate), args.Error(1)
+}
+func (m *mockScorecardRepo) ListTemplates() ([]domain.ScorecardTemplate, error) {
+    args := m.Called()
+    return args.Get(0).([]domain.ScorecardTemplate), args.Error(1)
+}
+
+// Plantilla 1-5 donde "Go" pesa el triple que "Comunicación"
+var backendTemplate = &domain.ScorecardTemplate{
+    ID: 1, Name: "Backend", ScaleMin: 1, ScaleMax: 5,
+    Competencies: []domain.Competency{{ID: 10, Name: "Go", Weight: 3}, {ID: 11, Name: "Comunicación", Weight: 1}},
+}
+
+type feedbackMocks struct {
+    feedback   *mockFeedbackRepo
+    templates  *mockScorecardRepo
+    candidates *mockCandidateRepo
+    interviews *mockInterviewRepo
+}
+
+func newFeedbackService() (service.FeedbackService, feedbackMocks) {
+    m := feedbackMocks{new(mockFeedbackRepo), new(mockScorecardRepo), new(mockCandidateRepo), new(mockInterviewRepo)}
+    m.templates.On("GetTemplate", 1).Return(backendTemplate, nil)
+    m.candidates.On("GetByID", 4).Return(&domain.Candidate{ID: 4}, nil)
+    // "maria" y "jose" entrevistaron al candidato 4
+    m.interviews.On("ListByCandidate", 4).Return([]domain.Interview{{ID: 12, CandidateID: 4, Interviewers: []string{"maria", "jose"}}}, nil)
+    return service.NewFeedbackService(m.feedback, m.templates, m.candidates, m.interviews), m
+}
+
+func TestSubmitFeedback_WeightedScore(t *testing.T) {
+    svc, m := newFeedbackService()
+
+    m.feedback.On("HasSubmitted", 4, "maria").Return(false, nil)
+    // Go 5 (100%) x3 + Comunicación 3 (50%) x1 => 87.5
+    m.feedback.On("Create", mock.MatchedBy(func(fb domain.Feedback) bool {
+        return fb.Score == 87.5 && fb.Interviewer == "maria" && fb.CandidateID == 4
+    })).Return(20, nil)
+
+    fb, err := svc.SubmitFeedback(4, domain.Feedback{
+        TemplateID:     1,
+        Recommendation: domain.RecommendYes,
+        Ratings:        []domain.Rating{{CompetencyID: 10, Score: 5}, {CompetencyID: 11, Score: 3}},
+    }, "maria")
+    assert.NoError(t, err)
+    assert.Equal(t, 20, fb.ID)
+    m.feedback.AssertExpectations(t)
+}
+
+func TestSubmitFeedback_Invalid(t *testing.T) {
+    svc, m := newFeedbackService()
+    m.feedback.On("HasSubmitted", 4, "maria").Return(true, nil)
+
+    cases := []struct {
+        fb   domain.Feedback
+        want error
+    }{
+        // Falta calificar una competencia
+        {domain.Feedback{TemplateID: 1, Recommendation: domain.RecommendNo, Ratings: []domain.Rating{{CompetencyID: 10, Score: 2}}}, service.ErrInvalidFeedback},
+        // Fuera de escala
+        {domain.Feedback{TemplateID: 1, Recommendation: domain.RecommendNo, Ratings: []domain.Rating{{CompetencyID: 10, Score: 6}, {CompetencyID: 11, Score: 2}}}, service.ErrInvalidFeedback},
+        {domain.Feedback{TemplateID: 1, Recommendation: "maybe", Ratings: []domain.Rating{{CompetencyID: 10, Score: 2}, {CompetencyID: 11, Score: 2}}}, service.ErrInvalidFeedback},
+        // Ya envió su evaluación
+        {domain.Feedback{TemplateID: 1, Recommendation: domain.RecommendNo, Ratings: []domain.Rating{{CompetencyID: 10, Score: 2}, {CompetencyID: 11, Score: 2}}}, service.ErrFeedbackExists},
+    }
+    for _, tc := range cases {
+        _, err := svc.SubmitFeedback(4, tc.fb, "maria")
+        assert.True(t, errors.Is(err, tc.want), "got %v", err)
+    }
+
+    _, err := svc.SubmitFeedback(4, cases[0].fb, "")
+    assert.True(t, errors.Is(err, service.ErrIdentityRequired))
+    m.feedback.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestListFeedback_HiddenUntilSubmitted(t *testing.T) {
+    svc, m := newFeedbackService()
+
+    all := []domain.Feedback{
+        {ID: 1, TemplateID: 1, Interviewer: "maria", Recommendation: domain.RecommendYes, Score: 87.5,
+            Ratings: []domain.Rating{{CompetencyID: 10, Score: 5}, {CompetencyID: 11, Score: 3}}},
+    }
+    m.feedback.On("ListByCandidate", 4).Return(all, nil)
+    m.feedback.On("HasSubmitted", 4, "jose").Return(false, nil)
+    m.feedback.On("HasSubmitted", 4, "maria").Return(true, nil)
+    m.feedback.On("HasSubmitted", 4, "recruiter").Return(false, nil)
+
+    // "jose" es entrevistador y no ha enviado la suya: no ve nada
+    list, err := svc.ListFeedback(4, "jose")
+    assert.NoError(t, err)
+    assert.Empty(t, list.Feedback)
+    assert.Equal(t, 1, list.Hidden)
+    summary, err := svc.Summary(4, "jose")
+    assert.NoError(t, err)
+    assert.Nil(t, summary)
+
+    list, err = svc.ListFeedback(4, "maria")
+    assert.NoError(t, err)
+    assert.True(t, list.Submitted)
+    assert.Len(t, list.Feedback, 1)
+
+    // Quien no entrevistó al candidato ve el resumen
+    summary, err = svc.Summary(4, "recruiter")
+    assert.NoError(t, err)
+    assert.Equal(t, 1, summary.Count)
+    assert.Equal(t, 87.5, summary.Score)
+    assert.Equal(t, []domain.CompetencyScore{{Name: "Go", Score: 100, Count: 1}, {Name: "Comunicación", Score: 50, Count: 1}}, summary.Competencies)
+    assert.Equal(t, map[string]int{"yes": 1}, summary.Recommendations)
+}
@@ -0,0 +1,163 @@
+package service_test
+
+import (
+    "errors"
+    "testing"
+    "time"
+
+    "github.com/stretchr/testify/assert"
+    "github.com/stretchr/testify/mock"
+
+    "github.com/torvictorvic/seek-v2/internal/domain"
+    "github.com/torvictorvic/seek-v2/internal/service"
+)
+
+// mockInterviewRepo implementa InterviewRepository usando testify/mock
+type mockInterviewRepo struct {
+    mock.Mock
+}
+
+func (m *mockInterviewRepo) Create(iv domain.Interview) (int, error) {
+    args := m.Called(iv)
+    return args.Int(0), args.Error(1)
+}
+func (m *mockInterviewRepo) GetByID(id int) (*domain.Interview, error) {
+    args := m.Called(id)
+    if args.Get(0) == nil {
+        return nil, args.Error(1)
+    }
+    return args.Get(0).(*domain.Interview), args.Error(1)
+}
+func (m *mockInterviewRepo) ListByCandidate(candidateID int) ([]domain.Interview, error) {
+    args := m.Called(candidateID)
+    return args.Get(0).([]domain.Interview), args.Error(1)
+}
+func (m *mockInterviewRepo) ListByInterviewer(interviewer string, from time.Time) ([]domain.Interview, error) {
+    args := m.Called(interviewer, from)
+    return args.Get(0).([]domain.Interview), args.Error(1)
+}
+func (m *mockInterviewRepo) FindOverlapping(iv domain.Interview) ([]domain.Interview, error) {
+    args := m.Called(iv)
+    return args.Get(0).([]domain.Interview), args.Error(1)
+}
+func (m *mockInterviewRepo) Update(iv domain.Interview) error {
+    return m.Called(iv).Error(0)
+}
+func (m *mockInterviewRepo) Delete(id int) error {
+    return m.Called(id).Error(0)
+}
+
+// mockCalendarFeedRepo implementa CalendarFeedRepository usando testify/mock
+type mockCalendarFeedRepo struct {
+    mock.Mock
+}
+
+func (m *mockCalendarFeedRepo) Save(user, tokenHash string) error {
+    return m.Called(user, tokenHash).Error(0)
+}
+func (m *mockCalendarFeedRepo) GetUser(tokenHash string) (string, error) {
+    args := m.Called(tokenHash)
+    return args.String(0), args.Error(1)
+}
+func (m *mockCalendarFeedRepo) Delete(user string) error {
+    return m.Called(user).Error(0)
+}
+
+var interviewStart = time.Date(2026, 10, 20, 15, 0, 0, 0, time.UTC)
+
+func newInterview() domain.Interview {
+    return domain.Interview{
+        CandidateID:  4,
+        Interviewers: []string{"maria", " maria ", "jose"},
+        StartsAt:     interviewStart,
+        EndsAt:       interviewStart.Add(time.Hour),
+        TimeZone:     "America/Lima",
+        Type:         domain.InterviewTechnical,
+    }
+}
+
+func TestScheduleInterview_Conflict(t *testing.T) {
+    interviews := new(mockInterviewRepo)
+    candidates := new(mockCandidateRepo)
+    svc := service.NewInterviewService(interviews, new(mockCalendarFeedRepo), candidates, new(mockApplicationRepo))
+
+    // "maria" ya tiene otra entrevista que empieza a mitad de la nueva
+    busy := domain.Interview{ID: 3, CandidateID: 8, Interviewers: []string{"maria"},
+        StartsAt: interviewStart.Add(30 * time.Minute), EndsAt: interviewStart.Add(90 * time.Minute)}
+    candidates.On("GetByID", 4).Return(&domain.Candidate{ID: 4}, nil)
+    interviews.On("FindOverlapping", mock.Anything).Return([]domain.Interview{busy}, nil)
+
+    _, err := svc.ScheduleInterview(newInterview(), "recruiter")
+    var conflict *service.InterviewConflictError
+    assert.True(t, errors.As(err, &conflict))
+    assert.True(t, errors.Is(err, service.ErrInterviewConflict))
+    assert.Equal(t, []domain.InterviewConflict{{InterviewID: 3, Reason: "interviewer", Interviewer: "maria"}}, conflict.Conflicts)
+    interviews.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestScheduleInterview_BackToBack(t *testing.T) {
+    interviews := new(mockInterviewRepo)
+    candidates := new(mockCandidateRepo)
+    svc := service.NewInterviewService(interviews, new(mockCalendarFeedRepo), candidates, new(mockApplicationRepo))
+
+    // Una entrevista que termina justo cuando empieza la nueva no es conflicto
+    before := domain.Interview{ID: 3, CandidateID: 4, Interviewers: []string{"maria"},
+        StartsAt: interviewStart.Add(-time.Hour), EndsAt: interviewStart}
+    candidates.On("GetByID", 4).Return(&domain.Candidate{ID: 4}, nil)
+    interviews.On("FindOverlapping", mock.Anything).Return([]domain.Interview{before}, nil)
+    interviews.On("Create", mock.MatchedBy(func(iv domain.Interview) bool {
+        // Entrevistadores sin repetir y creador registrado
+        return len(iv.Interviewers) == 2 && iv.CreatedBy == "recruiter"
+    })).Return(12, nil)
+    interviews.On("GetByID", 12).Return(&domain.Interview{ID: 12}, nil)
+
+    iv, err := svc.ScheduleInterview(newInterview(), "recruiter")
+    assert.NoError(t, err)
+    assert.Equal(t, 12, iv.ID)
+    interviews.AssertExpectations(t)
+}
+
+func TestScheduleInterview_Invalid(t *testing.T) {
+    svc := service.NewInterviewService(new(mockInterviewRepo), new(mockCalendarFeedRepo), new(mockCandidateRepo), new(mockApplicationRepo))
+
+    noInterviewers := newInterview()
+    noInterviewers.Interviewers = []string{" "}
+    reversed := newInterview()
+    reversed.EndsAt = reversed.StartsAt.Add(-time.Minute)
+    badZone := newInterview()
+    badZone.TimeZone = "Mars/Olympus"
+    videoWithoutLink := newInterview()
+    videoWithoutLink.Type = domain.InterviewVideo
+
+    for _, iv := range []domain.Interview{noInterviewers, reversed, badZone, videoWithoutLink} {
+        _, err := svc.ScheduleInterview(iv, "recruiter")
+        assert.True(t, errors.Is(err, service.ErrInvalidInterview))
+    }
+}
+
+func TestFeed(t *testing.T) {
+    interviews := new(mockInterviewRepo)
+    feeds := new(mockCalendarFeedRepo)
+    candidates := new(mockCandidateRepo)
+    svc := service.NewInterviewService(interviews, feeds, candidates, new(mockApplicationRepo))
+
+    // Se guarda solo el hash del token
+    var stored string
+    feeds.On("Save", "maria", mock.Anything).Run(func(args mock.Arguments) { stored = args.String(1) }).Return(nil)
+    token, err := svc.IssueFeedToken("maria")
+    assert.NoError(t, err)
+    assert.NotEqual(t, token, stored)
+
+    feeds.On("GetUser", stored).Return("maria", nil)
+    feeds.On("GetUser", mock.Anything).Return("", nil)
+    interviews.On("ListByInterviewer", "maria", mock.Anything).Return([]domain.Interview{{ID: 12, CandidateID: 4}}, nil)
+    candidates.On("GetByID", 4).Return(&domain.Candidate{ID: 4, Name: "Anna Walker", Email: "anna@example.com"}, nil)
+
+    cal, err := svc.Feed(token)
+    assert.NoError(t, err)
+    assert.Len(t, cal.Events, 1)
+    assert.Equal(t, "interview-12@seek-v2", cal.Events[0].UID)
+
+    _, err = svc.Feed("otro-token")
+    assert.True(t, errors.Is(err, service.ErrInvalidFeedToken))
+}
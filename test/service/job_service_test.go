@@ -0,0 +1,70 @@
+package service_test
+
+import (
+    "errors"
+    "testing"
+
+    "github.com/stretchr/testify/assert"
+    "github.com/stretchr/testify/mock"
+
+    "github.com/torvictorvic/seek-v2/internal/domain"
+    "github.com/torvictorvic/seek-v2/internal/service"
+)
+
+// mockJobRepo implementa JobRepository usando testify/mock
+type mockJobRepo struct {
+    mock.Mock
+}
+
+func (m *mockJobRepo) Create(job domain.Job) (int, error) {
+    args := m.Called(job)
+    return args.Int(0), args.Error(1)
+}
+func (m *mockJobRepo) GetByID(id int) (*domain.Job, error) {
+    args := m.Called(id)
+    if args.Get(0) == nil {
+        return nil, args.Error(1)
+    }
+    return args.Get(0).(*domain.Job), args.Error(1)
+}
+func (m *mockJobRepo) GetAll(filter domain.JobFilter) ([]domain.Job, error) {
+    args := m.Called(filter)
+    return args.Get(0).([]domain.Job), args.Error(1)
+}
+func (m *mockJobRepo) Update(job domain.Job) error {
+    args := m.Called(job)
+    return args.Error(0)
+}
+func (m *mockJobRepo) Delete(id int) error {
+    args := m.Called(id)
+    return args.Error(0)
+}
+
+func TestCreateJob_DefaultsToOpen(t *testing.T) {
+    mockRepo := new(mockJobRepo)
+    svc := service.NewJobService(mockRepo)
+
+    // Sin estado, la vacante se crea abierta
+    mockRepo.On("Create", domain.Job{Title: "QA Analyst", Status: domain.JobOpen}).Return(3, nil)
+
+    id, err := svc.CreateJob(domain.Job{Title: "QA Analyst"})
+    assert.NoError(t, err)
+    assert.Equal(t, 3, id)
+    mockRepo.AssertExpectations(t)
+}
+
+func TestCreateJob_Invalid(t *testing.T) {
+    mockRepo := new(mockJobRepo)
+    svc := service.NewJobService(mockRepo)
+
+    cases := []domain.Job{
+        {Status: domain.JobOpen},
+        {Title: "QA Analyst", Status: "paused"},
+        {Title: "QA Analyst", SalaryMin: 5000, SalaryMax: 3000},
+    }
+    for _, job := range cases {
+        _, err := svc.CreateJob(job)
+        assert.True(t, errors.Is(err, service.ErrInvalidJob))
+    }
+    mockRepo.AssertNotCalled(t, "Create", mock.Anything)
+}
@@ -0,0 +1,162 @@
+package service_test
+
+import (
+    "testing"
+
+    "github.com/stretchr/testify/assert"
+    "github.com/stretchr/testify/mock"
+
+    "github.com/torvictorvic/seek-v2/internal/domain"
+    "github.com/torvictorvic/seek-v2/internal/service"
+)
+
+// mockNoteRepo implementa NoteRepository usando testify/mock
+type mockNoteRepo struct {
+    mock.Mock
+}
+
+func (m *mockNoteRepo) Create(note domain.Note, notifications []domain.Notification) (int, error) {
+    args := m.Called(note, notifications)
+    return args.Int(0), args.Error(1)
+}
+func (m *mockNoteRepo) GetByID(id int) (*domain.Note, error) {
+    args := m.Called(id)
+    if args.Get(0) == nil {
+        return nil, args.Error(1)
+    }
+    // Retornamos una copia para que el servicio no modifique el fixture
+    note := *args.Get(0).(*domain.Note)
+    return &note, args.Error(1)
+}
+func (m *mockNoteRepo) ListByCandidate(candidateID int, viewer string) ([]domain.Note, error) {
+    args := m.Called(candidateID, viewer)
+    return args.Get(0).([]domain.Note), args.Error(1)
+}
+func (m *mockNoteRepo) Update(note domain.Note, previous domain.NoteRevision, notifications []domain.Notification) error {
+    args := m.Called(note, previous, notifications)
+    return args.Error(0)
+}
+func (m *mockNoteRepo) SetPinned(note domain.Note, actor string) error {
+    args := m.Called(note, actor)
+    return args.Error(0)
+}
+func (m *mockNoteRepo) Delete(note domain.Note, actor string) error {
+    args := m.Called(note, actor)
+    return args.Error(0)
+}
+func (m *mockNoteRepo) ListRevisions(noteID int) ([]domain.NoteRevision, error) {
+    args := m.Called(noteID)
+    return args.Get(0).([]domain.NoteRevision), args.Error(1)
+}
+
+// notifiedUsers retorna los usuarios de las notificaciones
+func notifiedUsers(notifications []domain.Notification) []string {
+    users := []string{}
+    for _, n := range notifications {
+        users = append(users, n.User)
+    }
+    return users
+}
+
+func TestParseMentions(t *testing.T) {
+    body := "Hablé con @ana y @luis.perez. Ver `@codigo` y escribir a jane@example.com\n```\n@bloque\n```\nGracias @ana."
+    assert.Equal(t, []string{"ana", "luis.perez"}, domain.ParseMentions(body))
+    assert.Empty(t, domain.ParseMentions("sin menciones"))
+}
+
+func TestAddNote_NotifiesMentionsExceptAuthor(t *testing.T) {
+    notes := new(mockNoteRepo)
+    candidates := new(mockCandidateRepo)
+    svc := service.NewNoteService(notes, candidates)
+
+    candidates.On("GetByID", 4).Return(&domain.Candidate{ID: 4}, nil)
+    notes.On("Create", mock.MatchedBy(func(n domain.Note) bool {
+        return n.Author == "ana" && n.Visibility == domain.NoteTeam
+    }), mock.MatchedBy(func(ns []domain.Notification) bool {
+        return assert.ObjectsAreEqual([]string{"luis"}, notifiedUsers(ns)) && ns[0].CandidateID == 4
+    })).Return(7, nil)
+    notes.On("GetByID", 7).Return(&domain.Note{ID: 7, CandidateID: 4, Author: "ana"}, nil)
+
+    note, err := svc.AddNote(4, domain.NoteRequest{Body: "@luis @ana revisen el CV"}, "ana")
+    assert.NoError(t, err)
+    assert.Equal(t, 7, note.ID)
+
+    notes.AssertExpectations(t)
+}
+
+func TestAddNote_PrivateDoesNotNotify(t *testing.T) {
+    notes := new(mockNoteRepo)
+    candidates := new(mockCandidateRepo)
+    svc := service.NewNoteService(notes, candidates)
+
+    candidates.On("GetByID", 4).Return(&domain.Candidate{ID: 4}, nil)
+    notes.On("Create", mock.Anything, []domain.Notification(nil)).Return(7, nil)
+    notes.On("GetByID", 7).Return(&domain.Note{ID: 7}, nil)
+
+    _, err := svc.AddNote(4, domain.NoteRequest{Body: "recordar a @luis", Visibility: domain.NotePrivate}, "ana")
+    assert.NoError(t, err)
+
+    notes.AssertExpectations(t)
+}
+
+func TestAddNote_Invalid(t *testing.T) {
+    notes := new(mockNoteRepo)
+    svc := service.NewNoteService(notes, new(mockCandidateRepo))
+
+    _, err := svc.AddNote(4, domain.NoteRequest{Body: "hola", Visibility: "public"}, "ana")
+    assert.ErrorIs(t, err, service.ErrInvalidNote)
+
+    _, err = svc.AddNote(4, domain.NoteRequest{Body: "hola"}, "")
+    assert.ErrorIs(t, err, service.ErrIdentityRequired)
+    notes.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestEditNote_NotifiesOnlyNewMentions(t *testing.T) {
+    notes := new(mockNoteRepo)
+    svc := service.NewNoteService(notes, new(mockCandidateRepo))
+
+    existing := &domain.Note{ID: 7, CandidateID: 4, Author: "ana", Body: "@luis", Visibility: domain.NoteTeam, Mentions: []string{"luis"}}
+    notes.On("GetByID", 7).Return(existing, nil)
+    notes.On("Update", mock.MatchedBy(func(n domain.Note) bool {
+        return n.Body == "@luis y @sofia"
+    }), mock.MatchedBy(func(r domain.NoteRevision) bool {
+        // La versión anterior se conserva
+        return r.NoteID == 7 && r.Body == "@luis" && r.Editor == "ana"
+    }), mock.MatchedBy(func(ns []domain.Notification) bool {
+        return assert.ObjectsAreEqual([]string{"sofia"}, notifiedUsers(ns))
+    })).Return(nil)
+
+    _, err := svc.EditNote(4, 7, domain.NoteRequest{Body: "@luis y @sofia"}, "ana")
+    assert.NoError(t, err)
+
+    notes.AssertExpectations(t)
+}
+
+func TestEditNote_NotAuthor(t *testing.T) {
+    notes := new(mockNoteRepo)
+    svc := service.NewNoteService(notes, new(mockCandidateRepo))
+
+    notes.On("GetByID", 7).Return(&domain.Note{ID: 7, CandidateID: 4, Author: "ana", Visibility: domain.NoteTeam}, nil)
+
+    _, err := svc.EditNote(4, 7, domain.NoteRequest{Body: "cambio"}, "luis")
+    assert.ErrorIs(t, err, service.ErrNotNoteAuthor)
+    notes.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestGetNote_PrivateOfOtherUser(t *testing.T) {
+    notes := new(mockNoteRepo)
+    svc := service.NewNoteService(notes, new(mockCandidateRepo))
+
+    notes.On("GetByID", 7).Return(&domain.Note{ID: 7, CandidateID: 4, Author: "ana", Visibility: domain.NotePrivate}, nil)
+
+    _, err := svc.GetNote(4, 7, "luis")
+    assert.ErrorIs(t, err, service.ErrNoteNotFound)
+
+    // Una nota de otro candidato tampoco se encuentra
+    _, err = svc.GetNote(5, 7, "ana")
+    assert.ErrorIs(t, err, service.ErrNoteNotFound)
+
+    note, err := svc.GetNote(4, 7, "ana")
+    assert.NoError(t, err)
+    assert.Equal(t, 7, note.ID)
+}